@@ -17,6 +17,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 // SignatureLength indicates the length of signature generated by SECP256K1 crypto library
@@ -300,14 +301,21 @@ func (sealed *SealedEnvelope) LoadProto(pbAct *iotextypes.Action) error {
 	return nil
 }
 
-// Sign signs the action using sender's private key
-func Sign(act Envelope, sk keypair.PrivateKey) (SealedEnvelope, error) {
+// Sign signs the action using sender's private key or signer. The envelope is signed from its core if the signer is
+// a signer.ActionSigner.
+func Sign(act Envelope, sk signer.HashSigner) (SealedEnvelope, error) {
 	sealed := SealedEnvelope{Envelope: act}
 
 	sealed.srcPubkey = sk.PublicKey()
 
 	hash := act.Hash()
-	sig, err := sk.Sign(hash[:])
+	var sig []byte
+	var err error
+	if s, ok := sk.(signer.ActionSigner); ok {
+		sig, err = s.SignAction(act.ByteStream())
+	} else {
+		sig, err = sk.Sign(hash[:])
+	}
 	if err != nil {
 		return sealed, errors.Wrapf(ErrAction, "failed to sign action hash = %x", hash)
 	}
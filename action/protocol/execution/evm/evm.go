@@ -27,6 +27,22 @@ import (
 // ErrInconsistentNonce is the error that the nonce is different from executor's nonce
 var ErrInconsistentNonce = errors.New("Nonce is not identical to executor nonce")
 
+// executionRevertedMsg is the message of the unexported error returned by the EVM when the execution reverts
+const executionRevertedMsg = "evm: execution reverted"
+
+type tracerCtxKey struct{}
+
+// WithTracerCtx adds a tracer into context, the executions run with the context are traced by the tracer
+func WithTracerCtx(ctx context.Context, tracer vm.Tracer) context.Context {
+	return context.WithValue(ctx, tracerCtxKey{}, tracer)
+}
+
+// GetTracerCtx gets the tracer from context
+func GetTracerCtx(ctx context.Context) (vm.Tracer, bool) {
+	tracer, ok := ctx.Value(tracerCtxKey{}).(vm.Tracer)
+	return tracer, ok
+}
+
 // CanTransfer checks whether the from account has enough balance
 func CanTransfer(db vm.StateDB, fromHash common.Address, balance *big.Int) bool {
 	return db.GetBalance(fromHash).Cmp(balance) >= 0
@@ -145,7 +161,8 @@ func ExecuteContract(
 	if err != nil {
 		return nil, nil, err
 	}
-	retval, depositGas, remainingGas, contractAddress, failed, err := executeInEVM(ps, stateDB, raCtx.GasLimit)
+	tracer, _ := GetTracerCtx(ctx)
+	retval, depositGas, remainingGas, contractAddress, failed, err := executeInEVM(ps, stateDB, raCtx.GasLimit, tracer)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -157,6 +174,8 @@ func ExecuteContract(
 	}
 	if failed {
 		receipt.Status = action.FailureReceiptStatus
+		// the return data of a failed execution is only kept by a revert
+		receipt.RevertData = retval
 	} else {
 		receipt.Status = action.SuccessReceiptStatus
 	}
@@ -188,13 +207,22 @@ func getChainConfig() *params.ChainConfig {
 	return &chainConfig
 }
 
-func executeInEVM(evmParams *Params, stateDB *StateDBAdapter, gasLimit uint64) ([]byte, uint64, uint64, string, bool, error) {
+func executeInEVM(
+	evmParams *Params,
+	stateDB *StateDBAdapter,
+	gasLimit uint64,
+	tracer vm.Tracer,
+) ([]byte, uint64, uint64, string, bool, error) {
 	remainingGas := evmParams.gas
 	if err := securityDeposit(evmParams, stateDB, gasLimit); err != nil {
 		log.L().Warn("unexpected error: not enough security deposit", zap.Error(err))
 		return nil, 0, 0, action.EmptyAddress, true, err
 	}
 	var config vm.Config
+	if tracer != nil {
+		config.Debug = true
+		config.Tracer = tracer
+	}
 	chainConfig := getChainConfig()
 	evm := vm.NewEVM(evmParams.context, stateDB, chainConfig, config)
 	intriGas, err := intrinsicGas(evmParams.data)
@@ -212,9 +240,11 @@ func executeInEVM(evmParams *Params, stateDB *StateDBAdapter, gasLimit uint64) (
 	if evmParams.contract == nil {
 		// create contract
 		var evmContractAddress common.Address
-		_, evmContractAddress, remainingGas, evmErr = evm.Create(executor, evmParams.data, remainingGas, evmParams.amount)
+		ret, evmContractAddress, remainingGas, evmErr = evm.Create(executor, evmParams.data, remainingGas, evmParams.amount)
 		log.L().Debug("evm Create.", log.Hex("addrHash", evmContractAddress[:]))
 		if evmErr == nil {
+			// the code of the created contract is not returned
+			ret = nil
 			if contractAddress, err := address.FromBytes(evmContractAddress.Bytes()); err == nil {
 				contractRawAddress = contractAddress.String()
 			}
@@ -224,6 +254,10 @@ func executeInEVM(evmParams *Params, stateDB *StateDBAdapter, gasLimit uint64) (
 		// process contract
 		ret, remainingGas, evmErr = evm.Call(executor, *evmParams.contract, evmParams.data, remainingGas, evmParams.amount)
 	}
+	if evmErr != nil && evmErr.Error() != executionRevertedMsg {
+		// only a revert returns data on failure
+		ret = nil
+	}
 	if evmErr != nil {
 		log.L().Debug("evm error", zap.Error(err))
 		if err == vm.ErrInsufficientBalance {
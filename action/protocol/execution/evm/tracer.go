@@ -0,0 +1,307 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// CallFrame is a node of the call tree of an execution
+type CallFrame struct {
+	Type    string
+	From    common.Address
+	To      common.Address
+	Value   *big.Int
+	Gas     uint64
+	GasUsed uint64
+	Input   []byte
+	Output  []byte
+	Error   string
+	Calls   []*CallFrame
+
+	// gas left before the call opcode, and the memory range of the call's return data
+	gasIn   uint64
+	outOff  uint64
+	outSize uint64
+}
+
+// CallTracer is a vm.Tracer which builds the call tree of an execution, including the message calls and contract
+// creations made by contracts
+type CallTracer struct {
+	root *CallFrame
+	// frames are the call frames being executed, frames[i] is running at depth i+1
+	frames []*CallFrame
+}
+
+// NewCallTracer creates a new call tracer
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+// CallTrace returns the root of the call tree
+func (t *CallTracer) CallTrace() *CallFrame {
+	return t.root
+}
+
+// CaptureStart implements the vm.Tracer interface to start the root frame
+func (t *CallTracer) CaptureStart(
+	from common.Address,
+	to common.Address,
+	create bool,
+	input []byte,
+	gas uint64,
+	value *big.Int,
+) error {
+	t.root = &CallFrame{
+		Type:  vm.CALL.String(),
+		From:  from,
+		To:    to,
+		Value: new(big.Int).Set(value),
+		Gas:   gas,
+		Input: common.CopyBytes(input),
+	}
+	if create {
+		t.root.Type = vm.CREATE.String()
+	}
+	t.frames = []*CallFrame{t.root}
+	return nil
+}
+
+// CaptureState implements the vm.Tracer interface to open a frame on every call opcode, and close it once the
+// execution returns to the caller's depth
+func (t *CallTracer) CaptureState(
+	env *vm.EVM,
+	pc uint64,
+	op vm.OpCode,
+	gas, cost uint64,
+	memory *vm.Memory,
+	stack *vm.Stack,
+	contract *vm.Contract,
+	depth int,
+	err error,
+) error {
+	if err != nil || len(t.frames) == 0 {
+		return nil
+	}
+	for len(t.frames) > depth && len(t.frames) > 1 {
+		t.closeFrame(gas, memory, stack)
+	}
+	switch op {
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL, vm.CREATE, vm.CREATE2:
+		t.openFrame(op, gas, memory, stack, contract)
+	case vm.REVERT:
+		frame := t.frames[len(t.frames)-1]
+		frame.Error = "execution reverted"
+		if len(stack.Data()) >= 2 {
+			frame.Output = memoryCopy(memory, stack.Back(0), stack.Back(1))
+		}
+	}
+	return nil
+}
+
+// CaptureFault implements the vm.Tracer interface to record the error of the current frame
+func (t *CallTracer) CaptureFault(
+	env *vm.EVM,
+	pc uint64,
+	op vm.OpCode,
+	gas, cost uint64,
+	memory *vm.Memory,
+	stack *vm.Stack,
+	contract *vm.Contract,
+	depth int,
+	err error,
+) error {
+	if err == nil || len(t.frames) < depth || depth < 1 {
+		return nil
+	}
+	frame := t.frames[depth-1]
+	if frame.Error == "" {
+		frame.Error = err.Error()
+	}
+	return nil
+}
+
+// CaptureEnd implements the vm.Tracer interface to finish the root frame
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) error {
+	if t.root == nil {
+		return nil
+	}
+	// the frames left open have been aborted by an error
+	for _, frame := range t.frames[1:] {
+		if frame.Error == "" {
+			frame.Error = "aborted"
+		}
+	}
+	t.frames = nil
+	t.root.GasUsed = gasUsed
+	if len(output) > 0 {
+		t.root.Output = common.CopyBytes(output)
+	}
+	if err != nil {
+		t.root.Error = err.Error()
+	}
+	return nil
+}
+
+func (t *CallTracer) openFrame(op vm.OpCode, gas uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract) {
+	frame := &CallFrame{
+		Type:  op.String(),
+		From:  contract.Address(),
+		Value: big.NewInt(0),
+		gasIn: gas,
+	}
+	var inOff, inSize int
+	switch op {
+	case vm.CREATE, vm.CREATE2:
+		if len(stack.Data()) < 3 {
+			return
+		}
+		frame.Value.Set(stack.Back(0))
+		inOff, inSize = 1, 2
+		frame.Gas = gas
+	case vm.CALL, vm.CALLCODE:
+		if len(stack.Data()) < 7 {
+			return
+		}
+		frame.Value.Set(stack.Back(2))
+		inOff, inSize = 3, 4
+		frame.outOff, frame.outSize = stack.Back(5).Uint64(), stack.Back(6).Uint64()
+	default:
+		if len(stack.Data()) < 6 {
+			return
+		}
+		inOff, inSize = 2, 3
+		frame.outOff, frame.outSize = stack.Back(4).Uint64(), stack.Back(5).Uint64()
+	}
+	if op != vm.CREATE && op != vm.CREATE2 {
+		frame.To = common.BigToAddress(stack.Back(1))
+		frame.Gas = stack.Back(0).Uint64()
+		if !stack.Back(0).IsUint64() || frame.Gas > gas {
+			frame.Gas = gas
+		}
+	}
+	if op == vm.DELEGATECALL {
+		frame.Value.Set(contract.Value())
+	}
+	frame.Input = memoryCopy(memory, stack.Back(inOff), stack.Back(inSize))
+	parent := t.frames[len(t.frames)-1]
+	parent.Calls = append(parent.Calls, frame)
+	t.frames = append(t.frames, frame)
+}
+
+func (t *CallTracer) closeFrame(gas uint64, memory *vm.Memory, stack *vm.Stack) {
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	if frame.gasIn > gas {
+		frame.GasUsed = frame.gasIn - gas
+	}
+	if len(stack.Data()) == 0 {
+		return
+	}
+	// the call opcode pushes 0 on failure, the success flag or the created contract address otherwise
+	ret := stack.Back(0)
+	if ret.Sign() == 0 {
+		if frame.Error == "" {
+			frame.Error = "call failed"
+		}
+		return
+	}
+	switch frame.Type {
+	case vm.CREATE.String(), vm.CREATE2.String():
+		frame.To = common.BigToAddress(ret)
+	default:
+		if frame.Output == nil {
+			frame.Output = memoryCopy(memory, new(big.Int).SetUint64(frame.outOff), new(big.Int).SetUint64(frame.outSize))
+		}
+	}
+}
+
+// memoryCopy returns a copy of the memory in range [off, off+size), or nil if the range is out of the memory
+func memoryCopy(memory *vm.Memory, off, size *big.Int) []byte {
+	if !off.IsUint64() || !size.IsUint64() || size.Sign() == 0 {
+		return nil
+	}
+	end := off.Uint64() + size.Uint64()
+	if end < off.Uint64() || end > uint64(memory.Len()) {
+		return nil
+	}
+	return memory.Get(int64(off.Uint64()), int64(size.Uint64()))
+}
+
+type multiTracer []vm.Tracer
+
+// NewMultiTracer creates a vm.Tracer which forwards the capture calls to each of the given tracers
+func NewMultiTracer(tracers ...vm.Tracer) vm.Tracer {
+	return multiTracer(tracers)
+}
+
+func (mt multiTracer) CaptureStart(
+	from common.Address,
+	to common.Address,
+	create bool,
+	input []byte,
+	gas uint64,
+	value *big.Int,
+) error {
+	for _, t := range mt {
+		if err := t.CaptureStart(from, to, create, input, gas, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mt multiTracer) CaptureState(
+	env *vm.EVM,
+	pc uint64,
+	op vm.OpCode,
+	gas, cost uint64,
+	memory *vm.Memory,
+	stack *vm.Stack,
+	contract *vm.Contract,
+	depth int,
+	err error,
+) error {
+	for _, t := range mt {
+		if err := t.CaptureState(env, pc, op, gas, cost, memory, stack, contract, depth, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mt multiTracer) CaptureFault(
+	env *vm.EVM,
+	pc uint64,
+	op vm.OpCode,
+	gas, cost uint64,
+	memory *vm.Memory,
+	stack *vm.Stack,
+	contract *vm.Contract,
+	depth int,
+	err error,
+) error {
+	for _, t := range mt {
+		if err := t.CaptureFault(env, pc, op, gas, cost, memory, stack, contract, depth, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mt multiTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	for _, t := range mt {
+		if err := t.CaptureEnd(output, gasUsed, d, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -80,6 +81,7 @@ type ExecutionConfig struct {
 	RawExpectedGasConsumed  uint              `json:"rawExpectedGasConsumed"`
 	ExpectedBalances        []ExpectedBalance `json:"expectedBalances"`
 	ExpectedLogs            []Log             `json:"expectedLogs"`
+	ExpectedRevertReason    string            `json:"expectedRevertReason"`
 }
 
 func (cfg *ExecutionConfig) PrivateKey() keypair.PrivateKey {
@@ -252,9 +254,9 @@ func runExecution(
 
 func (sct *SmartContractTest) prepareBlockchain(
 	ctx context.Context,
+	cfg config.Config,
 	r *require.Assertions,
 ) blockchain.Blockchain {
-	cfg := config.Default
 	cfg.Plugins[config.GatewayPlugin] = true
 	cfg.Chain.EnableAsyncIndexWrite = false
 	registry := protocol.Registry{}
@@ -333,7 +335,7 @@ func (sct *SmartContractTest) deployContracts(
 func (sct *SmartContractTest) run(r *require.Assertions) {
 	// prepare blockchain
 	ctx := context.Background()
-	bc := sct.prepareBlockchain(ctx, r)
+	bc := sct.prepareBlockchain(ctx, config.Default, r)
 	defer r.NoError(bc.Stop(ctx))
 
 	// deploy smart contract
@@ -353,6 +355,9 @@ func (sct *SmartContractTest) run(r *require.Assertions) {
 		r.NotNil(receipt)
 		if exec.Failed {
 			r.Equal(action.FailureReceiptStatus, receipt.Status)
+			if exec.ExpectedRevertReason != "" {
+				r.Equal(exec.ExpectedRevertReason, receipt.RevertReason())
+			}
 		} else {
 			r.Equal(action.SuccessReceiptStatus, receipt.Status)
 		}
@@ -388,6 +393,81 @@ func (sct *SmartContractTest) run(r *require.Assertions) {
 	}
 }
 
+func TestTraceExecution(t *testing.T) {
+	require := require.New(t)
+	jsonFile, err := os.Open("testdata/multisend.json")
+	require.NoError(err)
+	sctBytes, err := ioutil.ReadAll(jsonFile)
+	require.NoError(err)
+	sct := &SmartContractTest{}
+	require.NoError(json.Unmarshal(sctBytes, sct))
+
+	ctx := context.Background()
+	cfg := config.Default
+	cfg.Chain.EnableArchiveMode = true
+	bc := sct.prepareBlockchain(ctx, cfg, require)
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	contractAddresses := sct.deployContracts(bc, require)
+	require.Equal(1, len(contractAddresses))
+	contract, err := address.FromString(contractAddresses[0])
+	require.NoError(err)
+
+	// send to two accounts, and refund the rest to the sender
+	exec := sct.Executions[0]
+	_, receipt, err := runExecution(bc, &exec, contractAddresses[0])
+	require.NoError(err)
+	callTracer := evm.NewCallTracer()
+	structLogger := vm.NewStructLogger(nil)
+	_, traceReceipt, err := bc.TraceExecution(receipt.ActionHash, evm.NewMultiTracer(callTracer, structLogger))
+	require.NoError(err)
+	require.Equal(receipt.Status, traceReceipt.Status)
+	require.Equal(receipt.GasConsumed, traceReceipt.GasConsumed)
+	require.Equal(len(receipt.Logs), len(traceReceipt.Logs))
+	require.NotEmpty(structLogger.StructLogs())
+	callTrace := callTracer.CallTrace()
+	require.Equal("CALL", callTrace.Type)
+	require.Equal(exec.Executor().Bytes(), callTrace.From.Bytes())
+	require.Equal(contract.Bytes(), callTrace.To.Bytes())
+	require.Equal(exec.Amount(), callTrace.Value)
+	require.Empty(callTrace.Error)
+	require.Equal(3, len(callTrace.Calls))
+	for i, expected := range []struct {
+		to    string
+		value int64
+	}{
+		{"io18jaldgzc8wlyfnzamgas62yu3kg5nw527czg37", 123},
+		{"io1ntprz4p5zw38fvtfrcczjtcv3rkr3nqs6sm3pj", 321},
+		{exec.Executor().String(), 277},
+	} {
+		call := callTrace.Calls[i]
+		require.Equal("CALL", call.Type)
+		require.Equal(contract.Bytes(), call.From.Bytes())
+		to, err := address.FromString(expected.to)
+		require.NoError(err)
+		require.Equal(to.Bytes(), call.To.Bytes())
+		require.Equal(big.NewInt(expected.value), call.Value)
+		require.Empty(call.Error)
+	}
+
+	// not enough tokens
+	exec = sct.Executions[1]
+	_, receipt, err = runExecution(bc, &exec, contractAddresses[0])
+	require.NoError(err)
+	require.Equal(action.FailureReceiptStatus, receipt.Status)
+	callTracer = evm.NewCallTracer()
+	_, traceReceipt, err = bc.TraceExecution(receipt.ActionHash, callTracer)
+	require.NoError(err)
+	require.Equal(action.FailureReceiptStatus, traceReceipt.Status)
+	require.Equal(receipt.GasConsumed, traceReceipt.GasConsumed)
+	callTrace = callTracer.CallTrace()
+	require.NotEmpty(callTrace.Error)
+	require.Empty(callTrace.Calls)
+	// the revert data is returned as output
+	require.Equal(receipt.RevertData, callTrace.Output)
+}
+
 func TestProtocol_Handle(t *testing.T) {
 	testEVM := func(t *testing.T) {
 		log.S().Info("Test EVM")
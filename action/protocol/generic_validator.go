@@ -20,14 +20,29 @@ type GenericValidator struct {
 	mu             sync.RWMutex
 	cm             ChainManager
 	actionGasLimit uint64
+	sigCache       *action.SignatureCache
+}
+
+// GenericValidatorOption is the option to create a generic validator
+type GenericValidatorOption func(*GenericValidator)
+
+// WithSignatureCache returns an option to skip verifying the signatures of the actions in the cache
+func WithSignatureCache(sigCache *action.SignatureCache) GenericValidatorOption {
+	return func(v *GenericValidator) {
+		v.sigCache = sigCache
+	}
 }
 
 // NewGenericValidator constructs a new genericValidator
-func NewGenericValidator(cm ChainManager, actionGasLimit uint64) *GenericValidator {
-	return &GenericValidator{
+func NewGenericValidator(cm ChainManager, actionGasLimit uint64, opts ...GenericValidatorOption) *GenericValidator {
+	v := &GenericValidator{
 		cm:             cm,
 		actionGasLimit: actionGasLimit,
 	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 // Validate validates a generic action
@@ -43,7 +58,7 @@ func (v *GenericValidator) Validate(ctx context.Context, act action.SealedEnvelo
 		return errors.Wrap(action.ErrInsufficientBalanceForGas, "insufficient gas")
 	}
 	// Verify action using action sender's public key
-	if err := action.Verify(act); err != nil {
+	if err := v.sigCache.Verify(act); err != nil {
 		return errors.Wrap(err, "failed to verify action signature")
 	}
 	// Reject action if nonce is too low
@@ -7,6 +7,9 @@
 package action
 
 import (
+	"bytes"
+	"encoding/binary"
+
 	"github.com/golang/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/pkg/hash"
@@ -21,6 +24,10 @@ const (
 	SuccessReceiptStatus = uint64(1)
 )
 
+// revertSelector is the function selector of Error(string), whose ABI encoded call data is returned by a revert with
+// reason in Solidity
+var revertSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
 // Receipt represents the result of a contract
 type Receipt struct {
 	Status          uint64
@@ -29,6 +36,8 @@ type Receipt struct {
 	GasConsumed     uint64
 	ContractAddress string
 	Logs            []*Log
+	// RevertData is the return data of a reverted execution
+	RevertData []byte
 }
 
 // Log stores an evm contract event
@@ -53,6 +62,7 @@ func (receipt *Receipt) ConvertToReceiptPb() *iotextypes.Receipt {
 	for _, log := range receipt.Logs {
 		r.Logs = append(r.Logs, log.ConvertToLogPb())
 	}
+	r.RevertData = receipt.RevertData
 	return r
 }
 
@@ -69,6 +79,7 @@ func (receipt *Receipt) ConvertFromReceiptPb(pbReceipt *iotextypes.Receipt) {
 		receipt.Logs[i] = &Log{}
 		receipt.Logs[i].ConvertFromLogPb(log)
 	}
+	receipt.RevertData = pbReceipt.GetRevertData()
 }
 
 // Serialize returns a serialized byte stream for the Receipt
@@ -86,15 +97,53 @@ func (receipt *Receipt) Deserialize(buf []byte) error {
 	return nil
 }
 
-// Hash returns the hash of receipt
+// Hash returns the hash of receipt. The revert data is excluded, so that the receipt root of a block stays the same
+// no matter whether the revert data is kept
 func (receipt *Receipt) Hash() hash.Hash256 {
-	data, err := receipt.Serialize()
+	r := receipt.ConvertToReceiptPb()
+	r.RevertData = nil
+	data, err := proto.Marshal(r)
 	if err != nil {
 		log.L().Panic("Error when serializing a receipt")
 	}
 	return hash.Hash256b(data)
 }
 
+// RevertReason returns the revert reason decoded from the revert data, or an empty string if the execution didn't
+// revert with a reason
+func (receipt *Receipt) RevertReason() string {
+	reason, _ := DecodeRevertReason(receipt.RevertData)
+	return reason
+}
+
+// DecodeRevertReason decodes the reason string from the ABI encoded Error(string) data returned by a revert
+func DecodeRevertReason(data []byte) (string, bool) {
+	if len(data) < len(revertSelector)+64 || !bytes.Equal(data[:len(revertSelector)], revertSelector) {
+		return "", false
+	}
+	data = data[len(revertSelector):]
+	offset, ok := decodeABIUint(data[:32])
+	if !ok || offset > uint64(len(data)-32) {
+		return "", false
+	}
+	data = data[offset:]
+	size, ok := decodeABIUint(data[:32])
+	if !ok || size > uint64(len(data)-32) {
+		return "", false
+	}
+	return string(data[32 : 32+size]), true
+}
+
+// decodeABIUint decodes a 32-byte ABI encoded uint, which should fit into uint64
+func decodeABIUint(word []byte) (uint64, bool) {
+	for _, b := range word[:24] {
+		if b != 0 {
+			return 0, false
+		}
+	}
+	return binary.BigEndian.Uint64(word[24:32]), true
+}
+
 // ConvertToLogPb converts a Log to protobuf's Log
 func (log *Log) ConvertToLogPb() *iotextypes.Log {
 	l := &iotextypes.Log{}
@@ -0,0 +1,71 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+func TestReceiptRevertReason(t *testing.T) {
+	require := require.New(t)
+
+	// Error("not enough token")
+	data, err := hex.DecodeString("08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000010" +
+		"6e6f7420656e6f75676820746f6b656e00000000000000000000000000000000")
+	require.NoError(err)
+	reason, ok := DecodeRevertReason(data)
+	require.True(ok)
+	require.Equal("not enough token", reason)
+
+	receipt := &Receipt{
+		Status:      FailureReceiptStatus,
+		BlockHeight: 5,
+		ActionHash:  hash.Hash256b([]byte("33333")),
+		GasConsumed: 6,
+		RevertData:  data,
+	}
+	require.Equal("not enough token", receipt.RevertReason())
+	s, err := receipt.Serialize()
+	require.NoError(err)
+	actualReceipt := &Receipt{}
+	require.NoError(actualReceipt.Deserialize(s))
+	require.Equal(receipt.RevertData, actualReceipt.RevertData)
+	// the revert data is not part of the receipt hash
+	h := receipt.Hash()
+	receipt.RevertData = nil
+	require.Equal(h, receipt.Hash())
+	require.Equal("", receipt.RevertReason())
+
+	for _, d := range []string{
+		"",
+		// not Error(string)
+		"4e487b71" +
+			"0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000010",
+		// string out of range
+		"08c379a0" +
+			"0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000040" +
+			"6e6f7420656e6f75676820746f6b656e00000000000000000000000000000000",
+		// offset overflows uint64
+		"08c379a0" +
+			"0000000000000000000000000000000000000000000000010000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000010",
+	} {
+		data, err := hex.DecodeString(d)
+		require.NoError(err)
+		_, ok := DecodeRevertReason(data)
+		require.False(ok)
+	}
+}
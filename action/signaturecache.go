@@ -0,0 +1,64 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/pkg/cache"
+)
+
+var signatureCacheMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_signature_cache",
+		Help: "Number of action signature verifications by the result of cache lookup.",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	prometheus.MustRegister(signatureCacheMtc)
+}
+
+// SignatureCache is a bounded cache of the hashes of the actions whose signatures have been verified, so that an
+// action verified once, e.g., when it is added into the actpool or pre-validated in block sync, isn't verified again
+// when the block containing it is validated. The hash of a sealed envelope covers both the signature and the public
+// key, so a hit means the same bytes have been verified before.
+type SignatureCache struct {
+	cache *cache.ThreadSafeLruCache
+}
+
+// NewSignatureCache creates a signature cache holding at most size hashes
+func NewSignatureCache(size int) *SignatureCache {
+	return &SignatureCache{cache: cache.NewThreadSafeLruCache(size)}
+}
+
+// Verify verifies the signature of the action, unless it is in the cache. A nil cache always verifies.
+func (c *SignatureCache) Verify(sealed SealedEnvelope) error {
+	if c == nil {
+		return Verify(sealed)
+	}
+	h := sealed.Hash()
+	if _, ok := c.cache.Get(h); ok {
+		signatureCacheMtc.WithLabelValues("hit").Inc()
+		return nil
+	}
+	signatureCacheMtc.WithLabelValues("miss").Inc()
+	if err := Verify(sealed); err != nil {
+		return err
+	}
+	c.cache.Add(h, struct{}{})
+	return nil
+}
+
+// Len returns the number of verified actions in the cache
+func (c *SignatureCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.cache.Len()
+}
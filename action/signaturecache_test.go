@@ -0,0 +1,86 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func signedTransfer(t testing.TB, nonce uint64) SealedEnvelope {
+	tsf, err := NewTransfer(
+		nonce,
+		big.NewInt(1),
+		testaddress.Addrinfo["bravo"].String(),
+		nil,
+		uint64(100000),
+		big.NewInt(10),
+	)
+	require.NoError(t, err)
+	bd := &EnvelopeBuilder{}
+	elp := bd.SetNonce(nonce).
+		SetGasPrice(big.NewInt(10)).
+		SetGasLimit(uint64(100000)).
+		SetAction(tsf).Build()
+	selp, err := Sign(elp, testaddress.Keyinfo["alfa"].PriKey)
+	require.NoError(t, err)
+	return selp
+}
+
+func TestSignatureCache(t *testing.T) {
+	require := require.New(t)
+
+	c := NewSignatureCache(2)
+	selp1 := signedTransfer(t, 1)
+	require.NoError(c.Verify(selp1))
+	require.Equal(1, c.Len())
+	require.NoError(c.Verify(selp1))
+	require.Equal(1, c.Len())
+
+	// an action with a tampered signature is neither accepted nor cached
+	pb := selp1.Proto()
+	pb.Signature = append([]byte{}, pb.Signature...)
+	pb.Signature[0] ^= 1
+	tampered := SealedEnvelope{}
+	require.NoError(tampered.LoadProto(pb))
+	require.Error(c.Verify(tampered))
+	require.Equal(1, c.Len())
+
+	// the cache is bounded
+	require.NoError(c.Verify(signedTransfer(t, 2)))
+	require.NoError(c.Verify(signedTransfer(t, 3)))
+	require.Equal(2, c.Len())
+
+	// a nil cache always verifies
+	var nilCache *SignatureCache
+	require.NoError(nilCache.Verify(selp1))
+	require.Error(nilCache.Verify(tampered))
+	require.Equal(0, nilCache.Len())
+}
+
+func BenchmarkSignatureCache_Verify(b *testing.B) {
+	selp := signedTransfer(b, 1)
+	b.Run("no cache", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := Verify(selp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		c := NewSignatureCache(1)
+		for i := 0; i < b.N; i++ {
+			if err := c.Verify(selp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
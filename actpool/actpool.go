@@ -8,6 +8,8 @@ package actpool
 
 import (
 	"context"
+	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
@@ -20,11 +22,15 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/ratelimit"
+	"github.com/iotexproject/iotex-core/pkg/routine"
 )
 
 // ActPool is the interface of actpool
 type ActPool interface {
+	lifecycle.StartStopper
 	// Reset resets actpool state
 	Reset()
 	// PendingActionMap returns an action map with all accepted actions
@@ -45,12 +51,29 @@ type ActPool interface {
 	GetGasSize() uint64
 	// GetGasCapacity returns the act pool gas capacity
 	GetGasCapacity() uint64
+	// GetAccountStatuses returns the status of each sender with actions in pool, ordered by address
+	GetAccountStatuses() []AccountStatus
+	// AddSubscriber adds a subscriber to be notified of the actions becoming pending
+	AddSubscriber(PendingActionSubscriber) error
+	// RemoveSubscriber removes a pending action subscriber
+	RemoveSubscriber(PendingActionSubscriber) error
 	// AddActionValidators add validators
 	AddActionValidators(...protocol.ActionValidator)
 
 	AddActionEnvelopeValidators(...protocol.ActionEnvelopeValidator)
 }
 
+// AccountStatus is the status of the actions of a sender in pool
+type AccountStatus struct {
+	Address string
+	// PendingNonce is the next nonce of the sender after the actions in pool
+	PendingNonce uint64
+	// PendingActs are the actions with consecutive nonces following the confirmed nonce, ready to be picked
+	PendingActs []action.SealedEnvelope
+	// QueuedActs are the actions waiting for the nonce gaps before them to be filled
+	QueuedActs []action.SealedEnvelope
+}
+
 // Option sets action pool construction parameter
 type Option func(pool *actPool) error
 
@@ -75,6 +98,10 @@ type actPool struct {
 	timerFactory              *prometheustimer.TimerFactory
 	enableExperimentalActions bool
 	senderBlackList           map[string]bool
+	journal                   *journal
+	journalTask               *routine.RecurringTask
+	subscribers               []PendingActionSubscriber
+	senderLimiter             *ratelimit.Limiter
 }
 
 // NewActPool constructs a new actpool
@@ -94,6 +121,7 @@ func NewActPool(bc blockchain.Blockchain, cfg config.ActPool, opts ...Option) (A
 		senderBlackList: senderBlackList,
 		accountActs:     make(map[string]ActQueue),
 		allActions:      make(map[hash.Hash256]action.SealedEnvelope),
+		senderLimiter:   ratelimit.New("actpool_sender", cfg.SenderRateLimit),
 	}
 	for _, opt := range opts {
 		if err := opt(ap); err != nil {
@@ -110,9 +138,54 @@ func NewActPool(bc blockchain.Blockchain, cfg config.ActPool, opts ...Option) (A
 		return nil, err
 	}
 	ap.timerFactory = timerFactory
+	if cfg.Journal != "" {
+		ap.journal = newJournal(cfg.Journal)
+		if cfg.JournalRewriteInterval != 0 {
+			ap.journalTask = routine.NewRecurringTask(ap.rotateJournal, cfg.JournalRewriteInterval)
+		}
+	}
 	return ap, nil
 }
 
+// Start replays the actions in the journal into the pool, and starts compacting the journal periodically
+func (ap *actPool) Start(ctx context.Context) error {
+	if ap.journal == nil {
+		return nil
+	}
+	// The journal is not open for appending yet, so the replayed actions are not journaled again. The replayed
+	// actions have been admitted before, so they are not rate limited.
+	if err := ap.journal.load(func(act action.SealedEnvelope) error {
+		return ap.add(act, false)
+	}); err != nil {
+		return err
+	}
+	ap.mutex.Lock()
+	err := ap.journal.rotate(ap.allActs())
+	ap.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	if ap.journalTask != nil {
+		return ap.journalTask.Start(ctx)
+	}
+	return nil
+}
+
+// Stop stops compacting the journal and closes it
+func (ap *actPool) Stop(ctx context.Context) error {
+	if ap.journal == nil {
+		return nil
+	}
+	if ap.journalTask != nil {
+		if err := ap.journalTask.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	return ap.journal.close()
+}
+
 // AddActionValidators add validators
 func (ap *actPool) AddActionValidators(validators ...protocol.ActionValidator) {
 	ap.validators = append(ap.validators, validators...)
@@ -153,6 +226,10 @@ func (ap *actPool) PendingActionMap() map[string][]action.SealedEnvelope {
 }
 
 func (ap *actPool) Add(act action.SealedEnvelope) error {
+	return ap.add(act, true)
+}
+
+func (ap *actPool) add(act action.SealedEnvelope, rateLimited bool) error {
 	ap.mutex.Lock()
 	defer ap.mutex.Unlock()
 	if !ap.enableExperimentalActions && action.IsExperimentalAction(act.Action()) {
@@ -167,16 +244,14 @@ func (ap *actPool) Add(act action.SealedEnvelope) error {
 	if _, ok := ap.senderBlackList[srcAddr.String()]; ok {
 		return errors.Wrap(action.ErrAddress, "action source address is blacklisted")
 	}
-	// Reject action if pool space is full
-	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool {
-		return errors.Wrap(action.ErrActPool, "insufficient space for action")
-	}
 	intrinsicGas, err := act.IntrinsicGas()
 	if err != nil {
 		return errors.Wrap(err, "failed to get action's intrinsic gas")
 	}
-	if ap.gasInPool+intrinsicGas > ap.cfg.MaxGasLimitPerPool {
-		return errors.Wrap(action.ErrActPool, "insufficient gas space for action")
+	// Reject action if pool space is full, and there are not enough cheaper actions to evict
+	evictees, err := ap.evictees(srcAddr.String(), act, intrinsicGas)
+	if err != nil {
+		return err
 	}
 	hash := act.Hash()
 	// Reject action if it already exists in pool
@@ -208,6 +283,13 @@ func (ap *actPool) Add(act action.SealedEnvelope) error {
 			return errors.Wrapf(err, "reject invalid action: %x", hash)
 		}
 	}
+	// Reject action if the sender exceeds the admission rate, which is only charged after the signature is verified so
+	// that forged actions cannot use up the quota of a sender
+	if rateLimited {
+		if err := ap.senderLimiter.Allow(srcAddr.String()); err != nil {
+			return errors.Wrapf(action.ErrActPool, "action source address %s is rate limited: %v", srcAddr, err)
+		}
+	}
 	// Reject action if it's invalid
 	for _, validator := range ap.validators {
 		ctx := protocol.WithValidateActionsCtx(
@@ -220,7 +302,15 @@ func (ap *actPool) Add(act action.SealedEnvelope) error {
 			return errors.Wrapf(err, "reject invalid action: %x", hash)
 		}
 	}
-	return ap.enqueueAction(caller.String(), act, hash, act.Nonce())
+	if err := ap.enqueueAction(caller.String(), act, hash, act.Nonce(), evictees); err != nil {
+		return err
+	}
+	if ap.journal != nil {
+		if err := ap.journal.insert(act); err != nil && err != errNoActiveJournal {
+			log.L().Warn("Failed to journal action.", log.Hex("hash", hash[:]), zap.Error(err))
+		}
+	}
+	return nil
 }
 
 // GetPendingNonce returns pending nonce in pool or confirmed nonce given an account address
@@ -285,10 +375,67 @@ func (ap *actPool) GetGasCapacity() uint64 {
 	return ap.cfg.MaxGasLimitPerPool
 }
 
+// GetAccountStatuses returns the status of each sender with actions in pool, ordered by address
+func (ap *actPool) GetAccountStatuses() []AccountStatus {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	statuses := make([]AccountStatus, 0, len(ap.accountActs))
+	for from, queue := range ap.accountActs {
+		// The pending actions and nonce are both taken from the queue under the lock rather than the chain, which
+		// may commit a block meanwhile. The pending actions are the ones with the nonces below the pending nonce.
+		allActs := queue.AllActs()
+		pendingNonce := queue.PendingNonce()
+		i := sort.Search(len(allActs), func(i int) bool { return allActs[i].Nonce() >= pendingNonce })
+		statuses = append(statuses, AccountStatus{
+			Address:      from,
+			PendingNonce: pendingNonce,
+			PendingActs:  allActs[:i:i],
+			QueuedActs:   allActs[i:],
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Address < statuses[j].Address
+	})
+	return statuses
+}
+
+// AddSubscriber adds a subscriber to be notified of the actions becoming pending
+func (ap *actPool) AddSubscriber(s PendingActionSubscriber) error {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	if s == nil {
+		return errors.New("subscriber could not be nil")
+	}
+	ap.subscribers = append(ap.subscribers, s)
+	return nil
+}
+
+// RemoveSubscriber removes a pending action subscriber
+func (ap *actPool) RemoveSubscriber(s PendingActionSubscriber) error {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	for i, sub := range ap.subscribers {
+		if sub == s {
+			ap.subscribers = append(ap.subscribers[:i], ap.subscribers[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("cannot find subscription")
+}
+
 //======================================
 // private functions
 //======================================
-func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, hash hash.Hash256, actNonce uint64) error {
+func (ap *actPool) enqueueAction(
+	sender string,
+	act action.SealedEnvelope,
+	hash hash.Hash256,
+	actNonce uint64,
+	evictees []action.SealedEnvelope,
+) error {
 	confirmedNonce, err := ap.bc.Nonce(sender)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get sender's nonce for action %x", hash)
@@ -309,10 +456,21 @@ func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, hash
 		}
 		queue.SetPendingBalance(balance)
 	}
-	if queue.Overlaps(act) {
-		// Nonce already exists
-		return errors.Wrapf(action.ErrNonce, "duplicate nonce for action %x", hash)
+	replaced, replacing := queue.Get(actNonce)
+	if replacing && !ap.enoughPriceBump(replaced, act) {
+		// Nonce already exists, and the action doesn't pay enough to replace the queued one
+		return errors.Wrapf(
+			action.ErrNonce,
+			"duplicate nonce for action %x, whose gas price %s is not %d%% higher than the queued one %s",
+			hash,
+			act.GasPrice(),
+			ap.cfg.PriceBumpPercent,
+			replaced.GasPrice(),
+		)
 	}
+	// The replaced action's cost has been deducted from the pending balance if it's pending, and the replacement
+	// should be payable without invalidating the subsequent pending actions
+	wasPending := replacing && actNonce < queue.PendingNonce()
 
 	if actNonce-confirmedNonce-1 >= ap.cfg.MaxNumActsPerAcct {
 		// Nonce exceeds current range
@@ -327,17 +485,34 @@ func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, hash
 	if err != nil {
 		return errors.Wrapf(err, "failed to get cost of action %x", hash)
 	}
-	if queue.PendingBalance().Cmp(cost) < 0 {
+	balance := new(big.Int).Set(queue.PendingBalance())
+	if wasPending {
+		replacedCost, err := replaced.Cost()
+		if err != nil {
+			return errors.Wrapf(err, "failed to get cost of action %x", replaced.Hash())
+		}
+		balance.Add(balance, replacedCost)
+	}
+	if balance.Cmp(cost) < 0 {
 		// Pending balance is insufficient
 		return errors.Wrapf(
 			action.ErrBalance,
 			"insufficient balance for action %x, cost = %s, pending balance = %s",
 			hash,
 			cost.String(),
-			queue.PendingBalance().String(),
+			balance.String(),
 		)
 	}
 
+	ap.evictActs(sender, evictees)
+	if replacing {
+		queue.Remove(actNonce)
+		ap.removeInvalidActs([]action.SealedEnvelope{replaced})
+		log.L().Debug("Replaced action.",
+			log.Hex("hash", hash[:]),
+			zap.String("gasPrice", act.GasPrice().String()),
+			zap.String("replacedGasPrice", replaced.GasPrice().String()))
+	}
 	if err := queue.Put(act); err != nil {
 		return errors.Wrapf(err, "cannot put action %x into ActQueue", hash)
 	}
@@ -345,14 +520,157 @@ func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, hash
 
 	intrinsicGas, _ := act.IntrinsicGas()
 	ap.gasInPool += intrinsicGas
+	if wasPending {
+		queue.SetPendingBalance(balance.Sub(balance, cost))
+		ap.emitToSubscribers(act)
+		return nil
+	}
 	// If the pending nonce equals this nonce, update queue
 	nonce := queue.PendingNonce()
 	if actNonce == nonce {
 		ap.updateAccount(sender)
+		ap.emitPendingActs(queue, nonce)
 	}
 	return nil
 }
 
+// enoughPriceBump checks whether the gas price of the action exceeds the one of the replaced action by the price bump
+func (ap *actPool) enoughPriceBump(replaced action.SealedEnvelope, act action.SealedEnvelope) bool {
+	if act.GasPrice().Cmp(replaced.GasPrice()) <= 0 {
+		return false
+	}
+	threshold := new(big.Int).Mul(replaced.GasPrice(), new(big.Int).SetUint64(100+ap.cfg.PriceBumpPercent))
+	return new(big.Int).Mul(act.GasPrice(), big.NewInt(100)).Cmp(threshold) >= 0
+}
+
+// evictees returns the lowest-priced non-pending actions across accounts, which should be evicted to make room for
+// the given action when the pool is full. All of them pay a lower gas price than the given action, and an action is
+// evicted only after the ones with higher nonces of the same account.
+func (ap *actPool) evictees(
+	sender string,
+	act action.SealedEnvelope,
+	intrinsicGas uint64,
+) ([]action.SealedEnvelope, error) {
+	numActs := uint64(len(ap.allActions))
+	gasInPool := ap.gasInPool
+	if queue, ok := ap.accountActs[sender]; ok {
+		if replaced, exist := queue.Get(act.Nonce()); exist {
+			// The replaced action leaves the pool
+			replacedGas, _ := replaced.IntrinsicGas()
+			numActs--
+			gasInPool -= replacedGas
+		}
+	}
+	if numActs < ap.cfg.MaxNumActsPerPool && gasInPool+intrinsicGas <= ap.cfg.MaxGasLimitPerPool {
+		return nil, nil
+	}
+
+	// The non-pending actions of each account in ascending order of nonce, which are evicted from the highest nonce
+	// down, so that no nonce gap is left before the remaining ones
+	candidates := make(map[string][]action.SealedEnvelope)
+	for from, queue := range ap.accountActs {
+		for _, queued := range queue.AllActs() {
+			if queued.Nonce() < queue.PendingNonce() {
+				continue
+			}
+			if from == sender && queued.Nonce() <= act.Nonce() {
+				// The given action is never evicted, nor the ones before it
+				continue
+			}
+			candidates[from] = append(candidates[from], queued)
+		}
+	}
+	var evictees []action.SealedEnvelope
+	for numActs >= ap.cfg.MaxNumActsPerPool || gasInPool+intrinsicGas > ap.cfg.MaxGasLimitPerPool {
+		// The cheapest of the actions with the highest nonces, and the one of the smaller address among the same gas
+		// price
+		var evictee string
+		for from, acts := range candidates {
+			last := acts[len(acts)-1]
+			if last.GasPrice().Cmp(act.GasPrice()) >= 0 {
+				continue
+			}
+			if evictee != "" {
+				cheapest := candidates[evictee][len(candidates[evictee])-1]
+				if c := last.GasPrice().Cmp(cheapest.GasPrice()); c > 0 || (c == 0 && from > evictee) {
+					continue
+				}
+			}
+			evictee = from
+		}
+		if evictee == "" {
+			break
+		}
+		acts := candidates[evictee]
+		candidate := acts[len(acts)-1]
+		if len(acts) == 1 {
+			delete(candidates, evictee)
+		} else {
+			candidates[evictee] = acts[:len(acts)-1]
+		}
+		candidateGas, _ := candidate.IntrinsicGas()
+		numActs--
+		gasInPool -= candidateGas
+		evictees = append(evictees, candidate)
+	}
+	if numActs >= ap.cfg.MaxNumActsPerPool {
+		return nil, errors.Wrap(action.ErrActPool, "insufficient space for action")
+	}
+	if gasInPool+intrinsicGas > ap.cfg.MaxGasLimitPerPool {
+		return nil, errors.Wrap(action.ErrActPool, "insufficient gas space for action")
+	}
+	return evictees, nil
+}
+
+// evictActs removes the evicted non-pending actions from their queues and the pool
+func (ap *actPool) evictActs(sender string, acts []action.SealedEnvelope) {
+	for _, act := range acts {
+		from, err := address.FromBytes(act.SrcPubkey().Hash())
+		if err != nil {
+			continue
+		}
+		queue, ok := ap.accountActs[from.String()]
+		if !ok {
+			continue
+		}
+		if _, exist := queue.Remove(act.Nonce()); !exist {
+			continue
+		}
+		ap.removeInvalidActs([]action.SealedEnvelope{act})
+		hash := act.Hash()
+		log.L().Debug("Evicted action.", log.Hex("hash", hash[:]), zap.String("gasPrice", act.GasPrice().String()))
+		// Keep the sender's queue, which the action is being put into
+		if queue.Empty() && from.String() != sender {
+			delete(ap.accountActs, from.String())
+		}
+	}
+}
+
+// allActs returns all the actions in pool, ordered by sender and nonce
+func (ap *actPool) allActs() []action.SealedEnvelope {
+	senders := make([]string, 0, len(ap.accountActs))
+	for from := range ap.accountActs {
+		senders = append(senders, from)
+	}
+	sort.Strings(senders)
+	acts := make([]action.SealedEnvelope, 0, len(ap.allActions))
+	for _, from := range senders {
+		acts = append(acts, ap.accountActs[from].AllActs()...)
+	}
+	return acts
+}
+
+// rotateJournal compacts the journal into the actions currently in pool, dropping the ones which have been committed
+// to block, replaced, evicted or expired
+func (ap *actPool) rotateJournal() {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	if err := ap.journal.rotate(ap.allActs()); err != nil {
+		log.L().Error("Failed to rotate actpool journal.", zap.Error(err))
+	}
+}
+
 // removeConfirmedActs removes processed (committed to block) actions from pool
 func (ap *actPool) removeConfirmedActs() {
 	for from, queue := range ap.accountActs {
@@ -418,7 +736,34 @@ func (ap *actPool) reset() {
 			return
 		}
 		pendingNonce := confirmedNonce + 1
+		prevPendingNonce := queue.PendingNonce()
 		queue.SetPendingNonce(pendingNonce)
 		ap.updateAccount(from)
+		// The queued actions become pending if their nonce gaps are filled by the actions committed from elsewhere
+		if prevPendingNonce > pendingNonce {
+			pendingNonce = prevPendingNonce
+		}
+		ap.emitPendingActs(queue, pendingNonce)
+	}
+}
+
+// emitPendingActs notifies the subscribers of the actions in queue becoming pending, from the given nonce up to the
+// pending nonce
+func (ap *actPool) emitPendingActs(queue ActQueue, nonce uint64) {
+	if len(ap.subscribers) == 0 {
+		return
+	}
+	for ; nonce < queue.PendingNonce(); nonce++ {
+		if act, ok := queue.Get(nonce); ok {
+			ap.emitToSubscribers(act)
+		}
+	}
+}
+
+func (ap *actPool) emitToSubscribers(act action.SealedEnvelope) {
+	for _, s := range ap.subscribers {
+		if err := s.HandlePendingAction(act); err != nil {
+			log.L().Error("Failed to handle pending action.", zap.Error(err))
+		}
 	}
 }
@@ -8,7 +8,9 @@ package actpool
 
 import (
 	"context"
+	"io/ioutil"
 	"math/big"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -27,6 +29,8 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/ratelimit"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -1119,6 +1123,293 @@ func TestActPool_AddActionNotEnoughGasPride(t *testing.T) {
 	require.Error(t, ap.Add(tsf))
 }
 
+func TestActPool_ReplaceByFee(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+	_, err := bc.CreateState(addr1, big.NewInt(1000000))
+	require.NoError(err)
+	apConfig := getActPoolCfg()
+	apConfig.PriceBumpPercent = 10
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(10))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(10))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	gasInPool := ap.gasInPool
+	pBalance, _ := ap.getPendingBalance(addr1)
+	require.Equal(uint64(799980), pBalance.Uint64())
+
+	// the gas price doesn't exceed the queued one by the price bump
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(1), []byte{}, uint64(10000), big.NewInt(10))
+	require.NoError(err)
+	require.Equal(action.ErrNonce, errors.Cause(ap.Add(tsf3)))
+	// a pending action is replaced, with the pending balance updated
+	tsf4, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(11))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf4))
+	_, err = ap.GetActionByHash(tsf1.Hash())
+	require.Error(err)
+	_, err = ap.GetActionByHash(tsf4.Hash())
+	require.NoError(err)
+	require.Equal(uint64(2), ap.GetSize())
+	require.Equal(gasInPool, ap.gasInPool)
+	pBalance, _ = ap.getPendingBalance(addr1)
+	require.Equal(uint64(789980), pBalance.Uint64())
+	pNonce, _ := ap.getPendingNonce(addr1)
+	require.Equal(uint64(3), pNonce)
+	// the replacement is rejected if the balance is insufficient
+	tsf5, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(100))
+	require.NoError(err)
+	require.Equal(action.ErrBalance, errors.Cause(ap.Add(tsf5)))
+	// the replacement is accepted as long as the subsequent pending actions are still payable
+	tsf6, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(80))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf6))
+	require.Equal(uint64(2), ap.GetSize())
+	pBalance, _ = ap.getPendingBalance(addr1)
+	require.Equal(uint64(99980), pBalance.Uint64())
+	pNonce, _ = ap.getPendingNonce(addr1)
+	require.Equal(uint64(3), pNonce)
+	// a non-pending action is replaced
+	tsf7, err := testutil.SignedTransfer(addr2, priKey1, uint64(4), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	tsf8, err := testutil.SignedTransfer(addr2, priKey1, uint64(4), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(2))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf7))
+	require.NoError(ap.Add(tsf8))
+	require.Equal(uint64(3), ap.GetSize())
+	require.Equal([]action.SealedEnvelope{tsf6, tsf2, tsf8}, ap.GetUnconfirmedActs(addr1))
+	pBalance, _ = ap.getPendingBalance(addr1)
+	require.Equal(uint64(99980), pBalance.Uint64())
+}
+
+func TestActPool_EvictCheaperActs(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+	for _, addr := range []string{addr1, addr2, addr3} {
+		_, err := bc.CreateState(addr, big.NewInt(1000000))
+		require.NoError(err)
+	}
+	apConfig := getActPoolCfg()
+	apConfig.MaxNumActsPerPool = 3
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+
+	// nonce 3 and 4 of addr1 are not pending
+	tsf1, err := testutil.SignedTransfer(addr4, priKey1, uint64(3), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr4, priKey1, uint64(4), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(2))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr4, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	require.NoError(ap.Add(tsf3))
+
+	// no cheaper action to evict
+	tsf4, err := testutil.SignedTransfer(addr4, priKey3, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	require.Equal(action.ErrActPool, errors.Cause(ap.Add(tsf4)))
+	// the cheaper nonce 3 of addr1 is not evicted before nonce 4, which would leave a nonce gap
+	tsf5, err := testutil.SignedTransfer(addr4, priKey3, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(2))
+	require.NoError(err)
+	require.Equal(action.ErrActPool, errors.Cause(ap.Add(tsf5)))
+	// the cheapest action with the highest nonce is evicted
+	tsf6, err := testutil.SignedTransfer(addr4, priKey3, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(5))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf6))
+	require.Equal(uint64(3), ap.GetSize())
+	_, err = ap.GetActionByHash(tsf2.Hash())
+	require.Error(err)
+	require.Equal([]action.SealedEnvelope{tsf1}, ap.GetUnconfirmedActs(addr1))
+	tsf7, err := testutil.SignedTransfer(addr4, priKey3, uint64(2), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(5))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf7))
+	_, err = ap.GetActionByHash(tsf1.Hash())
+	require.Error(err)
+	require.Equal(0, len(ap.GetUnconfirmedActs(addr1)))
+	// pending actions are never evicted
+	tsf8, err := testutil.SignedTransfer(addr4, priKey3, uint64(3), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(5))
+	require.NoError(err)
+	require.Equal(action.ErrActPool, errors.Cause(ap.Add(tsf8)))
+	require.Equal(uint64(3), ap.GetSize())
+	pNonce, _ := ap.getPendingNonce(addr3)
+	require.Equal(uint64(3), pNonce)
+}
+
+func TestActPool_Journal(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol())
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+	_, err := bc.CreateState(addr1, big.NewInt(1000000))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(1000000))
+	require.NoError(err)
+
+	journalFile, err := ioutil.TempFile("", "actpool.journal")
+	require.NoError(err)
+	require.NoError(journalFile.Close())
+	defer testutil.CleanupPath(t, journalFile.Name())
+	apConfig := getActPoolCfg()
+	apConfig.Journal = journalFile.Name()
+	newActPool := func() *actPool {
+		Ap, err := NewActPool(bc, apConfig)
+		require.NoError(err)
+		ap, ok := Ap.(*actPool)
+		require.True(ok)
+		ap.AddActionValidators(account.NewProtocol())
+		require.NoError(ap.Start(context.Background()))
+		return ap
+	}
+
+	tsf1, err := testutil.SignedTransfer(addr3, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr3, priKey1, uint64(2), big.NewInt(20), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr3, priKey2, uint64(1), big.NewInt(30), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	tsf4, err := testutil.SignedTransfer(addr3, priKey2, uint64(1), big.NewInt(30), []byte{}, uint64(10000), big.NewInt(2))
+	require.NoError(err)
+	ap := newActPool()
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	require.NoError(ap.Add(tsf3))
+	// tsf4 replaces tsf3
+	require.NoError(ap.Add(tsf4))
+	require.NoError(ap.Stop(context.Background()))
+
+	// tsf1 is committed to block while the node is down, and the journal has a corrupted tail
+	ws, err := bc.GetFactory().NewWorkingSet()
+	require.NoError(err)
+	ctx := protocol.WithRunActionsCtx(context.Background(),
+		protocol.RunActionsCtx{
+			Producer: testaddress.Addrinfo["producer"],
+			GasLimit: uint64(1000000),
+		})
+	_, err = ws.RunActions(ctx, 0, []action.SealedEnvelope{tsf1})
+	require.NoError(err)
+	require.NoError(bc.GetFactory().Commit(ws))
+	f, err := os.OpenFile(journalFile.Name(), os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(err)
+	_, err = f.Write([]byte{0, 0, 1})
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	ap = newActPool()
+	require.Equal(uint64(2), ap.GetSize())
+	_, err = ap.GetActionByHash(tsf1.Hash())
+	require.Error(err)
+	_, err = ap.GetActionByHash(tsf3.Hash())
+	require.Error(err)
+	pNonce, err := ap.getPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(3), pNonce)
+	pNonce, err = ap.getPendingNonce(addr2)
+	require.NoError(err)
+	require.Equal(uint64(2), pNonce)
+
+	// the journal is compacted into the actions in pool on startup
+	tsf5, err := testutil.SignedTransfer(addr3, priKey1, uint64(3), big.NewInt(50), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf5))
+	require.NoError(ap.Stop(context.Background()))
+	j := newJournal(journalFile.Name())
+	var journaled []hash.Hash256
+	require.NoError(j.load(func(selp action.SealedEnvelope) error {
+		journaled = append(journaled, selp.Hash())
+		return nil
+	}))
+	require.Equal(3, len(journaled))
+	require.Equal(tsf2.Hash(), journaled[0])
+	require.Equal(tsf5.Hash(), journaled[2])
+}
+
+func TestActPool_RateLimit(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+	_, err := bc.CreateState(addr1, big.NewInt(1000000))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(1000000))
+	require.NoError(err)
+	apConfig := getActPoolCfg()
+	apConfig.SenderRateLimit = ratelimit.Config{Rate: 0.01, Burst: 2, BanThreshold: 2, BanDuration: time.Hour}
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesis.Default.ActionGasLimit))
+
+	// actions with invalid signatures don't take the sender's quota
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr3, priKey1, nonce, big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+		require.NoError(err)
+		forged := action.AssembleSealedEnvelope(tsf.Envelope, tsf.SrcPubkey(), []byte("forged"))
+		require.Error(ap.Add(forged))
+		require.NotEqual(action.ErrActPool, errors.Cause(ap.Add(forged)))
+	}
+	require.False(ap.senderLimiter.Banned(addr1))
+
+	var tsfs []action.SealedEnvelope
+	for nonce := uint64(1); nonce <= 4; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr3, priKey1, nonce, big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+		require.NoError(err)
+		tsfs = append(tsfs, tsf)
+	}
+	require.NoError(ap.Add(tsfs[0]))
+	require.NoError(ap.Add(tsfs[1]))
+	// a duplicate action doesn't take the sender's quota
+	require.Error(ap.Add(tsfs[1]))
+	require.Equal(action.ErrActPool, errors.Cause(ap.Add(tsfs[2])))
+	// the sender is banned after being throttled repeatedly
+	require.Equal(action.ErrActPool, errors.Cause(ap.Add(tsfs[3])))
+	require.True(ap.senderLimiter.Banned(addr1))
+	require.Equal(uint64(2), ap.GetSize())
+	// other senders are not affected
+	tsf, err := testutil.SignedTransfer(addr3, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf))
+}
+
 // Helper function to return the correct pending nonce just in case of empty queue
 func (ap *actPool) getPendingNonce(addr string) (uint64, error) {
 	if queue, ok := ap.accountActs[addr]; ok {
@@ -52,6 +52,8 @@ func (h *noncePriorityQueue) Pop() interface{} {
 type ActQueue interface {
 	Overlaps(action.SealedEnvelope) bool
 	Put(action.SealedEnvelope) error
+	Get(uint64) (action.SealedEnvelope, bool)
+	Remove(uint64) (action.SealedEnvelope, bool)
 	FilterNonce(uint64) []action.SealedEnvelope
 	UpdateQueue(uint64) []action.SealedEnvelope
 	SetPendingNonce(uint64)
@@ -120,6 +122,28 @@ func (q *actQueue) Put(act action.SealedEnvelope) error {
 	return nil
 }
 
+// Get returns the action of the given nonce in the queue
+func (q *actQueue) Get(nonce uint64) (action.SealedEnvelope, bool) {
+	act, exist := q.items[nonce]
+	return act, exist
+}
+
+// Remove removes the action of the given nonce from the queue, while the pending nonce and balance are left unchanged
+func (q *actQueue) Remove(nonce uint64) (action.SealedEnvelope, bool) {
+	act, exist := q.items[nonce]
+	if !exist {
+		return action.SealedEnvelope{}, false
+	}
+	delete(q.items, nonce)
+	for i := range q.index {
+		if q.index[i].nonce == nonce {
+			heap.Remove(&q.index, i)
+			break
+		}
+	}
+	return act, true
+}
+
 // FilterNonce removes all actions from the map with a nonce lower than the given threshold
 func (q *actQueue) FilterNonce(threshold uint64) []action.SealedEnvelope {
 	var removed []action.SealedEnvelope
@@ -0,0 +1,171 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// maxJournalRecordSize is the maximal size of an action record in the journal
+const maxJournalRecordSize = 32 * 1024 * 1024
+
+// errNoActiveJournal is returned when writing to a journal which is not open
+var errNoActiveJournal = errors.New("no active journal")
+
+// journal is an append-only log of the actions accepted into the pool, which is replayed on startup so that the
+// queued actions survive a restart of the node. Each record is an action in protobuf prefixed by its 4-byte length.
+type journal struct {
+	path   string
+	writer *os.File
+}
+
+// newJournal creates a journal at the given path
+func newJournal(path string) *journal {
+	return &journal{path: path}
+}
+
+// load reads the actions in the journal and feeds them into add. An error returned by add only drops the action,
+// and a corrupted tail of the journal, left by a crash in the middle of a write, is ignored.
+func (j *journal) load(add func(action.SealedEnvelope) error) error {
+	f, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to open journal %s", j.path)
+	}
+	defer f.Close()
+
+	var total, dropped int
+	r := bufio.NewReader(f)
+	for {
+		selp, err := readJournalRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.L().Warn("Stop loading corrupted actpool journal.", zap.String("path", j.path), zap.Error(err))
+			break
+		}
+		total++
+		if err := add(selp); err != nil {
+			dropped++
+			log.L().Debug("Dropped journaled action.", zap.Error(err))
+		}
+	}
+	log.L().Info("Loaded actpool journal.",
+		zap.String("path", j.path),
+		zap.Int("actions", total),
+		zap.Int("dropped", dropped))
+	return nil
+}
+
+// insert appends an action to the journal
+func (j *journal) insert(selp action.SealedEnvelope) error {
+	if j.writer == nil {
+		return errNoActiveJournal
+	}
+	return writeJournalRecord(j.writer, selp)
+}
+
+// rotate regenerates the journal with the given actions, and opens it for appending
+func (j *journal) rotate(acts []action.SealedEnvelope) error {
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return errors.Wrapf(err, "failed to close journal %s", j.path)
+		}
+		j.writer = nil
+	}
+	tmpPath := j.path + ".new"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create journal %s", tmpPath)
+	}
+	w := bufio.NewWriter(f)
+	for _, selp := range acts {
+		if err := writeJournalRecord(w, selp); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to write journal %s", tmpPath)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close journal %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return errors.Wrapf(err, "failed to replace journal %s", j.path)
+	}
+	if j.writer, err = os.OpenFile(j.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644); err != nil {
+		return errors.Wrapf(err, "failed to open journal %s", j.path)
+	}
+	return nil
+}
+
+// close closes the journal
+func (j *journal) close() error {
+	if j.writer == nil {
+		return nil
+	}
+	err := j.writer.Close()
+	j.writer = nil
+	return err
+}
+
+func writeJournalRecord(w io.Writer, selp action.SealedEnvelope) error {
+	data, err := proto.Marshal(selp.Proto())
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal action")
+	}
+	record := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(record, uint32(len(data)))
+	copy(record[4:], data)
+	if _, err := w.Write(record); err != nil {
+		return errors.Wrap(err, "failed to write action to journal")
+	}
+	return nil
+}
+
+func readJournalRecord(r io.Reader) (action.SealedEnvelope, error) {
+	var selp action.SealedEnvelope
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return selp, errors.Wrap(err, "truncated record length")
+		}
+		return selp, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxJournalRecordSize {
+		return selp, errors.Errorf("record size %d exceeds limit", n)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return selp, errors.Wrap(err, "truncated record")
+	}
+	pbAct := &iotextypes.Action{}
+	if err := proto.Unmarshal(data, pbAct); err != nil {
+		return selp, errors.Wrap(err, "failed to unmarshal action")
+	}
+	if err := selp.LoadProto(pbAct); err != nil {
+		return selp, errors.Wrap(err, "failed to load action")
+	}
+	return selp, nil
+}
@@ -0,0 +1,17 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"github.com/iotexproject/iotex-core/action"
+)
+
+// PendingActionSubscriber is an interface which will get notified when an action becomes pending in pool, i.e., it is
+// ready to be picked into the next block. It is called with the pool locked, so it must not block.
+type PendingActionSubscriber interface {
+	HandlePendingAction(action.SealedEnvelope) error
+}
@@ -11,9 +11,13 @@ import (
 	"encoding/hex"
 	"math/big"
 	"net"
+	"reflect"
 	"strconv"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/pkg/errors"
@@ -26,12 +30,18 @@ import (
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/blocksync"
 	"github.com/iotexproject/iotex-core/config"
+	dpos "github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/trie"
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/gasstation"
 	"github.com/iotexproject/iotex-core/indexservice"
@@ -42,6 +52,7 @@ import (
 	"github.com/iotexproject/iotex-core/protogen/iotexapi"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
 )
 
 var (
@@ -59,6 +70,10 @@ type BroadcastOutbound func(ctx context.Context, chainID uint32, msg proto.Messa
 // Config represents the config to setup api
 type Config struct {
 	broadcastHandler BroadcastOutbound
+	bs               blocksync.BlockSync
+	evidencePool     *dpos.EvidencePool
+	timeline         *dpos.Timeline
+	genesis          *genesis.Genesis
 }
 
 // Option is the option to override the api config
@@ -72,17 +87,55 @@ func WithBroadcastOutbound(broadcastHandler BroadcastOutbound) Option {
 	}
 }
 
+// WithBlockSync is the option to report the block sync progress
+func WithBlockSync(bs blocksync.BlockSync) Option {
+	return func(cfg *Config) error {
+		cfg.bs = bs
+		return nil
+	}
+}
+
 // Server provides api for user to query blockchain data
 type Server struct {
 	bc               blockchain.Blockchain
 	dp               dispatcher.Dispatcher
 	ap               actpool.ActPool
+	bs               blocksync.BlockSync
 	gs               *gasstation.GasStation
 	broadcastHandler BroadcastOutbound
+	evidencePool     *dpos.EvidencePool
+	timeline         *dpos.Timeline
 	cfg              config.API
 	idx              *indexservice.Server
 	registry         *protocol.Registry
+	chainListener    *chainListener
+	actPoolListener  *actPoolListener
 	grpcserver       *grpc.Server
+	web3server       *web3Server
+}
+
+// WithEvidencePool is the option to serve the equivocation evidences in the pool
+func WithEvidencePool(evidencePool *dpos.EvidencePool) Option {
+	return func(cfg *Config) error {
+		cfg.evidencePool = evidencePool
+		return nil
+	}
+}
+
+// WithGenesis is the option to serve the chain of the genesis, which is required by the web3 API
+func WithGenesis(g genesis.Genesis) Option {
+	return func(cfg *Config) error {
+		cfg.genesis = &g
+		return nil
+	}
+}
+
+// WithTimeline is the option to serve the timeline of the consensus rounds
+func WithTimeline(timeline *dpos.Timeline) Option {
+	return func(cfg *Config) error {
+		cfg.timeline = timeline
+		return nil
+	}
 }
 
 // NewServer creates a new server
@@ -102,7 +155,7 @@ func NewServer(
 		}
 	}
 
-	if cfg == (config.API{}) {
+	if reflect.DeepEqual(cfg, config.API{}) {
 		log.L().Warn("API server is not configured.")
 		cfg = config.Default.API
 	}
@@ -115,10 +168,15 @@ func NewServer(
 		bc:               chain,
 		dp:               dispatcher,
 		ap:               actPool,
+		bs:               apiCfg.bs,
 		broadcastHandler: apiCfg.broadcastHandler,
+		evidencePool:     apiCfg.evidencePool,
+		timeline:         apiCfg.timeline,
 		cfg:              cfg,
 		idx:              idx,
 		registry:         registry,
+		chainListener:    newChainListener(),
+		actPoolListener:  newActPoolListener(),
 		gs:               gasstation.NewGasStation(chain, cfg),
 	}
 
@@ -130,14 +188,25 @@ func NewServer(
 	grpc_prometheus.Register(svr.grpcserver)
 	reflection.Register(svr.grpcserver)
 
+	if cfg.Web3Port != 0 {
+		if apiCfg.genesis == nil {
+			return nil, errors.New("genesis is required by the web3 API")
+		}
+		web3server, err := newWeb3Server(svr, cfg, *apiCfg.genesis)
+		if err != nil {
+			return nil, err
+		}
+		svr.web3server = web3server
+	}
+
 	return svr, nil
 }
 
 // GetAccount returns the metadata of an account
 func (api *Server) GetAccount(ctx context.Context, in *iotexapi.GetAccountRequest) (*iotexapi.GetAccountResponse, error) {
-	state, err := api.bc.StateByAddr(in.Address)
+	state, err := api.accountState(in.Address, in.Height)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, err
 	}
 	pendingNonce, err := api.ap.GetPendingNonce(in.Address)
 	if err != nil {
@@ -168,7 +237,7 @@ func (api *Server) GetActions(ctx context.Context, in *iotexapi.GetActionsReques
 		return api.getSingleAction(request.ActionHash, request.CheckPending)
 	case in.GetByAddr() != nil:
 		request := in.GetByAddr()
-		return api.getActionsByAddress(request.Address, request.Start, request.Count)
+		return api.getActionsByAddress(request)
 	case in.GetUnconfirmedByAddr() != nil:
 		request := in.GetUnconfirmedByAddr()
 		return api.getUnconfirmedActionsByAddress(request.Address, request.Start, request.Count)
@@ -263,8 +332,9 @@ func (api *Server) GetChainMeta(ctx context.Context, in *iotexapi.GetChainMetaRe
 			Height:                  epochHeight,
 			GravityChainStartHeight: gravityChainStartHeight,
 		},
-		NumActions: int64(totalActions),
-		Tps:        tps,
+		NumActions:   int64(totalActions),
+		Tps:          tps,
+		LowestHeight: api.bc.LowestAvailableHeight(),
 	}
 
 	return &iotexapi.GetChainMetaResponse{ChainMeta: chainMeta}, nil
@@ -273,13 +343,17 @@ func (api *Server) GetChainMeta(ctx context.Context, in *iotexapi.GetChainMetaRe
 // GetServerMeta gets the server metadata
 func (api *Server) GetServerMeta(ctx context.Context,
 	in *iotexapi.GetServerMetaRequest) (*iotexapi.GetServerMetaResponse, error) {
-	return &iotexapi.GetServerMetaResponse{ServerMeta: &iotextypes.ServerMeta{
+	res := &iotexapi.GetServerMetaResponse{ServerMeta: &iotextypes.ServerMeta{
 		PackageVersion:  version.PackageVersion,
 		PackageCommitID: version.PackageCommitID,
 		GitStatus:       version.GitStatus,
 		GoVersion:       version.GoVersion,
 		BuildTime:       version.BuildTime,
-	}}, nil
+	}}
+	if api.bs != nil {
+		res.SyncStatus = convertSyncStatus(api.bs.SyncStatus())
+	}
+	return res, nil
 }
 
 // SendAction is the API to send an action to blockchain.
@@ -310,7 +384,7 @@ func (api *Server) GetReceiptByAction(ctx context.Context, in *iotexapi.GetRecei
 	}
 	receipt, err := api.bc.GetReceiptByActionHash(actHash)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, blockDataError(err)
 	}
 	blkHash, err := api.bc.GetBlockHashByActionHash(actHash)
 	if err != nil {
@@ -318,8 +392,9 @@ func (api *Server) GetReceiptByAction(ctx context.Context, in *iotexapi.GetRecei
 	}
 	return &iotexapi.GetReceiptByActionResponse{
 		ReceiptInfo: &iotexapi.ReceiptInfo{
-			Receipt: receipt.ConvertToReceiptPb(),
-			BlkHash: hex.EncodeToString(blkHash[:]),
+			Receipt:      receipt.ConvertToReceiptPb(),
+			BlkHash:      hex.EncodeToString(blkHash[:]),
+			RevertReason: receipt.RevertReason(),
 		},
 	}, nil
 }
@@ -342,9 +417,26 @@ func (api *Server) ReadContract(ctx context.Context, in *iotexapi.ReadContractRe
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	retval, receipt, err := api.bc.ExecuteContractRead(callerAddr, sc)
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	var (
+		retval  []byte
+		receipt *action.Receipt
+	)
+	if in.Height == 0 {
+		retval, receipt, err = api.bc.ExecuteContractRead(callerAddr, sc)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	} else {
+		if err := api.checkHistoricalHeight(in.Height); err != nil {
+			return nil, err
+		}
+		retval, receipt, err = api.bc.ExecuteContractReadAtHeight(callerAddr, sc, in.Height)
+		if err != nil {
+			return nil, historicalStateError(err)
+		}
+	}
+	if receipt.Status != action.SuccessReceiptStatus {
+		return nil, executionError(receipt)
 	}
 	return &iotexapi.ReadContractResponse{
 		Data:    hex.EncodeToString(retval),
@@ -448,8 +540,347 @@ func (api *Server) GetEpochMeta(
 	}, nil
 }
 
+// GetLogs gets the logs matching the filter within the block range
+func (api *Server) GetLogs(ctx context.Context, in *iotexapi.GetLogsRequest) (*iotexapi.GetLogsResponse, error) {
+	filter := in.GetFilter()
+	start, end := in.FromBlock, in.ToBlock
+	tipHeight := api.bc.TipHeight()
+	if start == 0 {
+		start = 1
+	}
+	if end == 0 || end > tipHeight {
+		end = tipHeight
+	}
+	if start > end {
+		return nil, status.Error(codes.InvalidArgument, "invalid block range")
+	}
+	for _, addr := range filter.GetAddress() {
+		if _, err := address.FromString(addr); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	topics := make([][]hash.Hash256, 0, len(filter.GetTopics()))
+	for _, position := range filter.GetTopics() {
+		hashes := make([]hash.Hash256, 0, len(position.GetTopic()))
+		for _, topic := range position.GetTopic() {
+			if len(topic) != len(hash.ZeroHash256) {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid topic %x", topic)
+			}
+			hashes = append(hashes, hash.BytesToHash256(topic))
+		}
+		topics = append(topics, hashes)
+	}
+	// the logs of the pruned blocks are not available
+	if lowest := api.bc.LowestAvailableHeight(); start < lowest {
+		start = lowest
+	}
+	if start > end {
+		return &iotexapi.GetLogsResponse{}, nil
+	}
+	// the range is bounded even with the criteria, which could match every block in it
+	if end-start+1 > api.cfg.RangeQueryLimit {
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+
+	heights, err := api.bc.GetLogBlockHeights(filter.GetAddress(), topics, start, end)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	lf := newLogFilter(filter)
+	logs := []*iotextypes.Log{}
+	for _, height := range heights {
+		receipts, err := api.bc.GetReceiptsByHeight(height)
+		if errors.Cause(err) == db.ErrNotExist {
+			// the block has no receipts
+			continue
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		for _, l := range lf.matchLogs(receipts) {
+			logs = append(logs, l.ConvertToLogPb())
+		}
+	}
+	return &iotexapi.GetLogsResponse{Logs: logs}, nil
+}
+
+// StreamBlocks streams newly committed blocks together with their receipts
+func (api *Server) StreamBlocks(in *iotexapi.StreamBlocksRequest, stream iotexapi.APIService_StreamBlocksServer) error {
+	return api.streamNewBlocks(stream.Context(), func(blk *block.Block) error {
+		receipts := make([]*iotextypes.Receipt, 0, len(blk.Receipts))
+		for _, receipt := range blk.Receipts {
+			receipts = append(receipts, receipt.ConvertToReceiptPb())
+		}
+		return stream.Send(&iotexapi.StreamBlocksResponse{
+			Block:    blk.ConvertToBlockPb(),
+			Receipts: receipts,
+		})
+	})
+}
+
+// StreamLogs streams logs of newly committed blocks that match the filter
+func (api *Server) StreamLogs(in *iotexapi.StreamLogsRequest, stream iotexapi.APIService_StreamLogsServer) error {
+	filter := newLogFilter(in.GetFilter())
+	return api.streamNewBlocks(stream.Context(), func(blk *block.Block) error {
+		for _, l := range filter.matchLogs(blk.Receipts) {
+			if err := stream.Send(&iotexapi.StreamLogsResponse{Log: l.ConvertToLogPb()}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetAccountProof returns the merkle proof of an account in the state trie at the given height
+func (api *Server) GetAccountProof(ctx context.Context, in *iotexapi.GetAccountProofRequest) (*iotexapi.GetAccountProofResponse, error) {
+	addr, err := address.FromString(in.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	height := in.Height
+	tipHeight := api.bc.TipHeight()
+	if height == 0 {
+		height = tipHeight
+	}
+	if height > tipHeight {
+		return nil, status.Error(codes.InvalidArgument, "height is higher than the tip height")
+	}
+	sf := api.bc.GetFactory()
+	rootHash, err := sf.RootHashByHeight(height)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	proof, err := sf.StateProof(hash.BytesToHash160(addr.Bytes()), rootHash)
+	if err != nil {
+		return nil, stateProofError(err)
+	}
+	return &iotexapi.GetAccountProofResponse{
+		Height:    height,
+		StateRoot: rootHash[:],
+		Proof:     proof,
+	}, nil
+}
+
+// TraceAction re-executes an execution on the state of its parent block and returns the EVM trace
+func (api *Server) TraceAction(ctx context.Context, in *iotexapi.TraceActionRequest) (*iotexapi.TraceActionResponse, error) {
+	actHash, err := toHash256(in.ActionHash)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	selp, err := api.bc.GetActionByActionHash(actHash)
+	if err != nil {
+		return nil, blockDataError(err)
+	}
+	if _, ok := selp.Action().(*action.Execution); !ok {
+		return nil, status.Error(codes.InvalidArgument, "not an execution")
+	}
+	structLogger := vm.NewStructLogger(&vm.LogConfig{
+		DisableMemory:  in.DisableMemory,
+		DisableStack:   in.DisableStack,
+		DisableStorage: true,
+	})
+	callTracer := evm.NewCallTracer()
+	retval, receipt, err := api.bc.TraceExecution(actHash, evm.NewMultiTracer(structLogger, callTracer))
+	if err != nil {
+		return nil, historicalStateError(err)
+	}
+	res := &iotexapi.TraceActionResponse{
+		Receipt: receipt.ConvertToReceiptPb(),
+		Output:  retval,
+	}
+	for _, structLog := range structLogger.StructLogs() {
+		logPb := &iotexapi.StructLog{
+			Pc:      structLog.Pc,
+			Op:      structLog.OpName(),
+			Gas:     structLog.Gas,
+			GasCost: structLog.GasCost,
+			Memory:  structLog.Memory,
+			Depth:   int32(structLog.Depth),
+			Error:   structLog.ErrorString(),
+		}
+		for _, item := range structLog.Stack {
+			logPb.Stack = append(logPb.Stack, hexutil.EncodeBig(item))
+		}
+		res.StructLogs = append(res.StructLogs, logPb)
+	}
+	if callTrace := callTracer.CallTrace(); callTrace != nil {
+		if res.CallTrace, err = convertCallFrame(callTrace); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return res, nil
+}
+
+// GetActPoolStatus returns the status of the actions waiting in the actpool
+func (api *Server) GetActPoolStatus(
+	ctx context.Context,
+	in *iotexapi.GetActPoolStatusRequest,
+) (*iotexapi.GetActPoolStatusResponse, error) {
+	if in.Address == "" && in.Count > api.cfg.RangeQueryLimit {
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	res := &iotexapi.GetActPoolStatusResponse{
+		Size:        api.ap.GetSize(),
+		Capacity:    api.ap.GetCapacity(),
+		GasSize:     api.ap.GetGasSize(),
+		GasCapacity: api.ap.GetGasCapacity(),
+	}
+	statuses := api.ap.GetAccountStatuses()
+	res.NumSenders = uint64(len(statuses))
+	for _, s := range statuses {
+		res.NumPending += uint64(len(s.PendingActs))
+		res.NumQueued += uint64(len(s.QueuedActs))
+	}
+	if in.Address != "" {
+		if _, err := address.FromString(in.Address); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		for _, s := range statuses {
+			if s.Address == in.Address {
+				res.Accounts = append(res.Accounts, convertAccountStatus(s))
+				break
+			}
+		}
+		return res, nil
+	}
+	for i := in.Start; i < uint64(len(statuses)) && i < in.Start+in.Count; i++ {
+		res.Accounts = append(res.Accounts, convertAccountStatus(statuses[i]))
+	}
+	return res, nil
+}
+
+// StreamPendingActions streams the hashes of actions newly becoming pending in the actpool
+func (api *Server) StreamPendingActions(
+	in *iotexapi.StreamPendingActionsRequest,
+	stream iotexapi.APIService_StreamPendingActionsServer,
+) error {
+	responder := newActionResponder(actionStreamBufferSize)
+	if err := api.actPoolListener.AddResponder(responder); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer api.actPoolListener.RemoveResponder(responder)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			// client disconnected
+			return nil
+		case err := <-responder.errChan:
+			return status.Error(codes.Aborted, err.Error())
+		case actHash := <-responder.pending:
+			if err := stream.Send(&iotexapi.StreamPendingActionsResponse{ActionHash: hex.EncodeToString(actHash[:])}); err != nil {
+				log.L().Info("Failed to send to stream client.", log.Hex("hash", actHash[:]), zap.Error(err))
+				return status.Error(codes.Unavailable, err.Error())
+			}
+		}
+	}
+}
+
+// GetConsensusEvidences returns the evidences of delegates endorsing conflicting consensus messages
+func (api *Server) GetConsensusEvidences(
+	ctx context.Context,
+	in *iotexapi.GetConsensusEvidencesRequest,
+) (*iotexapi.GetConsensusEvidencesResponse, error) {
+	if in.Count == 0 || in.Count > api.cfg.RangeQueryLimit {
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	if api.evidencePool == nil {
+		return nil, status.Error(codes.Unavailable, "consensus evidences are not recorded by the node")
+	}
+	evidences, err := api.evidencePool.Evidences(in.StartHeight, in.Count)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := &iotexapi.GetConsensusEvidencesResponse{}
+	for _, e := range evidences {
+		ePb, err := e.Proto()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		res.Evidences = append(res.Evidences, ePb)
+	}
+	return res, nil
+}
+
+// GetConsensusTimeline returns the timeline of the latest consensus rounds observed by the node, and the numbers of
+// proposals and endorsements missed by the delegates
+func (api *Server) GetConsensusTimeline(
+	ctx context.Context,
+	in *iotexapi.GetConsensusTimelineRequest,
+) (*iotexapi.GetConsensusTimelineResponse, error) {
+	if in.Count == 0 || in.Count > api.cfg.RangeQueryLimit {
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	if api.timeline == nil {
+		return nil, status.Error(codes.Unavailable, "consensus timeline is not recorded by the node")
+	}
+	rounds, err := api.timeline.Rounds(in.Count)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &iotexapi.GetConsensusTimelineResponse{
+		Rounds:   rounds,
+		Liveness: api.timeline.Liveness(),
+	}, nil
+}
+
+// GetTokenTransfersByAddress returns a page of the ERC20 token transfers sent or received by an address from the
+// relational index
+func (api *Server) GetTokenTransfersByAddress(
+	ctx context.Context,
+	in *iotexapi.GetTokenTransfersByAddressRequest,
+) (*iotexapi.GetTokenTransfersByAddressResponse, error) {
+	if !api.cfg.UseRDS || api.idx == nil {
+		return nil, status.Error(codes.Unavailable, "token transfers are not indexed by the node")
+	}
+	if in.Count > api.cfg.RangeQueryLimit {
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	if in.Count == 0 {
+		return &iotexapi.GetTokenTransfersByAddressResponse{}, nil
+	}
+	q, err := newAddressQuery(in.Address, in.Start, in.Count, in.Cursor, in.StartTime, in.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	page, err := api.idx.Indexer().GetTokenTransfersByAddress(q)
+	if err != nil {
+		return nil, indexQueryError(err)
+	}
+	res := &iotexapi.GetTokenTransfersByAddressResponse{
+		Transfers:  make([]*iotexapi.TokenTransfer, 0, len(page.Transfers)),
+		NextCursor: page.NextCursor,
+	}
+	for _, tt := range page.Transfers {
+		ts, err := ptypes.TimestampProto(tt.Timestamp)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		res.Transfers = append(res.Transfers, &iotexapi.TokenTransfer{
+			ActionHash: hex.EncodeToString(tt.ActionHash[:]),
+			LogIndex:   tt.LogIndex,
+			BlkHeight:  tt.BlockHeight,
+			Timestamp:  ts,
+			Token:      tt.Token,
+			Sender:     tt.Sender,
+			Recipient:  tt.Recipient,
+			Amount:     tt.Amount.String(),
+		})
+	}
+	return res, nil
+}
+
 // Start starts the API server
 func (api *Server) Start() error {
+	if err := api.bc.AddSubscriber(api.chainListener); err != nil {
+		return errors.Wrap(err, "failed to subscribe to block creations")
+	}
+	if err := api.ap.AddSubscriber(api.actPoolListener); err != nil {
+		return errors.Wrap(err, "failed to subscribe to pending actions")
+	}
 	portStr := ":" + strconv.Itoa(api.cfg.Port)
 	lis, err := net.Listen("tcp", portStr)
 	if err != nil {
@@ -463,30 +894,84 @@ func (api *Server) Start() error {
 			log.L().Fatal("Node failed to serve.", zap.Error(err))
 		}
 	}()
+	if api.web3server != nil {
+		return api.web3server.Start()
+	}
 	return nil
 }
 
 // Stop stops the API server
 func (api *Server) Stop() error {
+	api.chainListener.Stop()
+	if err := api.bc.RemoveSubscriber(api.chainListener); err != nil {
+		return errors.Wrap(err, "failed to unsubscribe block creations")
+	}
+	api.actPoolListener.Stop()
+	if err := api.ap.RemoveSubscriber(api.actPoolListener); err != nil {
+		return errors.Wrap(err, "failed to unsubscribe pending actions")
+	}
 	api.grpcserver.Stop()
+	if api.web3server != nil {
+		if err := api.web3server.Stop(); err != nil {
+			return errors.Wrap(err, "failed to stop web3 server")
+		}
+	}
 	log.L().Info("API server stops.")
 	return nil
 }
 
+// streamNewBlocks feeds each newly committed block to send until the client disconnects or falls behind
+func (api *Server) streamNewBlocks(ctx context.Context, send func(*block.Block) error) error {
+	responder := newBlockResponder(streamBufferSize)
+	if err := api.chainListener.AddResponder(responder); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer api.chainListener.RemoveResponder(responder)
+
+	for {
+		select {
+		case <-ctx.Done():
+			// client disconnected
+			return nil
+		case err := <-responder.errChan:
+			return status.Error(codes.Aborted, err.Error())
+		case blk := <-responder.pending:
+			if err := send(blk); err != nil {
+				log.L().Info("Failed to send to stream client.", zap.Uint64("height", blk.Height()), zap.Error(err))
+				return status.Error(codes.Unavailable, err.Error())
+			}
+		}
+	}
+}
+
 func (api *Server) readState(ctx context.Context, in *iotexapi.ReadStateRequest) (*iotexapi.ReadStateResponse, error) {
 	p, ok := api.registry.Find(string(in.ProtocolID))
 	if !ok {
 		return nil, status.Errorf(codes.Internal, "protocol %s isn't registered", string(in.ProtocolID))
 	}
+	var (
+		height = api.bc.TipHeight()
+		ws     factory.WorkingSet
+		err    error
+	)
+	if in.Height == 0 {
+		if ws, err = api.bc.GetFactory().NewWorkingSet(); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	} else {
+		if err := api.checkHistoricalHeight(in.Height); err != nil {
+			return nil, err
+		}
+		height = in.Height
+		if ws, err = api.bc.GetFactory().NewWorkingSetAtHeight(in.Height); err != nil {
+			return nil, historicalStateError(err)
+		}
+	}
 	// TODO: need to complete the context
 	ctx = protocol.WithRunActionsCtx(ctx, protocol.RunActionsCtx{
-		BlockHeight: api.bc.TipHeight(),
+		BlockHeight: height,
 		Registry:    api.registry,
 	})
-	ws, err := api.bc.GetFactory().NewWorkingSet()
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
 	data, err := p.ReadState(ctx, ws, in.MethodName, in.Arguments...)
 	// TODO: need to distinguish user error and system error
 	if err != nil {
@@ -510,7 +995,7 @@ func (api *Server) getActions(start uint64, count uint64) (*iotexapi.GetActionsR
 	for height := 1; height <= int(tipHeight); height++ {
 		blk, err := api.bc.GetBlockByHeight(uint64(height))
 		if err != nil {
-			return nil, status.Error(codes.NotFound, err.Error())
+			return nil, blockDataError(err)
 		}
 		selps := blk.Actions
 		for i := 0; i < len(selps); i++ {
@@ -541,6 +1026,9 @@ func (api *Server) getSingleAction(actionHash string, checkPending bool) (*iotex
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	act, err := api.getAction(actHash, checkPending)
+	if errors.Cause(err) == blockchain.ErrBlockPruned {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
 	if err != nil {
 		return nil, status.Error(codes.Unavailable, err.Error())
 	}
@@ -548,10 +1036,17 @@ func (api *Server) getSingleAction(actionHash string, checkPending bool) (*iotex
 }
 
 // getActionsByAddress returns all actions associated with an address
-func (api *Server) getActionsByAddress(address string, start uint64, count uint64) (*iotexapi.GetActionsResponse, error) {
+func (api *Server) getActionsByAddress(in *iotexapi.GetActionsByAddressRequest) (*iotexapi.GetActionsResponse, error) {
+	address, start, count := in.Address, in.Start, in.Count
 	if count > api.cfg.RangeQueryLimit {
 		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
 	}
+	if api.cfg.UseRDS && api.idx != nil {
+		return api.getActionsByAddressFromIndex(in)
+	}
+	if in.Cursor != "" || len(in.ActionTypes) > 0 || in.StartTime != nil || in.EndTime != nil {
+		return nil, status.Error(codes.InvalidArgument, "cursor and filters require the relational index")
+	}
 
 	var res []*iotexapi.ActionInfo
 	actions, err := api.getTotalActionsByAddress(address)
@@ -572,7 +1067,7 @@ func (api *Server) getActionsByAddress(address string, start uint64, count uint6
 
 		act, err := api.getAction(actions[i], false)
 		if err != nil {
-			return nil, status.Error(codes.NotFound, err.Error())
+			return nil, blockDataError(err)
 		}
 
 		res = append(res, act)
@@ -581,6 +1076,68 @@ func (api *Server) getActionsByAddress(address string, start uint64, count uint6
 	return &iotexapi.GetActionsResponse{ActionInfo: res}, nil
 }
 
+// getActionsByAddressFromIndex returns a page of the actions associated with an address from the relational index
+func (api *Server) getActionsByAddressFromIndex(in *iotexapi.GetActionsByAddressRequest) (*iotexapi.GetActionsResponse, error) {
+	if in.Count == 0 {
+		return &iotexapi.GetActionsResponse{}, nil
+	}
+	q, err := newAddressQuery(in.Address, in.Start, in.Count, in.Cursor, in.StartTime, in.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	q.ActionTypes = in.ActionTypes
+	page, err := api.idx.Indexer().GetActionsByAddress(q)
+	if err != nil {
+		return nil, indexQueryError(err)
+	}
+	res := make([]*iotexapi.ActionInfo, 0, len(page.Actions))
+	for _, record := range page.Actions {
+		act, err := api.getAction(record.ActionHash, false)
+		if err != nil {
+			return nil, blockDataError(err)
+		}
+		res = append(res, act)
+	}
+	return &iotexapi.GetActionsResponse{ActionInfo: res, NextCursor: page.NextCursor}, nil
+}
+
+// newAddressQuery returns the query of a page of the history of an address in the relational index
+func newAddressQuery(
+	addr string,
+	start uint64,
+	count uint64,
+	cursor string,
+	startTime *timestamp.Timestamp,
+	endTime *timestamp.Timestamp,
+) (*indexservice.AddressQuery, error) {
+	q := &indexservice.AddressQuery{
+		Address: addr,
+		Cursor:  cursor,
+		Offset:  start,
+		Limit:   count,
+	}
+	var err error
+	if startTime != nil {
+		if q.StartTime, err = ptypes.Timestamp(startTime); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if endTime != nil {
+		if q.EndTime, err = ptypes.Timestamp(endTime); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return q, nil
+}
+
+// indexQueryError converts an error of querying the relational index into a grpc status
+func indexQueryError(err error) error {
+	if errors.Cause(err) == indexservice.ErrInvalidQuery {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
 // getUnconfirmedActionsByAddress returns all unconfirmed actions in actpool associated with an address
 func (api *Server) getUnconfirmedActionsByAddress(address string, start uint64, count uint64) (*iotexapi.GetActionsResponse, error) {
 	if count > api.cfg.RangeQueryLimit {
@@ -624,7 +1181,7 @@ func (api *Server) getActionsByBlock(blkHash string, start uint64, count uint64)
 
 	blk, err := api.bc.GetBlockByHash(hash)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, blockDataError(err)
 	}
 
 	selps := blk.Actions
@@ -666,7 +1223,7 @@ func (api *Server) getBlockMetas(start uint64, number uint64) (*iotexapi.GetBloc
 		}
 		blk, err := api.bc.GetBlockByHeight(uint64(height))
 		if err != nil {
-			return nil, status.Error(codes.NotFound, err.Error())
+			return nil, blockDataError(err)
 		}
 		blockHeaderPb := blk.ConvertToBlockHeaderPb()
 
@@ -703,7 +1260,7 @@ func (api *Server) getBlockMeta(blkHash string) (*iotexapi.GetBlockMetasResponse
 
 	blk, err := api.bc.GetBlockByHash(hash)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, blockDataError(err)
 	}
 
 	blkHeaderPb := blk.ConvertToBlockHeaderPb()
@@ -771,7 +1328,7 @@ func (api *Server) getAction(actHash hash.Hash256, checkPending bool) (*iotexapi
 	var selp action.SealedEnvelope
 	var err error
 	if selp, err = api.bc.GetActionByActionHash(actHash); err != nil {
-		if checkPending {
+		if checkPending && errors.Cause(err) != blockchain.ErrBlockPruned {
 			// Try to fetch pending action from actpool
 			selp, err = api.ap.GetActionByHash(actHash)
 		}
@@ -783,28 +1340,114 @@ func (api *Server) getAction(actHash hash.Hash256, checkPending bool) (*iotexapi
 }
 
 func (api *Server) getTotalActionsByAddress(address string) ([]hash.Hash256, error) {
-	var actions []hash.Hash256
-	if api.cfg.UseRDS {
-		actionHistory, err := api.idx.Indexer().GetIndexHistory(config.IndexAction, address)
-		if err != nil {
-			return nil, err
-		}
-		actions = append(actions, actionHistory...)
-	} else {
-		actionsFromAddress, err := api.bc.GetActionsFromAddress(address)
+	actionsFromAddress, err := api.bc.GetActionsFromAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	actionsToAddress, err := api.bc.GetActionsToAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(actionsFromAddress, actionsToAddress...), nil
+}
+
+// accountState returns the account state at the height, or at the tip if height is 0
+func (api *Server) accountState(addr string, height uint64) (*state.Account, error) {
+	if height == 0 {
+		s, err := api.bc.StateByAddr(addr)
 		if err != nil {
-			return nil, err
+			return nil, status.Error(codes.NotFound, err.Error())
 		}
+		return s, nil
+	}
+	if err := api.checkHistoricalHeight(height); err != nil {
+		return nil, err
+	}
+	s, err := api.bc.GetFactory().AccountStateAtHeight(addr, height)
+	if err != nil {
+		return nil, historicalStateError(err)
+	}
+	return s, nil
+}
+
+// checkHistoricalHeight checks the height of a historical state query
+func (api *Server) checkHistoricalHeight(height uint64) error {
+	if height > api.bc.TipHeight() {
+		return status.Error(codes.InvalidArgument, "height is higher than the tip height")
+	}
+	return nil
+}
+
+// blockDataError converts the error of a block or receipt query into grpc status
+func blockDataError(err error) error {
+	if errors.Cause(err) == blockchain.ErrBlockPruned {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.NotFound, err.Error())
+}
+
+// historicalStateError converts the error of a historical state query into grpc status
+func historicalStateError(err error) error {
+	if errors.Cause(err) == factory.ErrNotArchiveMode {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// stateProofError converts the error of proving a state against a known root into grpc status. The trie nodes of the
+// root missing from the DB are pruned, which are only kept in archive mode or within the retention window.
+func stateProofError(err error) error {
+	switch errors.Cause(err) {
+	case db.ErrNotExist:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case trie.ErrNotExist:
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
 
-		actionsToAddress, err := api.bc.GetActionsToAddress(address)
+func convertCallFrame(frame *evm.CallFrame) (*iotexapi.CallFrame, error) {
+	from, err := address.FromBytes(frame.From.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	to, err := address.FromBytes(frame.To.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	framePb := &iotexapi.CallFrame{
+		Type:    frame.Type,
+		From:    from.String(),
+		To:      to.String(),
+		Value:   frame.Value.String(),
+		Gas:     frame.Gas,
+		GasUsed: frame.GasUsed,
+		Input:   frame.Input,
+		Output:  frame.Output,
+		Error:   frame.Error,
+	}
+	for _, call := range frame.Calls {
+		callPb, err := convertCallFrame(call)
 		if err != nil {
 			return nil, err
 		}
+		framePb.Calls = append(framePb.Calls, callPb)
+	}
+	return framePb, nil
+}
 
-		actionsFromAddress = append(actionsFromAddress, actionsToAddress...)
-		actions = append(actions, actionsFromAddress...)
+// executionError converts a failed execution into grpc status, carrying the revert reason if there is one
+func executionError(receipt *action.Receipt) error {
+	if len(receipt.RevertData) == 0 {
+		return status.Error(codes.Aborted, "execution failed")
+	}
+	msg := "execution reverted"
+	if reason := receipt.RevertReason(); reason != "" {
+		msg += ": " + reason
 	}
-	return actions, nil
+	return status.Error(codes.Aborted, msg)
 }
 
 func toHash256(hashString string) (hash.Hash256, error) {
@@ -828,3 +1471,49 @@ func getTranferAmountInBlock(blk *block.Block) *big.Int {
 	}
 	return totalAmount
 }
+
+// convertSyncStatus converts the progress of the block sync into protobuf
+func convertSyncStatus(status blocksync.SyncStatus) *iotexapi.SyncStatus {
+	pb := &iotexapi.SyncStatus{
+		StartHeight:     status.StartHeight,
+		TipHeight:       status.TipHeight,
+		HeaderHeight:    status.HeaderHeight,
+		TargetHeight:    status.TargetHeight,
+		BlocksPerSecond: status.BlocksPerSecond,
+		EtaSeconds:      uint64(status.ETA.Seconds()),
+	}
+	for _, p := range status.Peers {
+		pb.Peers = append(pb.Peers, &iotexapi.SyncPeerStatus{
+			Id:              p.ID,
+			Score:           int64(p.Score),
+			Blocks:          p.Blocks,
+			BlocksPerSecond: p.BlocksPerSecond,
+			Banned:          p.Banned,
+		})
+	}
+	return pb
+}
+
+// convertAccountStatus converts the status of a sender in actpool into protobuf, locating the nonce gaps before the
+// queued actions
+func convertAccountStatus(s actpool.AccountStatus) *iotexapi.ActPoolAccountStatus {
+	res := &iotexapi.ActPoolAccountStatus{
+		Address:      s.Address,
+		PendingNonce: s.PendingNonce,
+	}
+	for _, selp := range s.PendingActs {
+		h := selp.Hash()
+		res.PendingActionHashes = append(res.PendingActionHashes, hex.EncodeToString(h[:]))
+	}
+	nonce := s.PendingNonce
+	for _, selp := range s.QueuedActs {
+		h := selp.Hash()
+		res.QueuedActionHashes = append(res.QueuedActionHashes, hex.EncodeToString(h[:]))
+		res.QueuedNonces = append(res.QueuedNonces, selp.Nonce())
+		if selp.Nonce() > nonce {
+			res.NonceGaps = append(res.NonceGaps, &iotexapi.NonceRange{Start: nonce, End: selp.Nonce() - 1})
+		}
+		nonce = selp.Nonce() + 1
+	}
+	return res
+}
@@ -16,12 +16,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-election/test/mock/mock_committee"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
@@ -34,9 +39,16 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/vote"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/blocksync"
 	"github.com/iotexproject/iotex-core/config"
+	dpos "github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/gasstation"
+	"github.com/iotexproject/iotex-core/indexservice"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/unit"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
@@ -46,6 +58,7 @@ import (
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
 	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
 	"github.com/iotexproject/iotex-core/test/mock/mock_factory"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
@@ -551,6 +564,83 @@ func TestServer_GetAccount(t *testing.T) {
 	require.Error(err)
 }
 
+func TestServer_GetAccountProof(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	svr, err := createServer(cfg, true)
+	require.NoError(err)
+
+	for _, test := range getAccountTests {
+		res, err := svr.GetAccountProof(context.Background(), &iotexapi.GetAccountProofRequest{Address: test.in})
+		require.NoError(err)
+		require.Equal(svr.bc.TipHeight(), res.Height)
+		rootHash, err := svr.bc.GetFactory().RootHashByHeight(res.Height)
+		require.NoError(err)
+		require.Equal(rootHash[:], res.StateRoot)
+		addr, err := address.FromString(test.in)
+		require.NoError(err)
+		data, err := trie.VerifyProof(res.StateRoot, addr.Bytes(), res.Proof)
+		require.NoError(err)
+		var account state.Account
+		require.NoError(state.Deserialize(&account, data))
+		require.Equal(test.balance, account.Balance.String())
+		require.Equal(test.nonce, account.Nonce)
+	}
+	// proof of absence
+	pkHash := hash.Hash160b([]byte("nonexistent"))
+	addr, err := address.FromBytes(pkHash[:])
+	require.NoError(err)
+	res, err := svr.GetAccountProof(context.Background(), &iotexapi.GetAccountProofRequest{
+		Address: addr.String(),
+		Height:  svr.bc.TipHeight(),
+	})
+	require.NoError(err)
+	_, err = trie.VerifyProof(res.StateRoot, pkHash[:], res.Proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+	// failure
+	_, err = svr.GetAccountProof(context.Background(), &iotexapi.GetAccountProofRequest{})
+	require.Error(err)
+	_, err = svr.GetAccountProof(context.Background(), &iotexapi.GetAccountProofRequest{
+		Address: ta.Addrinfo["charlie"].String(),
+		Height:  svr.bc.TipHeight() + 1,
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	// the trie of an earlier root is pruned
+	_, err = svr.GetAccountProof(context.Background(), &iotexapi.GetAccountProofRequest{
+		Address: ta.Addrinfo["charlie"].String(),
+		Height:  1,
+	})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	sf := mock_factory.NewMockFactory(ctrl)
+	bc.EXPECT().TipHeight().Return(uint64(4)).AnyTimes()
+	bc.EXPECT().GetFactory().Return(sf).AnyTimes()
+	svr.bc = bc
+	for _, test := range []struct {
+		rootErr  error
+		proofErr error
+		code     codes.Code
+	}{
+		{errors.Wrap(db.ErrNotExist, "no root"), nil, codes.NotFound},
+		{errors.New("failed to read root"), nil, codes.Internal},
+		{nil, errors.Wrap(trie.ErrNotExist, "no root node"), codes.NotFound},
+		{nil, errors.New("failed to load trie"), codes.Internal},
+	} {
+		sf.EXPECT().RootHashByHeight(uint64(4)).Return(hash.ZeroHash256, test.rootErr).Times(1)
+		if test.rootErr == nil {
+			sf.EXPECT().StateProof(gomock.Any(), hash.ZeroHash256).Return(nil, test.proofErr).Times(1)
+		}
+		_, err = svr.GetAccountProof(context.Background(), &iotexapi.GetAccountProofRequest{
+			Address: ta.Addrinfo["charlie"].String(),
+		})
+		require.Equal(test.code, status.Code(err))
+	}
+}
+
 func TestServer_GetActions(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig()
@@ -630,6 +720,18 @@ func TestServer_GetActionsByAddress(t *testing.T) {
 		require.NoError(err)
 		require.Equal(test.numActions, len(res.ActionInfo))
 	}
+
+	// cursor and filters are only supported by the relational index
+	_, err = svr.GetActions(context.Background(), &iotexapi.GetActionsRequest{
+		Lookup: &iotexapi.GetActionsRequest_ByAddr{
+			ByAddr: &iotexapi.GetActionsByAddressRequest{
+				Address:     ta.Addrinfo["charlie"].String(),
+				Count:       1,
+				ActionTypes: []string{"Transfer"},
+			},
+		},
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
 }
 
 func TestServer_GetUnconfirmedActionsByAddress(t *testing.T) {
@@ -786,6 +888,41 @@ func TestServer_GetChainMeta(t *testing.T) {
 	}
 }
 
+func TestServer_GetServerMeta(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svr := &Server{}
+	res, err := svr.GetServerMeta(context.Background(), &iotexapi.GetServerMetaRequest{})
+	require.NoError(err)
+	require.NotNil(res.ServerMeta)
+	require.Nil(res.SyncStatus)
+
+	bs := mock_blocksync.NewMockBlockSync(ctrl)
+	bs.EXPECT().SyncStatus().Return(blocksync.SyncStatus{
+		StartHeight:     10,
+		TipHeight:       30,
+		HeaderHeight:    80,
+		TargetHeight:    100,
+		BlocksPerSecond: 2,
+		ETA:             35 * time.Second,
+		Peers:           []blocksync.PeerStatus{{ID: "peer", Score: -3, Blocks: 20, BlocksPerSecond: 1.5, Banned: true}},
+	}).Times(1)
+	svr.bs = bs
+	res, err = svr.GetServerMeta(context.Background(), &iotexapi.GetServerMetaRequest{})
+	require.NoError(err)
+	require.Equal(&iotexapi.SyncStatus{
+		StartHeight:     10,
+		TipHeight:       30,
+		HeaderHeight:    80,
+		TargetHeight:    100,
+		BlocksPerSecond: 2,
+		EtaSeconds:      35,
+		Peers:           []*iotexapi.SyncPeerStatus{{Id: "peer", Score: -3, Blocks: 20, BlocksPerSecond: 1.5, Banned: true}},
+	}, res.SyncStatus)
+}
+
 func TestServer_SendAction(t *testing.T) {
 	require := require.New(t)
 
@@ -907,6 +1044,177 @@ func TestServer_ReadUnclaimedBalance(t *testing.T) {
 	}
 }
 
+func TestServer_HistoricalState(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+	cfg.Chain.EnableArchiveMode = true
+
+	svr, err := createServer(cfg, true)
+	require.NoError(err)
+	tipHeight := svr.bc.TipHeight()
+
+	// account at the tip
+	addr := ta.Addrinfo["charlie"].String()
+	res, err := svr.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: addr})
+	require.NoError(err)
+	resAtTip, err := svr.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: addr, Height: tipHeight})
+	require.NoError(err)
+	require.Equal(res.AccountMeta, resAtTip.AccountMeta)
+	// nonce never decreases along the chain
+	var nonce uint64
+	for h := uint64(1); h <= tipHeight; h++ {
+		res, err := svr.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: addr, Height: h})
+		require.NoError(err)
+		require.True(res.AccountMeta.Nonce >= nonce)
+		nonce = res.AccountMeta.Nonce
+	}
+
+	// block reward accumulates in the unclaimed balance of the producer
+	for h := uint64(1); h <= tipHeight; h++ {
+		out, err := svr.ReadState(context.Background(), &iotexapi.ReadStateRequest{
+			ProtocolID: []byte(rewarding.ProtocolID),
+			MethodName: []byte("UnclaimedBalance"),
+			Arguments:  [][]byte{[]byte(identityset.Address(0).String())},
+			Height:     h,
+		})
+		require.NoError(err)
+		val, ok := big.NewInt(0).SetString(string(out.Data), 10)
+		require.True(ok)
+		require.Equal(unit.ConvertIotxToRau(int64(16*h)), val)
+	}
+
+	hash, err := toHash256(readContractTests[0].execHash)
+	require.NoError(err)
+	exec, err := svr.bc.GetActionByActionHash(hash)
+	require.NoError(err)
+	out, err := svr.ReadContract(context.Background(), &iotexapi.ReadContractRequest{
+		Action: exec.Proto(),
+		Height: tipHeight,
+	})
+	require.NoError(err)
+	require.Equal(readContractTests[0].retValue, out.Data)
+
+	_, err = svr.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: addr, Height: tipHeight + 1})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// past states are not available without archive mode
+	cfg = newConfig()
+	svr, err = createServer(cfg, true)
+	require.NoError(err)
+	_, err = svr.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: addr, Height: 1})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	_, err = svr.ReadContract(context.Background(), &iotexapi.ReadContractRequest{Action: exec.Proto(), Height: 1})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	_, err = svr.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: addr, Height: svr.bc.TipHeight()})
+	require.NoError(err)
+}
+
+func TestServer_TraceAction(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+	cfg.Chain.EnableArchiveMode = true
+
+	svr, err := createServer(cfg, true)
+	require.NoError(err)
+
+	res, err := svr.TraceAction(context.Background(), &iotexapi.TraceActionRequest{
+		ActionHash: hex.EncodeToString(executionHash2[:]),
+	})
+	require.NoError(err)
+	receipt, err := svr.bc.GetReceiptByActionHash(executionHash2)
+	require.NoError(err)
+	require.Equal(receipt.ConvertToReceiptPb(), res.Receipt)
+	// the contract has no code, so it's a plain call
+	require.Empty(res.StructLogs)
+	require.NotNil(res.CallTrace)
+	require.Equal("CALL", res.CallTrace.Type)
+	require.Equal(ta.Addrinfo["charlie"].String(), res.CallTrace.From)
+	require.Equal(ta.Addrinfo["delta"].String(), res.CallTrace.To)
+	require.Empty(res.CallTrace.Calls)
+
+	// only executions could be traced
+	_, err = svr.TraceAction(context.Background(), &iotexapi.TraceActionRequest{
+		ActionHash: hex.EncodeToString(transferHash1[:]),
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = svr.TraceAction(context.Background(), &iotexapi.TraceActionRequest{
+		ActionHash: hex.EncodeToString(hash.ZeroHash256[:]),
+	})
+	require.Equal(codes.NotFound, status.Code(err))
+
+	// the state of the parent block is not available without archive mode
+	svr, err = createServer(newConfig(), true)
+	require.NoError(err)
+	_, err = svr.TraceAction(context.Background(), &iotexapi.TraceActionRequest{
+		ActionHash: hex.EncodeToString(executionHash2[:]),
+	})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func TestServer_PrunedBlocks(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+	cfg.Chain.BlockRetentionHeight = 2
+	cfg.API.TpsWindow = 2
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	tipHeight := svr.bc.TipHeight()
+	lowestHeight := tipHeight - 1
+
+	res, err := svr.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	require.NoError(err)
+	require.Equal(lowestHeight, res.ChainMeta.LowestHeight)
+
+	_, err = svr.GetBlockMetas(context.Background(), &iotexapi.GetBlockMetasRequest{
+		Lookup: &iotexapi.GetBlockMetasRequest_ByIndex{
+			ByIndex: &iotexapi.GetBlockMetasByIndexRequest{Start: 1, Count: 1},
+		},
+	})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	metas, err := svr.GetBlockMetas(context.Background(), &iotexapi.GetBlockMetasRequest{
+		Lookup: &iotexapi.GetBlockMetasRequest_ByIndex{
+			ByIndex: &iotexapi.GetBlockMetasByIndexRequest{Start: lowestHeight, Count: 2},
+		},
+	})
+	require.NoError(err)
+	require.Equal(2, len(metas.BlkMetas))
+
+	// the actions of the pruned blocks are no longer indexed
+	actHashes, err := svr.bc.GetActionsFromAddress(ta.Addrinfo["producer"].String())
+	require.NoError(err)
+	require.Empty(actHashes)
+	actHashes, err = svr.bc.GetActionsFromAddress(ta.Addrinfo["charlie"].String())
+	require.NoError(err)
+	require.NotEmpty(actHashes)
+	for _, actHash := range actHashes {
+		blkHash, err := svr.bc.GetBlockHashByActionHash(actHash)
+		require.NoError(err)
+		height, err := svr.bc.GetHeightByHash(blkHash)
+		require.NoError(err)
+		require.True(height >= lowestHeight)
+		_, err = svr.GetReceiptByAction(context.Background(), &iotexapi.GetReceiptByActionRequest{
+			ActionHash: hex.EncodeToString(actHash[:]),
+		})
+		require.NoError(err)
+	}
+
+	// the logs are queried from the lowest available height
+	_, err = svr.GetLogs(context.Background(), &iotexapi.GetLogsRequest{
+		Filter:    &iotexapi.LogsFilter{Address: []string{ta.Addrinfo["producer"].String()}},
+		FromBlock: 1,
+		ToBlock:   tipHeight,
+	})
+	require.NoError(err)
+	logs, err := svr.GetLogs(context.Background(), &iotexapi.GetLogsRequest{
+		Filter:    &iotexapi.LogsFilter{Address: []string{ta.Addrinfo["producer"].String()}},
+		FromBlock: 1,
+		ToBlock:   lowestHeight - 1,
+	})
+	require.NoError(err)
+	require.Equal(0, len(logs.Logs))
+}
+
 func TestServer_TotalBalance(t *testing.T) {
 	cfg := newConfig()
 
@@ -1151,6 +1459,231 @@ func TestServer_GetEpochMeta(t *testing.T) {
 	}
 }
 
+func TestServer_GetLogs(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	svr := &Server{bc: chain, cfg: config.API{RangeQueryLimit: 10}}
+
+	contract := ta.Addrinfo["delta"].String()
+	topic1 := hash.Hash256b([]byte("topic1"))
+	topic2 := hash.Hash256b([]byte("topic2"))
+	filter := &iotexapi.LogsFilter{
+		Address: []string{contract},
+		Topics:  []*iotexapi.Topics{{}, {Topic: [][]byte{topic2[:]}}},
+	}
+	receipts := []*action.Receipt{
+		{
+			Logs: []*action.Log{
+				{Address: contract, Topics: []hash.Hash256{topic1, topic2}, BlockHeight: 3, Index: 0},
+				{Address: contract, Topics: []hash.Hash256{topic2, topic1}, BlockHeight: 3, Index: 1},
+			},
+		},
+	}
+	chain.EXPECT().TipHeight().Return(uint64(100)).AnyTimes()
+	chain.EXPECT().LowestAvailableHeight().Return(uint64(1)).AnyTimes()
+	chain.EXPECT().GetLogBlockHeights([]string{contract}, [][]hash.Hash256{{}, {topic2}}, uint64(1), uint64(10)).
+		Return([]uint64{3, 5}, nil).Times(1)
+	chain.EXPECT().GetReceiptsByHeight(uint64(3)).Return(receipts, nil).Times(1)
+	chain.EXPECT().GetReceiptsByHeight(uint64(5)).Return(nil, errors.Wrap(db.ErrNotExist, "no receipts")).Times(1)
+
+	res, err := svr.GetLogs(context.Background(), &iotexapi.GetLogsRequest{Filter: filter, ToBlock: 10})
+	require.NoError(err)
+	require.Equal(1, len(res.Logs))
+	require.Equal(uint32(0), res.Logs[0].Index)
+	require.Equal(uint64(3), res.Logs[0].BlkHeight)
+
+	// invalid range
+	_, err = svr.GetLogs(context.Background(), &iotexapi.GetLogsRequest{Filter: filter, FromBlock: 10, ToBlock: 5})
+	require.Error(err)
+	// the range is limited with or without the criteria
+	_, err = svr.GetLogs(context.Background(), &iotexapi.GetLogsRequest{FromBlock: 1, ToBlock: 20})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = svr.GetLogs(context.Background(), &iotexapi.GetLogsRequest{Filter: filter})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	// invalid topic
+	_, err = svr.GetLogs(context.Background(), &iotexapi.GetLogsRequest{
+		Filter: &iotexapi.LogsFilter{Topics: []*iotexapi.Topics{{Topic: [][]byte{{1, 2}}}}},
+	})
+	require.Error(err)
+}
+
+func TestServer_StreamBlocks(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	require.NoError(svr.bc.AddSubscriber(svr.chainListener))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &testStreamBlocksServer{ctx: ctx, resp: make(chan *iotexapi.StreamBlocksResponse, 1)}
+	errChan := make(chan error)
+	go func() {
+		errChan <- svr.StreamBlocks(&iotexapi.StreamBlocksRequest{}, stream)
+	}()
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		return numResponders(svr.chainListener.listener) == 1, nil
+	}))
+
+	blk, err := svr.bc.MintNewBlock(nil, testutil.TimestampNow())
+	require.NoError(err)
+	require.NoError(svr.bc.ValidateBlock(blk))
+	require.NoError(svr.bc.CommitBlock(blk))
+
+	select {
+	case res := <-stream.resp:
+		require.Equal(blk.Height(), res.Block.GetHeader().GetCore().GetHeight())
+		require.Equal(len(blk.Receipts), len(res.Receipts))
+	case <-time.After(2 * time.Second):
+		require.Fail("timed out waiting for streamed block")
+	}
+
+	// client disconnects
+	cancel()
+	require.NoError(<-errChan)
+	require.Equal(0, numResponders(svr.chainListener.listener))
+}
+
+func TestServer_GetActPoolStatus(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	svr, err := createServer(cfg, true)
+	require.NoError(err)
+	producer := ta.Addrinfo["producer"].String()
+	// the transfer waits for the nonces 6 and 7
+	tsf, err := testutil.SignedTransfer(ta.Addrinfo["alfa"].String(), ta.Keyinfo["producer"].PriKey, 8,
+		big.NewInt(1), []byte{}, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	require.NoError(err)
+	require.NoError(svr.ap.Add(tsf))
+
+	res, err := svr.GetActPoolStatus(context.Background(), &iotexapi.GetActPoolStatusRequest{Start: 0, Count: 10})
+	require.NoError(err)
+	require.Equal(uint64(5), res.Size)
+	require.Equal(svr.ap.GetGasSize(), res.GasSize)
+	require.Equal(uint64(1), res.NumSenders)
+	require.Equal(uint64(4), res.NumPending)
+	require.Equal(uint64(1), res.NumQueued)
+	require.Equal(1, len(res.Accounts))
+	status := res.Accounts[0]
+	require.Equal(producer, status.Address)
+	require.Equal(uint64(6), status.PendingNonce)
+	require.Equal(4, len(status.PendingActionHashes))
+	tsfHash := tsf.Hash()
+	require.Equal([]string{hex.EncodeToString(tsfHash[:])}, status.QueuedActionHashes)
+	require.Equal([]uint64{8}, status.QueuedNonces)
+	require.Equal([]*iotexapi.NonceRange{{Start: 6, End: 7}}, status.NonceGaps)
+
+	res, err = svr.GetActPoolStatus(context.Background(), &iotexapi.GetActPoolStatusRequest{Start: 1, Count: 10})
+	require.NoError(err)
+	require.Equal(uint64(1), res.NumSenders)
+	require.Empty(res.Accounts)
+	res, err = svr.GetActPoolStatus(context.Background(), &iotexapi.GetActPoolStatusRequest{
+		Address: ta.Addrinfo["alfa"].String(),
+	})
+	require.NoError(err)
+	require.Empty(res.Accounts)
+	res, err = svr.GetActPoolStatus(context.Background(), &iotexapi.GetActPoolStatusRequest{Address: producer})
+	require.NoError(err)
+	require.Equal(1, len(res.Accounts))
+	_, err = svr.GetActPoolStatus(context.Background(), &iotexapi.GetActPoolStatusRequest{Count: 1000})
+	require.Error(err)
+}
+
+func TestServer_StreamPendingActions(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	svr, err := createServer(cfg, true)
+	require.NoError(err)
+	require.NoError(svr.ap.AddSubscriber(svr.actPoolListener))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &testStreamPendingActionsServer{ctx: ctx, resp: make(chan *iotexapi.StreamPendingActionsResponse, 3)}
+	errChan := make(chan error)
+	go func() {
+		errChan <- svr.StreamPendingActions(&iotexapi.StreamPendingActionsRequest{}, stream)
+	}()
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		return numResponders(svr.actPoolListener.listener) == 1, nil
+	}))
+
+	var tsfs []action.SealedEnvelope
+	for nonce := uint64(6); nonce <= 8; nonce++ {
+		tsf, err := testutil.SignedTransfer(ta.Addrinfo["alfa"].String(), ta.Keyinfo["producer"].PriKey, nonce,
+			big.NewInt(1), []byte{}, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+		require.NoError(err)
+		tsfs = append(tsfs, tsf)
+	}
+	// the transfer with nonce 8 is queued until the one with nonce 7 fills the gap
+	require.NoError(svr.ap.Add(tsfs[0]))
+	require.NoError(svr.ap.Add(tsfs[2]))
+	require.NoError(svr.ap.Add(tsfs[1]))
+	for _, tsf := range tsfs {
+		select {
+		case res := <-stream.resp:
+			tsfHash := tsf.Hash()
+			require.Equal(hex.EncodeToString(tsfHash[:]), res.ActionHash)
+		case <-time.After(2 * time.Second):
+			require.Fail("timed out waiting for streamed action")
+		}
+	}
+
+	// client disconnects
+	cancel()
+	require.NoError(<-errChan)
+	require.Equal(0, numResponders(svr.actPoolListener.listener))
+}
+
+func TestServer_StreamLogs(t *testing.T) {
+	require := require.New(t)
+
+	svr := &Server{chainListener: newChainListener()}
+	topic := hash.Hash256b([]byte("topic"))
+	stream := &testStreamLogsServer{ctx: context.Background(), resp: make(chan *iotexapi.StreamLogsResponse, 2)}
+	errChan := make(chan error)
+	go func() {
+		errChan <- svr.StreamLogs(&iotexapi.StreamLogsRequest{
+			Filter: &iotexapi.LogsFilter{
+				Address: []string{"io1contract"},
+				Topics:  []*iotexapi.Topics{{Topic: [][]byte{topic[:]}}},
+			},
+		}, stream)
+	}()
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		return numResponders(svr.chainListener.listener) == 1, nil
+	}))
+
+	blk := &block.Block{
+		Receipts: []*action.Receipt{
+			{
+				Logs: []*action.Log{
+					{Address: "io1contract", Topics: []hash.Hash256{topic}, Index: 0},
+					{Address: "io1other", Topics: []hash.Hash256{topic}, Index: 1},
+					{Address: "io1contract", Topics: []hash.Hash256{hash.ZeroHash256}, Index: 2},
+				},
+			},
+		},
+	}
+	require.NoError(svr.chainListener.HandleBlock(blk))
+	select {
+	case res := <-stream.resp:
+		require.Equal("io1contract", res.Log.ContractAddress)
+		require.Equal(uint32(0), res.Log.Index)
+	case <-time.After(2 * time.Second):
+		require.Fail("timed out waiting for streamed log")
+	}
+
+	// server stops
+	svr.chainListener.Stop()
+	require.Error(<-errChan)
+	require.Equal(0, len(stream.resp))
+}
+
 func addProducerToFactory(sf factory.Factory) error {
 	ws, err := sf.NewWorkingSet()
 	if err != nil {
@@ -1457,12 +1990,205 @@ func createServer(cfg config.Config, needActPool bool) (*Server, error) {
 	apiCfg := config.API{TpsWindow: cfg.API.TpsWindow, GasStation: cfg.API.GasStation, RangeQueryLimit: 100}
 
 	svr := &Server{
-		bc:       bc,
-		ap:       ap,
-		cfg:      apiCfg,
-		gs:       gasstation.NewGasStation(bc, apiCfg),
-		registry: registry,
+		bc:              bc,
+		ap:              ap,
+		cfg:             apiCfg,
+		gs:              gasstation.NewGasStation(bc, apiCfg),
+		registry:        registry,
+		chainListener:   newChainListener(),
+		actPoolListener: newActPoolListener(),
 	}
 
 	return svr, nil
 }
+
+type testStreamBlocksServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	resp chan *iotexapi.StreamBlocksResponse
+}
+
+func (s *testStreamBlocksServer) Context() context.Context { return s.ctx }
+
+func (s *testStreamBlocksServer) Send(res *iotexapi.StreamBlocksResponse) error {
+	s.resp <- res
+	return nil
+}
+
+type testStreamLogsServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	resp chan *iotexapi.StreamLogsResponse
+}
+
+func (s *testStreamLogsServer) Context() context.Context { return s.ctx }
+
+func (s *testStreamLogsServer) Send(res *iotexapi.StreamLogsResponse) error {
+	s.resp <- res
+	return nil
+}
+
+type testStreamPendingActionsServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	resp chan *iotexapi.StreamPendingActionsResponse
+}
+
+func (s *testStreamPendingActionsServer) Context() context.Context { return s.ctx }
+
+func (s *testStreamPendingActionsServer) Send(res *iotexapi.StreamPendingActionsResponse) error {
+	s.resp <- res
+	return nil
+}
+
+func numResponders(l *listener) int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.responders)
+}
+
+func TestServer_GetConsensusEvidences(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	request := &iotexapi.GetConsensusEvidencesRequest{StartHeight: 1, Count: 10}
+	_, err = svr.GetConsensusEvidences(context.Background(), request)
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	svr.evidencePool = dpos.NewEvidencePool(db.NewMemKVStore())
+	require.NoError(svr.evidencePool.Start(context.Background()))
+	var msgs []*dpos.EndorsedConsensusMessage
+	for _, b := range []string{"a", "b"} {
+		blkHash := hash.Hash256b([]byte(b))
+		vote := dpos.NewConsensusVote(blkHash[:], dpos.COMMIT)
+		en, err := endorsement.Endorse(identityset.PrivateKey(1), vote, time.Unix(1500000000, 0))
+		require.NoError(err)
+		msgs = append(msgs, dpos.NewEndorsedConsensusMessage(3, vote, en))
+	}
+	evidence, err := dpos.NewEvidence(msgs[0], msgs[1])
+	require.NoError(err)
+	_, err = svr.evidencePool.Add(evidence)
+	require.NoError(err)
+
+	res, err := svr.GetConsensusEvidences(context.Background(), request)
+	require.NoError(err)
+	require.Equal(1, len(res.Evidences))
+	require.Equal(uint64(3), res.Evidences[0].First.Height)
+	require.NotNil(res.Evidences[0].Second.GetVote())
+	res, err = svr.GetConsensusEvidences(context.Background(), &iotexapi.GetConsensusEvidencesRequest{
+		StartHeight: 4,
+		Count:       10,
+	})
+	require.NoError(err)
+	require.Equal(0, len(res.Evidences))
+
+	_, err = svr.GetConsensusEvidences(context.Background(), &iotexapi.GetConsensusEvidencesRequest{StartHeight: 1})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_GetConsensusTimeline(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	request := &iotexapi.GetConsensusTimelineRequest{Count: 10}
+	_, err = svr.GetConsensusTimeline(context.Background(), request)
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	svr.timeline = dpos.NewTimeline(10, config.Default.Consensus.RollDPoS.FSM)
+	delegates := []string{identityset.Address(0).String(), identityset.Address(1).String()}
+	ts := time.Unix(1500000000, 0)
+	svr.timeline.StartRound(3, 0, delegates[0], delegates, ts)
+	svr.timeline.AddEndorsement(3, 0, delegates[1], dpos.PROPOSAL, ts.Add(time.Second))
+	svr.timeline.StartRound(3, 1, delegates[1], delegates, ts.Add(10*time.Second))
+
+	res, err := svr.GetConsensusTimeline(context.Background(), request)
+	require.NoError(err)
+	require.Equal(2, len(res.Rounds))
+	require.Equal(iotextypes.ConsensusRound_TIMEOUT, res.Rounds[0].Outcome)
+	require.Equal(1, len(res.Rounds[0].Endorsements))
+	require.Equal(iotextypes.ConsensusRound_PENDING, res.Rounds[1].Outcome)
+	require.Equal(2, len(res.Liveness))
+	for _, l := range res.Liveness {
+		if l.Address == delegates[0] {
+			require.Equal(uint64(1), l.MissedProposals)
+			require.Equal(uint64(0), l.MissedEndorsements)
+		} else {
+			require.Equal(uint64(0), l.MissedProposals)
+			require.Equal(uint64(0), l.MissedEndorsements)
+		}
+	}
+
+	_, err = svr.GetConsensusTimeline(context.Background(), &iotexapi.GetConsensusTimelineRequest{})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_GetTokenTransfersByAddress(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	request := &iotexapi.GetTokenTransfersByAddressRequest{Address: ta.Addrinfo["charlie"].String(), Count: 10}
+	_, err = svr.GetTokenTransfersByAddress(context.Background(), request)
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	testFile, err := ioutil.TempFile(os.TempDir(), "index")
+	require.NoError(err)
+	require.NoError(testFile.Close())
+	defer testutil.CleanupPath(t, testFile.Name())
+	cfg.DB.SQLITE3.SQLite3File = testFile.Name()
+	svr.idx = indexservice.NewServer(cfg, svr.bc)
+	require.NoError(svr.idx.Start(context.Background()))
+	defer func() {
+		require.NoError(svr.idx.Stop(context.Background()))
+	}()
+	svr.cfg.UseRDS = true
+
+	// the first action of block 1 emits a token transfer from alfa to charlie
+	blk, err := svr.bc.GetBlockByHeight(1)
+	require.NoError(err)
+	topic := func(addr address.Address) hash.Hash256 {
+		var h hash.Hash256
+		copy(h[12:], addr.Bytes())
+		return h
+	}
+	amount := hash.BytesToHash256(big.NewInt(7).Bytes())
+	actHash := blk.Actions[0].Hash()
+	blk.Receipts = []*action.Receipt{{
+		ActionHash: actHash,
+		Status:     action.SuccessReceiptStatus,
+		Logs: []*action.Log{{
+			Address: ta.Addrinfo["delta"].String(),
+			Topics: []hash.Hash256{
+				hash.BytesToHash256(crypto.Keccak256([]byte("Transfer(address,address,uint256)"))),
+				topic(ta.Addrinfo["alfa"]),
+				topic(ta.Addrinfo["charlie"]),
+			},
+			Data: amount[:],
+		}},
+	}}
+	require.NoError(svr.idx.Indexer().BuildIndex(blk))
+
+	res, err := svr.GetTokenTransfersByAddress(context.Background(), request)
+	require.NoError(err)
+	require.Equal(1, len(res.Transfers))
+	require.Equal(hex.EncodeToString(actHash[:]), res.Transfers[0].ActionHash)
+	require.Equal(uint64(1), res.Transfers[0].BlkHeight)
+	require.Equal(blk.Timestamp().Unix(), res.Transfers[0].Timestamp.Seconds)
+	require.Equal(ta.Addrinfo["delta"].String(), res.Transfers[0].Token)
+	require.Equal(ta.Addrinfo["alfa"].String(), res.Transfers[0].Sender)
+	require.Equal(ta.Addrinfo["charlie"].String(), res.Transfers[0].Recipient)
+	require.Equal("7", res.Transfers[0].Amount)
+	require.Empty(res.NextCursor)
+
+	request.Cursor = "invalid"
+	_, err = svr.GetTokenTransfersByAddress(context.Background(), request)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	request.Count = svr.cfg.RangeQueryLimit + 1
+	_, err = svr.GetTokenTransfersByAddress(context.Background(), request)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	// streamBufferSize is the number of blocks buffered for a streaming client before it is considered too slow
+	streamBufferSize = 32
+	// actionStreamBufferSize is the number of action hashes buffered for a streaming client before it is considered
+	// too slow
+	actionStreamBufferSize = 1024
+)
+
+var (
+	// ErrStreamTooSlow indicates the streaming client cannot keep up with the new blocks or actions
+	ErrStreamTooSlow = errors.New("stream client is too slow to keep up")
+	// ErrStreamClosed indicates the stream is closed because the server is stopping
+	ErrStreamClosed = errors.New("stream is closed by server")
+)
+
+// Responder receives the items dispatched by a listener, which are newly committed blocks or the hashes of new
+// pending actions
+type Responder interface {
+	// Respond hands a new item to the responder, it must not block
+	Respond(interface{}) error
+	// Exit terminates the responder with the reason
+	Exit(error)
+}
+
+// listener dispatches new items to the registered responders, and drops the ones failing to take them
+type listener struct {
+	mu         sync.RWMutex
+	responders map[Responder]struct{}
+}
+
+func newListener() *listener {
+	return &listener{
+		responders: make(map[Responder]struct{}),
+	}
+}
+
+// dispatch hands the item to every responder
+func (l *listener) dispatch(item interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for r := range l.responders {
+		if err := r.Respond(item); err != nil {
+			log.L().Warn("Drop stream responder.", zap.Error(err))
+			delete(l.responders, r)
+			r.Exit(err)
+		}
+	}
+}
+
+// AddResponder registers a responder to receive new items
+func (l *listener) AddResponder(r Responder) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.responders[r]; ok {
+		return errors.New("responder already exists")
+	}
+	l.responders[r] = struct{}{}
+	return nil
+}
+
+// RemoveResponder unregisters a responder
+func (l *listener) RemoveResponder(r Responder) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.responders, r)
+}
+
+// Stop terminates all the responders
+func (l *listener) Stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for r := range l.responders {
+		r.Exit(ErrStreamClosed)
+	}
+	l.responders = make(map[Responder]struct{})
+}
+
+// chainListener implements BlockCreationSubscriber and dispatches new blocks to the registered responders
+type chainListener struct {
+	*listener
+}
+
+func newChainListener() *chainListener {
+	return &chainListener{newListener()}
+}
+
+// HandleBlock is an implementation of interface BlockCreationSubscriber
+func (cl *chainListener) HandleBlock(blk *block.Block) error {
+	cl.dispatch(blk)
+	return nil
+}
+
+// actPoolListener implements PendingActionSubscriber and dispatches the hashes of new pending actions to the
+// registered responders
+type actPoolListener struct {
+	*listener
+}
+
+func newActPoolListener() *actPoolListener {
+	return &actPoolListener{newListener()}
+}
+
+// HandlePendingAction is an implementation of interface PendingActionSubscriber
+func (al *actPoolListener) HandlePendingAction(selp action.SealedEnvelope) error {
+	al.dispatch(selp.Hash())
+	return nil
+}
+
+// streamResponder signals a single streaming client to terminate
+type streamResponder struct {
+	errChan chan error
+	once    sync.Once
+}
+
+func newStreamResponder() *streamResponder {
+	return &streamResponder{errChan: make(chan error, 1)}
+}
+
+// Exit signals the stream to terminate with the error
+func (r *streamResponder) Exit(err error) {
+	r.once.Do(func() {
+		r.errChan <- err
+	})
+}
+
+// blockResponder buffers new blocks for a single streaming client
+type blockResponder struct {
+	*streamResponder
+	pending chan *block.Block
+}
+
+func newBlockResponder(bufferSize int) *blockResponder {
+	return &blockResponder{
+		streamResponder: newStreamResponder(),
+		pending:         make(chan *block.Block, bufferSize),
+	}
+}
+
+// Respond queues the block, and fails if the client has too many blocks pending
+func (r *blockResponder) Respond(item interface{}) error {
+	blk, ok := item.(*block.Block)
+	if !ok {
+		return errors.Errorf("unexpected item %T for a block stream", item)
+	}
+	select {
+	case r.pending <- blk:
+		return nil
+	default:
+		return ErrStreamTooSlow
+	}
+}
+
+// actionResponder buffers the hashes of new pending actions for a single streaming client
+type actionResponder struct {
+	*streamResponder
+	pending chan hash.Hash256
+}
+
+func newActionResponder(bufferSize int) *actionResponder {
+	return &actionResponder{
+		streamResponder: newStreamResponder(),
+		pending:         make(chan hash.Hash256, bufferSize),
+	}
+}
+
+// Respond queues the action hash, and fails if the client has too many actions pending
+func (r *actionResponder) Respond(item interface{}) error {
+	actHash, ok := item.(hash.Hash256)
+	if !ok {
+		return errors.Errorf("unexpected item %T for a pending action stream", item)
+	}
+	select {
+	case r.pending <- actHash:
+		return nil
+	default:
+		return ErrStreamTooSlow
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestChainListener(t *testing.T) {
+	require := require.New(t)
+
+	cl := newChainListener()
+	fast := newBlockResponder(2)
+	slow := newBlockResponder(1)
+	require.NoError(cl.AddResponder(fast))
+	require.NoError(cl.AddResponder(slow))
+	require.Error(cl.AddResponder(fast))
+
+	blk := &block.Block{}
+	require.Error(fast.Respond(hash.ZeroHash256))
+	require.NoError(cl.HandleBlock(blk))
+	require.Equal(2, numResponders(cl.listener))
+
+	// the slow responder has not consumed the first block, so it gets dropped
+	require.NoError(cl.HandleBlock(blk))
+	require.Equal(1, numResponders(cl.listener))
+	require.Equal(ErrStreamTooSlow, <-slow.errChan)
+	require.Equal(2, len(fast.pending))
+
+	cl.RemoveResponder(fast)
+	require.Equal(0, numResponders(cl.listener))
+
+	require.NoError(cl.AddResponder(fast))
+	cl.Stop()
+	require.Equal(0, numResponders(cl.listener))
+	require.Equal(ErrStreamClosed, <-fast.errChan)
+	// exit is only signaled once
+	fast.Exit(ErrStreamTooSlow)
+	require.Equal(0, len(fast.errChan))
+}
+
+func TestActPoolListener(t *testing.T) {
+	require := require.New(t)
+
+	al := newActPoolListener()
+	fast := newActionResponder(2)
+	slow := newActionResponder(1)
+	require.NoError(al.AddResponder(fast))
+	require.NoError(al.AddResponder(slow))
+	require.Error(al.AddResponder(fast))
+
+	selp, err := testutil.SignedTransfer(ta.Addrinfo["alfa"].String(), ta.Keyinfo["producer"].PriKey, 1,
+		big.NewInt(1), []byte{}, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	require.NoError(err)
+	require.NoError(al.HandlePendingAction(selp))
+	require.Equal(2, numResponders(al.listener))
+
+	// the slow responder has not consumed the first action, so it gets dropped
+	require.NoError(al.HandlePendingAction(selp))
+	require.Equal(1, numResponders(al.listener))
+	require.Equal(ErrStreamTooSlow, <-slow.errChan)
+	require.Equal(2, len(fast.pending))
+
+	al.Stop()
+	require.Equal(0, numResponders(al.listener))
+	require.Equal(ErrStreamClosed, <-fast.errChan)
+}
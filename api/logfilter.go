@@ -0,0 +1,76 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bytes"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+// logFilter matches contract logs by emitting address and topics
+type logFilter struct {
+	pbFilter *iotexapi.LogsFilter
+}
+
+func newLogFilter(in *iotexapi.LogsFilter) *logFilter {
+	if in == nil {
+		in = &iotexapi.LogsFilter{}
+	}
+	return &logFilter{pbFilter: in}
+}
+
+// match returns true if the log passes the filter
+func (l *logFilter) match(log *action.Log) bool {
+	addrs := l.pbFilter.GetAddress()
+	if len(addrs) > 0 {
+		var found bool
+		for _, addr := range addrs {
+			if addr == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for i, topics := range l.pbFilter.GetTopics() {
+		if len(topics.GetTopic()) == 0 {
+			// wildcard at this position
+			continue
+		}
+		if i >= len(log.Topics) {
+			return false
+		}
+		var found bool
+		for _, topic := range topics.GetTopic() {
+			if bytes.Equal(topic, log.Topics[i][:]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matchLogs returns the logs in receipts that pass the filter
+func (l *logFilter) matchLogs(receipts []*action.Receipt) []*action.Log {
+	var res []*action.Log
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			if l.match(log) {
+				res = append(res, log)
+			}
+		}
+	}
+	return res
+}
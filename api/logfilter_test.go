@@ -0,0 +1,55 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+func TestLogFilter(t *testing.T) {
+	require := require.New(t)
+
+	topicA := hash.Hash256b([]byte("a"))
+	topicB := hash.Hash256b([]byte("b"))
+	topicC := hash.Hash256b([]byte("c"))
+	log := &action.Log{
+		Address: "io1contract",
+		Topics:  []hash.Hash256{topicA, topicB},
+	}
+
+	tests := []struct {
+		filter *iotexapi.LogsFilter
+		match  bool
+	}{
+		{nil, true},
+		{&iotexapi.LogsFilter{Address: []string{"io1other", "io1contract"}}, true},
+		{&iotexapi.LogsFilter{Address: []string{"io1other"}}, false},
+		{&iotexapi.LogsFilter{Topics: []*iotexapi.Topics{{Topic: [][]byte{topicA[:]}}}}, true},
+		{&iotexapi.LogsFilter{Topics: []*iotexapi.Topics{{Topic: [][]byte{topicB[:]}}}}, false},
+		// wildcard at position 0, topicB or topicC at position 1
+		{&iotexapi.LogsFilter{Topics: []*iotexapi.Topics{{}, {Topic: [][]byte{topicC[:], topicB[:]}}}}, true},
+		// the log has no topic at position 2
+		{&iotexapi.LogsFilter{Topics: []*iotexapi.Topics{{}, {}, {Topic: [][]byte{topicC[:]}}}}, false},
+		{&iotexapi.LogsFilter{Address: []string{"io1contract"}, Topics: []*iotexapi.Topics{{Topic: [][]byte{topicC[:]}}}}, false},
+	}
+	for _, test := range tests {
+		require.Equal(test.match, newLogFilter(test.filter).match(log))
+	}
+
+	receipts := []*action.Receipt{
+		{Logs: []*action.Log{log, {Address: "io1other"}}},
+		{Logs: []*action.Log{{Address: "io1contract"}}},
+	}
+	logs := newLogFilter(&iotexapi.LogsFilter{Address: []string{"io1contract"}}).matchLogs(receipts)
+	require.Equal(2, len(logs))
+}
@@ -0,0 +1,839 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+var (
+	// ErrEthTransaction indicates that an RLP encoded Ethereum transaction is sent, which can't be converted into an
+	// action since its signature is over the Ethereum transaction hash
+	ErrEthTransaction = errors.New("unsupported Ethereum transaction, send a serialized IoTeX action instead")
+	// emptyUncleHash is the hash of an empty list of uncles in Ethereum
+	emptyUncleHash = common.HexToHash("1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347")
+	// emptyBloom is the bloom of a block or receipt, which is not maintained by IoTeX
+	emptyBloom = hexutil.Bytes(make([]byte, 256))
+	// emptyNonce is the proof-of-work nonce of a block
+	emptyNonce = hexutil.Bytes(make([]byte, 8))
+)
+
+// web3Server serves the Ethereum compatible JSON-RPC API over HTTP, so that Ethereum tooling such as web3 and MetaMask
+// could talk to IoTeX. Addresses are converted between io and 0x formats by their 20 bytes, in the same way as
+// "ioctl account ethaddr"
+type web3Server struct {
+	port       int
+	rpcServer  *rpc.Server
+	httpServer *http.Server
+}
+
+func newWeb3Server(api *Server, cfg config.API, g genesis.Genesis) (*web3Server, error) {
+	rpcServer := rpc.NewServer()
+	for name, service := range map[string]interface{}{
+		"eth":  &Web3EthService{api: api, genesis: g},
+		"net":  &Web3NetService{api: api},
+		"web3": &Web3ClientService{},
+	} {
+		if err := rpcServer.RegisterName(name, service); err != nil {
+			return nil, errors.Wrapf(err, "failed to register web3 service %s", name)
+		}
+	}
+	return &web3Server{
+		port:      cfg.Web3Port,
+		rpcServer: rpcServer,
+		httpServer: rpc.NewHTTPServer(
+			cfg.Web3CORSOrigins,
+			cfg.Web3VirtualHosts,
+			rpc.DefaultHTTPTimeouts,
+			rpcServer,
+		),
+	}, nil
+}
+
+// Start starts the web3 server
+func (svr *web3Server) Start() error {
+	lis, err := net.Listen("tcp", ":"+strconv.Itoa(svr.port))
+	if err != nil {
+		log.L().Error("Web3 server failed to listen.", zap.Error(err))
+		return errors.Wrap(err, "web3 server failed to listen")
+	}
+	log.L().Info("Web3 server is listening.", zap.String("addr", lis.Addr().String()))
+
+	go func() {
+		if err := svr.httpServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+			log.L().Fatal("Node failed to serve web3.", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Stop stops the web3 server
+func (svr *web3Server) Stop() error {
+	svr.rpcServer.Stop()
+	return svr.httpServer.Close()
+}
+
+type (
+	// Web3EthService implements the "eth" namespace
+	Web3EthService struct {
+		api     *Server
+		genesis genesis.Genesis
+	}
+
+	// Web3NetService implements the "net" namespace
+	Web3NetService struct {
+		api *Server
+	}
+
+	// Web3ClientService implements the "web3" namespace
+	Web3ClientService struct{}
+
+	// Web3CallArgs is the message of eth_call and eth_estimateGas
+	Web3CallArgs struct {
+		From     *common.Address `json:"from"`
+		To       *common.Address `json:"to"`
+		Gas      *hexutil.Uint64 `json:"gas"`
+		GasPrice *hexutil.Big    `json:"gasPrice"`
+		Value    *hexutil.Big    `json:"value"`
+		Data     hexutil.Bytes   `json:"data"`
+	}
+
+	// Web3Transaction is an action in the form of an Ethereum transaction
+	Web3Transaction struct {
+		BlockHash        *common.Hash    `json:"blockHash"`
+		BlockNumber      *hexutil.Uint64 `json:"blockNumber"`
+		TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
+		Hash             common.Hash     `json:"hash"`
+		From             common.Address  `json:"from"`
+		To               *common.Address `json:"to"`
+		Nonce            hexutil.Uint64  `json:"nonce"`
+		Gas              hexutil.Uint64  `json:"gas"`
+		GasPrice         *hexutil.Big    `json:"gasPrice"`
+		Value            *hexutil.Big    `json:"value"`
+		Input            hexutil.Bytes   `json:"input"`
+	}
+
+	// Web3Receipt is a receipt in the form of an Ethereum transaction receipt
+	Web3Receipt struct {
+		TransactionHash   common.Hash     `json:"transactionHash"`
+		TransactionIndex  hexutil.Uint64  `json:"transactionIndex"`
+		BlockHash         common.Hash     `json:"blockHash"`
+		BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+		From              common.Address  `json:"from"`
+		To                *common.Address `json:"to"`
+		CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
+		GasUsed           hexutil.Uint64  `json:"gasUsed"`
+		ContractAddress   *common.Address `json:"contractAddress"`
+		Logs              []*Web3Log      `json:"logs"`
+		LogsBloom         hexutil.Bytes   `json:"logsBloom"`
+		Status            hexutil.Uint64  `json:"status"`
+	}
+
+	// Web3Log is a contract log in the form of an Ethereum log
+	Web3Log struct {
+		Address          common.Address `json:"address"`
+		Topics           []common.Hash  `json:"topics"`
+		Data             hexutil.Bytes  `json:"data"`
+		BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+		BlockHash        common.Hash    `json:"blockHash"`
+		TransactionHash  common.Hash    `json:"transactionHash"`
+		TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+		LogIndex         hexutil.Uint64 `json:"logIndex"`
+		Removed          bool           `json:"removed"`
+	}
+
+	// Web3Block is a block in the form of an Ethereum block
+	Web3Block struct {
+		Number           hexutil.Uint64 `json:"number"`
+		Hash             common.Hash    `json:"hash"`
+		ParentHash       common.Hash    `json:"parentHash"`
+		Nonce            hexutil.Bytes  `json:"nonce"`
+		Sha3Uncles       common.Hash    `json:"sha3Uncles"`
+		LogsBloom        hexutil.Bytes  `json:"logsBloom"`
+		TransactionsRoot common.Hash    `json:"transactionsRoot"`
+		ReceiptsRoot     common.Hash    `json:"receiptsRoot"`
+		// the block header commits to the digest of the state changes instead of the state root
+		StateRoot       common.Hash    `json:"stateRoot"`
+		Miner           common.Address `json:"miner"`
+		Difficulty      hexutil.Uint64 `json:"difficulty"`
+		TotalDifficulty hexutil.Uint64 `json:"totalDifficulty"`
+		ExtraData       hexutil.Bytes  `json:"extraData"`
+		GasLimit        hexutil.Uint64 `json:"gasLimit"`
+		GasUsed         hexutil.Uint64 `json:"gasUsed"`
+		Timestamp       hexutil.Uint64 `json:"timestamp"`
+		Transactions    []interface{}  `json:"transactions"`
+		Uncles          []common.Hash  `json:"uncles"`
+	}
+
+	// Web3FilterQuery is the filter of eth_getLogs
+	Web3FilterQuery struct {
+		BlockHash *common.Hash      `json:"blockHash"`
+		FromBlock *rpc.BlockNumber  `json:"fromBlock"`
+		ToBlock   *rpc.BlockNumber  `json:"toBlock"`
+		Addresses Web3AddressFilter `json:"address"`
+		Topics    []Web3TopicFilter `json:"topics"`
+	}
+
+	// Web3AddressFilter is either a single address or a list of addresses
+	Web3AddressFilter []common.Address
+
+	// Web3TopicFilter is null matching any topic, a single topic, or a list of alternative topics
+	Web3TopicFilter []common.Hash
+
+	// web3BlockInfo is a block with the indexes of its actions
+	web3BlockInfo struct {
+		blk     *block.Block
+		hash    hash.Hash256
+		indexes map[hash.Hash256]uint64
+	}
+)
+
+// ClientVersion returns the version of the node
+func (s *Web3ClientService) ClientVersion() string {
+	return "iotex-core/" + version.PackageVersion
+}
+
+// Version returns the chain ID
+func (s *Web3NetService) Version() string {
+	return strconv.FormatUint(uint64(s.api.bc.ChainID()), 10)
+}
+
+// ChainId returns the chain ID
+func (s *Web3EthService) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(s.api.bc.ChainID())
+}
+
+// BlockNumber returns the tip height
+func (s *Web3EthService) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(s.api.bc.TipHeight())
+}
+
+// GasPrice returns the suggested gas price
+func (s *Web3EthService) GasPrice() (*hexutil.Big, error) {
+	price, err := s.api.gs.SuggestGasPrice()
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(new(big.Int).SetUint64(price)), nil
+}
+
+// GetBalance returns the balance of an account at the block number
+func (s *Web3EthService) GetBalance(addr common.Address, number rpc.BlockNumber) (*hexutil.Big, error) {
+	acct, err := s.accountState(addr, number)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(acct.Balance), nil
+}
+
+// GetTransactionCount returns the number of actions sent from an account at the block number, including the pending
+// actions in the actpool for the pending block number
+func (s *Web3EthService) GetTransactionCount(addr common.Address, number rpc.BlockNumber) (hexutil.Uint64, error) {
+	if number == rpc.PendingBlockNumber {
+		ioAddr, err := address.FromBytes(addr.Bytes())
+		if err != nil {
+			return 0, err
+		}
+		pendingNonce, err := s.api.ap.GetPendingNonce(ioAddr.String())
+		if err != nil {
+			return 0, err
+		}
+		// nonce starts from 1 in IoTeX
+		return hexutil.Uint64(pendingNonce - 1), nil
+	}
+	acct, err := s.accountState(addr, number)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(acct.Nonce), nil
+}
+
+// GetCode returns the code of a contract at the block number
+func (s *Web3EthService) GetCode(addr common.Address, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	acct, err := s.accountState(addr, number)
+	if err != nil {
+		return nil, err
+	}
+	if !acct.IsContract() {
+		return hexutil.Bytes{}, nil
+	}
+	ws, err := s.api.bc.GetFactory().NewWorkingSet()
+	if err != nil {
+		return nil, err
+	}
+	// code is keyed by its hash, so the latest DB serves any height
+	code, err := ws.GetDB().Get(evm.CodeKVNameSpace, acct.CodeHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get code of contract %x", addr)
+	}
+	return code, nil
+}
+
+// Call executes a message call without creating a transaction on chain
+func (s *Web3EthService) Call(args Web3CallArgs, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	height, err := s.height(number)
+	if err != nil {
+		return nil, err
+	}
+	caller, ex, err := args.toExecution(s.genesis)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		retval  []byte
+		receipt *action.Receipt
+	)
+	if height == s.api.bc.TipHeight() {
+		retval, receipt, err = s.api.bc.ExecuteContractRead(caller, ex)
+	} else {
+		retval, receipt, err = s.api.bc.ExecuteContractReadAtHeight(caller, ex, height)
+	}
+	if err != nil {
+		return nil, web3Error(historicalStateError(err))
+	}
+	if receipt.Status != action.SuccessReceiptStatus {
+		return nil, web3Error(executionError(receipt))
+	}
+	return retval, nil
+}
+
+// EstimateGas estimates the gas needed by a transaction
+func (s *Web3EthService) EstimateGas(args Web3CallArgs) (hexutil.Uint64, error) {
+	if args.To != nil {
+		acct, err := s.accountState(*args.To, rpc.LatestBlockNumber)
+		if err != nil {
+			return 0, err
+		}
+		if !acct.IsContract() {
+			// a transfer, which doesn't execute any contract in IoTeX, but pays for its payload
+			return transferGas(*args.To, args.Data)
+		}
+	}
+	caller, ex, err := args.toExecution(s.genesis)
+	if err != nil {
+		return 0, err
+	}
+	_, receipt, err := s.api.bc.ExecuteContractRead(caller, ex)
+	if err != nil {
+		return 0, err
+	}
+	if receipt.Status != action.SuccessReceiptStatus {
+		return 0, web3Error(executionError(receipt))
+	}
+	return hexutil.Uint64(receipt.GasConsumed), nil
+}
+
+// SendRawTransaction sends a signed action. As an IoTeX action is signed over its own hash instead of the Ethereum
+// transaction hash, the data should be a serialized IoTeX action, and an RLP encoded Ethereum transaction, whose
+// signature can't be mapped onto an action, is rejected
+func (s *Web3EthService) SendRawTransaction(ctx context.Context, data hexutil.Bytes) (common.Hash, error) {
+	if err := rlp.DecodeBytes(data, &types.Transaction{}); err == nil {
+		return common.Hash{}, ErrEthTransaction
+	}
+	actPb := &iotextypes.Action{}
+	if err := proto.Unmarshal(data, actPb); err != nil || actPb.GetCore() == nil {
+		return common.Hash{}, errors.New("data is not a serialized IoTeX action")
+	}
+	var selp action.SealedEnvelope
+	if err := selp.LoadProto(actPb); err != nil {
+		return common.Hash{}, err
+	}
+	if _, err := s.api.SendAction(ctx, &iotexapi.SendActionRequest{Action: actPb}); err != nil {
+		return common.Hash{}, web3Error(err)
+	}
+	return common.Hash(selp.Hash()), nil
+}
+
+// GetTransactionByHash returns a transaction, which is either in a block or pending in the actpool
+func (s *Web3EthService) GetTransactionByHash(h common.Hash) (*Web3Transaction, error) {
+	actHash := hash.Hash256(h)
+	selp, err := s.api.bc.GetActionByActionHash(actHash)
+	if err == nil {
+		info, err := s.blockOfAction(actHash)
+		if err != nil {
+			return nil, err
+		}
+		return toWeb3Transaction(selp, info)
+	}
+	if s.api.ap == nil {
+		return nil, nil
+	}
+	if selp, err = s.api.ap.GetActionByHash(actHash); err != nil {
+		// not found
+		return nil, nil
+	}
+	return toWeb3Transaction(selp, nil)
+}
+
+// GetTransactionReceipt returns the receipt of a transaction
+func (s *Web3EthService) GetTransactionReceipt(h common.Hash) (*Web3Receipt, error) {
+	actHash := hash.Hash256(h)
+	selp, err := s.api.bc.GetActionByActionHash(actHash)
+	if err != nil {
+		// not found
+		return nil, nil
+	}
+	info, err := s.blockOfAction(actHash)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := s.api.bc.GetReceiptsByHeight(info.blk.Height())
+	if err != nil {
+		return nil, err
+	}
+	tx, err := toWeb3Transaction(selp, info)
+	if err != nil {
+		return nil, err
+	}
+	var cumulativeGasUsed uint64
+	for _, r := range receipts {
+		cumulativeGasUsed += r.GasConsumed
+		if r.ActionHash != actHash {
+			continue
+		}
+		res := &Web3Receipt{
+			TransactionHash:   h,
+			TransactionIndex:  *tx.TransactionIndex,
+			BlockHash:         *tx.BlockHash,
+			BlockNumber:       *tx.BlockNumber,
+			From:              tx.From,
+			To:                tx.To,
+			CumulativeGasUsed: hexutil.Uint64(cumulativeGasUsed),
+			GasUsed:           hexutil.Uint64(r.GasConsumed),
+			Logs:              []*Web3Log{},
+			LogsBloom:         emptyBloom,
+			Status:            hexutil.Uint64(r.Status),
+		}
+		if r.ContractAddress != "" {
+			contract, err := toEthAddress(r.ContractAddress)
+			if err != nil {
+				return nil, err
+			}
+			res.ContractAddress = &contract
+		}
+		for _, l := range r.Logs {
+			web3Log, err := toWeb3Log(l.ConvertToLogPb(), info)
+			if err != nil {
+				return nil, err
+			}
+			res.Logs = append(res.Logs, web3Log)
+		}
+		return res, nil
+	}
+	return nil, errors.Errorf("receipt of action %x is missing", actHash)
+}
+
+// GetBlockByNumber returns the block of the number, with the full transactions if fullTx is true
+func (s *Web3EthService) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (*Web3Block, error) {
+	height, err := s.height(number)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := s.api.bc.GetBlockByHeight(height)
+	if err != nil {
+		return nil, web3Error(blockDataError(err))
+	}
+	return s.toWeb3Block(blk, fullTx)
+}
+
+// GetBlockByHash returns the block of the hash, with the full transactions if fullTx is true
+func (s *Web3EthService) GetBlockByHash(h common.Hash, fullTx bool) (*Web3Block, error) {
+	blk, err := s.api.bc.GetBlockByHash(hash.Hash256(h))
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return nil, nil
+		}
+		return nil, web3Error(blockDataError(err))
+	}
+	return s.toWeb3Block(blk, fullTx)
+}
+
+// GetBlockTransactionCountByNumber returns the number of transactions in the block of the number
+func (s *Web3EthService) GetBlockTransactionCountByNumber(number rpc.BlockNumber) (hexutil.Uint64, error) {
+	height, err := s.height(number)
+	if err != nil {
+		return 0, err
+	}
+	blk, err := s.api.bc.GetBlockByHeight(height)
+	if err != nil {
+		return 0, web3Error(blockDataError(err))
+	}
+	return hexutil.Uint64(len(blk.Actions)), nil
+}
+
+// GetLogs returns the logs matching the filter
+func (s *Web3EthService) GetLogs(ctx context.Context, query Web3FilterQuery) ([]*Web3Log, error) {
+	req := &iotexapi.GetLogsRequest{Filter: &iotexapi.LogsFilter{}}
+	if query.BlockHash != nil {
+		height, err := s.api.bc.GetHeightByHash(hash.Hash256(*query.BlockHash))
+		if err != nil {
+			return nil, err
+		}
+		req.FromBlock, req.ToBlock = height, height
+	} else {
+		// the range defaults to the latest block
+		from, to := rpc.LatestBlockNumber, rpc.LatestBlockNumber
+		if query.FromBlock != nil {
+			from = *query.FromBlock
+		}
+		if query.ToBlock != nil {
+			to = *query.ToBlock
+		}
+		var err error
+		if req.FromBlock, err = s.height(from); err != nil {
+			return nil, err
+		}
+		if req.ToBlock, err = s.height(to); err != nil {
+			return nil, err
+		}
+		if req.FromBlock == 0 {
+			req.FromBlock = 1
+		}
+	}
+	for _, addr := range query.Addresses {
+		ioAddr, err := address.FromBytes(addr.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		req.Filter.Address = append(req.Filter.Address, ioAddr.String())
+	}
+	for _, topics := range query.Topics {
+		t := &iotexapi.Topics{}
+		for _, topic := range topics {
+			t.Topic = append(t.Topic, topic.Bytes())
+		}
+		req.Filter.Topics = append(req.Filter.Topics, t)
+	}
+	res, err := s.api.GetLogs(ctx, req)
+	if err != nil {
+		return nil, web3Error(err)
+	}
+	logs := make([]*Web3Log, 0, len(res.Logs))
+	blocks := make(map[uint64]*web3BlockInfo)
+	for _, l := range res.Logs {
+		info, ok := blocks[l.BlkHeight]
+		if !ok {
+			blk, err := s.api.bc.GetBlockByHeight(l.BlkHeight)
+			if err != nil {
+				return nil, web3Error(blockDataError(err))
+			}
+			info = newWeb3BlockInfo(blk)
+			blocks[l.BlkHeight] = info
+		}
+		web3Log, err := toWeb3Log(l, info)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, web3Log)
+	}
+	return logs, nil
+}
+
+// height converts a block number into a height no higher than the tip
+func (s *Web3EthService) height(number rpc.BlockNumber) (uint64, error) {
+	tipHeight := s.api.bc.TipHeight()
+	switch {
+	case number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber:
+		return tipHeight, nil
+	case number < 0:
+		return 0, errors.Errorf("invalid block number %d", number)
+	case uint64(number) > tipHeight:
+		return 0, errors.Errorf("block number %d is higher than the tip height %d", number, tipHeight)
+	}
+	return uint64(number), nil
+}
+
+func (s *Web3EthService) accountState(addr common.Address, number rpc.BlockNumber) (*state.Account, error) {
+	ioAddr, err := address.FromBytes(addr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	height, err := s.height(number)
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		return nil, errors.New("state of the genesis block is not available")
+	}
+	if height == s.api.bc.TipHeight() {
+		// the current state
+		height = 0
+	}
+	acct, err := s.api.accountState(ioAddr.String(), height)
+	if err != nil {
+		return nil, web3Error(err)
+	}
+	return acct, nil
+}
+
+func (s *Web3EthService) blockOfAction(actHash hash.Hash256) (*web3BlockInfo, error) {
+	blkHash, err := s.api.bc.GetBlockHashByActionHash(actHash)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := s.api.bc.GetBlockByHash(blkHash)
+	if err != nil {
+		return nil, web3Error(blockDataError(err))
+	}
+	return newWeb3BlockInfo(blk), nil
+}
+
+func (s *Web3EthService) toWeb3Block(blk *block.Block, fullTx bool) (*Web3Block, error) {
+	info := newWeb3BlockInfo(blk)
+	miner, err := toEthAddress(blk.ProducerAddress())
+	if err != nil {
+		return nil, err
+	}
+	var gasUsed uint64
+	receipts, err := s.api.bc.GetReceiptsByHeight(blk.Height())
+	switch errors.Cause(err) {
+	case nil:
+		for _, r := range receipts {
+			gasUsed += r.GasConsumed
+		}
+	case db.ErrNotExist:
+		// the block has no receipts
+	default:
+		return nil, web3Error(blockDataError(err))
+	}
+	res := &Web3Block{
+		Number:           hexutil.Uint64(blk.Height()),
+		Hash:             common.Hash(info.hash),
+		ParentHash:       common.Hash(blk.PrevHash()),
+		Nonce:            emptyNonce,
+		Sha3Uncles:       emptyUncleHash,
+		LogsBloom:        emptyBloom,
+		TransactionsRoot: common.Hash(blk.TxRoot()),
+		ReceiptsRoot:     common.Hash(blk.ReceiptRoot()),
+		StateRoot:        common.Hash(blk.DeltaStateDigest()),
+		Miner:            miner,
+		ExtraData:        hexutil.Bytes{},
+		GasLimit:         hexutil.Uint64(s.genesis.BlockGasLimit),
+		GasUsed:          hexutil.Uint64(gasUsed),
+		Timestamp:        hexutil.Uint64(blk.Timestamp().Unix()),
+		Transactions:     make([]interface{}, 0, len(blk.Actions)),
+		Uncles:           []common.Hash{},
+	}
+	for _, selp := range blk.Actions {
+		if !fullTx {
+			res.Transactions = append(res.Transactions, common.Hash(selp.Hash()))
+			continue
+		}
+		tx, err := toWeb3Transaction(selp, info)
+		if err != nil {
+			return nil, err
+		}
+		res.Transactions = append(res.Transactions, tx)
+	}
+	return res, nil
+}
+
+// toExecution converts the call arguments into an execution and its caller, whose gas limit is the action gas limit
+// of the genesis unless given
+func (args *Web3CallArgs) toExecution(g genesis.Genesis) (address.Address, *action.Execution, error) {
+	var from common.Address
+	if args.From != nil {
+		from = *args.From
+	}
+	caller, err := address.FromBytes(from.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	contract := action.EmptyAddress
+	if args.To != nil {
+		to, err := address.FromBytes(args.To.Bytes())
+		if err != nil {
+			return nil, nil, err
+		}
+		contract = to.String()
+	}
+	gasLimit := g.ActionGasLimit
+	if args.Gas != nil {
+		gasLimit = uint64(*args.Gas)
+	}
+	gasPrice, amount := big.NewInt(0), big.NewInt(0)
+	if args.GasPrice != nil {
+		gasPrice = args.GasPrice.ToInt()
+	}
+	if args.Value != nil {
+		amount = args.Value.ToInt()
+	}
+	ex, err := action.NewExecution(contract, 0, amount, gasLimit, gasPrice, args.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return caller, ex, nil
+}
+
+// UnmarshalJSON accepts either a single address or a list of addresses
+func (f *Web3AddressFilter) UnmarshalJSON(data []byte) error {
+	var addr common.Address
+	if err := json.Unmarshal(data, &addr); err == nil {
+		*f = Web3AddressFilter{addr}
+		return nil
+	}
+	var addrs []common.Address
+	if err := json.Unmarshal(data, &addrs); err != nil {
+		return errors.Wrap(err, "invalid address filter")
+	}
+	*f = addrs
+	return nil
+}
+
+// UnmarshalJSON accepts null, a single topic, or a list of topics
+func (f *Web3TopicFilter) UnmarshalJSON(data []byte) error {
+	var topic *common.Hash
+	if err := json.Unmarshal(data, &topic); err == nil {
+		*f = nil
+		if topic != nil {
+			*f = Web3TopicFilter{*topic}
+		}
+		return nil
+	}
+	var topics []common.Hash
+	if err := json.Unmarshal(data, &topics); err != nil {
+		return errors.Wrap(err, "invalid topic filter")
+	}
+	*f = topics
+	return nil
+}
+
+func newWeb3BlockInfo(blk *block.Block) *web3BlockInfo {
+	info := &web3BlockInfo{
+		blk:     blk,
+		hash:    blk.HashBlock(),
+		indexes: make(map[hash.Hash256]uint64, len(blk.Actions)),
+	}
+	for i, selp := range blk.Actions {
+		info.indexes[selp.Hash()] = uint64(i)
+	}
+	return info
+}
+
+// toWeb3Transaction converts an action into a transaction, info is nil if the action is pending
+func toWeb3Transaction(selp action.SealedEnvelope, info *web3BlockInfo) (*Web3Transaction, error) {
+	actHash := selp.Hash()
+	tx := &Web3Transaction{
+		Hash:     common.Hash(actHash),
+		From:     common.BytesToAddress(selp.SrcPubkey().Hash()),
+		Nonce:    hexutil.Uint64(selp.Nonce()),
+		Gas:      hexutil.Uint64(selp.GasLimit()),
+		GasPrice: (*hexutil.Big)(selp.GasPrice()),
+		Value:    (*hexutil.Big)(big.NewInt(0)),
+		Input:    hexutil.Bytes{},
+	}
+	var to string
+	switch act := selp.Action().(type) {
+	case *action.Transfer:
+		to = act.Recipient()
+		tx.Value = (*hexutil.Big)(act.Amount())
+		tx.Input = act.Payload()
+	case *action.Execution:
+		to = act.Contract()
+		tx.Value = (*hexutil.Big)(act.Amount())
+		tx.Input = act.Data()
+	}
+	if to != "" {
+		addr, err := toEthAddress(to)
+		if err != nil {
+			return nil, err
+		}
+		tx.To = &addr
+	}
+	if info != nil {
+		blkHash := common.Hash(info.hash)
+		height := hexutil.Uint64(info.blk.Height())
+		index := hexutil.Uint64(info.indexes[actHash])
+		tx.BlockHash, tx.BlockNumber, tx.TransactionIndex = &blkHash, &height, &index
+	}
+	return tx, nil
+}
+
+func toWeb3Log(l *iotextypes.Log, info *web3BlockInfo) (*Web3Log, error) {
+	addr, err := toEthAddress(l.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	actHash := hash.BytesToHash256(l.ActHash)
+	res := &Web3Log{
+		Address:          addr,
+		Topics:           make([]common.Hash, 0, len(l.Topics)),
+		Data:             l.Data,
+		BlockNumber:      hexutil.Uint64(l.BlkHeight),
+		BlockHash:        common.Hash(info.hash),
+		TransactionHash:  common.Hash(actHash),
+		TransactionIndex: hexutil.Uint64(info.indexes[actHash]),
+		LogIndex:         hexutil.Uint64(l.Index),
+	}
+	for _, topic := range l.Topics {
+		res.Topics = append(res.Topics, common.BytesToHash(topic))
+	}
+	return res, nil
+}
+
+// transferGas returns the intrinsic gas of a transfer with the payload
+func transferGas(to common.Address, payload []byte) (hexutil.Uint64, error) {
+	recipient, err := address.FromBytes(to.Bytes())
+	if err != nil {
+		return 0, err
+	}
+	tsf, err := action.NewTransfer(0, big.NewInt(0), recipient.String(), payload, 0, big.NewInt(0))
+	if err != nil {
+		return 0, err
+	}
+	gas, err := tsf.IntrinsicGas()
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(gas), nil
+}
+
+// toEthAddress converts an io address into a 0x address
+func toEthAddress(ioAddr string) (common.Address, error) {
+	addr, err := address.FromString(ioAddr)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(addr.Bytes()), nil
+}
+
+// web3Error strips the grpc status of an error returned by the API server
+func web3Error(err error) error {
+	if s, ok := status.FromError(err); ok {
+		return errors.New(s.Message())
+	}
+	return err
+}
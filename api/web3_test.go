@@ -0,0 +1,182 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestWeb3Server(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	svr, err := createServer(cfg, true)
+	require.NoError(err)
+	cfg.Genesis.BlockGasLimit = 12345678
+	web3server, err := newWeb3Server(svr, cfg.API, cfg.Genesis)
+	require.NoError(err)
+	client := rpc.DialInProc(web3server.rpcServer)
+	defer client.Close()
+
+	ethAddr := func(name string) common.Address {
+		return common.BytesToAddress(ta.Addrinfo[name].Bytes())
+	}
+
+	var version string
+	require.NoError(client.Call(&version, "net_version"))
+	require.Equal("1", version)
+	var height hexutil.Uint64
+	require.NoError(client.Call(&height, "eth_blockNumber"))
+	require.Equal(hexutil.Uint64(4), height)
+
+	var balance hexutil.Big
+	require.NoError(client.Call(&balance, "eth_getBalance", ethAddr("charlie"), "latest"))
+	require.Equal("3", balance.ToInt().String())
+	var count hexutil.Uint64
+	require.NoError(client.Call(&count, "eth_getTransactionCount", ethAddr("producer"), "latest"))
+	require.Equal(hexutil.Uint64(1), count)
+	require.NoError(client.Call(&count, "eth_getTransactionCount", ethAddr("producer"), "pending"))
+	require.Equal(hexutil.Uint64(5), count)
+	require.Error(client.Call(&count, "eth_getTransactionCount", ethAddr("producer"), "0x5"))
+	var code hexutil.Bytes
+	require.NoError(client.Call(&code, "eth_getCode", ethAddr("delta"), "latest"))
+	require.Empty(code)
+
+	var blk Web3Block
+	require.NoError(client.Call(&blk, "eth_getBlockByNumber", "0x2", false))
+	require.Equal(hexutil.Uint64(2), blk.Number)
+	// including the reward granting action
+	require.Equal(7, len(blk.Transactions))
+	require.Equal(hexutil.Uint64(cfg.Genesis.BlockGasLimit), blk.GasLimit)
+	blkHash := blk.Hash
+	require.NoError(client.Call(&blk, "eth_getBlockByHash", blkHash, true))
+	require.Equal(hexutil.Uint64(2), blk.Number)
+	tx, ok := blk.Transactions[0].(map[string]interface{})
+	require.True(ok)
+	require.Equal(ethAddr("charlie").Hex(), common.HexToAddress(tx["from"].(string)).Hex())
+
+	var web3Tx *Web3Transaction
+	require.NoError(client.Call(&web3Tx, "eth_getTransactionByHash", common.Hash(transferHash1)))
+	require.NotNil(web3Tx)
+	require.Equal(hexutil.Uint64(1), *web3Tx.BlockNumber)
+	require.Equal(hexutil.Uint64(0), *web3Tx.TransactionIndex)
+	require.Equal(ethAddr("charlie"), *web3Tx.To)
+	require.Equal("10", web3Tx.Value.ToInt().String())
+	// pending in the actpool
+	require.NoError(client.Call(&web3Tx, "eth_getTransactionByHash", common.Hash(executionHash1)))
+	require.NotNil(web3Tx)
+	require.Nil(web3Tx.BlockHash)
+	require.NoError(client.Call(&web3Tx, "eth_getTransactionByHash", common.Hash(hash.ZeroHash256)))
+	require.Nil(web3Tx)
+
+	var receipt *Web3Receipt
+	require.NoError(client.Call(&receipt, "eth_getTransactionReceipt", common.Hash(transferHash1)))
+	require.NotNil(receipt)
+	require.Equal(hexutil.Uint64(action.SuccessReceiptStatus), receipt.Status)
+	require.Equal(hexutil.Uint64(1), receipt.BlockNumber)
+	require.Equal(receipt.GasUsed, receipt.CumulativeGasUsed)
+
+	var gas hexutil.Uint64
+	require.NoError(client.Call(&gas, "eth_estimateGas", map[string]interface{}{"to": ethAddr("alfa")}))
+	require.Equal(hexutil.Uint64(action.TransferBaseIntrinsicGas), gas)
+	require.NoError(client.Call(&gas, "eth_estimateGas", map[string]interface{}{
+		"to":   ethAddr("alfa"),
+		"data": hexutil.Bytes{1, 2},
+	}))
+	require.Equal(hexutil.Uint64(action.TransferBaseIntrinsicGas+2*action.TransferPayloadGas), gas)
+
+	var logs []*Web3Log
+	require.NoError(client.Call(&logs, "eth_getLogs", map[string]interface{}{
+		"fromBlock": "0x1",
+		"address":   ethAddr("delta"),
+		"topics":    []interface{}{nil, []common.Hash{{1}}},
+	}))
+	require.Empty(logs)
+
+	var actHash common.Hash
+	require.Error(client.Call(&actHash, "eth_sendRawTransaction", hexutil.Bytes{1, 2, 3}))
+	ethTx, err := rlp.EncodeToBytes(types.NewTransaction(1, ethAddr("alfa"), big.NewInt(1), 10000, big.NewInt(1), nil))
+	require.NoError(err)
+	err = client.Call(&actHash, "eth_sendRawTransaction", hexutil.Bytes(ethTx))
+	require.Error(err)
+	require.Equal(ErrEthTransaction.Error(), err.Error())
+}
+
+func TestWeb3ServerHTTP(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	svr, err := createServer(cfg, true)
+	require.NoError(err)
+	post := func(web3server *web3Server, host string, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "http://"+host+"/",
+			strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"web3_clientVersion","params":[]}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		web3server.httpServer.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// only localhost is allowed by default
+	web3server, err := newWeb3Server(svr, cfg.API, cfg.Genesis)
+	require.NoError(err)
+	w := post(web3server, "localhost:15014", "http://localhost:3000")
+	require.Equal(http.StatusOK, w.Code)
+	require.Equal("http://localhost:3000", w.Header().Get("Access-Control-Allow-Origin"))
+	w = post(web3server, "localhost:15014", "http://example.com")
+	require.Empty(w.Header().Get("Access-Control-Allow-Origin"))
+	w = post(web3server, "example.com", "http://example.com")
+	require.Equal(http.StatusForbidden, w.Code)
+
+	cfg.API.Web3CORSOrigins = []string{"http://*.example.com"}
+	cfg.API.Web3VirtualHosts = []string{"rpc.example.com"}
+	web3server, err = newWeb3Server(svr, cfg.API, cfg.Genesis)
+	require.NoError(err)
+	w = post(web3server, "rpc.example.com", "http://app.example.com")
+	require.Equal(http.StatusOK, w.Code)
+	require.Equal("http://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	w = post(web3server, "localhost", "http://localhost")
+	require.Equal(http.StatusForbidden, w.Code)
+}
+
+func TestWeb3Filter(t *testing.T) {
+	require := require.New(t)
+
+	var query Web3FilterQuery
+	require.NoError(json.Unmarshal([]byte(`{
+		"fromBlock": "earliest",
+		"address": "0x0000000000000000000000000000000000000001",
+		"topics": [null, "0x0000000000000000000000000000000000000000000000000000000000000002",
+			["0x0000000000000000000000000000000000000000000000000000000000000003"]]
+	}`), &query))
+	require.Equal(rpc.EarliestBlockNumber, *query.FromBlock)
+	require.Nil(query.ToBlock)
+	require.Equal(Web3AddressFilter{common.BigToAddress(common.Big1)}, query.Addresses)
+	require.Equal([]Web3TopicFilter{nil, {common.BigToHash(common.Big2)}, {common.BigToHash(common.Big3)}},
+		query.Topics)
+
+	require.NoError(json.Unmarshal([]byte(`{"address": ["0x0000000000000000000000000000000000000001"]}`), &query))
+	require.Equal(Web3AddressFilter{common.BigToAddress(common.Big1)}, query.Addresses)
+	require.Error(json.Unmarshal([]byte(`{"address": 1}`), &query))
+}
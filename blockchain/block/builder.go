@@ -13,8 +13,8 @@ import (
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/pkg/hash"
-	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 // Builder is used to construct Block.
@@ -68,16 +68,23 @@ func (b *Builder) SetReceiptRoot(h hash.Hash256) *Builder {
 	return b
 }
 
-// SignAndBuild signs and then builds a block.
-func (b *Builder) SignAndBuild(signerPrvKey keypair.PrivateKey) (Block, error) {
+// SignAndBuild signs and then builds a block. The header is signed from its core if the signer is a
+// signer.BlockHeaderSigner.
+func (b *Builder) SignAndBuild(signerPrvKey signer.HashSigner) (Block, error) {
 	if !bytes.Equal(b.blk.Header.pubkey.Bytes(), signerPrvKey.PublicKey().Bytes()) {
 		return Block{}, errors.New("public key from the signer doesn't match that from runnable actions")
 	}
 
-	h := b.blk.Header.HashHeaderCore()
-	sig, err := signerPrvKey.Sign(h[:])
+	var sig []byte
+	var err error
+	if s, ok := signerPrvKey.(signer.BlockHeaderSigner); ok {
+		sig, err = s.SignBlockHeader(b.blk.Header.CoreByteStream())
+	} else {
+		h := b.blk.Header.HashHeaderCore()
+		sig, err = signerPrvKey.Sign(h[:])
+	}
 	if err != nil {
-		return Block{}, errors.New("failed to sign block")
+		return Block{}, errors.Wrap(err, "failed to sign block")
 	}
 	b.blk.Header.blockSig = sig
 	return b.blk, nil
@@ -15,6 +15,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/facebookgo/clock"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -43,6 +44,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/state/factory"
 )
@@ -98,6 +100,11 @@ type Blockchain interface {
 	GetTotalActions() (uint64, error)
 	// GetReceiptByActionHash returns the receipt by action hash
 	GetReceiptByActionHash(h hash.Hash256) (*action.Receipt, error)
+	// GetReceiptsByHeight returns the receipts of the block at the height
+	GetReceiptsByHeight(height uint64) ([]*action.Receipt, error)
+	// GetLogBlockHeights returns the heights within [start, end] of blocks which may contain logs emitted by any of
+	// the contract addresses with any of topics[i] at position i
+	GetLogBlockHeights(addrs []string, topics [][]hash.Hash256, start uint64, end uint64) ([]uint64, error)
 	// GetActionsFromAddress returns actions from address
 	GetActionsFromAddress(address string) ([]hash.Hash256, error)
 	// GetActionsToAddress returns actions to address
@@ -118,6 +125,8 @@ type Blockchain interface {
 	TipHash() hash.Hash256
 	// TipHeight returns tip block's height
 	TipHeight() uint64
+	// LowestAvailableHeight returns the lowest height whose block body and receipts are not pruned
+	LowestAvailableHeight() uint64
 	// StateByAddr returns account of a given address
 	StateByAddr(address string) (*state.Account, error)
 	// RecoverChainAndState recovers the chain to target height and refresh state db if necessary
@@ -135,7 +144,7 @@ type Blockchain interface {
 	// CommitBlock validates and appends a block to the chain
 	CommitBlock(blk *block.Block) error
 	// ValidateBlock validates a new block before adding it to the blockchain
-	ValidateBlock(blk *block.Block) error
+	ValidateBlock(blk *block.Block, opts ...ValidateOption) error
 
 	// For action operations
 	// Validator returns the current validator object
@@ -147,6 +156,11 @@ type Blockchain interface {
 	// ExecuteContractRead runs a read-only smart contract operation, this is done off the network since it does not
 	// cause any state change
 	ExecuteContractRead(caller address.Address, ex *action.Execution) ([]byte, *action.Receipt, error)
+	// ExecuteContractReadAtHeight runs a read-only smart contract operation on top of the state at the given height
+	ExecuteContractReadAtHeight(caller address.Address, ex *action.Execution, height uint64) ([]byte, *action.Receipt, error)
+	// TraceExecution re-executes an execution on the state of its parent block with the tracer plugged into the EVM,
+	// which requires the state of the parent block in archive mode or within the trie retention window
+	TraceExecution(actHash hash.Hash256, tracer vm.Tracer) ([]byte, *action.Receipt, error)
 
 	// AddSubscriber make you listen to every single produced block
 	AddSubscriber(BlockCreationSubscriber) error
@@ -165,7 +179,7 @@ type blockchain struct {
 	validator     Validator
 	lifecycle     lifecycle.Lifecycle
 	clk           clock.Clock
-	blocklistener []BlockCreationSubscriber
+	blocklistener []*blockSubscriber
 	timerFactory  *prometheustimer.TimerFactory
 
 	// used by account-based model
@@ -173,6 +187,9 @@ type blockchain struct {
 
 	registry *protocol.Registry
 
+	// signer signs the blocks and the actions produced by the node
+	signer signer.HashSigner
+
 	enableExperimentalActions bool
 }
 
@@ -235,6 +252,7 @@ func BoltDBDaoOption() Option {
 			gateway && !cfg.Chain.EnableAsyncIndexWrite,
 			cfg.Chain.CompressBlock,
 			cfg.Chain.MaxCacheSize,
+			cfg.Chain.BlockRetentionHeight,
 		)
 		return nil
 	}
@@ -249,6 +267,7 @@ func InMemDaoOption() Option {
 			gateway && !cfg.Chain.EnableAsyncIndexWrite,
 			cfg.Chain.CompressBlock,
 			cfg.Chain.MaxCacheSize,
+			cfg.Chain.BlockRetentionHeight,
 		)
 
 		return nil
@@ -272,6 +291,15 @@ func RegistryOption(registry *protocol.Registry) Option {
 	}
 }
 
+// SignerOption sets the signer of the blocks and the actions produced by the node, instead of the producer private key
+// in config
+func SignerOption(s signer.HashSigner) Option {
+	return func(bc *blockchain, conf config.Config) error {
+		bc.signer = s
+		return nil
+	}
+}
+
 // EnableExperimentalActions enables the blockchain to process experimental actions
 func EnableExperimentalActions() Option {
 	return func(bc *blockchain, conf config.Config) error {
@@ -302,13 +330,17 @@ func NewBlockchain(cfg config.Config, opts ...Option) Blockchain {
 		log.L().Panic("Failed to generate prometheus timer factory.", zap.Error(err))
 	}
 	chain.timerFactory = timerFactory
+	if chain.signer == nil {
+		chain.signer = cfg.ProducerPrivateKey()
+	}
 	// Set block validator
+	producerAddr, err := address.FromBytes(chain.signer.PublicKey().Hash())
 	if err != nil {
 		log.L().Panic("Failed to get block producer address.", zap.Error(err))
 	}
 	chain.validator = &validator{
 		sf:                        chain.sf,
-		validatorAddr:             cfg.ProducerAddress().String(),
+		validatorAddr:             producerAddr.String(),
 		enableExperimentalActions: chain.enableExperimentalActions,
 	}
 
@@ -352,6 +384,13 @@ func (bc *blockchain) Start(ctx context.Context) (err error) {
 
 // Stop stops the blockchain.
 func (bc *blockchain) Stop(ctx context.Context) error {
+	// the committed blocks are handed to the subscribers before stopping, which may read the chain while handling
+	bc.mu.RLock()
+	subscribers := append([]*blockSubscriber{}, bc.blocklistener...)
+	bc.mu.RUnlock()
+	for _, s := range subscribers {
+		s.flush()
+	}
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 
@@ -489,6 +528,29 @@ func (bc *blockchain) GetReceiptByActionHash(h hash.Hash256) (*action.Receipt, e
 	return bc.dao.getReceiptByActionHash(h)
 }
 
+// GetReceiptsByHeight returns the receipts of the block at the height
+func (bc *blockchain) GetReceiptsByHeight(height uint64) ([]*action.Receipt, error) {
+	return bc.dao.getReceiptsByHeight(height)
+}
+
+// GetLogBlockHeights returns the heights of blocks which may contain the logs matching the addresses and topics
+func (bc *blockchain) GetLogBlockHeights(
+	addrs []string,
+	topics [][]hash.Hash256,
+	start uint64,
+	end uint64,
+) ([]uint64, error) {
+	addrBytes := make([]hash.Hash160, 0, len(addrs))
+	for _, addrStr := range addrs {
+		addr, err := address.FromString(addrStr)
+		if err != nil {
+			return nil, err
+		}
+		addrBytes = append(addrBytes, hash.BytesToHash160(addr.Bytes()))
+	}
+	return getLogBlockHeights(bc.dao.kvstore, addrBytes, topics, start, end)
+}
+
 // GetActionsFromAddress returns actions from address
 func (bc *blockchain) GetActionsFromAddress(addrStr string) ([]hash.Hash256, error) {
 	addr, err := address.FromString(addrStr)
@@ -569,13 +631,18 @@ func (bc *blockchain) TipHeight() uint64 {
 	return atomic.LoadUint64(&bc.tipHeight)
 }
 
+// LowestAvailableHeight returns the lowest height whose block body and receipts are not pruned
+func (bc *blockchain) LowestAvailableHeight() uint64 {
+	return bc.dao.getLowestAvailableHeight()
+}
+
 // ValidateBlock validates a new block before adding it to the blockchain
-func (bc *blockchain) ValidateBlock(blk *block.Block) error {
+func (bc *blockchain) ValidateBlock(blk *block.Block, opts ...ValidateOption) error {
 	bc.mu.RLock()
 	defer bc.mu.RUnlock()
 	timer := bc.timerFactory.NewTimer("ValidateBlock")
 	defer timer.End()
-	return bc.validateBlock(blk)
+	return bc.validateBlock(blk, opts...)
 }
 
 func (bc *blockchain) MintNewBlock(
@@ -594,12 +661,16 @@ func (bc *blockchain) MintNewBlock(
 		return nil, errors.Wrap(err, "Failed to obtain working set from state factory")
 	}
 
+	producerAddr, err := address.FromBytes(bc.signer.PublicKey().Hash())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get block producer address")
+	}
 	gasLimitForContext := bc.config.Genesis.BlockGasLimit
 	ctx := protocol.WithRunActionsCtx(context.Background(),
 		protocol.RunActionsCtx{
 			BlockHeight:    newblockHeight,
 			BlockTimeStamp: timestamp,
-			Producer:       bc.config.ProducerAddress(),
+			Producer:       producerAddr,
 			GasLimit:       gasLimitForContext,
 			ActionGasLimit: bc.config.Genesis.ActionGasLimit,
 			Registry:       bc.registry,
@@ -611,7 +682,7 @@ func (bc *blockchain) MintNewBlock(
 
 	blockMtc.WithLabelValues("numActions").Set(float64(len(actions)))
 
-	sk := bc.config.ProducerPrivateKey()
+	sk := bc.signer
 	ra := block.NewRunnableActionsBuilder().
 		SetHeight(newblockHeight).
 		SetTimeStamp(timestamp).
@@ -682,7 +753,7 @@ func (bc *blockchain) AddSubscriber(s BlockCreationSubscriber) error {
 	if s == nil {
 		return errors.New("subscriber could not be nil")
 	}
-	bc.blocklistener = append(bc.blocklistener, s)
+	bc.blocklistener = append(bc.blocklistener, newBlockSubscriber(s))
 
 	return nil
 }
@@ -691,7 +762,8 @@ func (bc *blockchain) RemoveSubscriber(s BlockCreationSubscriber) error {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 	for i, sub := range bc.blocklistener {
-		if sub == s {
+		if sub.subscriber == s {
+			sub.close()
 			bc.blocklistener = append(bc.blocklistener[:i], bc.blocklistener[i+1:]...)
 			log.L().Info("Successfully unsubscribe block creation.")
 			return nil
@@ -718,27 +790,82 @@ func (bc *blockchain) ExecuteContractRead(caller address.Address, ex *action.Exe
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to obtain working set from state factory")
 	}
-	producer, err := address.FromString(header.ProducerAddress())
+	return bc.executeContractRead(caller, ex, header, ws)
+}
+
+// ExecuteContractReadAtHeight runs a read-only smart contract operation on top of the state at the given height,
+// which is only available in archive mode unless it is the current height
+func (bc *blockchain) ExecuteContractReadAtHeight(
+	caller address.Address,
+	ex *action.Execution,
+	height uint64,
+) ([]byte, *action.Receipt, error) {
+	header, err := bc.BlockHeaderByHeight(height)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get block in ExecuteContractReadAtHeight")
+	}
+	ws, err := bc.sf.NewWorkingSetAtHeight(height)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to obtain working set at height %d from state factory", height)
+	}
+	return bc.executeContractRead(caller, ex, header, ws)
+}
+
+// TraceExecution re-executes an execution on the state of its parent block with the tracer plugged into the EVM
+func (bc *blockchain) TraceExecution(actHash hash.Hash256, tracer vm.Tracer) ([]byte, *action.Receipt, error) {
+	blkHash, err := bc.GetBlockHashByActionHash(actHash)
 	if err != nil {
 		return nil, nil, err
 	}
-	gasLimit := bc.config.Genesis.BlockGasLimit
-	ctx := protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
-		BlockHeight:    header.Height(),
-		BlockTimeStamp: header.Timestamp(),
+	blk, err := bc.dao.getBlock(blkHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	ws, err := bc.sf.NewWorkingSetAtHeight(blk.Height() - 1)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to obtain working set at height %d from state factory", blk.Height()-1)
+	}
+	producer, err := address.FromBytes(blk.PublicKey().Hash())
+	if err != nil {
+		return nil, nil, err
+	}
+	raCtx := protocol.RunActionsCtx{
+		BlockHeight:    blk.Height(),
+		BlockTimeStamp: blk.Timestamp(),
 		Producer:       producer,
-		Caller:         caller,
-		GasLimit:       gasLimit,
+		GasLimit:       bc.config.Genesis.BlockGasLimit,
 		ActionGasLimit: bc.config.Genesis.ActionGasLimit,
-		GasPrice:       big.NewInt(0),
-		IntrinsicGas:   0,
-	})
-	return evm.ExecuteContract(
-		ctx,
-		ws,
-		ex,
-		bc,
-	)
+		Registry:       bc.registry,
+	}
+	// replay the actions prior to the execution in the block, the same way as runActions
+	for _, selp := range blk.Actions {
+		if selp.Hash() != actHash {
+			receipt, err := ws.RunAction(raCtx, selp)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to replay action %x", selp.Hash())
+			}
+			if receipt != nil {
+				raCtx.GasLimit -= receipt.GasConsumed
+			}
+			continue
+		}
+		exec, ok := selp.Action().(*action.Execution)
+		if !ok {
+			return nil, nil, errors.Errorf("action %x is not an execution", actHash)
+		}
+		if raCtx.Caller, err = address.FromBytes(selp.SrcPubkey().Hash()); err != nil {
+			return nil, nil, err
+		}
+		raCtx.ActionHash = actHash
+		raCtx.GasPrice = selp.GasPrice()
+		if raCtx.IntrinsicGas, err = selp.IntrinsicGas(); err != nil {
+			return nil, nil, err
+		}
+		raCtx.Nonce = selp.Nonce()
+		ctx := evm.WithTracerCtx(protocol.WithRunActionsCtx(context.Background(), raCtx), tracer)
+		return evm.ExecuteContract(ctx, ws, exec, bc)
+	}
+	return nil, nil, errors.Errorf("block %x does not have action %x", blkHash, actHash)
 }
 
 // CreateState adds a new account with initial balance to the factory
@@ -807,6 +934,35 @@ func (bc *blockchain) GenesisTimestamp() int64 {
 // private functions
 //=====================================
 
+func (bc *blockchain) executeContractRead(
+	caller address.Address,
+	ex *action.Execution,
+	header *block.Header,
+	ws factory.WorkingSet,
+) ([]byte, *action.Receipt, error) {
+	producer, err := address.FromString(header.ProducerAddress())
+	if err != nil {
+		return nil, nil, err
+	}
+	gasLimit := bc.config.Genesis.BlockGasLimit
+	ctx := protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+		BlockHeight:    header.Height(),
+		BlockTimeStamp: header.Timestamp(),
+		Producer:       producer,
+		Caller:         caller,
+		GasLimit:       gasLimit,
+		ActionGasLimit: bc.config.Genesis.ActionGasLimit,
+		GasPrice:       big.NewInt(0),
+		IntrinsicGas:   0,
+	})
+	return evm.ExecuteContract(
+		ctx,
+		ws,
+		ex,
+		bc,
+	)
+}
+
 func (bc *blockchain) protocol(id string) (protocol.Protocol, bool) {
 	if bc.registry == nil {
 		return nil, false
@@ -928,13 +1084,13 @@ func (bc *blockchain) startExistingBlockchain() error {
 	return nil
 }
 
-func (bc *blockchain) validateBlock(blk *block.Block) error {
+func (bc *blockchain) validateBlock(blk *block.Block, opts ...ValidateOption) error {
 	validateTimer := bc.timerFactory.NewTimer("validate")
 	prevBlkHash := bc.tipHash
 	if blk.Height() == 1 {
 		prevBlkHash = bc.config.Genesis.Hash()
 	}
-	err := bc.validator.Validate(blk, bc.tipHeight, prevBlkHash)
+	err := bc.validator.Validate(blk, bc.tipHeight, prevBlkHash, opts...)
 	validateTimer.End()
 	if err != nil {
 		return errors.Wrapf(err, "error when validating block %d", blk.Height())
@@ -1219,7 +1375,7 @@ func (bc *blockchain) createPutPollResultAction(height uint64) (skip bool, se ac
 	default:
 		return
 	}
-	sk := bc.config.ProducerPrivateKey()
+	sk := bc.signer
 	nonce := uint64(0)
 	pollAction := action.NewPutPollResult(nonce, nextEpochHeight, l)
 	builder := action.EnvelopeBuilder{}
@@ -1228,15 +1384,8 @@ func (bc *blockchain) createPutPollResultAction(height uint64) (skip bool, se ac
 }
 
 func (bc *blockchain) emitToSubscribers(blk *block.Block) {
-	if bc.blocklistener == nil {
-		return
-	}
 	for _, s := range bc.blocklistener {
-		go func(bcs BlockCreationSubscriber, b *block.Block) {
-			if err := bcs.HandleBlock(b); err != nil {
-				log.L().Error("Failed to handle new block.", zap.Error(err))
-			}
-		}(s, blk)
+		s.enqueue(blk)
 	}
 }
 
@@ -1286,7 +1435,7 @@ func (bc *blockchain) createGrantRewardAction(rewardType int, height uint64) (ac
 		SetGasLimit(grant.GasLimit()).
 		SetAction(&grant).
 		Build()
-	sk := bc.config.ProducerPrivateKey()
+	sk := bc.signer
 	return action.Sign(envelope, sk)
 }
 
@@ -1308,7 +1457,7 @@ func (bc *blockchain) createMemorialTransfer(recipient string, gasLimit uint64)
 		SetGasLimit(tsf.GasLimit()).
 		SetAction(tsf).
 		Build()
-	sk := bc.config.ProducerPrivateKey()
+	sk := bc.signer
 	return action.Sign(envelope, sk)
 }
 
@@ -420,6 +420,7 @@ func TestBlockchain_MintNewBlock_PopAccount(t *testing.T) {
 
 type MockSubscriber struct {
 	counter int
+	heights []uint64
 	mu      sync.RWMutex
 }
 
@@ -427,6 +428,7 @@ func (ms *MockSubscriber) HandleBlock(blk *block.Block) error {
 	ms.mu.Lock()
 	tsfs, _, _ := action.ClassifyActions(blk.Actions)
 	ms.counter += len(tsfs)
+	ms.heights = append(ms.heights, blk.Height())
 	ms.mu.Unlock()
 	return nil
 }
@@ -487,6 +489,11 @@ func TestLoadBlockchainfromDB(t *testing.T) {
 	err = bc.Stop(ctx)
 	require.NoError(err)
 	require.Equal(23, ms.Counter())
+	// the blocks are handed to the subscriber in order
+	for i, h := range ms.heights {
+		require.Equal(height+uint64(i)+1, h)
+	}
+	require.Equal(bc.TipHeight()-height, uint64(len(ms.heights)))
 
 	// Load a blockchain from DB
 	sf, err = factory.NewFactory(cfg, factory.DefaultTrieOption())
@@ -6,9 +6,87 @@
 
 package blockchain
 
-import "github.com/iotexproject/iotex-core/blockchain/block"
+import (
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
 
 // BlockCreationSubscriber is an interface which will get notified when a block is created
 type BlockCreationSubscriber interface {
 	HandleBlock(*block.Block) error
 }
+
+// blockSubscriber hands the committed blocks to a subscriber one by one in the order of commit, without blocking the
+// chain on a slow subscriber
+type blockSubscriber struct {
+	subscriber BlockCreationSubscriber
+	mu         sync.Mutex
+	cond       *sync.Cond
+	queue      []*block.Block
+	busy       bool
+	closed     bool
+}
+
+func newBlockSubscriber(s BlockCreationSubscriber) *blockSubscriber {
+	bs := &blockSubscriber{subscriber: s}
+	bs.cond = sync.NewCond(&bs.mu)
+	go bs.run()
+	return bs
+}
+
+// enqueue queues a block to be handled after the ones queued before
+func (bs *blockSubscriber) enqueue(blk *block.Block) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if bs.closed {
+		return
+	}
+	bs.queue = append(bs.queue, blk)
+	bs.cond.Broadcast()
+}
+
+// flush waits until the queued blocks are handled
+func (bs *blockSubscriber) flush() {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	for (len(bs.queue) > 0 || bs.busy) && !bs.closed {
+		bs.cond.Wait()
+	}
+}
+
+// close stops handling blocks, and drops the queued ones
+func (bs *blockSubscriber) close() {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.closed = true
+	bs.queue = nil
+	bs.cond.Broadcast()
+}
+
+func (bs *blockSubscriber) run() {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	for {
+		for len(bs.queue) == 0 && !bs.closed {
+			bs.cond.Wait()
+		}
+		if bs.closed {
+			return
+		}
+		blk := bs.queue[0]
+		bs.queue[0] = nil
+		bs.queue = bs.queue[1:]
+		bs.busy = true
+		bs.mu.Unlock()
+		if err := bs.subscriber.HandleBlock(blk); err != nil {
+			log.L().Error("Failed to handle new block.", zap.Error(err))
+		}
+		bs.mu.Lock()
+		bs.busy = false
+		bs.cond.Broadcast()
+	}
+}
@@ -7,7 +7,9 @@
 package blockchain
 
 import (
+	"bytes"
 	"context"
+	"sync/atomic"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
@@ -34,21 +36,37 @@ const (
 	blockActionReceiptMappingNS      = "a2r"
 	blockAddressActionMappingNS      = "a2a"
 	blockAddressActionCountMappingNS = "a2c"
+	blockAddressActionPrunedNS       = "a2p"
 	blockHeaderNS                    = "bhr"
 	blockBodyNS                      = "bbd"
 	blockFooterNS                    = "bfr"
 	receiptsNS                       = "rpt"
+	blockLogHeightMappingNS          = "l2h"
+	blockLogCountMappingNS           = "l2c"
 
 	hashOffset = 12
+
+	// maxPruneBlocks is the max number of blocks pruned along with a new block, so that a long history is pruned
+	// gradually when the retention is enabled on an existing chain
+	maxPruneBlocks = 100
 )
 
 var (
 	topHeightKey     = []byte("th")
 	totalActionsKey  = []byte("ta")
+	prunedHeightKey  = []byte("ph")
+	reindexKey       = []byte("ri")
 	hashPrefix       = []byte("ha.")
 	heightPrefix     = []byte("he.")
 	actionFromPrefix = []byte("fr.")
 	actionToPrefix   = []byte("to.")
+	logAddressPrefix = []byte("la.")
+	logTopicPrefix   = []byte("lt.")
+)
+
+var (
+	// ErrBlockPruned indicates the block body or receipts are deleted since the block is out of the retention window
+	ErrBlockPruned = errors.New("block data is pruned")
 )
 
 var (
@@ -64,6 +82,8 @@ var (
 type blockDAO struct {
 	writeIndex    bool
 	compressBlock bool
+	retention     uint64 // number of recent blocks whose bodies and receipts are kept, 0 means all
+	prunedHeight  uint64 // height up to which the block bodies and receipts are pruned
 	kvstore       db.KVStore
 	timerFactory  *prometheustimer.TimerFactory
 	lifecycle     lifecycle.Lifecycle
@@ -73,10 +93,17 @@ type blockDAO struct {
 }
 
 // newBlockDAO instantiates a block DAO
-func newBlockDAO(kvstore db.KVStore, writeIndex bool, compressBlock bool, maxCacheSize int) *blockDAO {
+func newBlockDAO(
+	kvstore db.KVStore,
+	writeIndex bool,
+	compressBlock bool,
+	maxCacheSize int,
+	retention uint64,
+) *blockDAO {
 	blockDAO := &blockDAO{
 		writeIndex:    writeIndex,
 		compressBlock: compressBlock,
+		retention:     retention,
 		kvstore:       kvstore,
 	}
 	if maxCacheSize > 0 {
@@ -121,6 +148,15 @@ func (dao *blockDAO) Start(ctx context.Context) error {
 		}
 	}
 
+	// load the pruned height
+	switch value, err := dao.kvstore.Get(blockNS, prunedHeightKey); errors.Cause(err) {
+	case nil:
+		atomic.StoreUint64(&dao.prunedHeight, enc.MachineEndian.Uint64(value))
+	case db.ErrNotExist:
+	default:
+		return errors.Wrap(err, "failed to get pruned height")
+	}
+
 	return nil
 }
 
@@ -135,6 +171,10 @@ func (dao *blockDAO) getBlockHash(height uint64) (hash.Hash256, error) {
 	key := append(heightPrefix, byteutil.Uint64ToBytes(height)...)
 	value, err := dao.kvstore.Get(blockHashHeightMappingNS, key)
 	hash := hash.ZeroHash256
+	if errors.Cause(err) == db.ErrNotExist && dao.isPruned(height) {
+		// the chain is bootstrapped from a snapshot above the height
+		return hash, errors.Wrapf(ErrBlockPruned, "failed to get block hash of height %d", height)
+	}
 	if err != nil {
 		return hash, errors.Wrap(err, "failed to get block hash")
 	}
@@ -234,6 +274,11 @@ func (dao *blockDAO) body(h hash.Hash256) (*block.Body, error) {
 		cacheMtc.WithLabelValues("miss_body").Inc()
 	}
 	value, err := dao.kvstore.Get(blockBodyNS, h[:])
+	if errors.Cause(err) == db.ErrNotExist {
+		if height, heightErr := dao.getBlockHeight(h); heightErr == nil && dao.isPruned(height) {
+			return nil, errors.Wrapf(ErrBlockPruned, "block body %x at height %d is pruned", h, height)
+		}
+	}
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get block body %x", h)
 	}
@@ -321,29 +366,54 @@ func (dao *blockDAO) getTotalActions() (uint64, error) {
 	return enc.MachineEndian.Uint64(value), nil
 }
 
+// getLowestAvailableHeight returns the lowest height whose block body and receipts are available
+func (dao *blockDAO) getLowestAvailableHeight() uint64 {
+	return atomic.LoadUint64(&dao.prunedHeight) + 1
+}
+
+// isPruned returns true if the body and receipts of the block at the height are pruned
+func (dao *blockDAO) isPruned(height uint64) bool {
+	return height > 0 && height <= atomic.LoadUint64(&dao.prunedHeight)
+}
+
 // getReceiptByActionHash returns the receipt by execution hash
 func (dao *blockDAO) getReceiptByActionHash(h hash.Hash256) (*action.Receipt, error) {
 	heightBytes, err := dao.kvstore.Get(blockActionReceiptMappingNS, h[hashOffset:])
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get receipt index for action %x", h)
 	}
-	receiptsBytes, err := dao.kvstore.Get(receiptsNS, heightBytes)
+	receipts, err := dao.getReceiptsByHeight(enc.MachineEndian.Uint64(heightBytes))
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range receipts {
+		if r.ActionHash == h {
+			return r, nil
+		}
+	}
+	return nil, errors.Errorf("receipt of action %x isn't found", h)
+}
+
+// getReceiptsByHeight returns the receipts of the block at the height
+func (dao *blockDAO) getReceiptsByHeight(height uint64) ([]*action.Receipt, error) {
+	if dao.isPruned(height) {
+		return nil, errors.Wrapf(ErrBlockPruned, "receipts of block %d are pruned", height)
+	}
+	receiptsBytes, err := dao.kvstore.Get(receiptsNS, byteutil.Uint64ToBytes(height))
 	if err != nil {
-		height := enc.MachineEndian.Uint64(heightBytes)
 		return nil, errors.Wrapf(err, "failed to get receipts of block %d", height)
 	}
 	receipts := iotextypes.Receipts{}
 	if err := proto.Unmarshal(receiptsBytes, &receipts); err != nil {
 		return nil, err
 	}
+	res := make([]*action.Receipt, 0, len(receipts.Receipts))
 	for _, receipt := range receipts.Receipts {
-		r := action.Receipt{}
+		r := &action.Receipt{}
 		r.ConvertFromReceiptPb(receipt)
-		if r.ActionHash == h {
-			return &r, nil
-		}
+		res = append(res, r)
 	}
-	return nil, errors.Errorf("receipt of action %x isn't found", h)
+	return res, nil
 }
 
 // putBlock puts a block
@@ -403,13 +473,190 @@ func (dao *blockDAO) putBlock(blk *block.Block) error {
 		batch.Put(blockNS, topHeightKey, height, "failed to put top height")
 	}
 
-	if !dao.writeIndex {
-		return dao.kvstore.Commit(batch)
+	if dao.writeIndex {
+		if err := indexBlock(dao.kvstore, blk, batch); err != nil {
+			return err
+		}
 	}
-	if err := indexBlock(dao.kvstore, blk, batch); err != nil {
+	prunedHeight, err := dao.pruneBlocks(blk.Height(), batch)
+	if err != nil {
 		return err
 	}
-	return dao.kvstore.Commit(batch)
+	if err := dao.kvstore.Commit(batch); err != nil {
+		return err
+	}
+	atomic.StoreUint64(&dao.prunedHeight, prunedHeight)
+	return nil
+}
+
+// pruneBlocks deletes the bodies, receipts and the action, receipt and address indexes of the blocks which fall out of
+// the retention window at the tip height, and returns the new pruned height. Headers and footers are kept
+func (dao *blockDAO) pruneBlocks(tipHeight uint64, batch db.KVStoreBatch) (uint64, error) {
+	prunedHeight := atomic.LoadUint64(&dao.prunedHeight)
+	if dao.retention == 0 || tipHeight <= dao.retention+prunedHeight {
+		return prunedHeight, nil
+	}
+	target := tipHeight - dao.retention
+	if target > prunedHeight+maxPruneBlocks {
+		target = prunedHeight + maxPruneBlocks
+	}
+	prunedCounts := make(map[string]uint64)
+	for height := prunedHeight + 1; height <= target; height++ {
+		blkHash, err := dao.getBlockHash(height)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to get hash of block %d to prune", height)
+		}
+		body, err := dao.body(blkHash)
+		switch errors.Cause(err) {
+		case nil:
+			if err := dao.pruneActionIndexes(body, prunedCounts, batch); err != nil {
+				return 0, errors.Wrapf(err, "failed to prune action indexes of block %d", height)
+			}
+		case db.ErrNotExist:
+			// the block has no body to prune
+		default:
+			return 0, errors.Wrapf(err, "failed to get body of block %d to prune", height)
+		}
+		batch.Delete(blockBodyNS, blkHash[:], "failed to delete block body %x", blkHash)
+		if dao.bodyCache != nil {
+			dao.bodyCache.Remove(blkHash)
+		}
+		heightBytes := byteutil.Uint64ToBytes(height)
+		receiptsBytes, err := dao.kvstore.Get(receiptsNS, heightBytes)
+		if errors.Cause(err) == db.ErrNotExist {
+			continue
+		}
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to get receipts of block %d to prune", height)
+		}
+		receipts := iotextypes.Receipts{}
+		if err := proto.Unmarshal(receiptsBytes, &receipts); err != nil {
+			return 0, err
+		}
+		for _, r := range receipts.Receipts {
+			actHash := hash.BytesToHash256(r.GetActHash())
+			batch.Delete(blockActionReceiptMappingNS, actHash[hashOffset:],
+				"failed to delete receipt index for action %x", actHash)
+		}
+		batch.Delete(receiptsNS, heightBytes, "failed to delete receipts of block %d", height)
+	}
+	for key, count := range prunedCounts {
+		batch.Put(blockAddressActionPrunedNS, []byte(key), byteutil.Uint64ToBytes(count),
+			"failed to put pruned action count %x", key)
+	}
+	batch.Put(blockNS, prunedHeightKey, byteutil.Uint64ToBytes(target), "failed to put pruned height")
+	return target, nil
+}
+
+// pruneActionIndexes deletes the action -> block and address -> action entries of the actions in a pruned block.
+// Blocks are indexed and pruned in height order, so the entries of the block are the lowest ones left of each
+// address, whose index is the number of entries pruned of the address so far. prunedCounts caches these numbers
+// across the blocks pruned in one batch
+func (dao *blockDAO) pruneActionIndexes(body *block.Body, prunedCounts map[string]uint64, batch db.KVStoreBatch) error {
+	for _, selp := range body.Actions {
+		actHash := selp.Hash()
+		batch.Delete(blockActionBlockMappingNS, actHash[hashOffset:], "failed to delete action hash %x", actHash)
+
+		keys, err := addressIndexKeys(selp)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := dao.pruneAddressAction([]byte(key), actHash, prunedCounts, batch); err != nil {
+				return errors.Wrapf(err, "for address key %x", key)
+			}
+		}
+	}
+	return nil
+}
+
+// pruneAddressAction deletes the lowest address -> action entry left under the key, which is expected to be the
+// action. If it isn't, the index doesn't follow the height order, and the entry of the action is searched instead
+func (dao *blockDAO) pruneAddressAction(
+	key []byte,
+	actHash hash.Hash256,
+	prunedCounts map[string]uint64,
+	batch db.KVStoreBatch,
+) error {
+	pruned, ok := prunedCounts[string(key)]
+	if !ok {
+		var err error
+		if pruned, err = getPrunedActionCount(dao.kvstore, key); err != nil {
+			return err
+		}
+	}
+	lowestKey := indexKey(key, pruned)
+	value, err := dao.kvstore.Get(blockAddressActionMappingNS, lowestKey)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist:
+		// the address isn't indexed
+		return nil
+	default:
+		return errors.Wrapf(err, "failed to get action %d", pruned)
+	}
+	if bytes.Equal(value, actHash[:]) {
+		batch.Delete(blockAddressActionMappingNS, lowestKey, "failed to delete action hash %x", actHash)
+		prunedCounts[string(key)] = pruned + 1
+		return nil
+	}
+	iter, err := dao.kvstore.Iterate(blockAddressActionMappingNS, key)
+	if err != nil {
+		return errors.Wrap(err, "failed to iterate actions")
+	}
+	defer iter.Close()
+	for iter.Next() {
+		if bytes.Equal(iter.Value(), actHash[:]) {
+			k := make([]byte, len(iter.Key()))
+			copy(k, iter.Key())
+			batch.Delete(blockAddressActionMappingNS, k, "failed to delete action hash %x", actHash)
+		}
+	}
+	return errors.Wrap(iter.Error(), "failed to iterate actions")
+}
+
+// putSnapshotHeaders puts the headers and footers of the blocks imported from a snapshot into an empty chain DB. The
+// last header becomes the tip, and the blocks below it are regarded as pruned
+func (dao *blockDAO) putSnapshotHeaders(headers []*block.Header, footers []*block.Footer, totalActions uint64) error {
+	if len(headers) == 0 || len(headers) != len(footers) {
+		return errors.New("invalid snapshot headers")
+	}
+	batch := db.NewBatch()
+	for i, header := range headers {
+		serHeader, err := header.Serialize()
+		if err != nil {
+			return errors.Wrap(err, "failed to serialize block header")
+		}
+		serFooter, err := footers[i].Serialize()
+		if err != nil {
+			return errors.Wrap(err, "failed to serialize block footer")
+		}
+		if dao.compressBlock {
+			if serHeader, err = compress.Compress(serHeader); err != nil {
+				return errors.Wrapf(err, "error when compressing a block header")
+			}
+			if serFooter, err = compress.Compress(serFooter); err != nil {
+				return errors.Wrapf(err, "error when compressing a block footer")
+			}
+		}
+		hash := header.HashBlock()
+		height := byteutil.Uint64ToBytes(header.Height())
+		batch.Put(blockHeaderNS, hash[:], serHeader, "failed to put block header")
+		batch.Put(blockFooterNS, hash[:], serFooter, "failed to put block footer")
+		hashKey := append(hashPrefix, hash[:]...)
+		batch.Put(blockHashHeightMappingNS, hashKey, height, "failed to put hash -> height mapping")
+		heightKey := append(heightPrefix, height...)
+		batch.Put(blockHashHeightMappingNS, heightKey, hash[:], "failed to put height -> hash mapping")
+	}
+	tipHeight := headers[len(headers)-1].Height()
+	batch.Put(blockNS, topHeightKey, byteutil.Uint64ToBytes(tipHeight), "failed to put top height")
+	batch.Put(blockNS, prunedHeightKey, byteutil.Uint64ToBytes(tipHeight), "failed to put pruned height")
+	batch.Put(blockNS, totalActionsKey, byteutil.Uint64ToBytes(totalActions), "failed to put total actions")
+	if err := dao.kvstore.Commit(batch); err != nil {
+		return err
+	}
+	atomic.StoreUint64(&dao.prunedHeight, tipHeight)
+	return nil
 }
 
 // putReceipts store receipt into db
@@ -434,6 +681,11 @@ func (dao *blockDAO) putReceipts(blkHeight uint64, blkReceipts []*action.Receipt
 			r.ActionHash[:],
 		)
 	}
+	if dao.writeIndex {
+		if err := putLogs(dao.kvstore, blkHeight, blkReceipts, batch); err != nil {
+			return err
+		}
+	}
 	receiptsBytes, err := proto.Marshal(&receipts)
 	if err != nil {
 		return err
@@ -515,19 +767,42 @@ func (dao *blockDAO) deleteTipBlock() error {
 		return err
 	}
 
-	if err = deleteReceipts(blk, batch); err != nil {
+	receipts, err := dao.getReceiptsByHeight(blk.Height())
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist:
+		// the block has no receipt
+	default:
+		return errors.Wrap(err, "failed to get receipts of tip block")
+	}
+	deleteReceipts(receipts, batch)
+	if err := deleteLogs(dao.kvstore, blk.Height(), receipts, batch); err != nil {
 		return err
 	}
 
 	return dao.kvstore.Commit(batch)
 }
 
+// getPrunedActionCount returns the number of actions of the pruned blocks indexed under the address key
+func getPrunedActionCount(store db.KVStore, key []byte) (uint64, error) {
+	value, err := store.Get(blockAddressActionPrunedNS, key)
+	if errors.Cause(err) == db.ErrNotExist {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get count of pruned actions")
+	}
+	if len(value) != 8 {
+		return 0, errors.New("count of pruned actions is broken")
+	}
+	return enc.MachineEndian.Uint64(value), nil
+}
+
 // deleteReceipts deletes receipt information from db
-func deleteReceipts(blk *block.Block, batch db.KVStoreBatch) error {
-	for _, r := range blk.Receipts {
+func deleteReceipts(receipts []*action.Receipt, batch db.KVStoreBatch) {
+	for _, r := range receipts {
 		batch.Delete(blockActionReceiptMappingNS, r.ActionHash[hashOffset:], "failed to delete receipt for action %x", r.ActionHash[:])
 	}
-	return nil
 }
 
 // deleteActions deletes action information from db
@@ -34,6 +34,7 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
 )
@@ -150,7 +151,7 @@ func TestBlockDAO(t *testing.T) {
 
 	testBlockDao := func(kvstore db.KVStore, t *testing.T) {
 		ctx := context.Background()
-		dao := newBlockDAO(kvstore, config.Default.Explorer.Enabled, false, 0)
+		dao := newBlockDAO(kvstore, config.Default.Explorer.Enabled, false, 0, 0)
 		err := dao.Start(ctx)
 		assert.Nil(t, err)
 		defer func() {
@@ -222,7 +223,7 @@ func TestBlockDAO(t *testing.T) {
 
 	testActionsDao := func(kvstore db.KVStore, t *testing.T) {
 		ctx := context.Background()
-		dao := newBlockDAO(kvstore, true, false, 0)
+		dao := newBlockDAO(kvstore, true, false, 0, 0)
 		err := dao.Start(ctx)
 		assert.Nil(t, err)
 		defer func() {
@@ -313,7 +314,7 @@ func TestBlockDAO(t *testing.T) {
 		require := require.New(t)
 
 		ctx := context.Background()
-		dao := newBlockDAO(kvstore, true, false, 0)
+		dao := newBlockDAO(kvstore, true, false, 0, 0)
 		err := dao.Start(ctx)
 		require.NoError(err)
 		defer func() {
@@ -336,9 +337,17 @@ func TestBlockDAO(t *testing.T) {
 		require.NoError(err)
 		require.NotNil(blk)
 
+		// the tip block isn't deleted if its receipts can't be read
+		require.NoError(kvstore.Put(receiptsNS, byteutil.Uint64ToBytes(3), []byte("broken")))
+		require.Error(dao.deleteTipBlock())
+		actHash := blks[2].Actions[0].Hash()
+		require.NoError(dao.putReceipts(3, []*action.Receipt{{BlockHeight: 3, ActionHash: actHash}}))
+
 		// Delete tip block
 		err = dao.deleteTipBlock()
 		require.NoError(err)
+		_, err = dao.getReceiptByActionHash(actHash)
+		require.Equal(db.ErrNotExist, errors.Cause(err))
 		tipHeight, err = dao.getBlockchainHeight()
 		require.NoError(err)
 		require.Equal(uint64(2), tipHeight)
@@ -375,7 +384,7 @@ func TestBlockDAO(t *testing.T) {
 }
 
 func TestBlockDao_putReceipts(t *testing.T) {
-	blkDao := newBlockDAO(db.NewMemKVStore(), true, false, 0)
+	blkDao := newBlockDAO(db.NewMemKVStore(), true, false, 0, 0)
 	receipts := []*action.Receipt{
 		{
 			BlockHeight:     1,
@@ -402,6 +411,113 @@ func TestBlockDao_putReceipts(t *testing.T) {
 	}
 }
 
+func TestBlockDao_logIndex(t *testing.T) {
+	require := require.New(t)
+
+	kvstore := db.NewMemKVStore()
+	blkDao := newBlockDAO(kvstore, true, false, 0, 0)
+	require.NoError(blkDao.Start(context.Background()))
+	defer func() {
+		require.NoError(blkDao.Stop(context.Background()))
+	}()
+
+	addr1 := testaddress.Addrinfo["alfa"]
+	addr2 := testaddress.Addrinfo["bravo"]
+	topic1 := hash.Hash256b([]byte("topic1"))
+	topic2 := hash.Hash256b([]byte("topic2"))
+	logsByHeight := map[uint64][]*action.Log{
+		1: {{Address: addr1.String(), Topics: []hash.Hash256{topic1}}},
+		2: {{Address: addr2.String(), Topics: []hash.Hash256{topic2}}},
+		3: {
+			{Address: addr1.String(), Topics: []hash.Hash256{topic2}},
+			{Address: addr1.String(), Topics: []hash.Hash256{topic1, topic2}},
+		},
+		4: {},
+	}
+	for height := uint64(1); height <= 4; height++ {
+		receipts := []*action.Receipt{
+			{
+				BlockHeight: height,
+				ActionHash:  hash.Hash256b(byteutil.Uint64ToBytes(height)),
+				Logs:        logsByHeight[height],
+			},
+		}
+		require.NoError(blkDao.putReceipts(height, receipts))
+		// indexing the same block again is a no-op
+		require.NoError(blkDao.putReceipts(height, receipts))
+	}
+	addr1Bytes := hash.BytesToHash160(addr1.Bytes())
+	addr2Bytes := hash.BytesToHash160(addr2.Bytes())
+
+	tests := []struct {
+		addrs   []hash.Hash160
+		topics  [][]hash.Hash256
+		start   uint64
+		end     uint64
+		heights []uint64
+	}{
+		{nil, nil, 2, 4, []uint64{2, 3, 4}},
+		{[]hash.Hash160{addr1Bytes}, nil, 1, 4, []uint64{1, 3}},
+		{[]hash.Hash160{addr1Bytes}, nil, 2, 4, []uint64{3}},
+		{[]hash.Hash160{addr1Bytes, addr2Bytes}, nil, 1, 2, []uint64{1, 2}},
+		{nil, [][]hash.Hash256{{topic2}}, 1, 4, []uint64{2, 3}},
+		// topics are not indexed by position
+		{nil, [][]hash.Hash256{{}, {topic1, topic2}}, 1, 4, []uint64{1, 2, 3}},
+		{[]hash.Hash160{addr1Bytes}, [][]hash.Hash256{{topic2}}, 1, 4, []uint64{3}},
+		{[]hash.Hash160{addr2Bytes}, [][]hash.Hash256{{topic1}}, 1, 4, nil},
+	}
+	for _, test := range tests {
+		heights, err := getLogBlockHeights(kvstore, test.addrs, test.topics, test.start, test.end)
+		require.NoError(err)
+		require.Equal(test.heights, heights)
+	}
+
+	// roll back the log index of block 3
+	receipts, err := blkDao.getReceiptsByHeight(3)
+	require.NoError(err)
+	batch := db.NewBatch()
+	require.NoError(deleteLogs(kvstore, 3, receipts, batch))
+	require.NoError(kvstore.Commit(batch))
+	heights, err := getLogBlockHeights(kvstore, []hash.Hash160{addr1Bytes}, nil, 1, 4)
+	require.NoError(err)
+	require.Equal([]uint64{1}, heights)
+	heights, err = getLogBlockHeights(kvstore, nil, [][]hash.Hash256{{topic2}}, 1, 4)
+	require.NoError(err)
+	require.Equal([]uint64{2}, heights)
+
+	// a key never indexed has no block, while a failure of the DB is returned
+	count, err := getLogIndexCount(kvstore, logTopicKey(hash.ZeroHash256))
+	require.NoError(err)
+	require.Equal(uint64(0), count)
+	_, err = getLogIndexCount(&failingKVStore{kvstore}, logAddressKey(addr1Bytes))
+	require.Error(err)
+	_, err = getLogBlockHeights(&failingKVStore{kvstore}, []hash.Hash160{addr1Bytes}, nil, 1, 4)
+	require.Error(err)
+}
+
+func TestLogIndexKey(t *testing.T) {
+	require := require.New(t)
+
+	// the keys never share the spare capacity of the prefix
+	prefix := make([]byte, 2, 64)
+	key1 := logIndexKey(prefix, []byte{1})
+	key2 := logIndexKey(prefix, []byte{2})
+	require.Equal([]byte{0, 0, 1}, key1)
+	require.Equal([]byte{0, 0, 2}, key2)
+	heightKey := logIndexKey(key1, byteutil.Uint64ToBytes(3))
+	require.Equal([]byte{0, 0, 1}, key1)
+	require.Len(heightKey, 11)
+}
+
+// failingKVStore fails to read any key
+type failingKVStore struct {
+	db.KVStore
+}
+
+func (s *failingKVStore) Get(string, []byte) ([]byte, error) {
+	return nil, errors.New("disk failure")
+}
+
 func BenchmarkBlockCache(b *testing.B) {
 	test := func(cacheSize int, b *testing.B) {
 		b.StopTimer()
@@ -418,7 +534,7 @@ func BenchmarkBlockCache(b *testing.B) {
 		}()
 		store := db.NewOnDiskDB(cfg)
 
-		blkDao := newBlockDAO(store, false, false, cacheSize)
+		blkDao := newBlockDAO(store, false, false, cacheSize, 0)
 		require.NoError(b, blkDao.Start(context.Background()))
 		defer func() {
 			require.NoError(b, blkDao.Stop(context.Background()))
@@ -466,3 +582,96 @@ func BenchmarkBlockCache(b *testing.B) {
 		test(0, b)
 	})
 }
+
+func TestBlockDao_prune(t *testing.T) {
+	require := require.New(t)
+
+	kvstore := db.NewMemKVStore()
+	blkDao := newBlockDAO(kvstore, true, false, 10, 2)
+	require.NoError(blkDao.Start(context.Background()))
+
+	var blks []*block.Block
+	prevHash := hash.ZeroHash256
+	for height := uint64(1); height <= 5; height++ {
+		tsf, err := testutil.SignedTransfer(
+			testaddress.Addrinfo["bravo"].String(),
+			testaddress.Keyinfo["alfa"].PriKey,
+			height,
+			big.NewInt(1),
+			nil,
+			testutil.TestGasLimit,
+			big.NewInt(0),
+		)
+		require.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(tsf).
+			SignAndBuild(testaddress.Keyinfo["producer"].PubKey, testaddress.Keyinfo["producer"].PriKey)
+		require.NoError(err)
+		require.NoError(blkDao.putBlock(&blk))
+		require.NoError(blkDao.putReceipts(height, []*action.Receipt{{BlockHeight: height, ActionHash: tsf.Hash()}}))
+		blks = append(blks, &blk)
+		prevHash = blk.HashBlock()
+	}
+	require.Equal(uint64(4), blkDao.getLowestAvailableHeight())
+
+	for _, blk := range blks {
+		blkHash := blk.HashBlock()
+		actHash := blk.Actions[0].Hash()
+		// headers and footers are always kept
+		_, err := blkDao.Header(blkHash)
+		require.NoError(err)
+		_, err = blkDao.Footer(blkHash)
+		require.NoError(err)
+		_, err = blkDao.getBlock(blkHash)
+		_, receiptsErr := blkDao.getReceiptsByHeight(blk.Height())
+		_, receiptErr := blkDao.getReceiptByActionHash(actHash)
+		_, hashErr := getBlockHashByActionHash(kvstore, actHash)
+		if blk.Height() < 4 {
+			require.Equal(ErrBlockPruned, errors.Cause(err))
+			require.Equal(ErrBlockPruned, errors.Cause(receiptsErr))
+			// the action indexes are deleted along with the block
+			require.Equal(db.ErrNotExist, errors.Cause(receiptErr))
+			require.Equal(db.ErrNotExist, errors.Cause(hashErr))
+			continue
+		}
+		require.NoError(err)
+		require.NoError(receiptsErr)
+		require.NoError(receiptErr)
+		require.NoError(hashErr)
+	}
+	// so are the address indexes
+	sender := hash.BytesToHash160(testaddress.Addrinfo["alfa"].Bytes())
+	recipient := hash.BytesToHash160(testaddress.Addrinfo["bravo"].Bytes())
+	for _, addr := range []hash.Hash160{sender, recipient} {
+		expected := []hash.Hash256{blks[3].Actions[0].Hash(), blks[4].Actions[0].Hash()}
+		actions, err := getActionsBySenderAddress(kvstore, addr)
+		require.NoError(err)
+		if addr == recipient {
+			actions, err = getActionsByRecipientAddress(kvstore, addr)
+			require.NoError(err)
+		}
+		require.Equal(expected, actions)
+	}
+	count, err := getActionCountBySenderAddress(kvstore, sender)
+	require.NoError(err)
+	require.Equal(uint64(5), count)
+	// the entries of the available blocks are reindexed after the pruned ones
+	ib := &IndexBuilder{dao: blkDao, store: kvstore}
+	require.NoError(ib.Reindex(context.Background(), 4, 0))
+	actions, err := getActionsBySenderAddress(kvstore, sender)
+	require.NoError(err)
+	require.Equal([]hash.Hash256{blks[3].Actions[0].Hash(), blks[4].Actions[0].Hash()}, actions)
+	count, err = getActionCountBySenderAddress(kvstore, sender)
+	require.NoError(err)
+	require.Equal(uint64(5), count)
+	require.NoError(blkDao.Stop(context.Background()))
+
+	// the pruned height is loaded on restart
+	blkDao = newBlockDAO(kvstore, true, false, 0, 2)
+	require.NoError(blkDao.Start(context.Background()))
+	require.Equal(uint64(4), blkDao.getLowestAvailableHeight())
+	require.NoError(blkDao.Stop(context.Background()))
+}
@@ -27,12 +27,27 @@ import (
 // Validator is the interface of validator
 type Validator interface {
 	// Validate validates the given block's content
-	Validate(block *block.Block, tipHeight uint64, tipHash hash.Hash256) error
+	Validate(block *block.Block, tipHeight uint64, tipHash hash.Hash256, opts ...ValidateOption) error
 	// AddActionValidators add validators
 	AddActionValidators(...protocol.ActionValidator)
 	AddActionEnvelopeValidators(...protocol.ActionEnvelopeValidator)
 }
 
+// ValidateOption sets the checks of a block validation
+type ValidateOption func(*validateConfig)
+
+type validateConfig struct {
+	skipSigAndRoot bool
+}
+
+// SkipSigAndRootOption skips verifying the block's signature and tx root, which have been verified on the same block
+// object by the caller, e.g., pre-validated by block sync
+func SkipSigAndRootOption() ValidateOption {
+	return func(cfg *validateConfig) {
+		cfg.skipSigAndRoot = true
+	}
+}
+
 type validator struct {
 	sf                        factory.Factory
 	validatorAddr             string
@@ -57,12 +72,23 @@ var (
 )
 
 // Validate validates the given block's content
-func (v *validator) Validate(blk *block.Block, tipHeight uint64, tipHash hash.Hash256) error {
+func (v *validator) Validate(
+	blk *block.Block,
+	tipHeight uint64,
+	tipHash hash.Hash256,
+	opts ...ValidateOption,
+) error {
+	var cfg validateConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 	if err := verifyHeightAndHash(blk, tipHeight, tipHash); err != nil {
 		return errors.Wrap(err, "failed to verify block's height and hash")
 	}
-	if err := verifySigAndRoot(blk); err != nil {
-		return errors.Wrap(err, "failed to verify block's signature and merkle root")
+	if !cfg.skipSigAndRoot {
+		if err := verifySigAndRoot(blk); err != nil {
+			return errors.Wrap(err, "failed to verify block's signature and merkle root")
+		}
 	}
 
 	if v.sf != nil {
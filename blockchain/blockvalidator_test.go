@@ -53,6 +53,8 @@ func TestWrongRootHash(t *testing.T) {
 	require.Nil(val.Validate(&blk, 0, blkhash))
 	blk.Actions[0], blk.Actions[1] = blk.Actions[1], blk.Actions[0]
 	require.NotNil(val.Validate(&blk, 0, blkhash))
+	// the root is not verified again for a block which has passed the check
+	require.Nil(val.Validate(&blk, 0, blkhash, SkipSigAndRootOption()))
 }
 
 func TestSignBlock(t *testing.T) {
@@ -0,0 +1,358 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// ChainDBNamespaces are the namespaces of the chain DB
+var ChainDBNamespaces = []string{
+	blockNS,
+	blockHashHeightMappingNS,
+	blockActionBlockMappingNS,
+	blockActionReceiptMappingNS,
+	blockAddressActionMappingNS,
+	blockAddressActionCountMappingNS,
+	blockAddressActionPrunedNS,
+	blockHeaderNS,
+	blockBodyNS,
+	blockFooterNS,
+	receiptsNS,
+	blockLogHeightMappingNS,
+	blockLogCountMappingNS,
+}
+
+// NamespaceStat is the number of records in a namespace
+type NamespaceStat struct {
+	Namespace string `json:"namespace"`
+	Keys      uint64 `json:"keys"`
+}
+
+// IndexProblem is an inconsistency found in the indexes of the chain DB
+type IndexProblem struct {
+	// Height is the height of the block the problem is found at, or 0 if it isn't about a block
+	Height uint64 `json:"height"`
+	Reason string `json:"reason"`
+}
+
+// CountKeys counts the records in each of the namespaces of the KV store
+func CountKeys(kv db.KVStore, namespaces []string) ([]NamespaceStat, error) {
+	stats := make([]NamespaceStat, 0, len(namespaces))
+	for _, ns := range namespaces {
+		iter, err := kv.Iterate(ns, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to iterate namespace %s", ns)
+		}
+		stat := NamespaceStat{Namespace: ns}
+		for iter.Next() {
+			stat.Keys++
+		}
+		err = iter.Error()
+		iter.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to iterate namespace %s", ns)
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// ChainDBInspector inspects and repairs the chain DB of a stopped node. The action indexes are only verified and
+// rebuilt if they are written by the node, i.e., with the gateway plugin.
+type ChainDBInspector struct {
+	dao *blockDAO
+}
+
+// NewChainDBInspector creates an inspector of the chain DB
+func NewChainDBInspector(cfg config.Config, chainDB db.KVStore) *ChainDBInspector {
+	_, gateway := cfg.Plugins[config.GatewayPlugin]
+	return &ChainDBInspector{
+		// the indexes written asynchronously by the index builder are in the chain DB too
+		dao: newBlockDAO(chainDB, gateway, cfg.Chain.CompressBlock, 0, 0),
+	}
+}
+
+// Start opens the chain DB
+func (ci *ChainDBInspector) Start(ctx context.Context) error { return ci.dao.Start(ctx) }
+
+// Stop closes the chain DB
+func (ci *ChainDBInspector) Stop(ctx context.Context) error { return ci.dao.Stop(ctx) }
+
+// TipHeight returns the tip height recorded in the chain DB
+func (ci *ChainDBInspector) TipHeight() (uint64, error) { return ci.dao.getBlockchainHeight() }
+
+// BlockByHeight returns the block at the height
+func (ci *ChainDBInspector) BlockByHeight(height uint64) (*block.Block, error) {
+	blkHash, err := ci.dao.getBlockHash(height)
+	if err != nil {
+		return nil, err
+	}
+	return ci.dao.getBlock(blkHash)
+}
+
+// BlockByHash returns the block of the hash
+func (ci *ChainDBInspector) BlockByHash(h hash.Hash256) (*block.Block, error) {
+	return ci.dao.getBlock(h)
+}
+
+// ReceiptsByHeight returns the receipts of the block at the height
+func (ci *ChainDBInspector) ReceiptsByHeight(height uint64) ([]*action.Receipt, error) {
+	return ci.dao.getReceiptsByHeight(height)
+}
+
+// ReceiptByActionHash returns the receipt of the action
+func (ci *ChainDBInspector) ReceiptByActionHash(h hash.Hash256) (*action.Receipt, error) {
+	return ci.dao.getReceiptByActionHash(h)
+}
+
+// Verify checks the blocks whose headers are in the chain DB against the height <-> hash mappings and the tip height,
+// as well as the action, receipt and address indexes if they are written. It returns the problems found, and an
+// error only if the check can't proceed.
+func (ci *ChainDBInspector) Verify() ([]IndexProblem, error) {
+	heights, hashes, err := ci.scanHeaders()
+	if err != nil {
+		return nil, err
+	}
+	var problems []IndexProblem
+	report := func(height uint64, format string, args ...interface{}) {
+		problems = append(problems, IndexProblem{Height: height, Reason: fmt.Sprintf(format, args...)})
+	}
+	tipHeight, err := ci.dao.getBlockchainHeight()
+	if err != nil {
+		return nil, err
+	}
+	if len(heights) > 0 && heights[len(heights)-1] != tipHeight {
+		report(0, "tip height %d mismatches the last header at height %d", tipHeight, heights[len(heights)-1])
+	}
+	// the address indexes can only be replayed with all the block bodies
+	checkAddress := ci.dao.writeIndex && ci.dao.getLowestAvailableHeight() == 1 &&
+		len(heights) > 0 && heights[0] == 1 && uint64(len(heights)) == heights[len(heights)-1]
+	counts := newActionCounter()
+	var totalActions uint64
+	for i, height := range heights {
+		blkHash := hashes[i]
+		if i > 0 && heights[i-1] != height-1 {
+			report(height, "headers from height %d to %d are missing", heights[i-1]+1, height-1)
+		}
+		if h, err := ci.dao.getBlockHash(height); err != nil || h != blkHash {
+			report(height, "height -> hash mapping of block %x: hash %x, %v", blkHash, h, err)
+		}
+		if h, err := ci.dao.getBlockHeight(blkHash); err != nil || h != height {
+			report(height, "hash -> height mapping of block %x: height %d, %v", blkHash, h, err)
+		}
+		if _, err := ci.dao.footer(blkHash); err != nil {
+			report(height, "%v", err)
+		}
+		if ci.dao.isPruned(height) {
+			continue
+		}
+		header, err := ci.dao.header(blkHash)
+		if err != nil {
+			return nil, err
+		}
+		body, err := ci.dao.body(blkHash)
+		if err != nil {
+			report(height, "%v", err)
+			checkAddress = false
+			continue
+		}
+		if txRoot := body.CalculateTxRoot(); txRoot != header.TxRoot() {
+			report(height, "tx root %x of the body mismatches %x in the header", txRoot, header.TxRoot())
+		}
+		totalActions += uint64(len(body.Actions))
+		if !ci.dao.writeIndex {
+			continue
+		}
+		for _, selp := range body.Actions {
+			actHash := selp.Hash()
+			if h, err := getBlockHashByActionHash(ci.dao.kvstore, actHash); err != nil || h != blkHash {
+				report(height, "action -> block mapping of action %x: block %x, %v", actHash, h, err)
+			}
+		}
+		ci.verifyReceiptIndexes(height, report)
+		if !checkAddress {
+			continue
+		}
+		if err := counts.add(body.Actions, func(ns string, key []byte, actHash hash.Hash256) {
+			value, err := ci.dao.kvstore.Get(ns, key)
+			if err != nil || !bytes.Equal(value, actHash[:]) {
+				report(height, "address index %x of action %x: %x, %v", key, actHash, value, err)
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if !checkAddress {
+		return problems, nil
+	}
+	if value, err := ci.dao.getTotalActions(); err != nil || value != totalActions {
+		report(0, "total actions %d mismatches %d actions in the blocks, %v", value, totalActions, err)
+	}
+	stored, err := countsInStore(ci.dao.kvstore)
+	if err != nil {
+		return nil, err
+	}
+	for key, count := range counts.counts {
+		if stored[key] != count {
+			report(0, "action count of address index %x is %d, expecting %d", key, stored[key], count)
+		}
+		delete(stored, key)
+	}
+	for key, count := range stored {
+		report(0, "unexpected action count %d of address index %x", count, key)
+	}
+	return problems, nil
+}
+
+func (ci *ChainDBInspector) verifyReceiptIndexes(height uint64, report func(uint64, string, ...interface{})) {
+	receipts, err := ci.dao.getReceiptsByHeight(height)
+	if errors.Cause(err) == db.ErrNotExist {
+		// the block has no receipt
+		return
+	}
+	if err != nil {
+		report(height, "%v", err)
+		return
+	}
+	for _, r := range receipts {
+		value, err := ci.dao.kvstore.Get(blockActionReceiptMappingNS, r.ActionHash[hashOffset:])
+		if err != nil || len(value) != 8 || enc.MachineEndian.Uint64(value) != height {
+			report(height, "receipt index of action %x: %x, %v", r.ActionHash, value, err)
+		}
+	}
+}
+
+// RebuildHeightIndexes rewrites the height <-> hash mappings and the tip height from the block headers. It returns
+// the number of blocks whose mappings are rewritten. The other indexes are rebuilt by IndexBuilder.Reindex.
+func (ci *ChainDBInspector) RebuildHeightIndexes() (uint64, error) {
+	heights, hashes, err := ci.scanHeaders()
+	if err != nil {
+		return 0, err
+	}
+	if len(heights) == 0 {
+		return 0, nil
+	}
+	batch := db.NewBatch()
+	for i, height := range heights {
+		heightBytes := byteutil.Uint64ToBytes(height)
+		hashKey := append(append([]byte{}, hashPrefix...), hashes[i][:]...)
+		batch.Put(blockHashHeightMappingNS, hashKey, heightBytes, "failed to put hash -> height mapping")
+		heightKey := append(append([]byte{}, heightPrefix...), heightBytes...)
+		batch.Put(blockHashHeightMappingNS, heightKey, hashes[i][:], "failed to put height -> hash mapping")
+	}
+	tipHeight := heights[len(heights)-1]
+	if value, err := ci.dao.getBlockchainHeight(); err != nil || value < tipHeight {
+		batch.Put(blockNS, topHeightKey, byteutil.Uint64ToBytes(tipHeight), "failed to put top height")
+	}
+	if err := ci.dao.kvstore.Commit(batch); err != nil {
+		return 0, err
+	}
+	return uint64(len(heights)), nil
+}
+
+// LowestAvailableHeight returns the lowest height whose block body and receipts are available
+func (ci *ChainDBInspector) LowestAvailableHeight() uint64 { return ci.dao.getLowestAvailableHeight() }
+
+// IndexBuilder returns the index builder of the chain DB to reindex the blocks, or nil if the action indexes are not
+// written by the node
+func (ci *ChainDBInspector) IndexBuilder() *IndexBuilder {
+	if !ci.dao.writeIndex {
+		return nil
+	}
+	return &IndexBuilder{dao: ci.dao, store: ci.dao.kvstore}
+}
+
+// scanHeaders returns the heights and hashes of all the block headers in the ascending order of the heights
+func (ci *ChainDBInspector) scanHeaders() ([]uint64, []hash.Hash256, error) {
+	iter, err := ci.dao.kvstore.Iterate(blockHeaderNS, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var blkHashes []hash.Hash256
+	for iter.Next() {
+		blkHashes = append(blkHashes, hash.BytesToHash256(iter.Key()))
+	}
+	err = iter.Error()
+	iter.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	byHeight := make(map[uint64]hash.Hash256, len(blkHashes))
+	heights := make([]uint64, 0, len(blkHashes))
+	for _, blkHash := range blkHashes {
+		header, err := ci.dao.header(blkHash)
+		if err != nil {
+			return nil, nil, err
+		}
+		height := header.Height()
+		if existing, ok := byHeight[height]; ok {
+			return nil, nil, errors.Errorf("blocks %x and %x are both at height %d", existing, blkHash, height)
+		}
+		byHeight[height] = blkHash
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	hashes := make([]hash.Hash256, len(heights))
+	for i, height := range heights {
+		hashes[i] = byHeight[height]
+	}
+	return heights, hashes, nil
+}
+
+// actionCounter replays the address indexes written by putActions
+type actionCounter struct {
+	// counts maps the address index key, i.e., the prefix and the address, to the number of actions
+	counts map[string]uint64
+}
+
+func newActionCounter() *actionCounter {
+	return &actionCounter{counts: make(map[string]uint64)}
+}
+
+// add calls f with the namespace and key of the address indexes of the actions
+func (c *actionCounter) add(actions []action.SealedEnvelope, f func(string, []byte, hash.Hash256)) error {
+	for _, selp := range actions {
+		actHash := selp.Hash()
+		keys, err := addressIndexKeys(selp)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			f(blockAddressActionMappingNS, indexKey([]byte(key), c.counts[key]), actHash)
+			c.counts[key]++
+		}
+	}
+	return nil
+}
+
+// countsInStore returns the action counts of the address indexes in the store
+func countsInStore(kv db.KVStore) (map[string]uint64, error) {
+	iter, err := kv.Iterate(blockAddressActionCountMappingNS, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	counts := make(map[string]uint64)
+	for iter.Next() {
+		counts[string(iter.Key())] = enc.MachineEndian.Uint64(iter.Value())
+	}
+	return counts, iter.Error()
+}
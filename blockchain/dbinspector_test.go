@@ -0,0 +1,162 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestChainDBInspector(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	cfg.Plugins = map[int]interface{}{config.GatewayPlugin: nil}
+	cfg.Chain.EnableAsyncIndexWrite = false
+	kvstore := db.NewMemKVStore()
+	inspector := NewChainDBInspector(cfg, kvstore)
+	require.NoError(inspector.Start(ctx))
+	defer func() {
+		require.NoError(inspector.Stop(ctx))
+	}()
+	dao := inspector.dao
+	require.True(dao.writeIndex)
+
+	topic := hash.Hash256b([]byte("topic"))
+	var blks []*block.Block
+	prevHash := hash.ZeroHash256
+	for height := uint64(1); height <= 3; height++ {
+		tsf1, err := testutil.SignedTransfer(
+			testaddress.Addrinfo["bravo"].String(),
+			testaddress.Keyinfo["alfa"].PriKey,
+			2*height-1,
+			big.NewInt(1),
+			nil,
+			testutil.TestGasLimit,
+			big.NewInt(0),
+		)
+		require.NoError(err)
+		tsf2, err := testutil.SignedTransfer(
+			testaddress.Addrinfo["charlie"].String(),
+			testaddress.Keyinfo["alfa"].PriKey,
+			2*height,
+			big.NewInt(1),
+			nil,
+			testutil.TestGasLimit,
+			big.NewInt(0),
+		)
+		require.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(tsf1, tsf2).
+			SignAndBuild(testaddress.Keyinfo["producer"].PubKey, testaddress.Keyinfo["producer"].PriKey)
+		require.NoError(err)
+		require.NoError(dao.putBlock(&blk))
+		require.NoError(dao.putReceipts(height, []*action.Receipt{
+			{
+				BlockHeight: height,
+				ActionHash:  tsf1.Hash(),
+				Logs:        []*action.Log{{Address: testaddress.Addrinfo["charlie"].String(), Topics: []hash.Hash256{topic}}},
+			},
+			{BlockHeight: height, ActionHash: tsf2.Hash()},
+		}))
+		blks = append(blks, &blk)
+		prevHash = blk.HashBlock()
+	}
+
+	stats, err := CountKeys(kvstore, []string{blockHeaderNS, blockActionBlockMappingNS, blockAddressActionCountMappingNS})
+	require.NoError(err)
+	require.Equal([]NamespaceStat{
+		{Namespace: blockHeaderNS, Keys: 3},
+		{Namespace: blockActionBlockMappingNS, Keys: 6},
+		// the sender alfa, and the recipients bravo and charlie
+		{Namespace: blockAddressActionCountMappingNS, Keys: 3},
+	}, stats)
+
+	blk, err := inspector.BlockByHeight(2)
+	require.NoError(err)
+	require.Equal(blks[1].HashBlock(), blk.HashBlock())
+	receipts, err := inspector.ReceiptsByHeight(2)
+	require.NoError(err)
+	require.Equal(2, len(receipts))
+
+	problems, err := inspector.Verify()
+	require.NoError(err)
+	require.Empty(problems)
+
+	// corrupt the indexes
+	alfa := hash.BytesToHash160(testaddress.Addrinfo["alfa"].Bytes())
+	fromAlfa := append(append([]byte{}, actionFromPrefix...), alfa[:]...)
+	actHash := blks[2].Actions[0].Hash()
+	batch := db.NewBatch()
+	batch.Delete(blockHashHeightMappingNS, append(append([]byte{}, heightPrefix...), byteutil.Uint64ToBytes(2)...), "")
+	batch.Delete(blockActionBlockMappingNS, actHash[hashOffset:], "")
+	batch.Delete(blockActionReceiptMappingNS, actHash[hashOffset:], "")
+	batch.Delete(blockAddressActionMappingNS, append(fromAlfa, byteutil.Uint64ToBytes(1)...), "")
+	batch.Put(blockAddressActionCountMappingNS, fromAlfa, byteutil.Uint64ToBytes(4), "")
+	batch.Put(blockNS, totalActionsKey, byteutil.Uint64ToBytes(5), "")
+	batch.Delete(blockLogCountMappingNS, logTopicKey(topic), "")
+	require.NoError(kvstore.Commit(batch))
+
+	problems, err = inspector.Verify()
+	require.NoError(err)
+	require.Equal(6, len(problems))
+	for i, height := range []uint64{1, 2, 3, 3, 0, 0} {
+		require.Equal(height, problems[i].Height, problems[i].Reason)
+	}
+
+	blocks, err := inspector.RebuildHeightIndexes()
+	require.NoError(err)
+	require.Equal(uint64(3), blocks)
+	ib := inspector.IndexBuilder()
+	require.NotNil(ib)
+	require.NoError(ib.Reindex(ctx, inspector.LowestAvailableHeight(), 0))
+	problems, err = inspector.Verify()
+	require.NoError(err)
+	require.Empty(problems)
+	actions, err := getActionsBySenderAddress(kvstore, alfa)
+	require.NoError(err)
+	require.Equal(6, len(actions))
+	for i, h := range actions {
+		require.Equal(blks[i/2].Actions[i%2].Hash(), h)
+	}
+	heights, err := getLogBlockHeights(kvstore, nil, [][]hash.Hash256{{topic}}, 1, 3)
+	require.NoError(err)
+	require.Equal([]uint64{1, 2, 3}, heights)
+}
+
+func TestChainDBInspector_indexMode(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Plugins = map[int]interface{}{config.GatewayPlugin: nil}
+	// the indexes written by the index builder are checked as well
+	cfg.Chain.EnableAsyncIndexWrite = true
+	inspector := NewChainDBInspector(cfg, db.NewMemKVStore())
+	require.True(inspector.dao.writeIndex)
+	require.NotNil(inspector.IndexBuilder())
+
+	cfg.Plugins = nil
+	inspector = NewChainDBInspector(cfg, db.NewMemKVStore())
+	require.False(inspector.dao.writeIndex)
+	require.Nil(inspector.IndexBuilder())
+}
@@ -7,6 +7,7 @@
 package blockchain
 
 import (
+	"sort"
 	"strconv"
 
 	"github.com/pkg/errors"
@@ -39,6 +40,7 @@ func init() {
 
 // IndexBuilder defines the index builder
 type IndexBuilder struct {
+	dao          *blockDAO
 	store        db.KVStore
 	pendingBlks  chan *block.Block
 	cancelChan   chan interface{}
@@ -61,6 +63,7 @@ func NewIndexBuilder(chain Blockchain) (*IndexBuilder, error) {
 		return nil, err
 	}
 	return &IndexBuilder{
+		dao:          bc.dao,
 		store:        bc.dao.kvstore,
 		pendingBlks:  make(chan *block.Block, 64), // Actually 1 should be enough
 		cancelChan:   make(chan interface{}),
@@ -87,6 +90,14 @@ func (ib *IndexBuilder) Start(_ context.Context) error {
 				}
 				// index receipts
 				putReceipts(blk.Height(), blk.Receipts, batch)
+				// index logs
+				if err := putLogs(ib.store, blk.Height(), blk.Receipts, batch); err != nil {
+					log.L().Info(
+						"Error when indexing the logs",
+						zap.Uint64("height", blk.Height()),
+						zap.Error(err),
+					)
+				}
 				batchSizeMtc.WithLabelValues().Set(float64(batch.Size()))
 				if err := ib.store.Commit(batch); err != nil {
 					log.L().Info(
@@ -219,6 +230,244 @@ func putReceipts(blkHeight uint64, blkReceipts []*action.Receipt, batch db.KVSto
 	}
 }
 
+// putLogs indexes the block height by the contract addresses and topics of the logs in the receipts
+func putLogs(store db.KVStore, blkHeight uint64, blkReceipts []*action.Receipt, batch db.KVStoreBatch) error {
+	keys, err := logIndexKeys(blkReceipts)
+	if err != nil {
+		return err
+	}
+	heightBytes := byteutil.Uint64ToBytes(blkHeight)
+	for _, key := range keys {
+		count, err := getLogIndexCount(store, key)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			// the block has already been indexed under the key
+			lastHeight, err := getLogIndexHeight(store, key, count-1)
+			if err != nil {
+				return err
+			}
+			if lastHeight >= blkHeight {
+				continue
+			}
+		}
+		heightKey := logIndexKey(key, byteutil.Uint64ToBytes(count))
+		batch.Put(blockLogHeightMappingNS, heightKey, heightBytes,
+			"failed to put log index of block %d for key %x", blkHeight, key)
+		batch.Put(blockLogCountMappingNS, key, byteutil.Uint64ToBytes(count+1),
+			"failed to bump log index count for key %x", key)
+	}
+	return nil
+}
+
+// deleteLogs removes the block height from the log index
+func deleteLogs(store db.KVStore, blkHeight uint64, blkReceipts []*action.Receipt, batch db.KVStoreBatch) error {
+	keys, err := logIndexKeys(blkReceipts)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		count, err := getLogIndexCount(store, key)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			continue
+		}
+		lastHeight, err := getLogIndexHeight(store, key, count-1)
+		if err != nil {
+			return err
+		}
+		if lastHeight != blkHeight {
+			continue
+		}
+		heightKey := logIndexKey(key, byteutil.Uint64ToBytes(count-1))
+		batch.Delete(blockLogHeightMappingNS, heightKey, "failed to delete log index of block %d for key %x", blkHeight, key)
+		batch.Put(blockLogCountMappingNS, key, byteutil.Uint64ToBytes(count-1),
+			"failed to update log index count for key %x", key)
+	}
+	return nil
+}
+
+// logIndexKeys returns the distinct address and topic keys of the logs in the receipts
+func logIndexKeys(receipts []*action.Receipt) ([][]byte, error) {
+	var keys [][]byte
+	addrs := make(map[hash.Hash160]bool)
+	topics := make(map[hash.Hash256]bool)
+	for _, r := range receipts {
+		for _, l := range r.Logs {
+			addr, err := address.FromString(l.Address)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid address %s of log in action %x", l.Address, r.ActionHash)
+			}
+			addrBytes := hash.BytesToHash160(addr.Bytes())
+			if !addrs[addrBytes] {
+				addrs[addrBytes] = true
+				keys = append(keys, logAddressKey(addrBytes))
+			}
+			for _, topic := range l.Topics {
+				if !topics[topic] {
+					topics[topic] = true
+					keys = append(keys, logTopicKey(topic))
+				}
+			}
+		}
+	}
+	return keys, nil
+}
+
+func logAddressKey(addrBytes hash.Hash160) []byte {
+	return logIndexKey(logAddressPrefix, addrBytes[:])
+}
+
+func logTopicKey(topic hash.Hash256) []byte {
+	return logIndexKey(logTopicPrefix, topic[:])
+}
+
+// logIndexKey returns the prefix followed by the suffix in a new slice, so that the keys never share the backing array
+// of the prefix
+func logIndexKey(prefix []byte, suffix []byte) []byte {
+	key := make([]byte, 0, len(prefix)+len(suffix))
+	key = append(key, prefix...)
+	return append(key, suffix...)
+}
+
+// getLogIndexCount returns the number of blocks indexed under the key
+func getLogIndexCount(store db.KVStore, key []byte) (uint64, error) {
+	value, err := store.Get(blockLogCountMappingNS, key)
+	if errors.Cause(err) == db.ErrNotExist {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get log index count for key %x", key)
+	}
+	if len(value) == 0 {
+		return 0, errors.New("count of log index is broken")
+	}
+	return enc.MachineEndian.Uint64(value), nil
+}
+
+// getLogIndexHeight returns the i-th block height indexed under the key
+func getLogIndexHeight(store db.KVStore, key []byte, i uint64) (uint64, error) {
+	heightKey := logIndexKey(key, byteutil.Uint64ToBytes(i))
+	value, err := store.Get(blockLogHeightMappingNS, heightKey)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get log index %d", i)
+	}
+	if len(value) == 0 {
+		return 0, errors.Wrapf(db.ErrNotExist, "log index %d missing", i)
+	}
+	return enc.MachineEndian.Uint64(value), nil
+}
+
+// getLogIndexHeights returns the heights within [start, end] indexed under the key in ascending order
+func getLogIndexHeights(store db.KVStore, key []byte, start uint64, end uint64) ([]uint64, error) {
+	count, err := getLogIndexCount(store, key)
+	if err != nil {
+		return nil, err
+	}
+	// heights are indexed in ascending order, so search for the first one no less than start
+	var searchErr error
+	i := uint64(sort.Search(int(count), func(i int) bool {
+		height, err := getLogIndexHeight(store, key, uint64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return height >= start
+	}))
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	var res []uint64
+	for ; i < count; i++ {
+		height, err := getLogIndexHeight(store, key, i)
+		if err != nil {
+			return nil, err
+		}
+		if height > end {
+			break
+		}
+		res = append(res, height)
+	}
+	return res, nil
+}
+
+// getLogBlockHeights returns the heights within [start, end] of blocks having logs emitted by any of the addresses,
+// and for each non-empty topics[i], having any of the topics. The topics are not indexed by position, so the result
+// is a superset of the blocks with matching logs.
+func getLogBlockHeights(
+	store db.KVStore,
+	addrs []hash.Hash160,
+	topics [][]hash.Hash256,
+	start uint64,
+	end uint64,
+) ([]uint64, error) {
+	var candidates map[uint64]bool
+	if len(addrs) > 0 {
+		keys := make([][]byte, 0, len(addrs))
+		for _, addr := range addrs {
+			keys = append(keys, logAddressKey(addr))
+		}
+		heights, err := unionLogIndexHeights(store, keys, start, end)
+		if err != nil {
+			return nil, err
+		}
+		candidates = heights
+	}
+	for _, position := range topics {
+		if len(position) == 0 {
+			continue
+		}
+		keys := make([][]byte, 0, len(position))
+		for _, topic := range position {
+			keys = append(keys, logTopicKey(topic))
+		}
+		heights, err := unionLogIndexHeights(store, keys, start, end)
+		if err != nil {
+			return nil, err
+		}
+		if candidates == nil {
+			candidates = heights
+			continue
+		}
+		for height := range candidates {
+			if !heights[height] {
+				delete(candidates, height)
+			}
+		}
+	}
+
+	var res []uint64
+	if candidates == nil {
+		// no criteria, every block in the range is a candidate
+		for height := start; height <= end; height++ {
+			res = append(res, height)
+		}
+		return res, nil
+	}
+	for height := range candidates {
+		res = append(res, height)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res, nil
+}
+
+func unionLogIndexHeights(store db.KVStore, keys [][]byte, start uint64, end uint64) (map[uint64]bool, error) {
+	res := make(map[uint64]bool)
+	for _, key := range keys {
+		heights, err := getLogIndexHeights(store, key, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, height := range heights {
+			res[height] = true
+		}
+	}
+	return res, nil
+}
+
 func getBlockHashByActionHash(store db.KVStore, h hash.Hash256) (hash.Hash256, error) {
 	var blkHash hash.Hash256
 	value, err := store.Get(blockActionBlockMappingNS, h[hashOffset:])
@@ -247,33 +496,19 @@ func getActionCountBySenderAddress(store db.KVStore, addrBytes hash.Hash160) (ui
 
 // getActionsBySenderAddress returns actions for sender
 func getActionsBySenderAddress(store db.KVStore, addrBytes hash.Hash160) ([]hash.Hash256, error) {
-	// get action count for sender
-	senderActionCount, err := getActionCountBySenderAddress(store, addrBytes)
+	res, err := getActionsByAddress(store, addrBytes, actionFromPrefix)
 	if err != nil {
 		return nil, errors.Wrapf(err, "for sender %x", addrBytes)
 	}
-
-	res, err := getActionsByAddress(store, addrBytes, senderActionCount, actionFromPrefix)
-	if err != nil {
-		return nil, err
-	}
-
 	return res, nil
 }
 
 // getActionsByRecipientAddress returns actions for recipient
 func getActionsByRecipientAddress(store db.KVStore, addrBytes hash.Hash160) ([]hash.Hash256, error) {
-	// get action count for recipient
-	recipientActionCount, getCountErr := getActionCountByRecipientAddress(store, addrBytes)
-	if getCountErr != nil {
-		return nil, errors.Wrapf(getCountErr, "for recipient %x", addrBytes)
-	}
-
-	res, err := getActionsByAddress(store, addrBytes, recipientActionCount, actionToPrefix)
+	res, err := getActionsByAddress(store, addrBytes, actionToPrefix)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrapf(err, "for recipient %x", addrBytes)
 	}
-
 	return res, nil
 }
 
@@ -290,22 +525,40 @@ func getActionCountByRecipientAddress(store db.KVStore, addrBytes hash.Hash160)
 	return enc.MachineEndian.Uint64(value), nil
 }
 
-// getActionsByAddress returns actions by address
-func getActionsByAddress(store db.KVStore, addrBytes hash.Hash160, count uint64, keyPrefix []byte) ([]hash.Hash256, error) {
-	var res []hash.Hash256
+// getActionsByAddress returns actions by address in the order they are indexed
+func getActionsByAddress(store db.KVStore, addrBytes hash.Hash160, keyPrefix []byte) ([]hash.Hash256, error) {
+	prefix := make([]byte, 0, len(keyPrefix)+len(addrBytes))
+	prefix = append(prefix, keyPrefix...)
+	prefix = append(prefix, addrBytes[:]...)
+	iter, err := store.Iterate(blockAddressActionMappingNS, prefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to iterate actions")
+	}
+	defer iter.Close()
 
-	for i := uint64(0); i < count; i++ {
-		key := append(keyPrefix, addrBytes[:]...)
-		key = append(key, byteutil.Uint64ToBytes(i)...)
-		value, err := store.Get(blockAddressActionMappingNS, key)
-		if err != nil {
-			return res, errors.Wrapf(err, "failed to get action for index %d", i)
-		}
-		if len(value) == 0 {
-			return res, errors.Wrapf(db.ErrNotExist, "action for index %d missing", i)
+	type indexedAction struct {
+		index uint64
+		hash  hash.Hash256
+	}
+	var actions []indexedAction
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) != len(prefix)+8 {
+			return nil, errors.Errorf("invalid key %x of action index", key)
 		}
-		res = append(res, hash.BytesToHash256(value))
+		actions = append(actions, indexedAction{
+			index: enc.MachineEndian.Uint64(key[len(prefix):]),
+			hash:  hash.BytesToHash256(iter.Value()),
+		})
+	}
+	if err := iter.Error(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate actions")
+	}
+	// the index is encoded in little endian, which doesn't sort in order
+	sort.Slice(actions, func(i, j int) bool { return actions[i].index < actions[j].index })
+	res := make([]hash.Hash256, len(actions))
+	for i, act := range actions {
+		res[i] = act.hash
 	}
-
 	return res, nil
 }
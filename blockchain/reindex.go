@@ -0,0 +1,421 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// reindexBatchSize is the number of blocks reindexed in one batch along with the progress
+	reindexBatchSize = 100
+	// reindexWipeBatchSize is the number of index entries checked in one batch of the wipe
+	reindexWipeBatchSize = 10000
+)
+
+var reindexHeightMtc = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "iotex_indexer_reindex_height",
+		Help: "Height of the last block reindexed",
+	},
+	[]string{},
+)
+
+func init() {
+	prometheus.MustRegister(reindexHeightMtc)
+}
+
+// Reindex wipes and rebuilds the action, receipt, address and log indexes of the blocks in [start, end] from the
+// stored blocks and receipts, where 0 end means the tip height. The indexes of the blocks before start are kept. The
+// address and log indexes of the blocks after end are rebuilt as well, since the entries under an address or a log
+// key are numbered in the order of the heights. The total number of actions is recounted if all the blocks are
+// reindexed. The address and log entries from start on are wiped first, then the blocks are reindexed in height order
+// in batches, each of which is committed along with the progress, so that an interrupted reindex covering the range
+// resumes from where it stopped. It should be called before any new block is committed.
+func (ib *IndexBuilder) Reindex(ctx context.Context, start, end uint64) error {
+	tipHeight, err := ib.dao.getBlockchainHeight()
+	if err != nil {
+		return err
+	}
+	if end == 0 || end > tipHeight {
+		end = tipHeight
+	}
+	if start == 0 || start > end {
+		return errors.Errorf("invalid reindex range [%d, %d] with tip height %d", start, end, tipHeight)
+	}
+	if start < ib.dao.getLowestAvailableHeight() {
+		return errors.Wrapf(ErrBlockPruned, "failed to reindex from height %d", start)
+	}
+	next := start
+	if pendingNext, pendingStart, pendingEnd, err := ib.reindexProgress(); err == nil &&
+		pendingEnd == end && pendingStart <= start && pendingNext >= start && pendingNext <= tipHeight {
+		log.L().Info("Resume reindex.", zap.Uint64("start", pendingStart), zap.Uint64("next", pendingNext),
+			zap.Uint64("end", end))
+		start, next = pendingStart, pendingNext
+	} else if err := ib.wipeIndexes(start, end); err != nil {
+		return errors.Wrapf(err, "failed to wipe indexes from height %d", start)
+	}
+	recount := start == 1 && end == tipHeight
+
+	for height := next; height <= tipHeight; height += reindexBatchSize {
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "reindex stopped at height %d", height)
+		default:
+		}
+		last := height + reindexBatchSize - 1
+		if last > tipHeight {
+			last = tipHeight
+		}
+		batch := db.NewBatch()
+		if err := ib.reindexBlocks(height, last, end, recount, batch); err != nil {
+			return errors.Wrapf(err, "failed to reindex blocks [%d, %d]", height, last)
+		}
+		if last < tipHeight {
+			putReindexProgress(last+1, start, end, batch)
+		} else {
+			batch.Delete(blockNS, reindexKey, "failed to delete reindex progress")
+		}
+		if err := ib.store.Commit(batch); err != nil {
+			return err
+		}
+		reindexHeightMtc.WithLabelValues().Set(float64(last))
+		log.L().Info("Reindexed blocks.",
+			zap.Uint64("height", last),
+			zap.Uint64("end", end),
+			zap.Float64("progress", float64(last-next+1)/float64(tipHeight-next+1)))
+	}
+	return nil
+}
+
+// reindexProgress returns the next height, the start height and the end height of an interrupted reindex
+func (ib *IndexBuilder) reindexProgress() (uint64, uint64, uint64, error) {
+	value, err := ib.store.Get(blockNS, reindexKey)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if len(value) != 24 {
+		return 0, 0, 0, errors.New("reindex progress is broken")
+	}
+	return enc.MachineEndian.Uint64(value[:8]),
+		enc.MachineEndian.Uint64(value[8:16]),
+		enc.MachineEndian.Uint64(value[16:]),
+		nil
+}
+
+// putReindexProgress puts the next height, the start height and the end height of the reindex into the batch
+func putReindexProgress(next, start, end uint64, batch db.KVStoreBatch) {
+	progress := append(byteutil.Uint64ToBytes(next), byteutil.Uint64ToBytes(start)...)
+	progress = append(progress, byteutil.Uint64ToBytes(end)...)
+	batch.Put(blockNS, reindexKey, progress, "failed to put reindex progress")
+}
+
+// wipeIndexes deletes the address and log index entries of the blocks from the start height on, as well as the
+// address entries of the actions not indexed to a block, and resets the counts to the entries left. The counts are
+// committed along with the progress of the reindex of [start, end], which then rebuilds the entries from start on.
+func (ib *IndexBuilder) wipeIndexes(start, end uint64) error {
+	addrCounts, err := ib.wipeEntries(blockAddressActionMappingNS, func(value []byte) (bool, error) {
+		height, err := ib.actionHeight(hash.BytesToHash256(value))
+		if errors.Cause(err) == db.ErrNotExist {
+			return true, nil
+		}
+		return height >= start, err
+	})
+	if err != nil {
+		return err
+	}
+	logCounts, err := ib.wipeEntries(blockLogHeightMappingNS, func(value []byte) (bool, error) {
+		return len(value) != 8 || enc.MachineEndian.Uint64(value) >= start, nil
+	})
+	if err != nil {
+		return err
+	}
+	batch := db.NewBatch()
+	// the entries of the pruned blocks are deleted, and the ones left follow them
+	if err := ib.resetCounts(blockAddressActionCountMappingNS, addrCounts, func(key []byte) (uint64, error) {
+		return getPrunedActionCount(ib.store, key)
+	}, batch); err != nil {
+		return err
+	}
+	if err := ib.resetCounts(blockLogCountMappingNS, logCounts, func([]byte) (uint64, error) {
+		return 0, nil
+	}, batch); err != nil {
+		return err
+	}
+	putReindexProgress(start, start, end, batch)
+	return ib.store.Commit(batch)
+}
+
+// wipeEntries deletes the stale entries of an address or log index namespace, committing the deletions in batches,
+// and returns the number of entries left under each index key, i.e., the highest index left plus 1
+func (ib *IndexBuilder) wipeEntries(ns string, stale func([]byte) (bool, error)) (map[string]uint64, error) {
+	counts := make(map[string]uint64)
+	var from []byte
+	for {
+		batch := db.NewBatch()
+		next, err := ib.wipeEntriesFrom(ns, from, stale, counts, batch)
+		if err != nil {
+			return nil, err
+		}
+		if err := ib.store.Commit(batch); err != nil {
+			return nil, err
+		}
+		if next == nil {
+			return counts, nil
+		}
+		from = next
+	}
+}
+
+// wipeEntriesFrom puts the deletions of the stale entries of the namespace from the key on into the batch, up to
+// reindexWipeBatchSize entries, and returns the key to continue from, or nil at the end of the namespace
+func (ib *IndexBuilder) wipeEntriesFrom(
+	ns string,
+	from []byte,
+	stale func([]byte) (bool, error),
+	counts map[string]uint64,
+	batch db.KVStoreBatch,
+) ([]byte, error) {
+	iter, err := ib.store.Range(ns, from, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to iterate namespace %s", ns)
+	}
+	defer iter.Close()
+	for n := 0; iter.Next(); n++ {
+		key := iter.Key()
+		if n == reindexWipeBatchSize {
+			return append([]byte{}, key...), nil
+		}
+		if len(key) <= 8 {
+			return nil, errors.Errorf("invalid key %x of namespace %s", key, ns)
+		}
+		isStale, err := stale(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if isStale {
+			batch.Delete(ns, append([]byte{}, key...), "failed to delete index entry %x", key)
+			continue
+		}
+		indexedKey := string(key[:len(key)-8])
+		if i := enc.MachineEndian.Uint64(key[len(key)-8:]); counts[indexedKey] <= i {
+			counts[indexedKey] = i + 1
+		}
+	}
+	return nil, errors.Wrapf(iter.Error(), "failed to iterate namespace %s", ns)
+}
+
+// resetCounts puts the counts of the index keys left after a wipe into the batch, including the keys whose entries
+// are all wiped. A count is no less than the floor of the key.
+func (ib *IndexBuilder) resetCounts(
+	ns string,
+	counts map[string]uint64,
+	floor func([]byte) (uint64, error),
+	batch db.KVStoreBatch,
+) error {
+	stored := make(map[string][]byte)
+	iter, err := ib.store.Iterate(ns, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to iterate namespace %s", ns)
+	}
+	for iter.Next() {
+		stored[string(iter.Key())] = append([]byte{}, iter.Value()...)
+	}
+	err = iter.Error()
+	iter.Close()
+	if err != nil {
+		return errors.Wrapf(err, "failed to iterate namespace %s", ns)
+	}
+	for key := range stored {
+		if _, ok := counts[key]; !ok {
+			counts[key] = 0
+		}
+	}
+	for key, count := range counts {
+		lowest, err := floor([]byte(key))
+		if err != nil {
+			return err
+		}
+		if count < lowest {
+			count = lowest
+		}
+		value := byteutil.Uint64ToBytes(count)
+		if old, ok := stored[key]; ok && bytes.Equal(old, value) {
+			continue
+		}
+		batch.Put(ns, []byte(key), value, "failed to put count of key %x", key)
+	}
+	return nil
+}
+
+// reindexBlocks puts the indexes of the blocks in [first, last] into the batch, where the action and receipt indexes
+// are only put for the blocks up to end, and the address and log entries are appended to the ones left by the wipe
+// and the previous batches. If recount is true, the blocks are reindexed from height 1, and the total number of
+// actions is counted from the blocks reindexed so far rather than adjusted.
+func (ib *IndexBuilder) reindexBlocks(first, last, end uint64, recount bool, batch db.KVStoreBatch) error {
+	var totalActions uint64
+	if !recount || first > 1 {
+		var err error
+		totalActions, err = ib.dao.getTotalActions()
+		if err != nil && errors.Cause(err) != db.ErrNotExist {
+			return err
+		}
+	}
+	// the numbers of entries under the address and log keys, including the ones in the batch
+	addrCounts := make(map[string]uint64)
+	logCounts := make(map[string]uint64)
+	for height := first; height <= last; height++ {
+		blkHash, err := ib.dao.getBlockHash(height)
+		if err != nil {
+			return err
+		}
+		blk, err := ib.dao.getBlock(blkHash)
+		if err != nil {
+			return err
+		}
+		if height <= end {
+			if len(blk.Actions) > 0 && !recount {
+				// the actions of a block are counted in the total along with the action -> block mappings
+				firstHash := blk.Actions[0].Hash()
+				if value, err := ib.store.Get(blockActionBlockMappingNS, firstHash[hashOffset:]); err == nil &&
+					bytes.Equal(value, blkHash[:]) {
+					totalActions -= uint64(len(blk.Actions))
+				}
+			}
+			totalActions += uint64(len(blk.Actions))
+		}
+		for _, selp := range blk.Actions {
+			actHash := selp.Hash()
+			if height <= end {
+				batch.Put(blockActionBlockMappingNS, actHash[hashOffset:], blkHash[:],
+					"failed to put action hash %x", actHash)
+			}
+			keys, err := addressIndexKeys(selp)
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				i, err := nextIndex(ib.store, []byte(key), addrCounts, getActionCount)
+				if err != nil {
+					return err
+				}
+				batch.Put(blockAddressActionMappingNS, indexKey([]byte(key), i), actHash[:],
+					"failed to put action hash %x", actHash)
+			}
+		}
+		receipts, err := ib.dao.getReceiptsByHeight(height)
+		if err != nil && errors.Cause(err) != db.ErrNotExist {
+			return err
+		}
+		if height <= end {
+			putReceipts(height, receipts, batch)
+		}
+		keys, err := logIndexKeys(receipts)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			i, err := nextIndex(ib.store, key, logCounts, getLogIndexCount)
+			if err != nil {
+				return err
+			}
+			batch.Put(blockLogHeightMappingNS, indexKey(key, i), byteutil.Uint64ToBytes(height),
+				"failed to put log index of block %d for key %x", height, key)
+		}
+	}
+	if first <= end {
+		batch.Put(blockNS, totalActionsKey, byteutil.Uint64ToBytes(totalActions), "failed to put total actions")
+	}
+	for key, count := range addrCounts {
+		batch.Put(blockAddressActionCountMappingNS, []byte(key), byteutil.Uint64ToBytes(count),
+			"failed to put action count %x", key)
+	}
+	for key, count := range logCounts {
+		batch.Put(blockLogCountMappingNS, []byte(key), byteutil.Uint64ToBytes(count),
+			"failed to put log index count for key %x", key)
+	}
+	return nil
+}
+
+// nextIndex returns the index of the next entry under the key, and counts it in the counts of the batch
+func nextIndex(
+	store db.KVStore,
+	key []byte,
+	counts map[string]uint64,
+	getCount func(db.KVStore, []byte) (uint64, error),
+) (uint64, error) {
+	i, ok := counts[string(key)]
+	if !ok {
+		var err error
+		if i, err = getCount(store, key); err != nil {
+			return 0, err
+		}
+	}
+	counts[string(key)] = i + 1
+	return i, nil
+}
+
+// actionHeight returns the height of the block of the action
+func (ib *IndexBuilder) actionHeight(actHash hash.Hash256) (uint64, error) {
+	blkHash, err := getBlockHashByActionHash(ib.store, actHash)
+	if err != nil {
+		return 0, err
+	}
+	return ib.dao.getBlockHeight(blkHash)
+}
+
+// addressIndexKeys returns the keys of the address indexes of the action, i.e., the sender's and the recipient's if
+// any
+func addressIndexKeys(selp action.SealedEnvelope) ([]string, error) {
+	sender := hash.BytesToHash160(selp.SrcPubkey().Hash())
+	keys := []string{string(actionFromPrefix) + string(sender[:])}
+	dst, ok := selp.Destination()
+	if !ok || dst == "" {
+		return keys, nil
+	}
+	dstAddr, err := address.FromString(dst)
+	if err != nil {
+		return nil, err
+	}
+	recipient := hash.BytesToHash160(dstAddr.Bytes())
+	return append(keys, string(actionToPrefix)+string(recipient[:])), nil
+}
+
+// getActionCount returns the number of actions indexed under the address key
+func getActionCount(store db.KVStore, key []byte) (uint64, error) {
+	value, err := store.Get(blockAddressActionCountMappingNS, key)
+	if errors.Cause(err) == db.ErrNotExist {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(value) == 0 {
+		return 0, errors.New("count of actions is broken")
+	}
+	return enc.MachineEndian.Uint64(value), nil
+}
+
+// indexKey returns the key of the i-th entry indexed under the key
+func indexKey(key []byte, i uint64) []byte {
+	k := make([]byte, 0, len(key)+8)
+	k = append(k, key...)
+	return append(k, byteutil.Uint64ToBytes(i)...)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestIndexBuilder_Reindex(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	kvstore := db.NewMemKVStore()
+	dao := newBlockDAO(kvstore, true, false, 0, 0)
+	require.NoError(dao.Start(ctx))
+	defer func() {
+		require.NoError(dao.Stop(ctx))
+	}()
+	// the indexes of block 2 are lost, as if the index builder crashed
+	noIndexDao := newBlockDAO(kvstore, false, false, 0, 0)
+	require.NoError(noIndexDao.Start(ctx))
+	ib := &IndexBuilder{dao: dao, store: kvstore}
+	inspector := &ChainDBInspector{dao: dao}
+
+	topic := hash.Hash256b([]byte("topic"))
+	var actHashes []hash.Hash256
+	prevHash := hash.ZeroHash256
+	for height := uint64(1); height <= 4; height++ {
+		tsf, err := testutil.SignedTransfer(
+			testaddress.Addrinfo["bravo"].String(),
+			testaddress.Keyinfo["alfa"].PriKey,
+			height,
+			big.NewInt(1),
+			nil,
+			testutil.TestGasLimit,
+			big.NewInt(0),
+		)
+		require.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(tsf).
+			SignAndBuild(testaddress.Keyinfo["producer"].PubKey, testaddress.Keyinfo["producer"].PriKey)
+		require.NoError(err)
+		receipts := []*action.Receipt{{
+			BlockHeight: height,
+			ActionHash:  tsf.Hash(),
+			Logs:        []*action.Log{{Address: testaddress.Addrinfo["charlie"].String(), Topics: []hash.Hash256{topic}}},
+		}}
+		d := dao
+		if height == 2 {
+			d = noIndexDao
+		}
+		require.NoError(d.putBlock(&blk))
+		require.NoError(d.putReceipts(height, receipts))
+		actHashes = append(actHashes, tsf.Hash())
+		prevHash = blk.HashBlock()
+	}
+	alfa := hash.BytesToHash160(testaddress.Addrinfo["alfa"].Bytes())
+	bravo := hash.BytesToHash160(testaddress.Addrinfo["bravo"].Bytes())
+	checkIndexes := func() {
+		problems, err := inspector.Verify()
+		require.NoError(err)
+		require.Empty(problems)
+		actions, err := getActionsBySenderAddress(kvstore, alfa)
+		require.NoError(err)
+		require.Equal(actHashes, actions)
+		actions, err = getActionsByRecipientAddress(kvstore, bravo)
+		require.NoError(err)
+		require.Equal(actHashes, actions)
+		heights, err := getLogBlockHeights(kvstore, nil, [][]hash.Hash256{{topic}}, 1, 4)
+		require.NoError(err)
+		require.Equal([]uint64{1, 2, 3, 4}, heights)
+	}
+
+	problems, err := inspector.Verify()
+	require.NoError(err)
+	require.NotEmpty(problems)
+	_, err = getBlockHashByActionHash(kvstore, actHashes[1])
+	require.Error(err)
+
+	// a stale entry left by a crash is wiped
+	alfaKey := append(append([]byte{}, actionFromPrefix...), alfa[:]...)
+	stale := hash.Hash256b([]byte("stale"))
+	require.NoError(kvstore.Put(blockAddressActionMappingNS, indexKey(alfaKey, 3), stale[:]))
+	require.NoError(kvstore.Put(blockAddressActionCountMappingNS, alfaKey, byteutil.Uint64ToBytes(4)))
+
+	// the indexes of the later blocks are rebuilt after block 2
+	require.NoError(ib.Reindex(ctx, 2, 2))
+	checkIndexes()
+	_, _, _, err = ib.reindexProgress()
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+
+	// reindexing the indexed blocks changes nothing
+	require.NoError(ib.Reindex(ctx, 1, 0))
+	checkIndexes()
+
+	// an interrupted reindex covering the range resumes without wiping the blocks reindexed
+	require.NoError(ib.wipeIndexes(3, 4))
+	batch := db.NewBatch()
+	require.NoError(ib.reindexBlocks(3, 3, 4, false, batch))
+	batch.Delete(blockActionReceiptMappingNS, actHashes[2][hashOffset:], "")
+	putReindexProgress(4, 3, 4, batch)
+	require.NoError(kvstore.Commit(batch))
+	require.NoError(ib.Reindex(ctx, 3, 4))
+	problems, err = inspector.Verify()
+	require.NoError(err)
+	require.Equal(1, len(problems))
+	require.Equal(uint64(3), problems[0].Height)
+	require.NoError(ib.Reindex(ctx, 3, 4))
+	checkIndexes()
+
+	// reindexing all the blocks recounts the total actions
+	require.NoError(kvstore.Put(blockNS, totalActionsKey, byteutil.Uint64ToBytes(7)))
+	require.NoError(ib.Reindex(ctx, 1, 0))
+	checkIndexes()
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.Equal(context.Canceled, errors.Cause(ib.Reindex(cancelled, 1, 4)))
+	require.Error(ib.Reindex(ctx, 3, 2))
+	require.Error(ib.Reindex(ctx, 0, 2))
+}
@@ -0,0 +1,533 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+const (
+	snapshotManifestFile = "manifest.json"
+	snapshotStateFile    = "state"
+	snapshotHeadersFile  = "headers"
+
+	snapshotStagingDB = "state.db"
+
+	// snapshotImportBatchSize is the number of state records committed to the staging DB at a time
+	snapshotImportBatchSize = 10000
+	// snapshotMaxFieldSize is the max size of a field of a record in a snapshot, above the size of any trie node,
+	// contract code, block header or footer
+	snapshotMaxFieldSize = 16 << 20
+)
+
+// SnapshotManifest describes a chain snapshot, which consists of the state at a height and the headers and footers of
+// the blocks up to that height
+type SnapshotManifest struct {
+	ChainID      uint32            `json:"chainID"`
+	Height       uint64            `json:"height"`
+	BlockHash    string            `json:"blockHash"`
+	StateRoot    string            `json:"stateRoot"`
+	NumHeaders   uint64            `json:"numHeaders"`
+	TotalActions uint64            `json:"totalActions"`
+	Checksums    map[string]string `json:"checksums"`
+}
+
+// SnapshotTrust is the block hash and the state root at the snapshot height, obtained from a trusted source such as
+// the API of a trusted node, which a snapshot must match to be imported. As the block header doesn't commit to the
+// state root, a snapshot is only imported without them if Insecure is set, trusting whoever provides the snapshot
+type SnapshotTrust struct {
+	BlockHash hash.Hash256
+	StateRoot hash.Hash256
+	Insecure  bool
+}
+
+// ExportSnapshot writes a snapshot of the chain at the given height into w as a gzipped tar archive, including the
+// state and the last numHeaders block headers and footers. Height 0 means the tip height. The state of the height
+// must be retained in the trie DB, so a height other than the tip requires archive mode or trie pruning
+func ExportSnapshot(
+	cfg config.Config,
+	chainDB db.KVStore,
+	trieDB db.KVStore,
+	height uint64,
+	numHeaders uint64,
+	w io.Writer,
+) (*SnapshotManifest, error) {
+	ctx := context.Background()
+	dao := newBlockDAO(chainDB, false, cfg.Chain.CompressBlock, 0, 0)
+	if err := dao.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := dao.Stop(ctx); err != nil {
+			log.L().Error("Failed to stop chain DB.", zap.Error(err))
+		}
+	}()
+	if err := trieDB.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := trieDB.Stop(ctx); err != nil {
+			log.L().Error("Failed to stop trie DB.", zap.Error(err))
+		}
+	}()
+
+	tipHeight, err := dao.getBlockchainHeight()
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		height = tipHeight
+	}
+	if height == 0 || height > tipHeight {
+		return nil, errors.Errorf("invalid snapshot height %d, tip height is %d", height, tipHeight)
+	}
+	if numHeaders == 0 || numHeaders > height {
+		numHeaders = height
+	}
+	blkHash, err := dao.getBlockHash(height)
+	if err != nil {
+		return nil, err
+	}
+	totalActions, err := dao.getTotalActions()
+	if err != nil {
+		return nil, err
+	}
+	for h := height + 1; h <= tipHeight; h++ {
+		hash, err := dao.getBlockHash(h)
+		if err != nil {
+			return nil, err
+		}
+		body, err := dao.Body(hash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to count actions of block %d", h)
+		}
+		totalActions -= uint64(len(body.Actions))
+	}
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	manifest := &SnapshotManifest{
+		ChainID:      cfg.Chain.ID,
+		Height:       height,
+		BlockHash:    hex.EncodeToString(blkHash[:]),
+		NumHeaders:   numHeaders,
+		TotalActions: totalActions,
+		Checksums:    make(map[string]string),
+	}
+	if manifest.Checksums[snapshotStateFile], err = writeSnapshotFile(dir, snapshotStateFile, func(w io.Writer) error {
+		rootHash, err := factory.ExportState(trieDB, height, func(namespace string, key, value []byte) error {
+			return writeSnapshotRecord(w, []byte(namespace), key, value)
+		})
+		manifest.StateRoot = hex.EncodeToString(rootHash[:])
+		return err
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to export state")
+	}
+	if manifest.Checksums[snapshotHeadersFile], err = writeSnapshotFile(dir, snapshotHeadersFile, func(w io.Writer) error {
+		for h := height - numHeaders + 1; h <= height; h++ {
+			hash, err := dao.getBlockHash(h)
+			if err != nil {
+				return err
+			}
+			header, err := dao.Header(hash)
+			if err != nil {
+				return err
+			}
+			footer, err := dao.Footer(hash)
+			if err != nil {
+				return err
+			}
+			serHeader, err := header.Serialize()
+			if err != nil {
+				return err
+			}
+			serFooter, err := footer.Serialize()
+			if err != nil {
+				return err
+			}
+			if err := writeSnapshotRecord(w, serHeader, serFooter); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to export block headers")
+	}
+	serManifest, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, snapshotManifestFile), serManifest, 0600); err != nil {
+		return nil, err
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	// the manifest goes first, so that it is available before the data files on import
+	for _, name := range []string{snapshotManifestFile, snapshotStateFile, snapshotHeadersFile} {
+		if err := addTarFile(tw, dir, name); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// ImportSnapshot imports a snapshot into empty chain and trie DBs, so that the node starts from the snapshot height.
+// The block hash and the state root of the snapshot must match the trusted ones, and the checksums, the headers and
+// the state trie are verified before the snapshot is committed
+func ImportSnapshot(
+	cfg config.Config,
+	chainDB db.KVStore,
+	trieDB db.KVStore,
+	r io.Reader,
+	trust SnapshotTrust,
+) (*SnapshotManifest, error) {
+	if !trust.Insecure && (trust.BlockHash == hash.ZeroHash256 || trust.StateRoot == hash.ZeroHash256) {
+		return nil, errors.New("trusted block hash and state root are required to import a snapshot")
+	}
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	manifest, err := extractSnapshot(r, dir)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.ChainID != cfg.Chain.ID {
+		return nil, errors.Errorf("snapshot of chain %d doesn't match chain %d", manifest.ChainID, cfg.Chain.ID)
+	}
+	blkHash, err := toHash256(manifest.BlockHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid block hash in manifest")
+	}
+	if trust.BlockHash != hash.ZeroHash256 && trust.BlockHash != blkHash {
+		return nil, errors.Errorf("block hash %x of snapshot doesn't match the trusted hash %x", blkHash, trust.BlockHash)
+	}
+	stateRoot, err := toHash256(manifest.StateRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid state root in manifest")
+	}
+	if trust.StateRoot != hash.ZeroHash256 && trust.StateRoot != stateRoot {
+		return nil, errors.Errorf("state root %x of snapshot doesn't match the trusted root %x", stateRoot, trust.StateRoot)
+	}
+	if trust.Insecure {
+		log.L().Warn("Importing snapshot without verifying it against a trusted block hash and state root.")
+	}
+	headers, footers, err := readSnapshotHeaders(filepath.Join(dir, snapshotHeadersFile))
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySnapshotHeaders(manifest, blkHash, headers); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	dao := newBlockDAO(chainDB, false, cfg.Chain.CompressBlock, 0, 0)
+	if err := dao.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := dao.Stop(ctx); err != nil {
+			log.L().Error("Failed to stop chain DB.", zap.Error(err))
+		}
+	}()
+	if err := trieDB.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := trieDB.Stop(ctx); err != nil {
+			log.L().Error("Failed to stop trie DB.", zap.Error(err))
+		}
+	}()
+	if tipHeight, err := dao.getBlockchainHeight(); err != nil || tipHeight != 0 {
+		return nil, errors.New("snapshot can only be imported into an empty chain DB")
+	}
+	if _, err := trieDB.Get(factory.AccountKVNameSpace, []byte(factory.CurrentHeightKey)); errors.Cause(err) != db.ErrNotExist {
+		return nil, errors.New("snapshot can only be imported into an empty trie DB")
+	}
+
+	// the state is written into a staging DB, and copied into the trie DB only after it is verified against the root
+	stagingCfg := cfg.DB
+	stagingCfg.DbPath = filepath.Join(dir, snapshotStagingDB)
+	staging := db.NewOnDiskDB(stagingCfg)
+	if err := staging.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := staging.Stop(ctx); err != nil {
+			log.L().Error("Failed to stop staging DB.", zap.Error(err))
+		}
+	}()
+	if err := importSnapshotState(staging, filepath.Join(dir, snapshotStateFile)); err != nil {
+		return nil, err
+	}
+	if err := factory.CommitStateSnapshot(staging, trieDB, manifest.Height, stateRoot); err != nil {
+		return nil, err
+	}
+	if err := dao.putSnapshotHeaders(headers, footers, manifest.TotalActions); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// verifySnapshotHeaders checks the headers are signed by their producers, and chained up to the block hash
+func verifySnapshotHeaders(manifest *SnapshotManifest, blkHash hash.Hash256, headers []*block.Header) error {
+	if uint64(len(headers)) != manifest.NumHeaders || len(headers) == 0 {
+		return errors.Errorf("expecting %d headers, but got %d", manifest.NumHeaders, len(headers))
+	}
+	for i, header := range headers {
+		if !header.VerifySignature() {
+			return errors.Errorf("failed to verify signature of block %d", header.Height())
+		}
+		if i == 0 {
+			continue
+		}
+		if header.Height() != headers[i-1].Height()+1 || header.PrevHash() != headers[i-1].HashBlock() {
+			return errors.Errorf("block %d is not chained to its previous block", header.Height())
+		}
+	}
+	last := headers[len(headers)-1]
+	if last.Height() != manifest.Height || last.HashBlock() != blkHash {
+		return errors.Errorf("last header %x at height %d doesn't match the manifest", last.HashBlock(), last.Height())
+	}
+	return nil
+}
+
+// importSnapshotState writes the state records into the staging DB, which may only be the nodes of the account and
+// storage tries and the contract code
+func importSnapshotState(staging db.KVStore, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	batch := db.NewBatch()
+	for {
+		fields, err := readSnapshotRecord(r, 3)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read state record")
+		}
+		switch ns := string(fields[0]); ns {
+		case factory.AccountKVNameSpace, factory.ContractKVNameSpace, factory.CodeKVNameSpace:
+		default:
+			return errors.Errorf("unexpected namespace %s of state record %x", ns, fields[1])
+		}
+		batch.Put(string(fields[0]), fields[1], fields[2], "failed to put state record %x", fields[1])
+		if batch.Size() >= snapshotImportBatchSize {
+			if err := staging.Commit(batch); err != nil {
+				return err
+			}
+			batch.Clear()
+		}
+	}
+	return staging.Commit(batch)
+}
+
+func readSnapshotHeaders(path string) ([]*block.Header, []*block.Footer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	var (
+		headers []*block.Header
+		footers []*block.Footer
+	)
+	for {
+		fields, err := readSnapshotRecord(r, 2)
+		if err == io.EOF {
+			return headers, footers, nil
+		}
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to read header record")
+		}
+		header := &block.Header{}
+		if err := header.Deserialize(fields[0]); err != nil {
+			return nil, nil, err
+		}
+		footer := &block.Footer{}
+		if err := footer.Deserialize(fields[1]); err != nil {
+			return nil, nil, err
+		}
+		headers = append(headers, header)
+		footers = append(footers, footer)
+	}
+}
+
+// writeSnapshotFile writes a file in dir by f, and returns its sha256 checksum
+func writeSnapshotFile(dir string, name string, f func(io.Writer) error) (string, error) {
+	file, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	h := sha256.New()
+	w := bufio.NewWriter(io.MultiWriter(file, h))
+	if err := f(w); err != nil {
+		return "", err
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func addTarFile(tw *tar.Writer, dir string, name string) error {
+	file, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0600,
+		Size: info.Size(),
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, file)
+	return err
+}
+
+// extractSnapshot extracts the snapshot archive into dir, and verifies the checksums of the files
+func extractSnapshot(r io.Reader, dir string) (*SnapshotManifest, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid snapshot archive")
+	}
+	tr := tar.NewReader(gr)
+	var manifest *SnapshotManifest
+	verified := make(map[string]bool)
+	for {
+		th, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid snapshot archive")
+		}
+		if th.Name == snapshotManifestFile {
+			manifest = &SnapshotManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, errors.Wrap(err, "invalid snapshot manifest")
+			}
+			continue
+		}
+		if manifest == nil {
+			return nil, errors.New("snapshot manifest should come first")
+		}
+		checksum, ok := manifest.Checksums[th.Name]
+		if !ok || (th.Name != snapshotStateFile && th.Name != snapshotHeadersFile) {
+			return nil, errors.Errorf("unexpected file %s in snapshot", th.Name)
+		}
+		sum, err := writeSnapshotFile(dir, th.Name, func(w io.Writer) error {
+			_, err := io.Copy(w, tr)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if sum != checksum {
+			return nil, errors.Errorf("checksum of %s doesn't match the manifest", th.Name)
+		}
+		verified[th.Name] = true
+	}
+	if manifest == nil || !verified[snapshotStateFile] || !verified[snapshotHeadersFile] {
+		return nil, errors.New("incomplete snapshot archive")
+	}
+	return manifest, nil
+}
+
+// writeSnapshotRecord writes the fields of a record, each prefixed by its length in big endian
+func writeSnapshotRecord(w io.Writer, fields ...[]byte) error {
+	var size [4]byte
+	for _, field := range fields {
+		binary.BigEndian.PutUint32(size[:], uint32(len(field)))
+		if _, err := w.Write(size[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readSnapshotRecord reads a record of n fields, and returns io.EOF if there is no more record. A field larger than
+// snapshotMaxFieldSize is refused before it is allocated.
+func readSnapshotRecord(r io.Reader, n int) ([][]byte, error) {
+	fields := make([][]byte, n)
+	var size [4]byte
+	for i := range fields {
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			if i == 0 && err == io.EOF {
+				return nil, io.EOF
+			}
+			return nil, errors.Wrap(err, "truncated record")
+		}
+		fieldSize := binary.BigEndian.Uint32(size[:])
+		if fieldSize > snapshotMaxFieldSize {
+			return nil, errors.Errorf("record field of %d bytes exceeds the limit %d", fieldSize, snapshotMaxFieldSize)
+		}
+		fields[i] = make([]byte, fieldSize)
+		if _, err := io.ReadFull(r, fields[i]); err != nil {
+			return nil, errors.Wrap(err, "truncated record")
+		}
+	}
+	return fields, nil
+}
+
+func toHash256(s string) (hash.Hash256, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	if len(b) != len(hash.ZeroHash256) {
+		return hash.ZeroHash256, errors.Errorf("invalid hash length %d", len(b))
+	}
+	return hash.BytesToHash256(b), nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestSnapshot(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg := config.Default
+
+	newChain := func(chainDB, trieDB db.KVStore) (Blockchain, factory.Factory) {
+		sf, err := factory.NewFactory(cfg, factory.PrecreatedTrieDBOption(trieDB))
+		require.NoError(err)
+		registry := protocol.Registry{}
+		acc := account.NewProtocol()
+		require.NoError(registry.Register(account.ProtocolID, acc))
+		bc := NewBlockchain(
+			cfg,
+			PrecreatedStateFactoryOption(sf),
+			PrecreatedDaoOption(newBlockDAO(chainDB, false, false, 0, 0)),
+			RegistryOption(&registry),
+			EnableExperimentalActions(),
+		)
+		rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+		require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+		v := vote.NewProtocol(bc)
+		require.NoError(registry.Register(vote.ProtocolID, v))
+		bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, cfg.Genesis.ActionGasLimit))
+		bc.Validator().AddActionValidators(acc, v)
+		sf.AddActionHandlers(acc, v)
+		return bc, sf
+	}
+
+	chainDB := db.NewMemKVStore()
+	trieDB := db.NewMemKVStore()
+	bc, sf := newChain(chainDB, trieDB)
+	require.NoError(bc.Start(ctx))
+	require.NoError(addCreatorToFactory(sf))
+	require.NoError(addTestingTsfBlocks(bc))
+	tipHeight := bc.TipHeight()
+	tipHash := bc.TipHash()
+	totalActions, err := bc.GetTotalActions()
+	require.NoError(err)
+	balances := make(map[string]string)
+	for name, addr := range ta.Addrinfo {
+		balance, err := bc.Balance(addr.String())
+		require.NoError(err)
+		balances[name] = balance.String()
+	}
+	require.NoError(bc.Stop(ctx))
+
+	var buf bytes.Buffer
+	manifest, err := ExportSnapshot(cfg, chainDB, trieDB, 0, 3, &buf)
+	require.NoError(err)
+	require.Equal(tipHeight, manifest.Height)
+	require.Equal(uint64(3), manifest.NumHeaders)
+	require.Equal(totalActions, manifest.TotalActions)
+	snapshot := buf.Bytes()
+
+	stateRoot, err := hex.DecodeString(manifest.StateRoot)
+	require.NoError(err)
+	trust := SnapshotTrust{BlockHash: tipHash, StateRoot: hash.BytesToHash256(stateRoot)}
+
+	// the snapshot is rejected without the trusted hashes, if it doesn't match them, or is corrupted
+	for _, c := range []SnapshotTrust{
+		{},
+		{BlockHash: tipHash},
+		{StateRoot: trust.StateRoot},
+		{BlockHash: hash.Hash256b([]byte{1}), StateRoot: trust.StateRoot},
+		{BlockHash: tipHash, StateRoot: hash.Hash256b([]byte{1})},
+		{StateRoot: hash.Hash256b([]byte{1}), Insecure: true},
+	} {
+		_, err = ImportSnapshot(cfg, db.NewMemKVStore(), db.NewMemKVStore(), bytes.NewReader(snapshot), c)
+		require.Error(err)
+	}
+	_, err = ImportSnapshot(cfg, db.NewMemKVStore(), db.NewMemKVStore(), bytes.NewReader(snapshot[:len(snapshot)/2]), trust)
+	require.Error(err)
+	// the snapshot can only be imported into empty DBs
+	_, err = ImportSnapshot(cfg, chainDB, db.NewMemKVStore(), bytes.NewReader(snapshot), trust)
+	require.Error(err)
+	_, err = ImportSnapshot(cfg, db.NewMemKVStore(), trieDB, bytes.NewReader(snapshot), trust)
+	require.Error(err)
+	// the snapshot is imported without the trusted hashes only if it is explicitly insecure
+	_, err = ImportSnapshot(cfg, db.NewMemKVStore(), db.NewMemKVStore(), bytes.NewReader(snapshot),
+		SnapshotTrust{Insecure: true})
+	require.NoError(err)
+
+	newChainDB := db.NewMemKVStore()
+	newTrieDB := db.NewMemKVStore()
+	_, err = ImportSnapshot(cfg, newChainDB, newTrieDB, bytes.NewReader(snapshot), trust)
+	require.NoError(err)
+	bc, _ = newChain(newChainDB, newTrieDB)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.Equal(tipHeight, bc.TipHeight())
+	require.Equal(tipHash, bc.TipHash())
+	require.Equal(tipHeight+1, bc.LowestAvailableHeight())
+	newTotalActions, err := bc.GetTotalActions()
+	require.NoError(err)
+	require.Equal(totalActions, newTotalActions)
+	for name, addr := range ta.Addrinfo {
+		balance, err := bc.Balance(addr.String())
+		require.NoError(err)
+		require.Equal(balances[name], balance.String())
+	}
+	header, err := bc.BlockHeaderByHeight(tipHeight - 2)
+	require.NoError(err)
+	require.Equal(tipHeight-2, header.Height())
+	_, err = bc.GetBlockByHeight(tipHeight - 3)
+	require.Equal(ErrBlockPruned, errors.Cause(err))
+
+	// the chain goes on from the snapshot height
+	blk, err := bc.MintNewBlock(nil, testutil.TimestampNow())
+	require.NoError(err)
+	require.NoError(bc.ValidateBlock(blk))
+	require.NoError(bc.CommitBlock(blk))
+	require.Equal(tipHeight+1, bc.TipHeight())
+}
+
+func TestImportSnapshotState(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, snapshotStateFile)
+	importState := func(namespace string) (db.KVStore, error) {
+		_, err := writeSnapshotFile(dir, snapshotStateFile, func(w io.Writer) error {
+			return writeSnapshotRecord(w, []byte(namespace), []byte("key"), []byte("value"))
+		})
+		require.NoError(err)
+		trieDB := db.NewMemKVStore()
+		return trieDB, importSnapshotState(trieDB, path)
+	}
+
+	for _, namespace := range []string{factory.AccountKVNameSpace, factory.ContractKVNameSpace, factory.CodeKVNameSpace} {
+		trieDB, err := importState(namespace)
+		require.NoError(err)
+		value, err := trieDB.Get(namespace, []byte("key"))
+		require.NoError(err)
+		require.Equal([]byte("value"), value)
+	}
+	// a snapshot cannot write records of any other namespace
+	trieDB, err := importState(blockNS)
+	require.Error(err)
+	_, err = trieDB.Get(blockNS, []byte("key"))
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+}
+
+func TestReadSnapshotRecord(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	require.NoError(writeSnapshotRecord(&buf, []byte("key"), []byte("value")))
+	fields, err := readSnapshotRecord(&buf, 2)
+	require.NoError(err)
+	require.Equal([][]byte{[]byte("key"), []byte("value")}, fields)
+	_, err = readSnapshotRecord(&buf, 2)
+	require.Equal(io.EOF, err)
+
+	// a truncated record
+	require.NoError(writeSnapshotRecord(&buf, []byte("key")))
+	_, err = readSnapshotRecord(&buf, 2)
+	require.Error(err)
+	// a field too large is refused before it is read
+	buf.Reset()
+	buf.Write([]byte{0xff, 0xff, 0xff, 0xff})
+	_, err = readSnapshotRecord(&buf, 1)
+	require.Error(err)
+}
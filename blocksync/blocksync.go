@@ -8,11 +8,14 @@ package blocksync
 
 import (
 	"context"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
@@ -34,6 +37,7 @@ type (
 type Config struct {
 	unicastHandler   UnicastOutbound
 	neighborsHandler Neighbors
+	sigCache         *action.SignatureCache
 }
 
 // Option is the option to override the blocksync config
@@ -55,14 +59,75 @@ func WithNeighbors(neighborsHandler Neighbors) Option {
 	}
 }
 
+// WithSignatureCache is the option to share the cache of verified action signatures with the block validator
+func WithSignatureCache(sigCache *action.SignatureCache) Option {
+	return func(cfg *Config) error {
+		cfg.sigCache = sigCache
+		return nil
+	}
+}
+
 // BlockSync defines the interface of blocksyncer
 type BlockSync interface {
 	lifecycle.StartStopper
 
 	TargetHeight() uint64
+	SyncStatus() SyncStatus
 	ProcessSyncRequest(ctx context.Context, peer peerstore.PeerInfo, sync *iotexrpc.BlockSync) error
 	ProcessBlock(ctx context.Context, blk *block.Block) error
-	ProcessBlockSync(ctx context.Context, blk *block.Block) error
+	ProcessBlockSync(ctx context.Context, peer peerstore.PeerInfo, blk *block.Block) error
+	ProcessBlockHeaders(ctx context.Context, peer peerstore.PeerInfo, headers *iotexrpc.BlockHeaders) error
+}
+
+// SyncStatus is the progress of block sync
+type SyncStatus struct {
+	// StartHeight is the tip height when the current sync started
+	StartHeight  uint64
+	TipHeight    uint64
+	HeaderHeight uint64
+	TargetHeight uint64
+	// BlocksPerSecond is the average commit rate since the sync started
+	BlocksPerSecond float64
+	// ETA is the estimated time to reach the target height
+	ETA   time.Duration
+	Peers []PeerStatus
+}
+
+var (
+	syncHeightMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_blocksync_height",
+			Help: "Tip, validated header and target heights of block sync.",
+		},
+		[]string{"type"},
+	)
+	syncETAMtc = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "iotex_blocksync_eta_seconds",
+			Help: "Estimated time in seconds to reach the target height of block sync.",
+		},
+	)
+	peerThroughputMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_blocksync_peer_throughput",
+			Help: "Number of blocks per second delivered by sync peers.",
+		},
+		[]string{"peer"},
+	)
+	peerEventMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_blocksync_peer_events",
+			Help: "Number of sync peer misbehaviors and bans.",
+		},
+		[]string{"event"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(syncHeightMtc)
+	prometheus.MustRegister(syncETAMtc)
+	prometheus.MustRegister(peerThroughputMtc)
+	prometheus.MustRegister(peerEventMtc)
 }
 
 // blockSyncer implements BlockSync interface
@@ -71,6 +136,7 @@ type blockSyncer struct {
 	buf              *blockBuffer
 	worker           *syncWorker
 	bc               blockchain.Blockchain
+	maxHeaders       uint64
 	unicastHandler   UnicastOutbound
 	neighborsHandler Neighbors
 }
@@ -83,23 +149,25 @@ func NewBlockSyncer(
 	cs consensus.Consensus,
 	opts ...Option,
 ) (BlockSync, error) {
+	bsCfg := Config{}
+	for _, opt := range opts {
+		if err := opt(&bsCfg); err != nil {
+			return nil, err
+		}
+	}
 	buf := &blockBuffer{
 		blocks:       make(map[uint64]*block.Block),
 		bc:           chain,
 		ap:           ap,
 		cs:           cs,
+		pv:           newPrevalidator(bsCfg.sigCache, cfg.BlockSync.ValidationWorkers),
 		bufferSize:   cfg.BlockSync.BufferSize,
 		intervalSize: cfg.BlockSync.IntervalSize,
 	}
-	bsCfg := Config{}
-	for _, opt := range opts {
-		if err := opt(&bsCfg); err != nil {
-			return nil, err
-		}
-	}
 	bs := &blockSyncer{
 		bc:               chain,
 		buf:              buf,
+		maxHeaders:       cfg.BlockSync.MaxHeadersPerRequest,
 		unicastHandler:   bsCfg.unicastHandler,
 		neighborsHandler: bsCfg.neighborsHandler,
 		worker:           newSyncWorker(chain.ChainID(), cfg, bsCfg.unicastHandler, bsCfg.neighborsHandler, buf),
@@ -150,7 +218,16 @@ func (bs *blockSyncer) ProcessBlock(_ context.Context, blk *block.Block) error {
 	return nil
 }
 
-func (bs *blockSyncer) ProcessBlockSync(_ context.Context, blk *block.Block) error {
+// SyncStatus returns the progress of block sync
+func (bs *blockSyncer) SyncStatus() SyncStatus {
+	return bs.worker.Status()
+}
+
+// ProcessBlockSync processes a block sent by a peer in response to a sync request
+func (bs *blockSyncer) ProcessBlockSync(_ context.Context, peer peerstore.PeerInfo, blk *block.Block) error {
+	if !bs.worker.BlockReceived(peer.ID.Pretty(), blk) {
+		return nil
+	}
 	bs.buf.Flush(blk)
 	if bs.bc.TipHeight() == bs.TargetHeight() {
 		bs.worker.SetTargetHeight(bs.TargetHeight() + bs.buf.bufSize())
@@ -158,6 +235,12 @@ func (bs *blockSyncer) ProcessBlockSync(_ context.Context, blk *block.Block) err
 	return nil
 }
 
+// ProcessBlockHeaders processes the headers sent by a peer in response to a sync request
+func (bs *blockSyncer) ProcessBlockHeaders(_ context.Context, peer peerstore.PeerInfo, headers *iotexrpc.BlockHeaders) error {
+	bs.worker.HeadersReceived(peer.ID.Pretty(), headers)
+	return nil
+}
+
 // ProcessSyncRequest processes a block sync request
 func (bs *blockSyncer) ProcessSyncRequest(ctx context.Context, peer peerstore.PeerInfo, sync *iotexrpc.BlockSync) error {
 	end := bs.bc.TipHeight()
@@ -173,6 +256,9 @@ func (bs *blockSyncer) ProcessSyncRequest(ctx context.Context, peer peerstore.Pe
 			zap.Uint64("tipHeight", end),
 		)
 	}
+	if sync.Headers {
+		return bs.processHeadersRequest(peer, sync.Start, end)
+	}
 	for i := sync.Start; i <= end; i++ {
 		blk, err := bs.bc.GetBlockByHeight(i)
 		if err != nil {
@@ -187,3 +273,31 @@ func (bs *blockSyncer) ProcessSyncRequest(ctx context.Context, peer peerstore.Pe
 	}
 	return nil
 }
+
+// processHeadersRequest sends back the headers and footers of the requested blocks in one message
+func (bs *blockSyncer) processHeadersRequest(peer peerstore.PeerInfo, start, end uint64) error {
+	if end >= start+bs.maxHeaders {
+		end = start + bs.maxHeaders - 1
+	}
+	resp := &iotexrpc.BlockHeaders{}
+	for i := start; i <= end; i++ {
+		header, err := bs.bc.BlockHeaderByHeight(i)
+		if err != nil {
+			return err
+		}
+		footer, err := bs.bc.BlockFooterByHeight(i)
+		if err != nil {
+			return err
+		}
+		footerPb, err := footer.ConvertToBlockFooterPb()
+		if err != nil {
+			return err
+		}
+		resp.Headers = append(resp.Headers, header.BlockHeaderProto())
+		resp.Footers = append(resp.Footers, footerPb)
+	}
+	if err := bs.unicastHandler(context.Background(), peer, resp); err != nil {
+		log.L().Debug("Failed to response to headers request.", zap.Error(err))
+	}
+	return nil
+}
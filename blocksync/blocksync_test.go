@@ -32,7 +32,6 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
-	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
 	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -78,30 +77,6 @@ func TestNewBlockSyncer(t *testing.T) {
 	assert.NotNil(bs)
 }
 
-func TestBlockSyncerStart(t *testing.T) {
-	assert := assert.New(t)
-
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	mBs := mock_blocksync.NewMockBlockSync(ctrl)
-	mBs.EXPECT().Start(gomock.Any()).Times(1)
-	assert.Nil(mBs.Start(ctx))
-}
-
-func TestBlockSyncerStop(t *testing.T) {
-	assert := assert.New(t)
-
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	mBs := mock_blocksync.NewMockBlockSync(ctrl)
-	mBs.EXPECT().Stop(gomock.Any()).Times(1)
-	assert.Nil(mBs.Stop(ctx))
-}
-
 func TestBlockSyncerProcessSyncRequest(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -403,9 +378,9 @@ func TestBlockSyncerProcessBlockSync(t *testing.T) {
 	h1 := chain1.TipHeight()
 	assert.Equal(t, uint64(3), h1)
 
-	require.Nil(bs2.ProcessBlockSync(ctx, blk3))
-	require.Nil(bs2.ProcessBlockSync(ctx, blk2))
-	require.Nil(bs2.ProcessBlockSync(ctx, blk1))
+	require.Nil(bs2.ProcessBlockSync(ctx, peerstore.PeerInfo{}, blk3))
+	require.Nil(bs2.ProcessBlockSync(ctx, peerstore.PeerInfo{}, blk2))
+	require.Nil(bs2.ProcessBlockSync(ctx, peerstore.PeerInfo{}, blk1))
 	h2 := chain2.TipHeight()
 	assert.Equal(t, h1, h2)
 }
@@ -36,66 +36,143 @@ type blockBuffer struct {
 	bc           blockchain.Blockchain
 	ap           actpool.ActPool
 	cs           consensus.Consensus
+	pv           *prevalidator
 	bufferSize   uint64
 	intervalSize uint64
 	commitHeight uint64 // last commit block height
+	// rejected are the blocks failing validation, to be handled by the sync worker
+	rejected []*block.Block
+	// committing is set while a flush commits the buffered blocks, in which case the other flushes only buffer theirs
+	committing bool
+	// prunedHeight is the tip when the buffer is pruned last time
+	prunedHeight uint64
 }
 
 // CommitHeight return the last commit block height
 func (b *blockBuffer) CommitHeight() uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 	return b.commitHeight
 }
 
 // Flush tries to put given block into buffer and flush buffer into blockchain.
 func (b *blockBuffer) Flush(blk *block.Block) (bool, bCheckinResult) {
-	b.mu.Lock()
-	defer b.mu.Unlock()
 	if blk == nil {
 		return false, bCheckinSkipNil
 	}
+	b.mu.Lock()
 	confirmedHeight := b.bc.TipHeight()
 	// check
 	blkHeight := blk.Height()
 	if blkHeight <= confirmedHeight {
+		b.mu.Unlock()
 		return false, bCheckinLower
 	}
 	if _, ok := b.blocks[blkHeight]; ok {
+		b.mu.Unlock()
 		return false, bCheckinExisting
 	}
 	if blkHeight > confirmedHeight+b.bufferSize {
+		b.mu.Unlock()
 		return false, bCheckinHigher
 	}
 	b.blocks[blkHeight] = blk
+	// the next block is committed right away, where its actions are verified in parallel by the block validator
+	if blkHeight > confirmedHeight+1 {
+		b.pv.submit(blk)
+	}
+	b.prune(confirmedHeight)
+	// the block is committed by the flush committing the blocks already
+	if b.committing {
+		b.mu.Unlock()
+		return false, bCheckinValid
+	}
+	b.committing = true
+	b.mu.Unlock()
+
 	l := log.L().With(
 		zap.Uint64("recvHeight", blkHeight),
 		zap.Uint64("confirmedHeight", confirmedHeight),
 		zap.String("source", "blockBuffer"))
-	var heightToSync uint64
-	for heightToSync = confirmedHeight + 1; heightToSync <= confirmedHeight+b.bufferSize; heightToSync++ {
+	return b.commit(l) >= blkHeight, bCheckinValid
+}
+
+// commit commits the buffered blocks following the tip one by one, and returns the height of the last one. The lock
+// is released while a block is committed, so that the incoming blocks are buffered and pre-validated meanwhile.
+func (b *blockBuffer) commit(l *zap.Logger) uint64 {
+	b.mu.Lock()
+	defer func() {
+		b.committing = false
+		b.mu.Unlock()
+	}()
+	var committedHeight uint64
+	for {
+		heightToSync := b.bc.TipHeight() + 1
 		blk, ok := b.blocks[heightToSync]
 		if !ok {
-			break
+			return committedHeight
 		}
+		// the block is kept in the buffer while it is committed, so that it is neither buffered again nor requested
+		b.mu.Unlock()
+		err := b.commitBlock(blk, l)
+		b.mu.Lock()
 		delete(b.blocks, heightToSync)
-		if err := commitBlock(b.bc, b.ap, b.cs, blk); err != nil && errors.Cause(err) != blockchain.ErrInvalidTipHeight {
-			l.Error("Failed to commit the block.", zap.Error(err), zap.Uint64("syncHeight", heightToSync))
-			break
+		if err != nil {
+			b.reject(blk)
+			return committedHeight
 		}
+		committedHeight = heightToSync
 		b.commitHeight = heightToSync
 		l.Info("Successfully committed block.", zap.Uint64("syncedHeight", heightToSync))
 	}
+}
 
-	// clean up on memory leak
-	if len(b.blocks) > int(b.bufferSize)*2 {
-		l.Warn("blockBuffer is leaking memory.", zap.Int("bufferSize", len(b.blocks)))
-		for h := range b.blocks {
-			if h <= confirmedHeight {
-				delete(b.blocks, h)
-			}
+func (b *blockBuffer) commitBlock(blk *block.Block, l *zap.Logger) error {
+	prevalidated, err := b.pv.wait(blk)
+	if err != nil {
+		l.Error("Failed to pre-validate the block.", zap.Error(err), zap.Uint64("syncHeight", blk.Height()))
+		return err
+	}
+	var opts []blockchain.ValidateOption
+	if prevalidated {
+		opts = append(opts, blockchain.SkipSigAndRootOption())
+	}
+	if err := commitBlock(b.bc, b.ap, b.cs, blk, opts...); err != nil && errors.Cause(err) != blockchain.ErrInvalidTipHeight {
+		l.Error("Failed to commit the block.", zap.Error(err), zap.Uint64("syncHeight", blk.Height()))
+		return err
+	}
+	return nil
+}
+
+// prune discards the buffered blocks which are no higher than the tip, e.g., committed by the consensus, along with
+// their pre-validation, once the tip moves
+func (b *blockBuffer) prune(confirmedHeight uint64) {
+	if confirmedHeight <= b.prunedHeight {
+		return
+	}
+	b.prunedHeight = confirmedHeight
+	for h, blk := range b.blocks {
+		if h <= confirmedHeight {
+			b.pv.drop(blk)
+			delete(b.blocks, h)
 		}
 	}
+}
+
+// reject records a block failing validation, the records are bounded in case they aren't drained
+func (b *blockBuffer) reject(blk *block.Block) {
+	if uint64(len(b.rejected)) < b.bufferSize {
+		b.rejected = append(b.rejected, blk)
+	}
+}
 
-	return heightToSync > blkHeight, bCheckinValid
+// drainRejected returns and clears the blocks failing validation
+func (b *blockBuffer) drainRejected() []*block.Block {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	rejected := b.rejected
+	b.rejected = nil
+	return rejected
 }
 
 // GetBlocksIntervalsToSync returns groups of syncBlocksInterval are missing upto targetHeight.
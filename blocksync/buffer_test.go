@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
@@ -23,6 +24,7 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -285,3 +287,87 @@ func TestBlockBufferGetBlocksIntervalsToSync(t *testing.T) {
 	b.Flush(blk)
 	assert.Len(b.GetBlocksIntervalsToSync(0), 0)
 }
+
+func TestBlockBufferPrune(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var tip uint64
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().DoAndReturn(func() uint64 { return tip }).AnyTimes()
+	pv := newPrevalidator(action.NewSignatureCache(16), 1)
+	b := blockBuffer{
+		bc:         chain,
+		pv:         pv,
+		blocks:     make(map[uint64]*block.Block),
+		bufferSize: 16,
+	}
+	blks := newTestBlocks(t, 0, hash.ZeroHash256, 8)
+	moved, re := b.Flush(blks[2])
+	require.False(moved)
+	require.Equal(bCheckinValid, re)
+	require.Len(pv.results, 1)
+
+	// the blocks committed by the consensus are discarded along with their pre-validation
+	tip = 5
+	moved, re = b.Flush(blks[7])
+	require.False(moved)
+	require.Equal(bCheckinValid, re)
+	require.Len(b.blocks, 1)
+	require.Len(pv.results, 1)
+	_, ok := pv.results[blks[7]]
+	require.True(ok)
+}
+
+// BenchmarkBlockBufferFlush flushes the blocks from the peers while a block is being committed, which only buffer the
+// blocks instead of waiting for the commit
+func BenchmarkBlockBufferFlush(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	defer ctrl.Finish()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().Return(uint64(0)).AnyTimes()
+	// the first block executes until the benchmark ends, and then fails, so that the flushed blocks aren't committed
+	executing := make(chan struct{})
+	executed := make(chan struct{})
+	chain.EXPECT().ValidateBlock(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(*block.Block, ...blockchain.ValidateOption) error {
+			close(executing)
+			<-executed
+			return blockchain.ErrInvalidBlock
+		}).Times(1)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().ValidateBlockFooter(gomock.Any()).Return(nil).Times(1)
+
+	blks := make([]*block.Block, b.N+1)
+	for i := range blks {
+		blks[i] = block.NewBlockDeprecated(
+			uint32(123),
+			uint64(i+1),
+			hash.ZeroHash256,
+			testutil.TimestampNow(),
+			ta.Keyinfo["producer"].PubKey,
+			nil,
+		)
+	}
+	buf := blockBuffer{
+		bc:         chain,
+		cs:         cs,
+		blocks:     make(map[uint64]*block.Block),
+		bufferSize: uint64(len(blks)),
+	}
+	committed := make(chan struct{})
+	go func() {
+		buf.Flush(blks[0])
+		close(committed)
+	}()
+	<-executing
+	b.ResetTimer()
+	for _, blk := range blks[1:] {
+		buf.Flush(blk)
+	}
+	b.StopTimer()
+	close(executed)
+	<-committed
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+)
+
+// ErrInvalidHeader indicates a header which doesn't extend the header chain
+var ErrInvalidHeader = errors.New("invalid block header")
+
+// syncHeader is a validated header along with its footer
+type syncHeader struct {
+	header *block.Header
+	footer *block.Footer
+	hash   hash.Hash256
+}
+
+// headerChain is the chain of the headers validated ahead of the block bodies, extending the tip of the blockchain.
+// A header is validated by its link to the previous one, the producer's signature, and the proposer and the quorum of
+// the delegate endorsements in its footer. The headers whose delegates are unknown until the blockchain grows cannot be
+// validated, so the chain is blocked at them until its base moves.
+type headerChain struct {
+	base     uint64
+	baseHash hash.Hash256
+	// headers[i] is the header at height base+1+i
+	headers []*syncHeader
+	// blocked is true if the next header cannot be validated yet
+	blocked bool
+}
+
+// tip returns the height of the last header
+func (hc *headerChain) tip() uint64 {
+	return hc.base + uint64(len(hc.headers))
+}
+
+// tipHash returns the hash of the last header
+func (hc *headerChain) tipHash() hash.Hash256 {
+	if len(hc.headers) == 0 {
+		return hc.baseHash
+	}
+	return hc.headers[len(hc.headers)-1].hash
+}
+
+// get returns the header at the height, or nil if it isn't in the chain
+func (hc *headerChain) get(height uint64) *syncHeader {
+	if height <= hc.base || height > hc.tip() {
+		return nil
+	}
+	return hc.headers[height-hc.base-1]
+}
+
+// rebase moves the base of the chain to the tip of the blockchain, the chain is reset if it conflicts with the
+// blockchain
+func (hc *headerChain) rebase(height uint64, h hash.Hash256) {
+	if height <= hc.base {
+		if height < hc.base {
+			hc.reset(height, h)
+		}
+		return
+	}
+	if sh := hc.get(height); sh == nil || sh.hash != h {
+		hc.reset(height, h)
+		return
+	}
+	hc.headers = hc.headers[height-hc.base:]
+	hc.base = height
+	hc.baseHash = h
+	hc.blocked = false
+}
+
+// reset drops all the headers
+func (hc *headerChain) reset(height uint64, h hash.Hash256) {
+	hc.base = height
+	hc.baseHash = h
+	hc.headers = nil
+	hc.blocked = false
+}
+
+// extend validates the headers and appends them to the chain. The headers before the first invalid or unverifiable
+// one are kept, and scheme.ErrNotVerifiable is returned for an unverifiable one.
+func (hc *headerChain) extend(headers []*block.Header, footers []*block.Footer, cs consensus.Consensus) error {
+	if len(headers) != len(footers) {
+		return errors.Wrapf(ErrInvalidHeader, "%d headers with %d footers", len(headers), len(footers))
+	}
+	for i, header := range headers {
+		if header.Height() != hc.tip()+1 {
+			return errors.Wrapf(ErrInvalidHeader, "height %d, expecting %d", header.Height(), hc.tip()+1)
+		}
+		if header.PrevHash() != hc.tipHash() {
+			return errors.Wrapf(ErrInvalidHeader, "wrong prev hash of header %d", header.Height())
+		}
+		if !header.VerifySignature() {
+			return errors.Wrapf(ErrInvalidHeader, "failed to verify signature of header %d", header.Height())
+		}
+		var prevFooter *block.Footer
+		if len(hc.headers) > 0 {
+			prevFooter = hc.headers[len(hc.headers)-1].footer
+		}
+		// the signatures of the endorsements are verified along with the quorum by the consensus
+		if err := cs.ValidateHeaderFooter(header, footers[i], prevFooter); err != nil {
+			if errors.Cause(err) == scheme.ErrNotVerifiable {
+				hc.blocked = true
+				return err
+			}
+			return errors.Wrapf(ErrInvalidHeader, "footer of header %d: %v", header.Height(), err)
+		}
+		hc.headers = append(hc.headers, &syncHeader{header: header, footer: footers[i], hash: header.HashBlock()})
+	}
+	return nil
+}
+
+// convertFromBlockHeadersPb converts the headers and footers in a block sync response
+func convertFromBlockHeadersPb(pb *iotexrpc.BlockHeaders) ([]*block.Header, []*block.Footer, error) {
+	if len(pb.Headers) != len(pb.Footers) {
+		return nil, nil, errors.Wrapf(ErrInvalidHeader, "%d headers with %d footers", len(pb.Headers), len(pb.Footers))
+	}
+	headers := make([]*block.Header, len(pb.Headers))
+	footers := make([]*block.Footer, len(pb.Footers))
+	for i := range pb.Headers {
+		headers[i] = &block.Header{}
+		if err := headers[i].LoadFromBlockHeaderProto(pb.Headers[i]); err != nil {
+			return nil, nil, err
+		}
+		footers[i] = &block.Footer{}
+		if err := footers[i].ConvertFromBlockFooterPb(pb.Footers[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return headers, footers, nil
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+package blocksync
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// newTestBlocks creates n finalized blocks extending the given hash at the height
+func newTestBlocks(t *testing.T, height uint64, prevHash hash.Hash256, n int) []*block.Block {
+	require := require.New(t)
+	blks := make([]*block.Block, n)
+	for i := range blks {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height+uint64(i)+1).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+		require.NoError(err)
+		blkHash := blk.HashBlock()
+		en, err := endorsement.Endorse(
+			ta.Keyinfo["alfa"].PriKey,
+			rolldpos.NewConsensusVote(blkHash[:], rolldpos.COMMIT),
+			testutil.TimestampNow(),
+		)
+		require.NoError(err)
+		require.NoError(blk.Finalize([]*endorsement.Endorsement{en}, testutil.TimestampNow()))
+		blks[i] = &blk
+		prevHash = blkHash
+	}
+	return blks
+}
+
+func headersOf(blks []*block.Block) ([]*block.Header, []*block.Footer) {
+	headers := make([]*block.Header, len(blks))
+	footers := make([]*block.Footer, len(blks))
+	for i, blk := range blks {
+		headers[i] = &blk.Header
+		footers[i] = &blk.Footer
+	}
+	return headers, footers
+}
+
+func TestHeaderChain(t *testing.T) {
+	require := require.New(t)
+
+	baseHash := hash.Hash256b([]byte("base"))
+	blks := newTestBlocks(t, 10, baseHash, 5)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().ValidateHeaderFooter(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(header *block.Header, footer *block.Footer, _ *block.Footer) error {
+			// the consensus verifies the signatures of the endorsements
+			return verifyEndorsementSignatures(header.HashBlock(), footer.Endorsements())
+		},
+	).AnyTimes()
+	hc := &headerChain{}
+	hc.reset(10, baseHash)
+	require.Equal(uint64(10), hc.tip())
+	require.Equal(baseHash, hc.tipHash())
+
+	headers, footers := headersOf(blks)
+	require.NoError(hc.extend(headers[:3], footers[:3], cs))
+	require.Equal(uint64(13), hc.tip())
+	require.Equal(blks[2].HashBlock(), hc.tipHash())
+	require.Nil(hc.get(10))
+	require.Nil(hc.get(14))
+	require.Equal(blks[0].HashBlock(), hc.get(11).hash)
+
+	// headers not following the tip are rejected
+	err := hc.extend(headers[4:], footers[4:], cs)
+	require.Equal(ErrInvalidHeader, errors.Cause(err))
+	err = hc.extend(headers[3:], footers[:1], cs)
+	require.Equal(ErrInvalidHeader, errors.Cause(err))
+
+	// a forged endorsement is rejected by the consensus, and the headers before it are kept
+	forged := newTestBlocks(t, 13, blks[2].HashBlock(), 2)
+	forged[1].Footer = blks[0].Footer
+	fh, ff := headersOf(forged)
+	err = hc.extend(fh, ff, cs)
+	require.Equal(ErrInvalidHeader, errors.Cause(err))
+	require.Equal(uint64(14), hc.tip())
+
+	// rebase onto a committed header keeps the ones after it
+	hc.rebase(12, blks[1].HashBlock())
+	require.Equal(uint64(12), hc.base)
+	require.Equal(uint64(14), hc.tip())
+	// rebase onto a conflicting block resets the chain
+	hc.rebase(13, hash.Hash256b([]byte("fork")))
+	require.Equal(uint64(13), hc.tip())
+	require.Nil(hc.get(13))
+
+	// round trip through the sync response
+	pb := &iotexrpc.BlockHeaders{}
+	for i := range headers {
+		pb.Headers = append(pb.Headers, headers[i].BlockHeaderProto())
+		fpb, err := footers[i].ConvertToBlockFooterPb()
+		require.NoError(err)
+		pb.Footers = append(pb.Footers, fpb)
+	}
+	headers2, footers2, err := convertFromBlockHeadersPb(pb)
+	require.NoError(err)
+	hc.reset(10, baseHash)
+	require.NoError(hc.extend(headers2, footers2, cs))
+	require.Equal(blks[4].HashBlock(), hc.tipHash())
+	pb.Footers = pb.Footers[1:]
+	_, _, err = convertFromBlockHeadersPb(pb)
+	require.Equal(ErrInvalidHeader, errors.Cause(err))
+}
+
+func TestHeaderChainFooter(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	baseHash := hash.Hash256b([]byte("base"))
+	blks := newTestBlocks(t, 10, baseHash, 3)
+	headers, footers := headersOf(blks)
+	hc := &headerChain{}
+	hc.reset(10, baseHash)
+
+	// the footer of each header is validated following the previous one, the first one follows the base in the chain
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	gomock.InOrder(
+		cs.EXPECT().ValidateHeaderFooter(headers[0], footers[0], nil).Return(nil),
+		cs.EXPECT().ValidateHeaderFooter(headers[1], footers[1], footers[0]).Return(nil),
+		cs.EXPECT().ValidateHeaderFooter(headers[2], footers[2], footers[1]).
+			Return(rolldpos.ErrInsufficientEndorsements),
+	)
+	err := hc.extend(headers, footers, cs)
+	require.Equal(ErrInvalidHeader, errors.Cause(err))
+	require.Equal(uint64(12), hc.tip())
+	require.False(hc.blocked)
+
+	// the chain is blocked at a header whose delegates are unknown, until its base moves
+	cs.EXPECT().ValidateHeaderFooter(headers[2], footers[2], footers[1]).
+		Return(errors.Wrap(scheme.ErrNotVerifiable, "unknown delegates"))
+	err = hc.extend(headers[2:], footers[2:], cs)
+	require.Equal(scheme.ErrNotVerifiable, errors.Cause(err))
+	require.Equal(uint64(12), hc.tip())
+	require.True(hc.blocked)
+	hc.rebase(11, blks[0].HashBlock())
+	require.False(hc.blocked)
+	require.Equal(uint64(12), hc.tip())
+}
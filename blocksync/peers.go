@@ -0,0 +1,182 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"sort"
+	"time"
+
+	"github.com/facebookgo/clock"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	// minPeerScore is the score below which a peer is banned
+	minPeerScore = -20
+	// timeoutPenalty is the score deducted when a peer doesn't answer a request in time
+	timeoutPenalty = 5
+	// throughputWeight is the weight of the latest sample in the moving average of a peer's throughput
+	throughputWeight = 0.3
+)
+
+// PeerStatus is the sync status of a neighbor
+type PeerStatus struct {
+	ID string
+	// Score is raised by the requests answered and lowered by the requests timed out or answered with invalid data
+	Score int
+	// Blocks is the number of blocks and headers delivered
+	Blocks uint64
+	// BlocksPerSecond is the moving average of the delivery throughput
+	BlocksPerSecond float64
+	Banned          bool
+}
+
+// syncPeer is a neighbor to sync from
+type syncPeer struct {
+	info        peerstore.PeerInfo
+	score       int
+	blocks      uint64
+	throughput  float64
+	inflight    int
+	noHeaders   bool
+	bannedUntil time.Time
+}
+
+// peerManager keeps the scores of the neighbors, which decide the peers to send requests to. A peer scored below
+// minPeerScore is dropped for a while.
+type peerManager struct {
+	peers       map[string]*syncPeer
+	banDuration time.Duration
+	clock       clock.Clock
+}
+
+func newPeerManager(banDuration time.Duration) *peerManager {
+	return &peerManager{
+		peers:       make(map[string]*syncPeer),
+		banDuration: banDuration,
+		clock:       clock.New(),
+	}
+}
+
+// update tracks the current neighbors. The peers which are gone are forgotten unless they are banned.
+func (pm *peerManager) update(infos []peerstore.PeerInfo) {
+	now := pm.clock.Now()
+	current := make(map[string]bool, len(infos))
+	for _, info := range infos {
+		id := info.ID.Pretty()
+		current[id] = true
+		if p, ok := pm.peers[id]; ok {
+			p.info = info
+			continue
+		}
+		pm.peers[id] = &syncPeer{info: info}
+	}
+	for id, p := range pm.peers {
+		if !current[id] && !now.Before(p.bannedUntil) {
+			delete(pm.peers, id)
+			peerThroughputMtc.DeleteLabelValues(id)
+		}
+	}
+}
+
+// get returns the peer of the id, or nil if it isn't tracked
+func (pm *peerManager) get(id string) *syncPeer {
+	return pm.peers[id]
+}
+
+// available returns the peers which aren't banned and have less than maxInflight requests outstanding, the ones
+// with higher scores and throughputs first
+func (pm *peerManager) available(maxInflight int) []*syncPeer {
+	now := pm.clock.Now()
+	var peers []*syncPeer
+	for _, p := range pm.peers {
+		if now.Before(p.bannedUntil) || p.inflight >= maxInflight {
+			continue
+		}
+		peers = append(peers, p)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		if peers[i].score != peers[j].score {
+			return peers[i].score > peers[j].score
+		}
+		if peers[i].throughput != peers[j].throughput {
+			return peers[i].throughput > peers[j].throughput
+		}
+		return peers[i].info.ID < peers[j].info.ID
+	})
+	return peers
+}
+
+// reward credits a peer for delivering n blocks or headers in the given duration
+func (pm *peerManager) reward(id string, n uint64, elapsed time.Duration) {
+	p, ok := pm.peers[id]
+	if !ok {
+		return
+	}
+	p.score++
+	p.blocks += n
+	if elapsed <= 0 {
+		elapsed = time.Millisecond
+	}
+	sample := float64(n) / elapsed.Seconds()
+	if p.throughput == 0 {
+		p.throughput = sample
+	} else {
+		p.throughput = throughputWeight*sample + (1-throughputWeight)*p.throughput
+	}
+	peerThroughputMtc.WithLabelValues(id).Set(p.throughput)
+}
+
+// penalize lowers the score of a peer, and bans it if the score drops below minPeerScore
+func (pm *peerManager) penalize(id string, penalty int, reason string) {
+	p, ok := pm.peers[id]
+	if !ok {
+		return
+	}
+	peerEventMtc.WithLabelValues(reason).Inc()
+	p.score -= penalty
+	if p.score < minPeerScore {
+		pm.ban(p, id, reason)
+	}
+}
+
+// banInvalid bans a peer right away for sending invalid headers or blocks
+func (pm *peerManager) banInvalid(id string) {
+	if p, ok := pm.peers[id]; ok {
+		peerEventMtc.WithLabelValues("invalid").Inc()
+		pm.ban(p, id, "invalid")
+	}
+}
+
+func (pm *peerManager) ban(p *syncPeer, id string, reason string) {
+	log.L().Warn("Ban sync peer.", zap.String("peer", id), zap.String("reason", reason))
+	peerEventMtc.WithLabelValues("banned").Inc()
+	p.score = 0
+	p.throughput = 0
+	p.bannedUntil = pm.clock.Now().Add(pm.banDuration)
+	peerThroughputMtc.DeleteLabelValues(id)
+}
+
+// statuses returns the status of the tracked peers
+func (pm *peerManager) statuses() []PeerStatus {
+	now := pm.clock.Now()
+	statuses := make([]PeerStatus, 0, len(pm.peers))
+	for id, p := range pm.peers {
+		statuses = append(statuses, PeerStatus{
+			ID:              id,
+			Score:           p.score,
+			Blocks:          p.blocks,
+			BlocksPerSecond: p.throughput,
+			Banned:          now.Before(p.bannedUntil),
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+	return statuses
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+package blocksync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	peer "github.com/libp2p/go-libp2p-peer"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPeer(id string) peerstore.PeerInfo {
+	return peerstore.PeerInfo{ID: peer.ID(id)}
+}
+
+func TestPeerManager(t *testing.T) {
+	require := require.New(t)
+
+	clk := clock.NewMock()
+	pm := newPeerManager(time.Minute)
+	pm.clock = clk
+	a, b := newTestPeer("a"), newTestPeer("b")
+	aID, bID := a.ID.Pretty(), b.ID.Pretty()
+	pm.update([]peerstore.PeerInfo{a, b})
+	require.Len(pm.available(1), 2)
+
+	// the faster peer comes first
+	pm.reward(aID, 10, time.Second)
+	pm.reward(bID, 100, time.Second)
+	peers := pm.available(1)
+	require.Equal(bID, peers[0].info.ID.Pretty())
+	require.Equal(100.0, peers[0].throughput)
+	pm.reward(bID, 0, time.Second)
+	require.InDelta(70.0, pm.get(bID).throughput, 1e-9)
+
+	// a busy peer isn't available
+	pm.get(bID).inflight = 1
+	peers = pm.available(1)
+	require.Len(peers, 1)
+	require.Equal(aID, peers[0].info.ID.Pretty())
+	pm.get(bID).inflight = 0
+
+	// timeouts lower the score
+	pm.penalize(aID, timeoutPenalty, "timeout")
+	require.Equal(-4, pm.get(aID).score)
+	require.Equal(bID, pm.available(1)[0].info.ID.Pretty())
+
+	// invalid data bans the peer, which isn't forgotten while banned
+	pm.banInvalid(bID)
+	require.Len(pm.available(1), 1)
+	pm.update([]peerstore.PeerInfo{a})
+	require.NotNil(pm.get(bID))
+	statuses := pm.statuses()
+	require.Len(statuses, 2)
+	require.Equal(aID, statuses[0].ID)
+	require.False(statuses[0].Banned)
+	require.Equal(bID, statuses[1].ID)
+	require.True(statuses[1].Banned)
+	require.Equal(uint64(100), statuses[1].Blocks)
+
+	// the ban expires
+	clk.Add(time.Minute)
+	pm.update([]peerstore.PeerInfo{a, b})
+	require.Len(pm.available(1), 2)
+	require.Equal(0, pm.get(bID).score)
+	pm.update([]peerstore.PeerInfo{a})
+	require.Nil(pm.get(bID))
+
+	// unknown peers are ignored
+	pm.reward(bID, 1, time.Second)
+	pm.banInvalid(bID)
+	require.Nil(pm.get(bID))
+}
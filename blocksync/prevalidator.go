@@ -0,0 +1,136 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// prevalidator performs the stateless checks of the buffered blocks in the background, i.e., the block signature,
+// the tx root, the signatures of the endorsements and the actions, so that they are done for the upcoming heights
+// while the current height executes. The block validator skips the block signature and the tx root of a block passing
+// them, and the verified action signatures are put into the signature cache, which lets the block validator skip them
+// later on.
+type prevalidator struct {
+	sigCache *action.SignatureCache
+	workers  chan struct{}
+	mu       sync.Mutex
+	results  map[*block.Block]*prevalidation
+}
+
+// prevalidation is the pending result of the stateless checks of a block
+type prevalidation struct {
+	done chan struct{}
+	err  error
+}
+
+// newPrevalidator creates a prevalidator running at most numWorkers checks at once, or returns nil if numWorkers is
+// 0, which disables the pre-validation
+func newPrevalidator(sigCache *action.SignatureCache, numWorkers int) *prevalidator {
+	if numWorkers <= 0 {
+		return nil
+	}
+	return &prevalidator{
+		sigCache: sigCache,
+		workers:  make(chan struct{}, numWorkers),
+		results:  make(map[*block.Block]*prevalidation),
+	}
+}
+
+// submit starts checking the block in the background
+func (p *prevalidator) submit(blk *block.Block) {
+	if p == nil {
+		return
+	}
+	pv := &prevalidation{done: make(chan struct{})}
+	p.mu.Lock()
+	p.results[blk] = pv
+	p.mu.Unlock()
+	go func() {
+		p.workers <- struct{}{}
+		defer func() {
+			<-p.workers
+			close(pv.done)
+		}()
+		pv.err = p.validate(blk)
+	}()
+}
+
+// wait waits for the checks of the block to finish and returns whether they are done and the result. A block which
+// isn't submitted isn't checked.
+func (p *prevalidator) wait(blk *block.Block) (bool, error) {
+	if p == nil {
+		return false, nil
+	}
+	p.mu.Lock()
+	pv, ok := p.results[blk]
+	delete(p.results, blk)
+	p.mu.Unlock()
+	if !ok {
+		return false, nil
+	}
+	<-pv.done
+	return true, pv.err
+}
+
+// drop discards the result of the block
+func (p *prevalidator) drop(blk *block.Block) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.results, blk)
+}
+
+func (p *prevalidator) validate(blk *block.Block) error {
+	if !blk.VerifySignature() {
+		return errors.Wrapf(
+			blockchain.ErrInvalidBlock,
+			"failed to verify block's signature with public key: %x",
+			blk.PublicKey(),
+		)
+	}
+	if txRoot := blk.CalculateTxRoot(); txRoot != blk.TxRoot() {
+		return errors.Wrapf(
+			blockchain.ErrInvalidBlock,
+			"wrong tx hash %x, expecting %x",
+			txRoot,
+			blk.TxRoot(),
+		)
+	}
+	if err := verifyEndorsementSignatures(blk.HashBlock(), blk.Endorsements()); err != nil {
+		return errors.Wrap(blockchain.ErrInvalidBlock, err.Error())
+	}
+	for _, selp := range blk.Actions {
+		if err := p.sigCache.Verify(selp); err != nil {
+			return errors.Wrapf(err, "failed to verify signature of action %x", selp.Hash())
+		}
+	}
+	return nil
+}
+
+// verifyEndorsementSignatures checks the signatures of the commit endorsements of a block. Whether the endorsers are
+// the delegates isn't checked, which needs the state of the chain.
+func verifyEndorsementSignatures(blkHash hash.Hash256, endorsements []*endorsement.Endorsement) error {
+	vote := rolldpos.NewConsensusVote(blkHash[:], rolldpos.COMMIT)
+	for _, en := range endorsements {
+		if !endorsement.VerifyEndorsement(vote, en) {
+			return errors.Errorf("failed to verify endorsement of %x", en.Endorser().Bytes())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestPrevalidator(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(newPrevalidator(nil, 0))
+
+	sigCache := action.NewSignatureCache(16)
+	p := newPrevalidator(sigCache, 2)
+	selp, err := testutil.SignedTransfer(ta.Addrinfo["bravo"].String(),
+		ta.Keyinfo["alfa"].PriKey, 1, big.NewInt(1), nil, 100000, big.NewInt(0))
+	require.NoError(err)
+	pb := selp.Proto()
+	pb.Signature = append([]byte{}, pb.Signature...)
+	pb.Signature[0] ^= 1
+	tampered := action.SealedEnvelope{}
+	require.NoError(tampered.LoadProto(pb))
+
+	newBlock := func(height uint64, acts ...action.SealedEnvelope) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(acts...).
+			SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+		require.NoError(err)
+		return &blk
+	}
+
+	// the verified action signatures are cached
+	blk1 := newBlock(1, selp)
+	p.submit(blk1)
+	done, err := p.wait(blk1)
+	require.True(done)
+	require.NoError(err)
+	require.Equal(1, sigCache.Len())
+	// the result is consumed by wait
+	done, err = p.wait(blk1)
+	require.False(done)
+	require.NoError(err)
+
+	blk2 := newBlock(2, tampered)
+	p.submit(blk2)
+	_, err = p.wait(blk2)
+	require.Error(err)
+	require.Equal(1, sigCache.Len())
+
+	blk3 := newBlock(3)
+	blk3.Actions = append(blk3.Actions, selp)
+	p.submit(blk3)
+	_, err = p.wait(blk3)
+	require.Equal(blockchain.ErrInvalidBlock, errors.Cause(err))
+
+	// a dropped block is not checked
+	blk4 := newBlock(4, tampered)
+	p.submit(blk4)
+	p.drop(blk4)
+	done, err = p.wait(blk4)
+	require.False(done)
+	require.NoError(err)
+
+	// the endorsements must be signed on the block
+	blks := newTestBlocks(t, 4, hash.ZeroHash256, 2)
+	p.submit(blks[0])
+	done, err = p.wait(blks[0])
+	require.True(done)
+	require.NoError(err)
+	blks[1].Footer = blks[0].Footer
+	p.submit(blks[1])
+	_, err = p.wait(blks[1])
+	require.Equal(blockchain.ErrInvalidBlock, errors.Cause(err))
+}
@@ -7,18 +7,33 @@
 package blocksync
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/consensus"
 )
 
-func commitBlock(bc blockchain.Blockchain, ap actpool.ActPool, cs consensus.Consensus, blk *block.Block) error {
-	if err := cs.ValidateBlockFooter(blk); err != nil {
+func commitBlock(
+	bc blockchain.Blockchain,
+	ap actpool.ActPool,
+	cs consensus.Consensus,
+	blk *block.Block,
+	opts ...blockchain.ValidateOption,
+) error {
+	// the endorsements are checked against the delegates and the last block of the chain, so they are validated along
+	// with the execution of the block instead of ahead of time
+	footerErr := make(chan error, 1)
+	go func() {
+		footerErr <- cs.ValidateBlockFooter(blk)
+	}()
+	if err := bc.ValidateBlock(blk, opts...); err != nil {
+		<-footerErr
 		return err
 	}
-	if err := bc.ValidateBlock(blk); err != nil {
-		return err
+	if err := <-footerErr; err != nil {
+		return errors.Wrap(err, "failed to validate block footer")
 	}
 	if err := bc.CommitBlock(blk); err != nil {
 		return err
@@ -8,12 +8,16 @@ package blocksync
 
 import (
 	"context"
-	"math/rand"
 	"sync"
+	"time"
 
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/routine"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
@@ -24,6 +28,18 @@ type syncBlocksInterval struct {
 	End   uint64
 }
 
+// syncRequest is a block sync request waiting for the response
+type syncRequest struct {
+	peer    string
+	start   uint64
+	end     uint64
+	sent    time.Time
+	pending uint64
+}
+
+// syncWorker syncs the blocks headers-first. The headers up to the target height are fetched from the best peer and
+// validated, then the bodies of the blocks are downloaded concurrently from multiple peers and matched against the
+// validated headers. The peers which time out or send invalid data are scored down and eventually banned.
 type syncWorker struct {
 	chainID          uint32
 	mu               sync.RWMutex
@@ -32,8 +48,22 @@ type syncWorker struct {
 	neighborsHandler Neighbors
 	buf              *blockBuffer
 	task             *routine.RecurringTask
-	maxRepeat        int
-	repeatDecayStep  int
+	clock            clock.Clock
+	trigger          chan struct{}
+	quit             chan struct{}
+
+	peers      *peerManager
+	headers    *headerChain
+	headerReq  *syncRequest
+	bodyReqs   map[uint64]*syncRequest
+	senders    map[uint64]string
+	timeout    time.Duration
+	maxHeaders uint64
+	maxReqs    int
+
+	// the start of the current sync session, for estimating the progress
+	startHeight uint64
+	startTime   time.Time
 }
 
 func newSyncWorker(
@@ -49,8 +79,15 @@ func newSyncWorker(
 		neighborsHandler: neighborsHandler,
 		buf:              buf,
 		targetHeight:     0,
-		maxRepeat:        cfg.BlockSync.MaxRepeat,
-		repeatDecayStep:  cfg.BlockSync.RepeatDecayStep,
+		clock:            clock.New(),
+		trigger:          make(chan struct{}, 1),
+		peers:            newPeerManager(cfg.BlockSync.PeerBanDuration),
+		headers:          &headerChain{},
+		bodyReqs:         make(map[uint64]*syncRequest),
+		senders:          make(map[uint64]string),
+		timeout:          cfg.BlockSync.RequestTimeout,
+		maxHeaders:       cfg.BlockSync.MaxHeadersPerRequest,
+		maxReqs:          cfg.BlockSync.MaxRequestsPerPeer,
 	}
 	if cfg.BlockSync.Interval != 0 {
 		w.task = routine.NewRecurringTask(w.Sync, cfg.BlockSync.Interval)
@@ -59,17 +96,46 @@ func newSyncWorker(
 }
 
 func (w *syncWorker) Start(ctx context.Context) error {
-	if w.task != nil {
-		return w.task.Start(ctx)
+	if w.task == nil {
+		return nil
 	}
-	return nil
+	// besides the recurring sync, the next requests are sent as soon as the previous ones are answered
+	w.mu.Lock()
+	quit := make(chan struct{})
+	w.quit = quit
+	w.mu.Unlock()
+	go func() {
+		for {
+			select {
+			case <-w.trigger:
+				w.Sync()
+			case <-quit:
+				return
+			}
+		}
+	}()
+	return w.task.Start(ctx)
 }
 
 func (w *syncWorker) Stop(ctx context.Context) error {
-	if w.task != nil {
-		return w.task.Stop(ctx)
+	if w.task == nil {
+		return nil
+	}
+	w.mu.Lock()
+	if w.quit != nil {
+		close(w.quit)
+		w.quit = nil
+	}
+	w.mu.Unlock()
+	return w.task.Stop(ctx)
+}
+
+// notify schedules a sync without waiting for the next round
+func (w *syncWorker) notify() {
+	select {
+	case w.trigger <- struct{}{}:
+	default:
 	}
-	return nil
 }
 
 func (w *syncWorker) SetTargetHeight(h uint64) {
@@ -80,7 +146,7 @@ func (w *syncWorker) SetTargetHeight(h uint64) {
 	}
 }
 
-// Sync checks the sliding window and send more sync request if needed
+// Sync expires the timed out requests, and sends the header and block requests needed to reach the target height
 func (w *syncWorker) Sync() {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -95,26 +161,298 @@ func (w *syncWorker) Sync() {
 		log.L().Warn("Error when get neighbor peers.", zap.Error(err))
 		return
 	}
-	intervals := w.buf.GetBlocksIntervalsToSync(w.targetHeight)
+	w.peers.update(peers)
+	now := w.clock.Now()
+	tip := w.buf.bc.TipHeight()
+	for _, blk := range w.buf.drainRejected() {
+		w.blockRejected(blk)
+	}
+	for h := range w.senders {
+		if h <= tip {
+			delete(w.senders, h)
+		}
+	}
+	w.headers.rebase(tip, w.buf.bc.TipHash())
+	w.updateSession(tip, now)
+	w.expire(now)
+	w.requestHeaders(ctx, now)
+	w.requestBodies(ctx, tip, now)
+	w.updateMetrics(tip, now)
+}
+
+// updateSession starts a sync session when the chain falls behind the target, and ends it once caught up
+func (w *syncWorker) updateSession(tip uint64, now time.Time) {
+	switch {
+	case tip >= w.targetHeight:
+		w.startTime = time.Time{}
+	case w.startTime.IsZero():
+		w.startHeight = tip
+		w.startTime = now
+	}
+}
+
+// expire drops the requests which aren't answered in time, so that they are sent to other peers
+func (w *syncWorker) expire(now time.Time) {
+	if req := w.headerReq; req != nil && now.Sub(req.sent) > w.timeout {
+		w.headerReq = nil
+		w.finishRequest(req)
+		w.peers.penalize(req.peer, timeoutPenalty, "timeout")
+	}
+	expired := make(map[*syncRequest]bool)
+	for h, req := range w.bodyReqs {
+		if now.Sub(req.sent) > w.timeout {
+			delete(w.bodyReqs, h)
+			expired[req] = true
+		}
+	}
+	for req := range expired {
+		w.finishRequest(req)
+		w.peers.penalize(req.peer, timeoutPenalty, "timeout")
+	}
+}
+
+// requestHeaders asks the best peer for the headers following the header chain
+func (w *syncWorker) requestHeaders(ctx context.Context, now time.Time) {
+	if w.headerReq != nil || w.headers.blocked || w.headers.tip() >= w.targetHeight {
+		return
+	}
+	for _, p := range w.peers.available(w.maxReqs) {
+		if p.noHeaders {
+			continue
+		}
+		start := w.headers.tip() + 1
+		end := w.targetHeight
+		if end-start+1 > w.maxHeaders {
+			end = start + w.maxHeaders - 1
+		}
+		req := &syncRequest{peer: p.info.ID.Pretty(), start: start, end: end, sent: now, pending: end - start + 1}
+		if !w.send(ctx, p, req, true) {
+			continue
+		}
+		w.headerReq = req
+		return
+	}
+}
+
+// requestBodies distributes the missing blocks up to the header chain among the available peers. Without validated
+// headers ahead, e.g., when the peers don't serve headers, the blocks up to the target height are requested.
+func (w *syncWorker) requestBodies(ctx context.Context, tip uint64, now time.Time) {
+	target := w.headers.tip()
+	if target <= tip {
+		target = w.targetHeight
+	}
+	peers := w.peers.available(w.maxReqs)
+	if len(peers) == 0 {
+		return
+	}
+	intervals := w.buf.GetBlocksIntervalsToSync(target)
 	if intervals != nil {
-		log.L().Info("block sync intervals.",
+		log.L().Debug("block sync intervals.",
 			zap.Any("intervals", intervals),
+			zap.Uint64("headerHeight", w.headers.tip()),
 			zap.Uint64("targetHeight", w.targetHeight))
 	}
+	next := 0
+	for _, interval := range w.splitInflight(intervals) {
+		p := w.pick(peers, &next)
+		if p == nil {
+			return
+		}
+		req := &syncRequest{
+			peer:    p.info.ID.Pretty(),
+			start:   interval.Start,
+			end:     interval.End,
+			sent:    now,
+			pending: interval.End - interval.Start + 1,
+		}
+		if w.send(ctx, p, req, false) {
+			for h := interval.Start; h <= interval.End; h++ {
+				w.bodyReqs[h] = req
+			}
+		}
+	}
+}
 
-	for i, interval := range intervals {
-		repeat := w.maxRepeat - i/w.repeatDecayStep
-		if repeat <= 0 {
-			repeat = 1
+// pick returns the next peer with a free request slot in round robin, or nil if all are busy
+func (w *syncWorker) pick(peers []*syncPeer, next *int) *syncPeer {
+	for i := 0; i < len(peers); i++ {
+		p := peers[(*next+i)%len(peers)]
+		if p.inflight < w.maxReqs {
+			*next = (*next + i + 1) % len(peers)
+			return p
 		}
-		for j := 0; j < repeat; j++ {
-			rrIdx := rand.Intn(len(peers))
-			p := peers[rrIdx]
-			if err := w.unicastHandler(ctx, p, &iotexrpc.BlockSync{
-				Start: interval.Start, End: interval.End,
-			}); err != nil {
-				log.L().Debug("Failed to sync block.", zap.Error(err))
+	}
+	return nil
+}
+
+// splitInflight removes the heights being requested from the intervals
+func (w *syncWorker) splitInflight(intervals []syncBlocksInterval) []syncBlocksInterval {
+	var out []syncBlocksInterval
+	for _, interval := range intervals {
+		start := interval.Start
+		for h := interval.Start; h <= interval.End; h++ {
+			if _, ok := w.bodyReqs[h]; !ok {
+				continue
 			}
+			if h > start {
+				out = append(out, syncBlocksInterval{Start: start, End: h - 1})
+			}
+			start = h + 1
+		}
+		if start <= interval.End {
+			out = append(out, syncBlocksInterval{Start: start, End: interval.End})
 		}
 	}
+	return out
+}
+
+func (w *syncWorker) send(ctx context.Context, p *syncPeer, req *syncRequest, headers bool) bool {
+	if err := w.unicastHandler(ctx, p.info, &iotexrpc.BlockSync{
+		Start: req.start, End: req.end, Headers: headers,
+	}); err != nil {
+		log.L().Debug("Failed to sync block.", zap.Error(err))
+		return false
+	}
+	p.inflight++
+	return true
+}
+
+// finishRequest releases the slot of the request at its peer
+func (w *syncWorker) finishRequest(req *syncRequest) {
+	if p := w.peers.get(req.peer); p != nil && p.inflight > 0 {
+		p.inflight--
+	}
+}
+
+// BlockReceived checks a block sent by a peer against the validated header, and credits the peer if the block
+// answers its request. It returns false if the block is invalid and should be dropped.
+func (w *syncWorker) BlockReceived(peer string, blk *block.Block) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	height := blk.Height()
+	if sh := w.headers.get(height); sh != nil && sh.hash != blk.HashBlock() {
+		w.peers.banInvalid(peer)
+		log.L().Warn("Drop block mismatching the header.", zap.String("peer", peer), zap.Uint64("height", height))
+		return false
+	}
+	if blk.CalculateTxRoot() != blk.TxRoot() {
+		w.peers.banInvalid(peer)
+		log.L().Warn("Drop block with wrong tx root.", zap.String("peer", peer), zap.Uint64("height", height))
+		return false
+	}
+	w.senders[height] = peer
+	// a peer not serving headers answers the header request with blocks
+	if req := w.headerReq; req != nil && req.peer == peer && height >= req.start && height <= req.end {
+		w.headerReq = nil
+		w.finishRequest(req)
+		if p := w.peers.get(peer); p != nil {
+			p.noHeaders = true
+		}
+	}
+	req, ok := w.bodyReqs[height]
+	if !ok || req.peer != peer {
+		return true
+	}
+	delete(w.bodyReqs, height)
+	req.pending--
+	if req.pending == 0 {
+		w.finishRequest(req)
+		w.peers.reward(peer, req.end-req.start+1, w.clock.Now().Sub(req.sent))
+		w.notify()
+	}
+	return true
+}
+
+// HeadersReceived extends the header chain with the headers answering the header request
+func (w *syncWorker) HeadersReceived(peer string, pb *iotexrpc.BlockHeaders) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	req := w.headerReq
+	if req == nil || req.peer != peer {
+		log.L().Debug("Drop unrequested headers.", zap.String("peer", peer))
+		return
+	}
+	w.headerReq = nil
+	w.finishRequest(req)
+	defer w.notify()
+	headers, footers, err := convertFromBlockHeadersPb(pb)
+	if err == nil {
+		err = w.headers.extend(headers, footers, w.buf.cs)
+	}
+	if errors.Cause(err) == scheme.ErrNotVerifiable {
+		// the headers up to the unknown delegates are kept, and the rest are fetched once the chain grows
+		log.L().Debug("Stop extending headers.", zap.String("peer", peer), zap.Error(err))
+		w.peers.reward(peer, uint64(len(headers)), w.clock.Now().Sub(req.sent))
+		return
+	}
+	if err != nil {
+		w.peers.banInvalid(peer)
+		log.L().Warn("Drop invalid headers.", zap.String("peer", peer), zap.Error(err))
+		return
+	}
+	if len(headers) == 0 {
+		// the peer is behind the target
+		w.peers.penalize(peer, timeoutPenalty, "empty")
+		return
+	}
+	w.peers.reward(peer, uint64(len(headers)), w.clock.Now().Sub(req.sent))
+}
+
+// blockRejected bans the peer which sent a block failing validation, and resets the header chain if the block
+// matches it, since the peer which sent the headers lied too
+func (w *syncWorker) blockRejected(blk *block.Block) {
+	height := blk.Height()
+	if peer, ok := w.senders[height]; ok {
+		delete(w.senders, height)
+		w.peers.banInvalid(peer)
+	}
+	if sh := w.headers.get(height); sh != nil && sh.hash == blk.HashBlock() {
+		w.headers.reset(w.headers.base, w.headers.baseHash)
+	}
+}
+
+// Status returns the sync progress
+func (w *syncWorker) Status() SyncStatus {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	tip := w.buf.bc.TipHeight()
+	status := SyncStatus{
+		StartHeight:  tip,
+		TipHeight:    tip,
+		HeaderHeight: w.headers.tip(),
+		TargetHeight: w.targetHeight,
+		Peers:        w.peers.statuses(),
+	}
+	if status.HeaderHeight < tip {
+		status.HeaderHeight = tip
+	}
+	if w.startTime.IsZero() || tip >= w.targetHeight {
+		return status
+	}
+	status.StartHeight = w.startHeight
+	status.BlocksPerSecond, status.ETA = progress(w.startHeight, tip, w.targetHeight, w.clock.Now().Sub(w.startTime))
+	return status
+}
+
+// progress estimates the sync rate and the remaining time
+func progress(start, tip, target uint64, elapsed time.Duration) (float64, time.Duration) {
+	if tip <= start || elapsed <= 0 {
+		return 0, 0
+	}
+	rate := float64(tip-start) / elapsed.Seconds()
+	return rate, time.Duration(float64(target-tip) / rate * float64(time.Second))
+}
+
+func (w *syncWorker) updateMetrics(tip uint64, now time.Time) {
+	syncHeightMtc.WithLabelValues("tip").Set(float64(tip))
+	syncHeightMtc.WithLabelValues("header").Set(float64(w.headers.tip()))
+	syncHeightMtc.WithLabelValues("target").Set(float64(w.targetHeight))
+	var eta time.Duration
+	if !w.startTime.IsZero() {
+		_, eta = progress(w.startHeight, tip, w.targetHeight, now.Sub(w.startTime))
+	}
+	syncETAMtc.Set(eta.Seconds())
 }
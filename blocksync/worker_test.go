@@ -0,0 +1,134 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+package blocksync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
+)
+
+func TestSyncWorker(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	baseHash := hash.Hash256b([]byte("base"))
+	blks := newTestBlocks(t, 10, baseHash, 5)
+	mBc := mock_blockchain.NewMockBlockchain(ctrl)
+	mBc.EXPECT().TipHeight().Return(uint64(10)).AnyTimes()
+	mBc.EXPECT().TipHash().Return(baseHash).AnyTimes()
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().ValidateHeaderFooter(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	a, b := newTestPeer("a"), newTestPeer("b")
+	aID, bID := a.ID.Pretty(), b.ID.Pretty()
+	requests := make(map[string][]*iotexrpc.BlockSync)
+	unicast := func(_ context.Context, p peerstore.PeerInfo, msg proto.Message) error {
+		requests[p.ID.Pretty()] = append(requests[p.ID.Pretty()], msg.(*iotexrpc.BlockSync))
+		return nil
+	}
+	neighbors := func(_ context.Context) ([]peerstore.PeerInfo, error) {
+		return []peerstore.PeerInfo{a, b}, nil
+	}
+	buf := &blockBuffer{
+		blocks:       make(map[uint64]*block.Block),
+		bc:           mBc,
+		cs:           cs,
+		bufferSize:   16,
+		intervalSize: 2,
+	}
+	cfg := config.Default
+	cfg.BlockSync.Interval = 0
+	w := newSyncWorker(1, cfg, unicast, neighbors, buf)
+	clk := clock.NewMock()
+	w.clock = clk
+	w.peers.clock = clk
+	w.SetTargetHeight(15)
+
+	// the headers are requested from the best peer, and the blocks are spread among the peers
+	w.Sync()
+	require.Equal([]*iotexrpc.BlockSync{
+		{Start: 11, End: 15, Headers: true},
+		{Start: 11, End: 12},
+	}, requests[aID])
+	require.Equal([]*iotexrpc.BlockSync{
+		{Start: 13, End: 14},
+		{Start: 15, End: 15},
+	}, requests[bID])
+
+	// the requests in flight aren't sent again
+	w.Sync()
+	require.Len(requests[aID], 2)
+	require.Len(requests[bID], 2)
+
+	// the validated headers extend the header chain
+	pb := &iotexrpc.BlockHeaders{}
+	for _, blk := range blks {
+		pb.Headers = append(pb.Headers, blk.ConvertToBlockHeaderPb())
+		footer, err := blk.ConvertToBlockFooterPb()
+		require.NoError(err)
+		pb.Footers = append(pb.Footers, footer)
+	}
+	w.HeadersReceived(bID, pb)
+	require.Equal(uint64(10), w.headers.tip())
+	w.HeadersReceived(aID, pb)
+	require.Equal(uint64(15), w.headers.tip())
+	require.Equal(1, w.peers.get(aID).score)
+
+	// a block matching the header is accepted, and the request is credited once fully answered
+	require.True(w.BlockReceived(aID, blks[0]))
+	require.Equal(1, w.peers.get(aID).inflight)
+	require.True(w.BlockReceived(aID, blks[1]))
+	buf.blocks[11], buf.blocks[12] = blks[0], blks[1]
+	require.Equal(0, w.peers.get(aID).inflight)
+	require.Equal(2, w.peers.get(aID).score)
+
+	// a block mismatching the header bans the peer
+	forged := newTestBlocks(t, 12, blks[1].HashBlock(), 1)
+	require.False(w.BlockReceived(bID, forged[0]))
+	require.True(w.peers.get(bID).bannedUntil.After(clk.Now()))
+
+	// the timed out requests are sent to the other peers
+	clk.Add(cfg.BlockSync.RequestTimeout + time.Second)
+	w.Sync()
+	require.Equal([]*iotexrpc.BlockSync{
+		{Start: 13, End: 14},
+		{Start: 15, End: 15},
+	}, requests[aID][2:])
+	require.Len(requests[bID], 2)
+
+	status := w.Status()
+	require.Equal(uint64(10), status.TipHeight)
+	require.Equal(uint64(15), status.HeaderHeight)
+	require.Equal(uint64(15), status.TargetHeight)
+	require.Len(status.Peers, 2)
+	require.True(status.Peers[1].Banned)
+}
+
+func TestSyncProgress(t *testing.T) {
+	require := require.New(t)
+
+	rate, eta := progress(10, 10, 100, time.Second)
+	require.Zero(rate)
+	require.Zero(eta)
+	rate, eta = progress(10, 30, 100, 10*time.Second)
+	require.Equal(2.0, rate)
+	require.Equal(35*time.Second, eta)
+}
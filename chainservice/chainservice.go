@@ -28,6 +28,7 @@ import (
 	"github.com/iotexproject/iotex-core/blocksync"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus"
+	dpos "github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/explorer"
@@ -37,6 +38,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 // ChainService is a blockchain service with all blockchain components.
@@ -50,8 +52,11 @@ type ChainService struct {
 	explorer          *explorer.Server
 	api               *api.Server
 	indexBuilder      *blockchain.IndexBuilder
+	reindexStart      uint64
+	reindexEnd        uint64
 	indexservice      *indexservice.Server
 	registry          *protocol.Registry
+	sigCache          *action.SignatureCache
 }
 
 type optionParams struct {
@@ -94,6 +99,10 @@ func New(
 		}
 	}
 
+	producerSigner, err := newSigner(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the signer of the block producer")
+	}
 	var chainOpts []blockchain.Option
 	if ops.isTesting {
 		chainOpts = []blockchain.Option{
@@ -107,7 +116,7 @@ func New(
 		}
 	}
 	registry := protocol.Registry{}
-	chainOpts = append(chainOpts, blockchain.RegistryOption(&registry))
+	chainOpts = append(chainOpts, blockchain.RegistryOption(&registry), blockchain.SignerOption(producerSigner))
 	var electionCommittee committee.Committee
 	if cfg.Genesis.EnableGravityChainVoting {
 		committeeConfig := cfg.Chain.Committee
@@ -146,6 +155,7 @@ func New(
 		chainOpts = []blockchain.Option{
 			blockchain.DefaultStateFactoryOption(),
 			blockchain.BoltDBDaoOption(),
+			blockchain.SignerOption(producerSigner),
 		}
 		if cfg.System.EnableExperimentalActions {
 			chainOpts = append(chainOpts, blockchain.EnableExperimentalActions())
@@ -154,10 +164,13 @@ func New(
 	}
 
 	var indexBuilder *blockchain.IndexBuilder
-	if _, ok := cfg.Plugins[config.GatewayPlugin]; ok && cfg.Chain.EnableAsyncIndexWrite {
+	_, gateway := cfg.Plugins[config.GatewayPlugin]
+	if gateway && (cfg.Chain.EnableAsyncIndexWrite || cfg.Chain.ReindexStartHeight > 0) {
 		if indexBuilder, err = blockchain.NewIndexBuilder(chain); err != nil {
 			return nil, errors.Wrap(err, "failed to create index builder")
 		}
+	}
+	if gateway && cfg.Chain.EnableAsyncIndexWrite {
 		if err := chain.AddSubscriber(indexBuilder); err != nil {
 			log.L().Warn("Failed to add subscriber: index builder.", zap.Error(err))
 		}
@@ -182,14 +195,38 @@ func New(
 			return p2pAgent.BroadcastOutbound(p2p.WitContext(context.Background(), p2p.Context{ChainID: chain.ChainID()}), msg)
 		}),
 		consensus.WithRollDPoSProtocol(rDPoSProtocol),
+		consensus.WithSigner(producerSigner),
 	}
 	if ops.rootChainAPI != nil {
 		copts = append(copts, consensus.WithRootChainAPI(ops.rootChainAPI))
 	}
+	var (
+		evidencePool *dpos.EvidencePool
+		timeline     *dpos.Timeline
+	)
+	if cfg.Consensus.Scheme == config.RollDPoSScheme {
+		evidenceDB := db.NewMemKVStore()
+		if cfg.Consensus.RollDPoS.EvidenceDBPath != "" && !ops.isTesting {
+			dbCfg := cfg.DB
+			dbCfg.DbPath = cfg.Consensus.RollDPoS.EvidenceDBPath
+			evidenceDB = db.NewOnDiskDB(dbCfg)
+		}
+		evidencePool = dpos.NewEvidencePool(evidenceDB)
+		timeline = dpos.NewTimeline(cfg.Consensus.RollDPoS.TimelineSize, cfg.Consensus.RollDPoS.FSM)
+		copts = append(
+			copts,
+			consensus.WithEvidencePool(evidencePool),
+			consensus.WithTimeline(timeline),
+		)
+	}
 	consensus, err := consensus.NewConsensus(cfg, chain, actPool, copts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create consensus")
 	}
+	var sigCache *action.SignatureCache
+	if cfg.Chain.SignatureCacheSize > 0 {
+		sigCache = action.NewSignatureCache(cfg.Chain.SignatureCacheSize)
+	}
 	bs, err := blocksync.NewBlockSyncer(
 		cfg,
 		chain,
@@ -200,6 +237,7 @@ func New(
 			return p2pAgent.UnicastOutbound(ctx, peer, msg)
 		}),
 		blocksync.WithNeighbors(p2pAgent.Neighbors),
+		blocksync.WithSignatureCache(sigCache),
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create blockSyncer")
@@ -242,6 +280,10 @@ func New(
 			actPool,
 			idx,
 			&registry,
+			api.WithBlockSync(bs),
+			api.WithEvidencePool(evidencePool),
+			api.WithTimeline(timeline),
+			api.WithGenesis(cfg.Genesis),
 			api.WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
 				ctx = p2p.WitContext(ctx, p2p.Context{ChainID: chainID})
 				return p2pAgent.BroadcastOutbound(ctx, msg)
@@ -261,9 +303,12 @@ func New(
 		electionCommittee: electionCommittee,
 		indexservice:      idx,
 		indexBuilder:      indexBuilder,
+		reindexStart:      cfg.Chain.ReindexStartHeight,
+		reindexEnd:        cfg.Chain.ReindexEndHeight,
 		explorer:          exp,
 		api:               apiSvr,
 		registry:          &registry,
+		sigCache:          sigCache,
 	}, nil
 }
 
@@ -282,6 +327,21 @@ func (cs *ChainService) Start(ctx context.Context) error {
 	if err := cs.chain.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting blockchain")
 	}
+	if cs.reindexStart > 0 {
+		// rebuild the indexes before any new block is committed
+		if err := cs.indexBuilder.Reindex(ctx, cs.reindexStart, cs.reindexEnd); err != nil {
+			return errors.Wrap(err, "error when reindexing blocks")
+		}
+	}
+	if cs.indexservice != nil {
+		// the range of the blocks missed by the index service is taken before any new block is committed
+		if err := cs.indexservice.StartBackfill(ctx); err != nil {
+			return errors.Wrap(err, "error when back-filling indexservice")
+		}
+	}
+	if err := cs.actpool.Start(ctx); err != nil {
+		return errors.Wrap(err, "error when starting actpool")
+	}
 	if err := cs.consensus.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting consensus")
 	}
@@ -334,6 +394,9 @@ func (cs *ChainService) Stop(ctx context.Context) error {
 	if err := cs.blocksync.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping blocksync")
 	}
+	if err := cs.actpool.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping actpool")
+	}
 	if err := cs.chain.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping blockchain")
 	}
@@ -358,13 +421,22 @@ func (cs *ChainService) HandleBlock(ctx context.Context, pbBlock *iotextypes.Blo
 	return cs.blocksync.ProcessBlock(ctx, blk)
 }
 
-// HandleBlockSync handles incoming block sync request.
-func (cs *ChainService) HandleBlockSync(ctx context.Context, pbBlock *iotextypes.Block) error {
+// HandleBlockSync handles incoming block sent in response to a block sync request.
+func (cs *ChainService) HandleBlockSync(ctx context.Context, peer peerstore.PeerInfo, pbBlock *iotextypes.Block) error {
 	blk := &block.Block{}
 	if err := blk.ConvertFromBlockPb(pbBlock); err != nil {
 		return err
 	}
-	return cs.blocksync.ProcessBlockSync(ctx, blk)
+	return cs.blocksync.ProcessBlockSync(ctx, peer, blk)
+}
+
+// HandleBlockHeaders handles incoming block headers sent in response to a block sync request.
+func (cs *ChainService) HandleBlockHeaders(
+	ctx context.Context,
+	peer peerstore.PeerInfo,
+	headers *iotexrpc.BlockHeaders,
+) error {
+	return cs.blocksync.ProcessBlockHeaders(ctx, peer, headers)
 }
 
 // HandleSyncRequest handles incoming sync request.
@@ -390,6 +462,11 @@ func (cs *ChainService) ActionPool() actpool.ActPool {
 	return cs.actpool
 }
 
+// SignatureCache returns the cache of verified action signatures, which is shared by the action validators
+func (cs *ChainService) SignatureCache() *action.SignatureCache {
+	return cs.sigCache
+}
+
 // Consensus returns the consensus instance
 func (cs *ChainService) Consensus() consensus.Consensus {
 	return cs.consensus
@@ -433,3 +510,27 @@ func (cs *ChainService) RegisterProtocol(id string, p protocol.Protocol) error {
 
 // Registry returns a pointer to the registry
 func (cs *ChainService) Registry() *protocol.Registry { return cs.registry }
+
+// newSigner creates the signer of the block producer from config
+func newSigner(cfg config.Config) (signer.Signer, error) {
+	switch cfg.Signer.Type {
+	case config.KeystoreSigner:
+		return signer.NewKeystore(cfg.Signer.KeystorePath, cfg.Signer.KeystorePassword)
+	case config.RemoteSigner:
+		tlsCfg, err := signer.NewClientTLSConfig(
+			cfg.Signer.RemoteClientCertFile,
+			cfg.Signer.RemoteClientKeyFile,
+			cfg.Signer.RemoteCACertFile,
+		)
+		if err != nil {
+			return nil, err
+		}
+		s, err := signer.NewRemote(cfg.Signer.RemoteAddr, cfg.Signer.RemoteTimeout, tlsCfg)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	default:
+		return signer.NewInProcess(cfg.ProducerPrivateKey()), nil
+	}
+}
@@ -94,8 +94,12 @@ func getActionByHash(args []string) (string, error) {
 		}
 		return "", err
 	}
-	return "\n#This action has been written on blockchain\n" +
-		printReceiptProto(responseReceipt.ReceiptInfo.Receipt), nil
+	output = "\n#This action has been written on blockchain\n" +
+		printReceiptProto(responseReceipt.ReceiptInfo.Receipt)
+	if len(responseReceipt.ReceiptInfo.RevertReason) != 0 {
+		output += fmt.Sprintf("\nrevertReason: %s", responseReceipt.ReceiptInfo.RevertReason)
+	}
+	return output, nil
 }
 
 func printAction(actionInfo *iotexapi.ActionInfo) (string, error) {
@@ -187,6 +191,9 @@ func printReceiptProto(receipt *iotextypes.Receipt) string {
 		output += fmt.Sprintf("\ncontractAddress: %s %s", receipt.ContractAddress,
 			Match(receipt.ContractAddress, "address"))
 	}
+	if len(receipt.RevertData) != 0 {
+		output += fmt.Sprintf("\nrevertData: %x", receipt.RevertData)
+	}
 	return output
 }
 
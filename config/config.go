@@ -10,6 +10,7 @@ import (
 	"flag"
 	"math/big"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/ratelimit"
 	"github.com/iotexproject/iotex-core/pkg/unit"
 )
 
@@ -36,6 +38,8 @@ func init() {
 	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
 	flag.StringVar(&_subChainPath, "sub-config-path", "", "Sub chain Config path")
 	flag.Var(&_plugins, "plugin", "Plugin of the node")
+	flag.StringVar(&_reindex, "reindex", "", "Rebuild the action, receipt and address indexes of the blocks in "+
+		"[start:end] before the node starts, end is optional and defaults to the tip height")
 }
 
 var (
@@ -45,6 +49,7 @@ var (
 	_secretPath   string
 	_subChainPath string
 	_plugins      strs
+	_reindex      string
 )
 
 const (
@@ -54,16 +59,18 @@ const (
 	StandaloneScheme = "STANDALONE"
 	// NOOPScheme means that the node does not create only block
 	NOOPScheme = "NOOP"
-	// IndexTransfer is table identifier for transfer index in indexer
-	IndexTransfer = "transfer"
-	// IndexVote is table identifier for vote index in indexer
-	IndexVote = "vote"
-	// IndexExecution is table identifier for execution index in indexer
-	IndexExecution = "execution"
-	// IndexAction is table identifier for action index in indexer
-	IndexAction = "action"
-	// IndexReceipt is table identifier for receipt index in indexer
-	IndexReceipt = "receipt"
+	// BoltDBBackend is the KV store backend based on BoltDB
+	BoltDBBackend = "bolt"
+	// BadgerDBBackend is the KV store backend based on BadgerDB
+	BadgerDBBackend = "badger"
+	// LevelDBBackend is the LSM-tree KV store backend based on LevelDB
+	LevelDBBackend = "leveldb"
+	// InProcessSigner signs with the producer private key in the config
+	InProcessSigner = "INPROCESS"
+	// KeystoreSigner signs with the producer private key decrypted from a keystore file
+	KeystoreSigner = "KEYSTORE"
+	// RemoteSigner signs with a remote signer, which holds the producer private key
+	RemoteSigner = "REMOTE"
 )
 
 const (
@@ -114,14 +121,32 @@ var (
 			CompressBlock:           false,
 			AllowedBlockGasResidue:  10000,
 			MaxCacheSize:            0,
+			BlockRetentionHeight:    0,
+			EnableArchiveMode:       false,
+			TrieRetentionHeight:     0,
+			SignatureCacheSize:      100000,
 		},
 		ActPool: ActPool{
-			MaxNumActsPerPool:  32000,
-			MaxGasLimitPerPool: 320000000,
-			MaxNumActsPerAcct:  2000,
-			ActionExpiry:       10 * time.Minute,
-			MinGasPriceStr:     big.NewInt(unit.Qev).String(),
-			BlackList:          []string{},
+			MaxNumActsPerPool:      32000,
+			MaxGasLimitPerPool:     320000000,
+			MaxNumActsPerAcct:      2000,
+			ActionExpiry:           10 * time.Minute,
+			MinGasPriceStr:         big.NewInt(unit.Qev).String(),
+			BlackList:              []string{},
+			PriceBumpPercent:       10,
+			JournalRewriteInterval: time.Hour,
+			SenderRateLimit: ratelimit.Config{
+				Rate:         10,
+				Burst:        500,
+				BanThreshold: 500,
+				BanDuration:  10 * time.Minute,
+			},
+			PeerRateLimit: ratelimit.Config{
+				Rate:         200,
+				Burst:        2000,
+				BanThreshold: 2000,
+				BanDuration:  10 * time.Minute,
+			},
 		},
 		Consensus: Consensus{
 			Scheme: StandaloneScheme,
@@ -137,14 +162,20 @@ var (
 				},
 				ToleratedOvertime: 2 * time.Second,
 				Delay:             5 * time.Second,
+				EvidenceDBPath:    "./evidence.db",
+				WALPath:           "./consensus.wal",
+				TimelineSize:      256,
 			},
 		},
 		BlockSync: BlockSync{
-			Interval:        10 * time.Second,
-			BufferSize:      100,
-			IntervalSize:    10,
-			MaxRepeat:       3,
-			RepeatDecayStep: 1,
+			Interval:             10 * time.Second,
+			BufferSize:           100,
+			IntervalSize:         10,
+			RequestTimeout:       5 * time.Second,
+			MaxHeadersPerRequest: 500,
+			MaxRequestsPerPeer:   2,
+			PeerBanDuration:      10 * time.Minute,
+			ValidationWorkers:    4,
 		},
 		Dispatcher: Dispatcher{
 			EventChanSize: 10000,
@@ -170,14 +201,13 @@ var (
 				DefaultGas:         1,
 				Percentile:         60,
 			},
-			RangeQueryLimit: 1000,
+			RangeQueryLimit:  1000,
+			Web3CORSOrigins:  []string{"http://localhost", "http://localhost:*"},
+			Web3VirtualHosts: []string{"localhost"},
 		},
 		Indexer: Indexer{
 			Enabled:           false,
-			NodeAddr:          "",
 			WhetherLocalStore: true,
-			BlockByIndexList:  []string{IndexTransfer, IndexVote, IndexExecution, IndexAction, IndexReceipt},
-			IndexHistoryList:  []string{IndexTransfer, IndexVote, IndexExecution, IndexAction},
 		},
 		System: System{
 			Active:                    true,
@@ -194,6 +224,10 @@ var (
 				SQLite3File: "./explorer.db",
 			},
 		},
+		Signer: Signer{
+			Type:          InProcessSigner,
+			RemoteTimeout: 2 * time.Second,
+		},
 		Genesis: genesis.Default,
 	}
 
@@ -202,11 +236,14 @@ var (
 
 	// Validates is the collection config validation functions
 	Validates = []Validate{
+		ValidateChain,
 		ValidateRollDPoS,
 		ValidateDispatcher,
 		ValidateExplorer,
 		ValidateAPI,
 		ValidateActPool,
+		ValidateDB,
+		ValidateSigner,
 	}
 
 	// PrivateKey is a randomly generated producer's key for testing purpose
@@ -250,6 +287,22 @@ type (
 		AllowedBlockGasResidue uint64 `yaml:"allowedBlockGasResidue"`
 		// MaxCacheSize is the max number of blocks that will be put into an LRU cache. 0 means disabled
 		MaxCacheSize int `yaml:"maxCacheSize"`
+		// BlockRetentionHeight is the number of recent blocks whose bodies and receipts are kept, older ones are pruned
+		// while their headers, footers and action indexes are kept. 0 means all blocks are kept
+		BlockRetentionHeight uint64 `yaml:"blockRetentionHeight"`
+		// EnableArchiveMode keeps the state trie of every height, so that states could be queried at any past height
+		EnableArchiveMode bool `yaml:"enableArchiveMode"`
+		// TrieRetentionHeight is the number of recent heights whose state tries are kept, older stale trie nodes are
+		// pruned in the background. 0 means the stale nodes are deleted as soon as a block is committed
+		TrieRetentionHeight uint64 `yaml:"trieRetentionHeight"`
+		// SignatureCacheSize is the number of verified action signatures cached and shared by the actpool and the
+		// block validator
+		SignatureCacheSize int `yaml:"signatureCacheSize"`
+		// ReindexStartHeight and ReindexEndHeight are the range of blocks whose action, receipt and address indexes
+		// are rebuilt before the node starts, which is set by the -reindex flag. 0 start height means disabled, and 0
+		// end height means the tip height. An interrupted reindex resumes on restart with the same range.
+		ReindexStartHeight uint64 `yaml:"-"`
+		ReindexEndHeight   uint64 `yaml:"-"`
 	}
 
 	// Consensus is the config struct for consensus package
@@ -264,10 +317,17 @@ type (
 		Interval     time.Duration `yaml:"interval"` // update duration
 		BufferSize   uint64        `yaml:"bufferSize"`
 		IntervalSize uint64        `yaml:"intervalSize"`
-		// MaxRepeat is the maximal number of repeat of a block sync request
-		MaxRepeat int `yaml:"maxRepeat"`
-		// RepeatDecayStep is the step for repeat number decreasing by 1
-		RepeatDecayStep int `yaml:"repeatDecayStep"`
+		// RequestTimeout is how long a sync request is waited for before it is sent to another peer
+		RequestTimeout time.Duration `yaml:"requestTimeout"`
+		// MaxHeadersPerRequest is the maximal number of headers requested from or served to a peer at once
+		MaxHeadersPerRequest uint64 `yaml:"maxHeadersPerRequest"`
+		// MaxRequestsPerPeer is the maximal number of outstanding sync requests to a peer
+		MaxRequestsPerPeer int `yaml:"maxRequestsPerPeer"`
+		// PeerBanDuration is how long a peer is not synced from after it's scored down for timeouts or invalid data
+		PeerBanDuration time.Duration `yaml:"peerBanDuration"`
+		// ValidationWorkers is the number of workers doing the stateless checks of the buffered blocks ahead of their
+		// execution. 0 disables the pre-validation
+		ValidationWorkers int `yaml:"validationWorkers"`
 	}
 
 	// RollDPoS is the config struct for RollDPoS consensus package
@@ -275,6 +335,15 @@ type (
 		FSM               consensusfsm.Config `yaml:"fsm"`
 		ToleratedOvertime time.Duration       `yaml:"toleratedOvertime"`
 		Delay             time.Duration       `yaml:"delay"`
+		// EvidenceDBPath is the path of the DB persisting the equivocation evidences, which are kept in memory if
+		// it is empty, so that the evidences not yet included in a block are lost on a restart
+		EvidenceDBPath string `yaml:"evidenceDBPath"`
+		// WALPath is the path of the write-ahead log of the messages endorsed by the delegate, which are kept in
+		// memory only if it is empty. An empty path is only meant for the delegates run in the same process, since
+		// the delegate may endorse conflicting messages after a restart without the log
+		WALPath string `yaml:"walPath"`
+		// TimelineSize is the number of the latest rounds kept in the consensus timeline
+		TimelineSize int `yaml:"timelineSize"`
 	}
 
 	// Dispatcher is the dispatcher config
@@ -298,9 +367,35 @@ type (
 	API struct {
 		UseRDS          bool       `yaml:"useRDS"`
 		Port            int        `yaml:"port"`
+		Web3Port        int        `yaml:"web3Port"`
 		TpsWindow       int        `yaml:"tpsWindow"`
 		GasStation      GasStation `yaml:"gasStation"`
 		RangeQueryLimit uint64     `yaml:"rangeQueryLimit"`
+		// Web3CORSOrigins are the origins of the browser requests allowed by the web3 server, e.g. "http://*.iotex.io"
+		Web3CORSOrigins []string `yaml:"web3CORSOrigins"`
+		// Web3VirtualHosts are the host names accepted by the web3 server, which guards against DNS rebinding
+		Web3VirtualHosts []string `yaml:"web3VirtualHosts"`
+	}
+
+	// Signer is the config of the signer of the block producer
+	Signer struct {
+		// Type is the type of the signer, which is INPROCESS, KEYSTORE or REMOTE
+		Type string `yaml:"type"`
+		// KeystorePath is the path of the keystore file of the producer private key
+		KeystorePath string `yaml:"keystorePath"`
+		// KeystorePassword is the password of the keystore file, which could be read from an environment variable
+		// as ${VAR}
+		KeystorePassword string `yaml:"keystorePassword"`
+		// RemoteAddr is the address of the remote signer
+		RemoteAddr string `yaml:"remoteAddr"`
+		// RemoteTimeout is the timeout of a request to the remote signer
+		RemoteTimeout time.Duration `yaml:"remoteTimeout"`
+		// RemoteCACertFile is the CA certificate to verify the remote signer
+		RemoteCACertFile string `yaml:"remoteCACertFile"`
+		// RemoteClientCertFile and RemoteClientKeyFile are the certificate and key of the node, with which the remote
+		// signer authenticates the node
+		RemoteClientCertFile string `yaml:"remoteClientCertFile"`
+		RemoteClientKeyFile  string `yaml:"remoteClientKeyFile"`
 	}
 
 	// GasStation is the gas station config
@@ -312,13 +407,9 @@ type (
 
 	// Indexer is the index service config
 	Indexer struct {
-		Enabled           bool   `yaml:"enabled"`
-		NodeAddr          string `yaml:"nodeAddr"`
-		WhetherLocalStore bool   `yaml:"whetherLocalStore"`
-		// BlockByIndexList store list of BlockByIndex tables
-		BlockByIndexList []string `yaml:"blockByIndexList"`
-		// IndexHistoryList store list of IndexHistory tables
-		IndexHistoryList []string `yaml:"indexHistoryList"`
+		Enabled bool `yaml:"enabled"`
+		// WhetherLocalStore stores the index in the local sqlite3 database, otherwise in aws rds
+		WhetherLocalStore bool `yaml:"whetherLocalStore"`
 	}
 
 	// System is the system config
@@ -349,11 +440,25 @@ type (
 		MinGasPriceStr string `yaml:"minGasPrice"`
 		// BlackList lists the account address that are banned from initiating actions
 		BlackList []string `yaml:"blackList"`
+		// PriceBumpPercent is the minimal percentage by which the gas price of an action should exceed the one of the
+		// queued action with the same sender and nonce to replace it
+		PriceBumpPercent uint64 `yaml:"priceBumpPercent"`
+		// Journal is the path of the journal file which persists the actions in pool across restarts, empty to disable
+		Journal string `yaml:"journal"`
+		// JournalRewriteInterval is the interval to compact the journal with the actions in pool
+		JournalRewriteInterval time.Duration `yaml:"journalRewriteInterval"`
+		// SenderRateLimit limits the rate of actions admitted from each sender address
+		SenderRateLimit ratelimit.Config `yaml:"senderRateLimit"`
+		// PeerRateLimit limits the rate of actions admitted from each peer broadcasting them
+		PeerRateLimit ratelimit.Config `yaml:"peerRateLimit"`
 	}
 
 	// DB is the config for database
 	DB struct {
 		DbPath string `yaml:"dbPath"`
+		// Backend is the KV store backend, one of bolt, badger and leveldb. If it is empty, BadgerDB is used if
+		// UseBadgerDB is set, otherwise BoltDB is used.
+		Backend string `yaml:"backend"`
 		// Use BadgerDB, otherwise use BoltDB
 		UseBadgerDB bool `yaml:"useBadgerDB"`
 		// NumRetries is the number of retries
@@ -402,6 +507,7 @@ type (
 		DB         DB                          `yaml:"db"`
 		Log        log.GlobalConfig            `yaml:"log"`
 		SubLogs    map[string]log.GlobalConfig `yaml:"subLogs"`
+		Signer     Signer                      `yaml:"signer"`
 		Genesis    genesis.Genesis             `yaml:"genesis"`
 	}
 
@@ -447,6 +553,12 @@ func New(validates ...Validate) (Config, error) {
 		}
 	}
 
+	if _reindex != "" {
+		if cfg.Chain.ReindexStartHeight, cfg.Chain.ReindexEndHeight, err = parseHeightRange(_reindex); err != nil {
+			return Config{}, errors.Wrap(err, "failed to parse reindex range")
+		}
+	}
+
 	// By default, the config needs to pass all the validation
 	if len(validates) == 0 {
 		validates = Validates
@@ -527,6 +639,31 @@ func (ap ActPool) MinGasPrice() *big.Int {
 	return mgp
 }
 
+// ValidateChain validates the chain configs
+func ValidateChain(cfg Config) error {
+	if cfg.Chain.EnableArchiveMode && cfg.Chain.EnableTrielessStateDB {
+		return errors.Wrap(ErrInvalidCfg, "archive mode requires the state trie, trieless state db should be disabled")
+	}
+	if cfg.Chain.TrieRetentionHeight > 0 {
+		if cfg.Chain.EnableArchiveMode {
+			return errors.Wrap(ErrInvalidCfg, "trie pruning should be disabled in archive mode")
+		}
+		if cfg.Chain.EnableTrielessStateDB {
+			return errors.Wrap(ErrInvalidCfg, "trie pruning requires the state trie, trieless state db should be disabled")
+		}
+	}
+	if cfg.Chain.ReindexStartHeight > 0 {
+		if _, ok := cfg.Plugins[GatewayPlugin]; !ok {
+			return errors.Wrap(ErrInvalidCfg, "reindex requires the gateway plugin")
+		}
+		if cfg.Chain.ReindexEndHeight > 0 && cfg.Chain.ReindexEndHeight < cfg.Chain.ReindexStartHeight {
+			return errors.Wrapf(ErrInvalidCfg, "reindex end height %d is lower than start height %d",
+				cfg.Chain.ReindexEndHeight, cfg.Chain.ReindexStartHeight)
+		}
+	}
+	return nil
+}
+
 // ValidateDispatcher validates the dispatcher configs
 func ValidateDispatcher(cfg Config) error {
 	if cfg.Dispatcher.EventChanSize <= 0 {
@@ -545,6 +682,9 @@ func ValidateRollDPoS(cfg Config) error {
 	if fsm.EventChanSize <= 0 {
 		return errors.Wrap(ErrInvalidCfg, "roll-DPoS event chan size should be greater than 0")
 	}
+	if rollDPoS.TimelineSize <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "roll-DPoS timeline size should be greater than 0")
+	}
 	return nil
 }
 
@@ -583,5 +723,64 @@ func ValidateActPool(cfg Config) error {
 	return nil
 }
 
+// ValidateDB validates the db configs
+func ValidateDB(cfg Config) error {
+	switch cfg.DB.Backend {
+	case "", BadgerDBBackend:
+		return nil
+	case BoltDBBackend, LevelDBBackend:
+		if cfg.DB.UseBadgerDB {
+			return errors.Wrapf(ErrInvalidCfg, "useBadgerDB conflicts with db backend %s", cfg.DB.Backend)
+		}
+		return nil
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unknown db backend %s", cfg.DB.Backend)
+	}
+}
+
+// ValidateSigner validates the signer configs
+func ValidateSigner(cfg Config) error {
+	switch cfg.Signer.Type {
+	case InProcessSigner:
+		return nil
+	case KeystoreSigner:
+		if cfg.Signer.KeystorePath == "" {
+			return errors.Wrap(ErrInvalidCfg, "keystore path of the signer is empty")
+		}
+		return nil
+	case RemoteSigner:
+		if cfg.Signer.RemoteAddr == "" {
+			return errors.Wrap(ErrInvalidCfg, "address of the remote signer is empty")
+		}
+		if cfg.Signer.RemoteTimeout <= 0 {
+			return errors.Wrap(ErrInvalidCfg, "timeout of the remote signer should be greater than 0")
+		}
+		if cfg.Signer.RemoteCACertFile == "" || cfg.Signer.RemoteClientCertFile == "" ||
+			cfg.Signer.RemoteClientKeyFile == "" {
+			return errors.Wrap(ErrInvalidCfg, "TLS certificates and key of the remote signer are required")
+		}
+		return nil
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unknown signer type %s", cfg.Signer.Type)
+	}
+}
+
+// parseHeightRange parses a height range in the format of start[:end]
+func parseHeightRange(s string) (uint64, uint64, error) {
+	parts := strings.SplitN(s, ":", 2)
+	start, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || start == 0 {
+		return 0, 0, errors.Wrapf(ErrInvalidCfg, "invalid start height %s", parts[0])
+	}
+	if len(parts) == 1 || parts[1] == "" {
+		return start, 0, nil
+	}
+	end, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(ErrInvalidCfg, "invalid end height %s", parts[1])
+	}
+	return start, end, nil
+}
+
 // DoNotValidate validates the given config
 func DoNotValidate(cfg Config) error { return nil }
@@ -160,6 +160,66 @@ func TestValidateExplorer(t *testing.T) {
 	)
 }
 
+func TestValidateChain(t *testing.T) {
+	cfg := Default
+	cfg.Chain.EnableArchiveMode = true
+	cfg.Chain.EnableTrielessStateDB = true
+	err := ValidateChain(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(
+		t,
+		strings.Contains(err.Error(), "archive mode requires the state trie"),
+	)
+
+	cfg.Chain.EnableTrielessStateDB = false
+	require.NoError(t, ValidateChain(cfg))
+
+	cfg.Chain.TrieRetentionHeight = 100
+	err = ValidateChain(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(
+		t,
+		strings.Contains(err.Error(), "trie pruning should be disabled in archive mode"),
+	)
+
+	cfg.Chain.EnableArchiveMode = false
+	require.NoError(t, ValidateChain(cfg))
+
+	cfg.Chain.ReindexStartHeight = 10
+	err = ValidateChain(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "reindex requires the gateway plugin"))
+
+	cfg.Plugins = map[int]interface{}{GatewayPlugin: nil}
+	require.NoError(t, ValidateChain(cfg))
+	cfg.Chain.ReindexEndHeight = 9
+	err = ValidateChain(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "reindex end height 9 is lower than start height 10"))
+}
+
+func TestParseHeightRange(t *testing.T) {
+	for _, c := range []struct {
+		s          string
+		start, end uint64
+	}{
+		{"1", 1, 0},
+		{"5:", 5, 0},
+		{"5:100", 5, 100},
+	} {
+		start, end, err := parseHeightRange(c.s)
+		require.NoError(t, err)
+		require.Equal(t, c.start, start)
+		require.Equal(t, c.end, end)
+	}
+	for _, s := range []string{"", "0", "a:1", "1:b"} {
+		_, _, err := parseHeightRange(s)
+		require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	}
+}
+
 func TestValidateDispatcher(t *testing.T) {
 	cfg := Default
 	cfg.Dispatcher.EventChanSize = 0
@@ -184,6 +244,16 @@ func TestValidateRollDPoS(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "roll-DPoS event chan size should be greater than 0"),
 	)
+
+	cfg.Consensus.RollDPoS.FSM.EventChanSize = 10000
+	cfg.Consensus.RollDPoS.TimelineSize = 0
+	err = ValidateRollDPoS(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(
+		t,
+		strings.Contains(err.Error(), "roll-DPoS timeline size should be greater than 0"),
+	)
 }
 
 func TestValidateActPool(t *testing.T) {
@@ -225,3 +295,56 @@ func TestValidateActPool(t *testing.T) {
 		),
 	)
 }
+
+func TestValidateDB(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateDB(cfg))
+	for _, backend := range []string{BoltDBBackend, BadgerDBBackend, LevelDBBackend} {
+		cfg.DB.Backend = backend
+		require.NoError(t, ValidateDB(cfg))
+	}
+
+	cfg.DB.UseBadgerDB = true
+	err := ValidateDB(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "useBadgerDB conflicts with db backend leveldb"))
+
+	cfg.DB.UseBadgerDB = false
+	cfg.DB.Backend = "rocksdb"
+	err = ValidateDB(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "unknown db backend rocksdb"))
+}
+
+func TestValidateSigner(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateSigner(cfg))
+
+	cfg.Signer.Type = KeystoreSigner
+	err := ValidateSigner(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "keystore path of the signer is empty"))
+	cfg.Signer.KeystorePath = "producer.keystore"
+	require.NoError(t, ValidateSigner(cfg))
+
+	cfg.Signer.Type = RemoteSigner
+	err = ValidateSigner(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "address of the remote signer is empty"))
+	cfg.Signer.RemoteAddr = "127.0.0.1:14015"
+	err = ValidateSigner(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "TLS certificates and key of the remote signer are required"))
+	cfg.Signer.RemoteCACertFile = "signer-ca.pem"
+	cfg.Signer.RemoteClientCertFile = "node.pem"
+	cfg.Signer.RemoteClientKeyFile = "node-key.pem"
+	require.NoError(t, ValidateSigner(cfg))
+	cfg.Signer.RemoteTimeout = 0
+	err = ValidateSigner(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+
+	cfg.Signer.Type = "hsm"
+	err = ValidateSigner(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "unknown signer type hsm"))
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/state"
 )
 
@@ -36,6 +37,7 @@ type Consensus interface {
 	HandleConsensusMsg(*iotextypes.ConsensusMessage) error
 	Calibrate(uint64)
 	ValidateBlockFooter(*block.Block) error
+	ValidateHeaderFooter(header *block.Header, footer *block.Footer, prevFooter *block.Footer) error
 	Metrics() (scheme.ConsensusMetrics, error)
 	Activate(bool)
 	Active() bool
@@ -51,6 +53,9 @@ type optionParams struct {
 	rootChainAPI     explorerapi.Explorer
 	broadcastHandler scheme.Broadcast
 	rp               *rp.Protocol
+	evidencePool     *rolldpos.EvidencePool
+	timeline         *rolldpos.Timeline
+	signer           signer.Signer
 }
 
 // Option sets Consensus construction parameter.
@@ -80,6 +85,30 @@ func WithRollDPoSProtocol(rp *rp.Protocol) Option {
 	}
 }
 
+// WithEvidencePool is an option to persist the equivocation evidences detected by RollDPoS in the pool
+func WithEvidencePool(evidencePool *rolldpos.EvidencePool) Option {
+	return func(ops *optionParams) error {
+		ops.evidencePool = evidencePool
+		return nil
+	}
+}
+
+// WithTimeline is an option to record the consensus rounds observed by RollDPoS in the timeline
+func WithTimeline(timeline *rolldpos.Timeline) Option {
+	return func(ops *optionParams) error {
+		ops.timeline = timeline
+		return nil
+	}
+}
+
+// WithSigner is an option to sign the endorsements with the signer, instead of the producer private key in config
+func WithSigner(s signer.Signer) Option {
+	return func(ops *optionParams) error {
+		ops.signer = s
+		return nil
+	}
+}
+
 // NewConsensus creates a IotxConsensus struct.
 func NewConsensus(
 	cfg config.Config,
@@ -127,17 +156,25 @@ func NewConsensus(
 		return nil
 	}
 
-	var err error
+	if ops.signer == nil {
+		ops.signer = signer.NewInProcess(cfg.ProducerPrivateKey())
+	}
+	producerAddr, err := address.FromBytes(ops.signer.PublicKey().Hash())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get block producer address")
+	}
 	switch cfg.Consensus.Scheme {
 	case config.RollDPoSScheme:
 		bd := rolldpos.NewRollDPoSBuilder().
-			SetAddr(cfg.ProducerAddress().String()).
-			SetPriKey(cfg.ProducerPrivateKey()).
+			SetAddr(producerAddr.String()).
+			SetSigner(ops.signer).
 			SetConfig(cfg).
 			SetBlockchain(bc).
 			SetActPool(ap).
 			SetClock(clock).
 			SetBroadcast(ops.broadcastHandler).
+			SetEvidencePool(ops.evidencePool).
+			SetTimeline(ops.timeline).
 			RegisterProtocol(ops.rp)
 		if ops.rootChainAPI != nil {
 			bd = bd.SetCandidatesByHeightFunc(func(h uint64) ([]*state.Candidate, error) {
@@ -229,6 +266,11 @@ func (c *IotxConsensus) ValidateBlockFooter(blk *block.Block) error {
 	return c.scheme.ValidateBlockFooter(blk)
 }
 
+// ValidateHeaderFooter validates the proposer and the endorsements of a block header ahead of the block
+func (c *IotxConsensus) ValidateHeaderFooter(header *block.Header, footer *block.Footer, prevFooter *block.Footer) error {
+	return c.scheme.ValidateHeaderFooter(header, footer, prevFooter)
+}
+
 // Scheme returns the scheme instance
 func (c *IotxConsensus) Scheme() scheme.Scheme {
 	return c.scheme
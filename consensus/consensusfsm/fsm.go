@@ -103,6 +103,8 @@ type ConsensusFSM struct {
 	cfg   Config
 	ctx   Context
 	wg    sync.WaitGroup
+	// mu guards the wait group against the timers firing while the fsm stops
+	mu sync.Mutex
 }
 
 // NewConsensusFSM returns a new fsm
@@ -227,12 +229,7 @@ func (m *ConsensusFSM) Start(c context.Context) error {
 			case <-m.close:
 				running = false
 			case evt := <-m.evtq:
-				if err := m.handle(evt); err != nil {
-					m.ctx.Logger().Error(
-						"consensus state transition fails",
-						zap.Error(err),
-					)
-				}
+				m.handleEvent(evt)
 			}
 		}
 		m.wg.Done()
@@ -240,9 +237,23 @@ func (m *ConsensusFSM) Start(c context.Context) error {
 	return nil
 }
 
+// Step handles the next event in the queue, and returns false if the queue is empty. It drives the fsm step by step
+// in place of the event loop run by Start, e.g., in a deterministic simulation.
+func (m *ConsensusFSM) Step() bool {
+	select {
+	case evt := <-m.evtq:
+		m.handleEvent(evt)
+		return true
+	default:
+		return false
+	}
+}
+
 // Stop stops the consensus fsm
 func (m *ConsensusFSM) Stop(_ context.Context) error {
+	m.mu.Lock()
 	close(m.close)
+	m.mu.Unlock()
 	m.wg.Wait()
 	return nil
 }
@@ -300,20 +311,42 @@ func (m *ConsensusFSM) produce(evt *ConsensusEvent, delay time.Duration) {
 	}
 	consensusMtc.WithLabelValues(string(evt.Type())).Inc()
 	if delay > 0 {
-		m.wg.Add(1)
-		go func() {
-			select {
-			case <-m.close:
-			case <-m.clock.After(delay):
-				m.evtq <- evt
-			}
-			m.wg.Done()
-		}()
+		m.clock.AfterFunc(delay, func() {
+			m.enqueue(evt)
+		})
 	} else {
 		m.evtq <- evt
 	}
 }
 
+// enqueue adds an event produced by a timer into the queue, unless the fsm is stopped. It is tracked by the wait
+// group, so that no event is added after Stop returns.
+func (m *ConsensusFSM) enqueue(evt *ConsensusEvent) {
+	m.mu.Lock()
+	select {
+	case <-m.close:
+		m.mu.Unlock()
+		return
+	default:
+	}
+	m.wg.Add(1)
+	m.mu.Unlock()
+	defer m.wg.Done()
+	select {
+	case <-m.close:
+	case m.evtq <- evt:
+	}
+}
+
+func (m *ConsensusFSM) handleEvent(evt *ConsensusEvent) {
+	if err := m.handle(evt); err != nil {
+		m.ctx.Logger().Error(
+			"consensus state transition fails",
+			zap.Error(err),
+		)
+	}
+}
+
 func (m *ConsensusFSM) handle(evt *ConsensusEvent) error {
 	if m.ctx.IsStaleEvent(evt) {
 		m.ctx.Logger().Debug("stale event", zap.Any("event", evt.Type()))
@@ -391,7 +424,7 @@ func (m *ConsensusFSM) prepare(_ fsm.Event) (fsm.State, error) {
 	}
 	m.ctx.Logger().Info("Start a new round", zap.Duration("delay", delay))
 	if delay > 0 {
-		time.Sleep(delay)
+		m.clock.Sleep(delay)
 	}
 	// Setup timeout for waiting for proposed block
 	ttl := m.cfg.AcceptBlockTTL
@@ -60,6 +60,26 @@ func TestBackdoorEvt(t *testing.T) {
 	}
 }
 
+func TestStopWithPendingTimers(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClock := clock.NewMock()
+	cfsm, err := NewConsensusFSM(Config{EventChanSize: 10}, NewMockContext(ctrl), mockClock)
+	require.NoError(err)
+
+	cfsm.produce(&ConsensusEvent{eventType: ePrepare}, time.Second)
+	cfsm.produce(&ConsensusEvent{eventType: ePrepare}, 2*time.Second)
+	mockClock.Add(time.Second)
+	require.Equal(1, cfsm.NumPendingEvents())
+
+	// the timer firing after the fsm stops adds no event
+	require.NoError(cfsm.Stop(context.Background()))
+	mockClock.Add(time.Second)
+	require.Equal(1, cfsm.NumPendingEvents())
+}
+
 func TestStateTransitionFunctions(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
@@ -11,4 +11,6 @@ import "errors"
 var (
 	// ErrNotImplemented indicates the method is not implemented yet
 	ErrNotImplemented = errors.New("not implemented")
+	// ErrNotVerifiable indicates the data cannot be verified until the chain grows
+	ErrNotVerifiable = errors.New("not verifiable yet")
 )
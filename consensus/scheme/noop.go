@@ -46,6 +46,11 @@ func (n *Noop) ValidateBlockFooter(*block.Block) error {
 	return nil
 }
 
+// ValidateHeaderFooter validates the footer of a block header ahead of the block
+func (n *Noop) ValidateHeaderFooter(*block.Header, *block.Footer, *block.Footer) error {
+	return nil
+}
+
 // Metrics is not implemented for noop scheme
 func (n *Noop) Metrics() (ConsensusMetrics, error) {
 	return ConsensusMetrics{}, errors.Wrapf(
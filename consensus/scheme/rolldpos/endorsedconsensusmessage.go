@@ -71,6 +71,12 @@ func (ecm *EndorsedConsensusMessage) Proto() (*iotextypes.ConsensusMessage, erro
 			return nil, err
 		}
 		cmsg.Msg = &iotextypes.ConsensusMessage_BlockProposal{BlockProposal: mbp}
+	case *Evidence:
+		mbp, err := message.Proto()
+		if err != nil {
+			return nil, err
+		}
+		cmsg.Msg = &iotextypes.ConsensusMessage_Evidence{Evidence: mbp}
 	default:
 		return nil, errors.New("unknown consensus message type")
 	}
@@ -93,6 +99,12 @@ func (ecm *EndorsedConsensusMessage) LoadProto(msg *iotextypes.ConsensusMessage)
 			return err
 		}
 		ecm.message = proposal
+	case msg.GetEvidence() != nil:
+		evidence := &Evidence{}
+		if err := evidence.LoadProto(msg.GetEvidence()); err != nil {
+			return err
+		}
+		ecm.message = evidence
 	default:
 		return errors.New("unknown message")
 	}
@@ -0,0 +1,275 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+const (
+	// evidenceNS is the namespace of the evidences in the evidence store
+	evidenceNS = "Evidence"
+	// proposalSlot is the slot of the block proposal in a round
+	proposalSlot = "proposal"
+)
+
+// Evidence proves that a delegate endorsed two conflicting consensus messages of the same height and round, i.e.,
+// proposed two blocks, or voted on two blocks with the same topic
+type Evidence struct {
+	first  *EndorsedConsensusMessage
+	second *EndorsedConsensusMessage
+}
+
+// NewEvidence creates an evidence of two conflicting consensus messages. The messages are sorted, so that the
+// evidence of the same messages is identical.
+func NewEvidence(first, second *EndorsedConsensusMessage) (*Evidence, error) {
+	firstPb, err := first.Proto()
+	if err != nil {
+		return nil, err
+	}
+	secondPb, err := second.Proto()
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Compare(byteutil.Must(proto.Marshal(firstPb)), byteutil.Must(proto.Marshal(secondPb))) > 0 {
+		first, second = second, first
+	}
+	return &Evidence{first: first, second: second}, nil
+}
+
+// First returns the first conflicting message
+func (e *Evidence) First() *EndorsedConsensusMessage {
+	return e.first
+}
+
+// Second returns the second conflicting message
+func (e *Evidence) Second() *EndorsedConsensusMessage {
+	return e.second
+}
+
+// Height returns the height of the conflicting messages
+func (e *Evidence) Height() uint64 {
+	return e.first.Height()
+}
+
+// Endorser returns the public key of the delegate endorsing the conflicting messages
+func (e *Evidence) Endorser() keypair.PublicKey {
+	return e.first.Endorsement().Endorser()
+}
+
+// Hash returns the hash of the evidence
+func (e *Evidence) Hash() ([]byte, error) {
+	msg, err := e.Proto()
+	if err != nil {
+		return nil, err
+	}
+	h := hash.Hash256b(byteutil.Must(proto.Marshal(msg)))
+
+	return h[:], nil
+}
+
+// Proto converts to a protobuf message
+func (e *Evidence) Proto() (*iotextypes.ConsensusEvidence, error) {
+	first, err := e.first.Proto()
+	if err != nil {
+		return nil, err
+	}
+	second, err := e.second.Proto()
+	if err != nil {
+		return nil, err
+	}
+	return &iotextypes.ConsensusEvidence{First: first, Second: second}, nil
+}
+
+// LoadProto loads from a protobuf message
+func (e *Evidence) LoadProto(msg *iotextypes.ConsensusEvidence) error {
+	if msg.First == nil || msg.Second == nil {
+		return errors.New("missing conflicting message")
+	}
+	e.first = &EndorsedConsensusMessage{}
+	if err := e.first.LoadProto(msg.First); err != nil {
+		return err
+	}
+	e.second = &EndorsedConsensusMessage{}
+	return e.second.LoadProto(msg.Second)
+}
+
+// slotOf returns the kind of a consensus message, which a delegate may endorse only once in a round, and the hash of
+// the block it endorses
+func slotOf(msg *EndorsedConsensusMessage) (string, []byte, error) {
+	switch doc := msg.Document().(type) {
+	case *blockProposal:
+		blkHash := doc.block.HashBlock()
+		return proposalSlot, blkHash[:], nil
+	case *ConsensusVote:
+		return voteSlot(doc.Topic()), doc.BlockHash(), nil
+	default:
+		return "", nil, errors.New("no slot for the consensus message")
+	}
+}
+
+// voteSlot returns the slot of a vote of the topic in a round
+func voteSlot(topic ConsensusVoteTopic) string {
+	return fmt.Sprintf("vote-%d", topic)
+}
+
+type slotRecord struct {
+	msg      *EndorsedConsensusMessage
+	reported bool
+}
+
+// equivocationDetector remembers the first message each delegate endorses in each slot of the recent heights
+type equivocationDetector struct {
+	mutex sync.Mutex
+	slots map[uint64]map[string]*slotRecord
+}
+
+func newEquivocationDetector() *equivocationDetector {
+	return &equivocationDetector{slots: map[uint64]map[string]*slotRecord{}}
+}
+
+// Detect records the message endorsed in the round, and returns an evidence if its endorser has endorsed a different
+// block in the same slot. An equivocation is reported only once per slot.
+func (d *equivocationDetector) Detect(round uint32, msg *EndorsedConsensusMessage) (*Evidence, error) {
+	kind, blkHash, err := slotOf(msg)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%s/%d/%s", msg.Endorsement().Endorser().HexString(), round, kind)
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	slots, ok := d.slots[msg.Height()]
+	if !ok {
+		slots = map[string]*slotRecord{}
+		d.slots[msg.Height()] = slots
+	}
+	record, ok := slots[key]
+	if !ok {
+		slots[key] = &slotRecord{msg: msg}
+		return nil, nil
+	}
+	_, recordedHash, err := slotOf(record.msg)
+	if err != nil {
+		return nil, err
+	}
+	if record.reported || bytes.Equal(recordedHash, blkHash) {
+		return nil, nil
+	}
+	record.reported = true
+
+	return NewEvidence(record.msg, msg)
+}
+
+// Prune drops the messages below the height
+func (d *equivocationDetector) Prune(height uint64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for h := range d.slots {
+		if h < height {
+			delete(d.slots, h)
+		}
+	}
+}
+
+// EvidencePool persists the evidences of equivocation, indexed by height
+type EvidencePool struct {
+	kvStore db.KVStore
+}
+
+// NewEvidencePool creates an evidence pool on the KV store
+func NewEvidencePool(kvStore db.KVStore) *EvidencePool {
+	return &EvidencePool{kvStore: kvStore}
+}
+
+// Start starts the evidence pool
+func (p *EvidencePool) Start(ctx context.Context) error {
+	return p.kvStore.Start(ctx)
+}
+
+// Stop stops the evidence pool
+func (p *EvidencePool) Stop(ctx context.Context) error {
+	return p.kvStore.Stop(ctx)
+}
+
+// Add persists the evidence, and returns false if it already exists
+func (p *EvidencePool) Add(e *Evidence) (bool, error) {
+	h, err := e.Hash()
+	if err != nil {
+		return false, err
+	}
+	key := append(heightKey(e.Height()), h...)
+	_, err = p.kvStore.Get(evidenceNS, key)
+	switch errors.Cause(err) {
+	case nil:
+		return false, nil
+	case db.ErrNotExist:
+	default:
+		return false, err
+	}
+	msg, err := e.Proto()
+	if err != nil {
+		return false, err
+	}
+	value, err := proto.Marshal(msg)
+	if err != nil {
+		return false, err
+	}
+	if err := p.kvStore.Put(evidenceNS, key, value); err != nil {
+		return false, errors.Wrap(err, "failed to persist the evidence")
+	}
+	return true, nil
+}
+
+// Evidences returns the evidences of the heights in [startHeight, startHeight+count)
+func (p *EvidencePool) Evidences(startHeight uint64, count uint64) ([]*Evidence, error) {
+	if count == 0 {
+		return nil, nil
+	}
+	var end []byte
+	if startHeight+count > startHeight {
+		end = heightKey(startHeight + count)
+	}
+	iter, err := p.kvStore.Range(evidenceNS, heightKey(startHeight), end)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var evidences []*Evidence
+	for iter.Next() {
+		msg := &iotextypes.ConsensusEvidence{}
+		if err := proto.Unmarshal(iter.Value(), msg); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal the evidence")
+		}
+		e := &Evidence{}
+		if err := e.LoadProto(msg); err != nil {
+			return nil, err
+		}
+		evidences = append(evidences, e)
+	}
+	return evidences, iter.Error()
+}
+
+// heightKey encodes the height in big endian, so that the keys are sorted by height
+func heightKey(height uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, height)
+	return key
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+)
+
+func makeVote(
+	t *testing.T,
+	priKey keypair.PrivateKey,
+	height uint64,
+	blkHash hash.Hash256,
+	topic ConsensusVoteTopic,
+	ts time.Time,
+) *EndorsedConsensusMessage {
+	vote := NewConsensusVote(blkHash[:], topic)
+	en, err := endorsement.Endorse(priKey, vote, ts)
+	require.NoError(t, err)
+	return NewEndorsedConsensusMessage(height, vote, en)
+}
+
+func TestEquivocationDetector(t *testing.T) {
+	require := require.New(t)
+	ts := time.Unix(1500000000, 0)
+	hashA := hash.Hash256b([]byte("a"))
+	hashB := hash.Hash256b([]byte("b"))
+	a := makeVote(t, identityset.PrivateKey(1), 9, hashA, LOCK, ts)
+	b := makeVote(t, identityset.PrivateKey(1), 9, hashB, LOCK, ts)
+
+	d := newEquivocationDetector()
+	for _, c := range []struct {
+		round uint32
+		msg   *EndorsedConsensusMessage
+	}{
+		{0, a},
+		// the same message again
+		{0, a},
+		// another topic
+		{0, makeVote(t, identityset.PrivateKey(1), 9, hashB, COMMIT, ts)},
+		// another round
+		{1, b},
+		// another endorser
+		{0, makeVote(t, identityset.PrivateKey(2), 9, hashB, LOCK, ts)},
+		// another height
+		{0, makeVote(t, identityset.PrivateKey(1), 10, hashB, LOCK, ts)},
+	} {
+		evidence, err := d.Detect(c.round, c.msg)
+		require.NoError(err)
+		require.Nil(evidence)
+	}
+
+	evidence, err := d.Detect(0, b)
+	require.NoError(err)
+	require.NotNil(evidence)
+	require.Equal(uint64(9), evidence.Height())
+	require.Equal(identityset.PrivateKey(1).PublicKey().Bytes(), evidence.Endorser().Bytes())
+	reversed, err := NewEvidence(b, a)
+	require.NoError(err)
+	require.Equal(evidence.First(), reversed.First())
+	require.Equal(evidence.Second(), reversed.Second())
+
+	// an equivocation is reported once
+	evidence, err = d.Detect(0, makeVote(t, identityset.PrivateKey(1), 9, hash.Hash256b([]byte("c")), LOCK, ts))
+	require.NoError(err)
+	require.Nil(evidence)
+
+	d.Prune(10)
+	evidence, err = d.Detect(0, b)
+	require.NoError(err)
+	require.Nil(evidence)
+	evidence, err = d.Detect(0, makeVote(t, identityset.PrivateKey(1), 10, hashA, LOCK, ts))
+	require.NoError(err)
+	require.NotNil(evidence)
+}
+
+func TestEvidencePool(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	ts := time.Unix(1500000000, 0)
+	hashA := hash.Hash256b([]byte("a"))
+	hashB := hash.Hash256b([]byte("b"))
+
+	pool := NewEvidencePool(db.NewMemKVStore())
+	require.NoError(pool.Start(ctx))
+	defer func() {
+		require.NoError(pool.Stop(ctx))
+	}()
+	var evidences []*Evidence
+	for _, height := range []uint64{9, 11} {
+		a := makeVote(t, identityset.PrivateKey(1), height, hashA, PROPOSAL, ts)
+		b := makeVote(t, identityset.PrivateKey(1), height, hashB, PROPOSAL, ts)
+		evidence, err := NewEvidence(a, b)
+		require.NoError(err)
+		added, err := pool.Add(evidence)
+		require.NoError(err)
+		require.True(added)
+		// the evidence of the same messages is added once
+		evidence, err = NewEvidence(b, a)
+		require.NoError(err)
+		added, err = pool.Add(evidence)
+		require.NoError(err)
+		require.False(added)
+		evidences = append(evidences, evidence)
+	}
+
+	loaded, err := pool.Evidences(0, math.MaxUint64)
+	require.NoError(err)
+	require.Equal(2, len(loaded))
+	for i, e := range loaded {
+		expected, err := evidences[i].Hash()
+		require.NoError(err)
+		h, err := e.Hash()
+		require.NoError(err)
+		require.Equal(expected, h)
+		require.True(endorsement.VerifyEndorsedDocument(e.First()))
+		require.True(endorsement.VerifyEndorsedDocument(e.Second()))
+	}
+	loaded, err = pool.Evidences(10, 2)
+	require.NoError(err)
+	require.Equal(1, len(loaded))
+	require.Equal(uint64(11), loaded[0].Height())
+	loaded, err = pool.Evidences(10, 1)
+	require.NoError(err)
+	require.Equal(0, len(loaded))
+}
+
+func TestRollDPoS_Equivocation(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().GenesisTimestamp().Return(int64(1500000000)).AnyTimes()
+	footer := &block.Footer{}
+	commitTime, err := ptypes.TimestampProto(time.Unix(1500000100, 0))
+	require.NoError(err)
+	require.NoError(footer.ConvertFromBlockFooterPb(&iotextypes.BlockFooter{Timestamp: commitTime}))
+	chain.EXPECT().BlockFooterByHeight(gomock.Any()).Return(footer, nil).AnyTimes()
+	candidates := make([]*state.Candidate, 5)
+	for i := range candidates {
+		candidates[i] = &state.Candidate{Address: identityset.Address(i).String()}
+	}
+	chain.EXPECT().CandidatesByHeight(gomock.Any()).Return(candidates, nil).AnyTimes()
+	chain.EXPECT().TipHeight().Return(uint64(8)).AnyTimes()
+	cfg := config.Default
+	cfg.Genesis.NumDelegates = 4
+	cfg.Genesis.NumSubEpochs = 1
+	cfg.Genesis.BlockInterval = 10 * time.Second
+	newRollDPoS := func(broadcast func(proto.Message) error) *RollDPoS {
+		r, err := NewRollDPoSBuilder().
+			SetConfig(cfg).
+			SetAddr(identityset.Address(0).String()).
+			SetPriKey(identityset.PrivateKey(0)).
+			SetBlockchain(chain).
+			SetActPool(mock_actpool.NewMockActPool(ctrl)).
+			SetBroadcast(broadcast).
+			SetClock(clock.NewMock()).
+			RegisterProtocol(rolldpos.NewProtocol(
+				cfg.Genesis.NumCandidateDelegates,
+				cfg.Genesis.NumDelegates,
+				cfg.Genesis.NumSubEpochs,
+			)).
+			Build()
+		require.NoError(err)
+		require.NoError(r.ctx.evidencePool.Start(ctx))
+		return r
+	}
+	var broadcasted []*iotextypes.ConsensusMessage
+	r := newRollDPoS(func(msg proto.Message) error {
+		broadcasted = append(broadcasted, msg.(*iotextypes.ConsensusMessage))
+		return nil
+	})
+	defer func() {
+		require.NoError(r.ctx.evidencePool.Stop(ctx))
+	}()
+
+	// find a delegate and a non-delegate of height 9
+	delegates, err := r.ctx.roundCalc.Delegates(9)
+	require.NoError(err)
+	delegate, nonDelegate := -1, -1
+	for i := range candidates {
+		isDelegate := false
+		for _, d := range delegates {
+			isDelegate = isDelegate || d == identityset.Address(i).String()
+		}
+		if isDelegate && delegate < 0 {
+			delegate = i
+		}
+		if !isDelegate {
+			nonDelegate = i
+		}
+	}
+	require.True(delegate >= 0 && nonDelegate >= 0)
+
+	ts := time.Unix(1500000200, 0)
+	hashA := hash.Hash256b([]byte("a"))
+	hashB := hash.Hash256b([]byte("b"))
+	a := makeVote(t, identityset.PrivateKey(delegate), 9, hashA, LOCK, ts)
+	b := makeVote(t, identityset.PrivateKey(delegate), 9, hashB, LOCK, ts.Add(time.Second))
+	r.checkEquivocation(a)
+	require.Equal(0, len(broadcasted))
+	r.checkEquivocation(b)
+	require.Equal(1, len(broadcasted))
+	require.NotNil(broadcasted[0].GetEvidence())
+	evidences, err := r.ctx.evidencePool.Evidences(9, 1)
+	require.NoError(err)
+	require.Equal(1, len(evidences))
+	require.NoError(r.ctx.VerifyEvidence(evidences[0]))
+
+	// the evidence received is verified and persisted, but not broadcasted again
+	var rebroadcasted []proto.Message
+	r2 := newRollDPoS(func(msg proto.Message) error {
+		rebroadcasted = append(rebroadcasted, msg)
+		return nil
+	})
+	defer func() {
+		require.NoError(r2.ctx.evidencePool.Stop(ctx))
+	}()
+	require.NoError(r2.handleEvidence(broadcasted[0]))
+	evidences, err = r2.ctx.evidencePool.Evidences(9, 1)
+	require.NoError(err)
+	require.Equal(1, len(evidences))
+	require.Equal(0, len(rebroadcasted))
+
+	// invalid evidences
+	for _, c := range []struct {
+		first  *EndorsedConsensusMessage
+		second *EndorsedConsensusMessage
+	}{
+		// the same block
+		{a, makeVote(t, identityset.PrivateKey(delegate), 9, hashA, LOCK, ts.Add(time.Second))},
+		// different topics
+		{a, makeVote(t, identityset.PrivateKey(delegate), 9, hashB, COMMIT, ts)},
+		// different rounds
+		{a, makeVote(t, identityset.PrivateKey(delegate), 9, hashB, LOCK, ts.Add(cfg.Genesis.BlockInterval))},
+		// different endorsers
+		{a, makeVote(t, identityset.PrivateKey(nonDelegate), 9, hashB, LOCK, ts)},
+		// not a delegate
+		{
+			makeVote(t, identityset.PrivateKey(nonDelegate), 9, hashA, LOCK, ts),
+			makeVote(t, identityset.PrivateKey(nonDelegate), 9, hashB, LOCK, ts),
+		},
+	} {
+		evidence, err := NewEvidence(c.first, c.second)
+		require.NoError(err)
+		require.Error(r.ctx.VerifyEvidence(evidence))
+	}
+	// a forged signature
+	forged := NewEndorsedConsensusMessage(9, NewConsensusVote(hashB[:], LOCK), a.Endorsement())
+	evidence, err := NewEvidence(a, forged)
+	require.NoError(err)
+	require.Error(r.ctx.VerifyEvidence(evidence))
+}
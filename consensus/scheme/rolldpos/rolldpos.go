@@ -15,6 +15,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
@@ -22,11 +23,13 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 var (
@@ -45,11 +48,47 @@ var (
 		},
 		[]string{},
 	)
+
+	evidenceMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_evidences",
+			Help: "Number of equivocation evidences, detected locally or received from the network",
+		},
+		[]string{"source"},
+	)
+
+	roundMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_rounds",
+			Help: "Number of consensus rounds observed by the node, by outcome",
+		},
+		[]string{"outcome"},
+	)
+
+	missedProposalMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_missed_proposals",
+			Help: "Number of proposals missed by the delegates",
+		},
+		[]string{"delegate"},
+	)
+
+	missedEndorsementMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_missed_endorsements",
+			Help: "Number of phases in which the delegates miss the endorsements",
+		},
+		[]string{"delegate"},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(timeSlotMtc)
 	prometheus.MustRegister(blockIntervalMtc)
+	prometheus.MustRegister(evidenceMtc)
+	prometheus.MustRegister(roundMtc)
+	prometheus.MustRegister(missedProposalMtc)
+	prometheus.MustRegister(missedEndorsementMtc)
 }
 
 var (
@@ -90,8 +129,31 @@ type RollDPoS struct {
 
 // Start starts RollDPoS consensus
 func (r *RollDPoS) Start(ctx context.Context) error {
-	if err := r.cfsm.Start(ctx); err != nil {
-		return errors.Wrap(err, "error when starting the consensus FSM")
+	return r.start(ctx, true)
+}
+
+// StartStepping starts RollDPoS consensus without the event loop of the consensus FSM, whose events are then handled
+// one by one with Step
+func (r *RollDPoS) StartStepping(ctx context.Context) error {
+	return r.start(ctx, false)
+}
+
+// Step handles the next pending event of the consensus FSM, and returns false if there is none
+func (r *RollDPoS) Step() bool {
+	return r.cfsm.Step()
+}
+
+func (r *RollDPoS) start(ctx context.Context, loop bool) error {
+	if err := r.ctx.evidencePool.Start(ctx); err != nil {
+		return errors.Wrap(err, "error when starting the evidence pool")
+	}
+	if err := r.ctx.Restore(); err != nil {
+		return errors.Wrap(err, "error when restoring from the consensus WAL")
+	}
+	if loop {
+		if err := r.cfsm.Start(ctx); err != nil {
+			return errors.Wrap(err, "error when starting the consensus FSM")
+		}
 	}
 	if _, err := r.cfsm.BackToPrepare(r.ctx.cfg.Delay); err != nil {
 		return err
@@ -102,12 +164,22 @@ func (r *RollDPoS) Start(ctx context.Context) error {
 
 // Stop stops RollDPoS consensus
 func (r *RollDPoS) Stop(ctx context.Context) error {
-	return errors.Wrap(r.cfsm.Stop(ctx), "error when stopping the consensus FSM")
+	if err := r.cfsm.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping the consensus FSM")
+	}
+	if err := r.ctx.wal.close(); err != nil {
+		return errors.Wrap(err, "error when closing the consensus WAL")
+	}
+	return errors.Wrap(r.ctx.evidencePool.Stop(ctx), "error when stopping the evidence pool")
 }
 
 // HandleConsensusMsg handles incoming consensus message
 func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	<-r.ready
+	if msg.GetEvidence() != nil {
+		// an evidence may be of any past height
+		return r.handleEvidence(msg)
+	}
 	consensusHeight := r.ctx.Height()
 	switch {
 	case consensusHeight == 0:
@@ -141,12 +213,16 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 		if err := r.ctx.CheckBlockProposer(endorsedMessage.Height(), consensusMessage, en); err != nil {
 			return errors.Wrap(err, "failed to verify block proposal")
 		}
+		r.checkEquivocation(endorsedMessage)
+		r.recordArrival(endorsedMessage)
 		r.cfsm.ProduceReceiveBlockEvent(endorsedMessage)
 		return nil
 	case *ConsensusVote:
 		if err := r.ctx.CheckVoteEndorser(endorsedMessage.Height(), consensusMessage, en); err != nil {
 			return errors.Wrapf(err, "failed to verify vote")
 		}
+		r.checkEquivocation(endorsedMessage)
+		r.recordArrival(endorsedMessage)
 		switch consensusMessage.Topic() {
 		case PROPOSAL:
 			r.cfsm.ProduceReceiveProposalEndorsementEvent(endorsedMessage)
@@ -162,6 +238,49 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	}
 }
 
+func (r *RollDPoS) handleEvidence(msg *iotextypes.ConsensusMessage) error {
+	endorsedMessage := &EndorsedConsensusMessage{}
+	if err := endorsedMessage.LoadProto(msg); err != nil {
+		return errors.Wrapf(err, "failed to decode endorsed consensus message")
+	}
+	if !endorsement.VerifyEndorsedDocument(endorsedMessage) {
+		return errors.New("failed to verify signature in endorsement")
+	}
+	evidence, ok := endorsedMessage.Document().(*Evidence)
+	if !ok {
+		return errors.New("invalid evidence")
+	}
+	if err := r.ctx.VerifyEvidence(evidence); err != nil {
+		return errors.Wrap(err, "failed to verify evidence")
+	}
+	return r.ctx.AddEvidence(evidence, false)
+}
+
+// checkEquivocation reports the evidence if the endorser of a verified message has endorsed a conflicting one
+func (r *RollDPoS) checkEquivocation(msg *EndorsedConsensusMessage) {
+	if msg.Height() > r.ctx.chain.TipHeight()+1 {
+		// the round of a future message is unknown until the previous block is committed
+		return
+	}
+	evidence, err := r.ctx.CheckEquivocation(msg)
+	if err == nil && evidence != nil {
+		err = r.ctx.AddEvidence(evidence, true)
+	}
+	if err != nil {
+		log.Logger("consensus").Error("Failed to check equivocation.", zap.Error(err))
+	}
+}
+
+// recordArrival records the arrival of a verified message in the timeline
+func (r *RollDPoS) recordArrival(msg *EndorsedConsensusMessage) {
+	if msg.Height() > r.ctx.chain.TipHeight()+1 {
+		return
+	}
+	if err := r.ctx.RecordArrival(msg); err != nil {
+		log.Logger("consensus").Error("Failed to record the arrival of consensus message.", zap.Error(err))
+	}
+}
+
 // Calibrate called on receive a new block not via consensus
 func (r *RollDPoS) Calibrate(height uint64) {
 	r.cfsm.Calibrate(height)
@@ -199,6 +318,61 @@ func (r *RollDPoS) ValidateBlockFooter(blk *block.Block) error {
 	return nil
 }
 
+// ValidateHeaderFooter validates the proposer and the endorsements of a block header ahead of the block, as
+// ValidateBlockFooter does. prevFooter is the footer of the previous block, or nil if the previous block is in the
+// chain. The delegates of the epochs after the one of the next height are unknown yet, so the headers of them are not
+// verifiable.
+func (r *RollDPoS) ValidateHeaderFooter(header *block.Header, footer *block.Footer, prevFooter *block.Footer) error {
+	height := header.Height()
+	rc := r.ctx.RoundCalc()
+	if rc.rp.GetEpochNum(height) > rc.rp.GetEpochNum(r.ctx.chain.TipHeight()+1) {
+		return errors.Wrapf(scheme.ErrNotVerifiable, "delegates of height %d are unknown yet", height)
+	}
+	delegates, err := rc.Delegates(height)
+	if err != nil {
+		return errors.Wrapf(scheme.ErrNotVerifiable, "failed to get delegates of height %d: %v", height, err)
+	}
+	if prevFooter == nil && height > 1 {
+		if prevFooter, err = r.ctx.chain.BlockFooterByHeight(height - 1); err != nil {
+			return errors.Wrapf(scheme.ErrNotVerifiable, "failed to get footer of height %d: %v", height-1, err)
+		}
+	}
+	roundNum, _, err := rc.roundInfoAfter(prevFooter, header.Timestamp(), false)
+	if err != nil {
+		return err
+	}
+	proposer, err := rc.calculateProposer(height, roundNum, delegates)
+	if err != nil {
+		return err
+	}
+	if proposer != header.ProducerAddress() {
+		return errors.Errorf("block proposer %s is invalid, %s expected", header.ProducerAddress(), proposer)
+	}
+	isDelegate := make(map[string]bool, len(delegates))
+	for _, d := range delegates {
+		isDelegate[d] = true
+	}
+	blkHash := header.HashBlock()
+	vote := NewConsensusVote(blkHash[:], COMMIT)
+	endorsers := make(map[string]bool)
+	for _, en := range footer.Endorsements() {
+		if !endorsement.VerifyEndorsement(vote, en) {
+			return errors.New("invalid endorsement for the vote")
+		}
+		endorser, err := address.FromBytes(en.Endorser().Hash())
+		if err != nil {
+			return err
+		}
+		if isDelegate[endorser.String()] {
+			endorsers[endorser.String()] = true
+		}
+	}
+	if 3*len(endorsers) <= 2*len(delegates) {
+		return ErrInsufficientEndorsements
+	}
+	return nil
+}
+
 // Metrics returns RollDPoS consensus metrics
 func (r *RollDPoS) Metrics() (scheme.ConsensusMetrics, error) {
 	var metrics scheme.ConsensusMetrics
@@ -248,12 +422,13 @@ func (r *RollDPoS) Active() bool {
 // Builder is the builder for RollDPoS
 type Builder struct {
 	cfg config.Config
-	// TODO: we should use keystore in the future
 	encodedAddr            string
-	priKey                 keypair.PrivateKey
+	signer                 signer.Signer
 	chain                  blockchain.Blockchain
 	actPool                actpool.ActPool
 	broadcastHandler       scheme.Broadcast
+	evidencePool           *EvidencePool
+	timeline               *Timeline
 	clock                  clock.Clock
 	rootChainAPI           explorer.Explorer
 	rp                     *rolldpos.Protocol
@@ -277,9 +452,15 @@ func (b *Builder) SetAddr(encodedAddr string) *Builder {
 	return b
 }
 
-// SetPriKey sets the private key
+// SetPriKey sets the private key, which signs in process
 func (b *Builder) SetPriKey(priKey keypair.PrivateKey) *Builder {
-	b.priKey = priKey
+	b.signer = signer.NewInProcess(priKey)
+	return b
+}
+
+// SetSigner sets the signer of the endorsements and the blocks
+func (b *Builder) SetSigner(s signer.Signer) *Builder {
+	b.signer = s
 	return b
 }
 
@@ -301,6 +482,18 @@ func (b *Builder) SetBroadcast(broadcastHandler scheme.Broadcast) *Builder {
 	return b
 }
 
+// SetEvidencePool sets the pool persisting the equivocation evidences
+func (b *Builder) SetEvidencePool(evidencePool *EvidencePool) *Builder {
+	b.evidencePool = evidencePool
+	return b
+}
+
+// SetTimeline sets the timeline recording the consensus rounds
+func (b *Builder) SetTimeline(timeline *Timeline) *Builder {
+	b.timeline = timeline
+	return b
+}
+
 // SetClock sets the clock
 func (b *Builder) SetClock(clock clock.Clock) *Builder {
 	b.clock = clock
@@ -341,6 +534,12 @@ func (b *Builder) Build() (*RollDPoS, error) {
 	if b.clock == nil {
 		b.clock = clock.New()
 	}
+	if b.evidencePool == nil {
+		b.evidencePool = NewEvidencePool(db.NewMemKVStore())
+	}
+	if b.timeline == nil {
+		b.timeline = NewTimeline(b.cfg.Consensus.RollDPoS.TimelineSize, b.cfg.Consensus.RollDPoS.FSM)
+	}
 	ctx := newRollDPoSCtx(
 		b.cfg.Consensus.RollDPoS,
 		b.cfg.System.Active,
@@ -352,9 +551,11 @@ func (b *Builder) Build() (*RollDPoS, error) {
 		b.actPool,
 		b.rp,
 		b.broadcastHandler,
+		b.evidencePool,
+		b.timeline,
 		b.candidatesByHeightFunc,
 		b.encodedAddr,
-		b.priKey,
+		b.signer,
 		b.clock,
 	)
 	cfsm, err := consensusfsm.NewConsensusFSM(b.cfg.Consensus.RollDPoS.FSM, ctx, b.clock)
@@ -33,12 +33,15 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
 	cp "github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/p2p/node"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/test/identityset"
@@ -258,6 +261,101 @@ func TestValidateBlockFooter(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestValidateHeaderFooter(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	candidates := make([]*state.Candidate, 5)
+	for i := range candidates {
+		candidates[i] = &state.Candidate{Address: identityset.Address(i).String()}
+	}
+	footer := &block.Footer{}
+	blockchain := mock_blockchain.NewMockBlockchain(ctrl)
+	blockchain.EXPECT().GenesisTimestamp().Return(int64(1500000000)).AnyTimes()
+	blockchain.EXPECT().TipHeight().Return(uint64(8)).AnyTimes()
+	blockchain.EXPECT().BlockFooterByHeight(uint64(8)).Return(footer, nil).Times(1)
+	blockchain.EXPECT().CandidatesByHeight(gomock.Any()).Return(candidates, nil).AnyTimes()
+
+	cfg := config.Default
+	cfg.Genesis.NumDelegates = 4
+	cfg.Genesis.NumSubEpochs = 1
+	cfg.Genesis.BlockInterval = 10 * time.Second
+	rp := rolldpos.NewProtocol(
+		cfg.Genesis.NumCandidateDelegates,
+		cfg.Genesis.NumDelegates,
+		cfg.Genesis.NumSubEpochs,
+	)
+	r, err := NewRollDPoSBuilder().
+		SetConfig(cfg).
+		SetAddr(identityset.Address(1).String()).
+		SetPriKey(identityset.PrivateKey(1)).
+		SetBlockchain(blockchain).
+		SetActPool(mock_actpool.NewMockActPool(ctrl)).
+		SetBroadcast(func(_ proto.Message) error {
+			return nil
+		}).
+		SetClock(clock.NewMock()).
+		RegisterProtocol(rp).
+		Build()
+	require.NoError(err)
+
+	delegates, err := r.ctx.RoundCalc().Delegates(9)
+	require.NoError(err)
+	require.Len(delegates, 4)
+	var delegateKeys []int
+	nonDelegateKey := -1
+	for i, c := range candidates {
+		if r.ctx.RoundCalc().IsDelegate(c.Address, 9) {
+			delegateKeys = append(delegateKeys, i)
+		} else {
+			nonDelegateKey = i
+		}
+	}
+	// endorse makes a block of proposer 1 endorsed by the given keys
+	endorse := func(keys []int, topic ConsensusVoteTopic) *block.Block {
+		blk := makeBlock(t, 1, 0, false, 9)
+		blkHash := blk.HashBlock()
+		footer := iotextypes.BlockFooter{}
+		for _, i := range keys {
+			en, err := endorsement.Endorse(identityset.PrivateKey(i), NewConsensusVote(blkHash[:], topic), time.Unix(1500000000, 0))
+			require.NoError(err)
+			enProto, err := en.Proto()
+			require.NoError(err)
+			footer.Endorsements = append(footer.Endorsements, enProto)
+		}
+		footer.Timestamp, err = ptypes.TimestampProto(time.Unix(1500000000, 0))
+		require.NoError(err)
+		require.NoError(blk.Footer.ConvertFromBlockFooterPb(&footer))
+		return blk
+	}
+
+	// the previous footer is read from the chain if not given
+	blk := endorse(delegateKeys[:3], COMMIT)
+	require.NoError(r.ValidateHeaderFooter(&blk.Header, &blk.Footer, nil))
+	require.NoError(r.ValidateHeaderFooter(&blk.Header, &blk.Footer, footer))
+
+	// proposer is wrong
+	blk = makeBlock(t, 0, 0, false, 9)
+	require.Error(r.ValidateHeaderFooter(&blk.Header, &blk.Footer, footer))
+	// not enough endorsements
+	blk = endorse(delegateKeys[:2], COMMIT)
+	require.Equal(ErrInsufficientEndorsements, errors.Cause(r.ValidateHeaderFooter(&blk.Header, &blk.Footer, footer)))
+	// endorsements of the same delegate or of a non-delegate are not counted
+	blk = endorse(append([]int{nonDelegateKey}, delegateKeys[0], delegateKeys[1], delegateKeys[1]), COMMIT)
+	require.Equal(ErrInsufficientEndorsements, errors.Cause(r.ValidateHeaderFooter(&blk.Header, &blk.Footer, footer)))
+	// some endorsement is invalid
+	blk = endorse(delegateKeys, LOCK)
+	require.Error(r.ValidateHeaderFooter(&blk.Header, &blk.Footer, footer))
+
+	// the delegates of the epochs after the next height's are unknown
+	header, err := block.NewTestingBuilder().
+		SetHeight(13).
+		SetTimeStamp(time.Now()).
+		SignAndBuild(identityset.PrivateKey(1).PublicKey(), identityset.PrivateKey(1))
+	require.NoError(err)
+	require.Equal(scheme.ErrNotVerifiable, errors.Cause(r.ValidateHeaderFooter(&header.Header, &header.Footer, footer)))
+}
+
 func TestRollDPoS_Metrics(t *testing.T) {
 	t.Parallel()
 
@@ -354,9 +452,11 @@ func makeTestRollDPoSCtx(
 			cfg.Genesis.NumSubEpochs,
 		),
 		broadcastCB,
+		NewEvidencePool(db.NewMemKVStore()),
+		NewTimeline(cfg.Consensus.RollDPoS.TimelineSize, cfg.Consensus.RollDPoS.FSM),
 		chain.CandidatesByHeight,
 		addr.encodedAddr,
-		addr.priKey,
+		signer.NewInProcess(addr.priKey),
 		clock,
 	)
 }
@@ -407,6 +507,8 @@ func TestRollDPoSConsensus(t *testing.T) {
 		cfg.Consensus.RollDPoS.FSM.UnmatchedEventTTL = time.Second
 		cfg.Consensus.RollDPoS.FSM.UnmatchedEventInterval = 10 * time.Millisecond
 		cfg.Consensus.RollDPoS.ToleratedOvertime = 200 * time.Millisecond
+		// the delegates run in the same process keep their WALs in memory
+		cfg.Consensus.RollDPoS.WALPath = ""
 
 		cfg.Genesis.BlockInterval = time.Second
 		cfg.Genesis.Blockchain.NumDelegates = uint64(numNodes)
@@ -7,10 +7,12 @@
 package rolldpos
 
 import (
+	"bytes"
 	"sync"
 	"time"
 
 	"github.com/facebookgo/clock"
+	"github.com/golang/protobuf/proto"
 	"github.com/iotexproject/go-fsm"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -19,13 +21,15 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
-	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/state"
 )
 
@@ -39,9 +43,13 @@ type rollDPoSCtx struct {
 	actPool          actpool.ActPool
 	broadcastHandler scheme.Broadcast
 	roundCalc        *roundCalculator
+	detector         *equivocationDetector
+	evidencePool     *EvidencePool
+	timeline         *Timeline
+	wal              *wal
 
 	encodedAddr string
-	priKey      keypair.PrivateKey
+	signer      signer.Signer
 	round       *roundCtx
 	clock       clock.Clock
 	active      bool
@@ -59,9 +67,11 @@ func newRollDPoSCtx(
 	actPool actpool.ActPool,
 	rp *rolldpos.Protocol,
 	broadcastHandler scheme.Broadcast,
+	evidencePool *EvidencePool,
+	timeline *Timeline,
 	candidatesByHeightFunc CandidatesByHeightFunc,
 	encodedAddr string,
-	priKey keypair.PrivateKey,
+	producerSigner signer.Signer,
 	clock clock.Clock,
 ) *rollDPoSCtx {
 	if candidatesByHeightFunc == nil {
@@ -84,13 +94,17 @@ func newRollDPoSCtx(
 		cfg:              cfg,
 		active:           active,
 		encodedAddr:      encodedAddr,
-		priKey:           priKey,
+		signer:           producerSigner,
 		chain:            chain,
 		actPool:          actPool,
 		broadcastHandler: broadcastHandler,
 		clock:            clock,
 		rootChainAPI:     rootChainAPI,
 		roundCalc:        roundCalc,
+		detector:         newEquivocationDetector(),
+		evidencePool:     evidencePool,
+		timeline:         timeline,
+		wal:              newWAL(cfg.WALPath),
 		round:            round,
 	}
 }
@@ -171,10 +185,223 @@ func (ctx *rollDPoSCtx) CheckBlockProposer(
 	return nil
 }
 
+// CheckEquivocation records a verified consensus message, and returns an evidence if its endorser has endorsed a
+// conflicting message in the same round
+func (ctx *rollDPoSCtx) CheckEquivocation(msg *EndorsedConsensusMessage) (*Evidence, error) {
+	roundNum, _, err := ctx.roundCalc.RoundInfo(msg.Height(), msg.Endorsement().Timestamp())
+	if err != nil {
+		return nil, err
+	}
+	return ctx.detector.Detect(roundNum, msg)
+}
+
+// VerifyEvidence checks that the evidence proves a delegate endorsed two conflicting messages
+func (ctx *rollDPoSCtx) VerifyEvidence(e *Evidence) error {
+	first, second := e.First(), e.Second()
+	if first.Height() != second.Height() {
+		return errors.New("conflicting messages of different heights")
+	}
+	if !bytes.Equal(first.Endorsement().Endorser().Bytes(), second.Endorsement().Endorser().Bytes()) {
+		return errors.New("conflicting messages of different endorsers")
+	}
+	if !endorsement.VerifyEndorsedDocument(first) || !endorsement.VerifyEndorsedDocument(second) {
+		return errors.New("failed to verify signature in endorsement")
+	}
+	firstSlot, firstHash, err := slotOf(first)
+	if err != nil {
+		return err
+	}
+	secondSlot, secondHash, err := slotOf(second)
+	if err != nil {
+		return err
+	}
+	if firstSlot != secondSlot || bytes.Equal(firstHash, secondHash) {
+		return errors.New("messages are not conflicting")
+	}
+	firstRound, _, err := ctx.roundCalc.RoundInfo(first.Height(), first.Endorsement().Timestamp())
+	if err != nil {
+		return err
+	}
+	secondRound, _, err := ctx.roundCalc.RoundInfo(second.Height(), second.Endorsement().Timestamp())
+	if err != nil {
+		return err
+	}
+	if firstRound != secondRound {
+		return errors.New("conflicting messages of different rounds")
+	}
+	endorserAddr, err := address.FromBytes(e.Endorser().Hash())
+	if err != nil {
+		return err
+	}
+	if !ctx.roundCalc.IsDelegate(endorserAddr.String(), first.Height()) {
+		return errors.Errorf("%s is not delegate of the corresponding round", endorserAddr)
+	}
+	return nil
+}
+
+// AddEvidence persists a verified evidence, and broadcasts it endorsed by the node if it is newly detected
+func (ctx *rollDPoSCtx) AddEvidence(e *Evidence, broadcast bool) error {
+	added, err := ctx.evidencePool.Add(e)
+	if err != nil || !added {
+		return err
+	}
+	endorserAddr, err := address.FromBytes(e.Endorser().Hash())
+	if err != nil {
+		return err
+	}
+	source := "remote"
+	if broadcast {
+		source = "local"
+	}
+	evidenceMtc.WithLabelValues(source).Inc()
+	log.Logger("consensus").Warn(
+		"equivocation detected",
+		zap.String("endorser", endorserAddr.String()),
+		zap.Uint64("height", e.Height()),
+		zap.String("source", source),
+	)
+	if !broadcast {
+		return nil
+	}
+	evidence, err := e.Proto()
+	if err != nil {
+		return err
+	}
+	evidenceBytes, err := proto.Marshal(evidence)
+	if err != nil {
+		return err
+	}
+	ts := ctx.clock.Now()
+	en, err := endorsement.EndorseWith(
+		ctx.signer.PublicKey(),
+		func([]byte) ([]byte, error) {
+			return ctx.signer.SignEvidence(evidenceBytes, ts)
+		},
+		e,
+		ts,
+	)
+	if err != nil {
+		return err
+	}
+	msg, err := NewEndorsedConsensusMessage(e.Height(), e, en).Proto()
+	if err != nil {
+		return err
+	}
+	return ctx.broadcastHandler(msg)
+}
+
+// RecordArrival records the arrival of a verified consensus message in the timeline of its round
+func (ctx *rollDPoSCtx) RecordArrival(msg *EndorsedConsensusMessage) error {
+	roundNum, _, err := ctx.roundCalc.RoundInfo(msg.Height(), msg.Endorsement().Timestamp())
+	if err != nil {
+		return err
+	}
+	switch doc := msg.Document().(type) {
+	case *blockProposal:
+		ctx.timeline.AddProposal(msg.Height(), roundNum, ctx.clock.Now())
+	case *ConsensusVote:
+		endorserAddr, err := address.FromBytes(msg.Endorsement().Endorser().Hash())
+		if err != nil {
+			return err
+		}
+		ctx.timeline.AddEndorsement(msg.Height(), roundNum, endorserAddr.String(), doc.Topic(), ctx.clock.Now())
+	}
+	return nil
+}
+
 func (ctx *rollDPoSCtx) RoundCalc() *roundCalculator {
 	return ctx.roundCalc
 }
 
+// Restore replays the records of the next height in the write-ahead log, restoring the block locked by the node and
+// the messages it has endorsed in the round, and opens the log for appending
+func (ctx *rollDPoSCtx) Restore() error {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	records, err := ctx.wal.load()
+	if err != nil {
+		return err
+	}
+	height := ctx.chain.TipHeight() + 1
+	var kept []*iotextypes.ConsensusWALRecord
+	for _, record := range records {
+		if record.Height == height {
+			kept = append(kept, record)
+		}
+	}
+	if err := ctx.wal.reset(height, kept); err != nil {
+		return err
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	round, err := ctx.roundCalc.NewRoundWithToleration(height, ctx.clock.Now())
+	if err != nil {
+		return err
+	}
+	var messages int
+	for _, record := range kept {
+		switch {
+		case record.GetLock() != nil:
+			lock := &blockProposal{}
+			if err := lock.LoadProto(record.GetLock()); err != nil {
+				return errors.Wrap(err, "failed to load the lock from WAL")
+			}
+			if err := ctx.restoreBlock(round, lock.block); err != nil {
+				return err
+			}
+			blkHash := lock.block.HashBlock()
+			round.status = locked
+			round.blockInLock = blkHash[:]
+			round.proofOfLock = lock.proofOfLock
+		case record.GetMessage() != nil:
+			msg := &EndorsedConsensusMessage{}
+			if err := msg.LoadProto(record.GetMessage()); err != nil {
+				return errors.Wrap(err, "failed to load the endorsed message from WAL")
+			}
+			roundNum, _, err := ctx.roundCalc.RoundInfo(height, msg.Endorsement().Timestamp())
+			if err != nil {
+				return err
+			}
+			if err := ctx.wal.Remember(roundNum, msg); err != nil {
+				return err
+			}
+			messages++
+			switch doc := msg.Document().(type) {
+			case *blockProposal:
+				if err := ctx.restoreBlock(round, doc.block); err != nil {
+					return err
+				}
+			case *ConsensusVote:
+				// the own vote is counted again, if the block has been restored
+				if err := round.AddVoteEndorsement(doc, msg.Endorsement()); err != nil {
+					ctx.logger().Debug("Failed to restore vote from WAL.", zap.Error(err))
+				}
+			}
+		}
+	}
+	ctx.round = round
+	ctx.logger().Info(
+		"Restored round from consensus WAL.",
+		zap.Int("messages", messages),
+		zap.Bool("locked", round.IsLocked()),
+	)
+	return nil
+}
+
+// restoreBlock adds a block restored from the write-ahead log to the round, after validating it to regenerate its
+// working set
+func (ctx *rollDPoSCtx) restoreBlock(round *roundCtx, blk *block.Block) error {
+	blkHash := blk.HashBlock()
+	if round.Block(blkHash[:]) != nil {
+		return nil
+	}
+	if err := ctx.chain.ValidateBlock(blk); err != nil {
+		return errors.Wrap(err, "failed to validate the block restored from WAL")
+	}
+	return round.AddBlock(blk)
+}
+
 /////////////////////////////////////
 // ConsensusFSM interfaces
 /////////////////////////////////////
@@ -230,7 +457,20 @@ func (ctx *rollDPoSCtx) Prepare() (
 		zap.Uint32("round", newRound.roundNum),
 		zap.String("roundStartTime", newRound.roundStartTime.String()),
 	)
+	if newRound.height != ctx.wal.Height() {
+		if err = ctx.wal.reset(newRound.height, nil); err != nil {
+			return
+		}
+	}
 	ctx.round = newRound
+	ctx.detector.Prune(newRound.height)
+	ctx.timeline.StartRound(
+		newRound.height,
+		newRound.roundNum,
+		newRound.proposer,
+		newRound.delegates,
+		newRound.roundStartTime,
+	)
 	if active = ctx.active; !active {
 		ctx.logger().Info("current node is in standby mode")
 		delay = ctx.round.NextRoundStartTime().Sub(ctx.clock.Now())
@@ -303,6 +543,12 @@ func (ctx *rollDPoSCtx) NewLockEndorsement(
 	case nil:
 		if len(blkHash) != 0 {
 			ctx.loggerWithStats().Debug("Locked", log.Hex("block", blkHash))
+			if ctx.round.IsLocked() {
+				lock := newBlockProposal(ctx.round.Block(ctx.round.HashOfBlockInLock()), ctx.round.ProofOfLock())
+				if err := ctx.wal.AppendLock(lock); err != nil {
+					return nil, errors.Wrap(err, "failed to write the lock to WAL")
+				}
+			}
 			return ctx.newEndorsement(
 				blkHash,
 				LOCK,
@@ -373,7 +619,7 @@ func (ctx *rollDPoSCtx) Commit(msg interface{}) (bool, error) {
 	case blockchain.ErrInvalidTipHeight:
 		return true, nil
 	case nil:
-		break
+		ctx.timeline.Commit(ctx.round.Height(), ctx.round.Number(), ctx.clock.Now())
 	default:
 		return false, errors.Wrap(err, "error when committing a block")
 	}
@@ -390,7 +636,7 @@ func (ctx *rollDPoSCtx) Commit(msg interface{}) (bool, error) {
 		}
 		// putblock to parent chain if the current node is proposer and current chain is a sub chain
 		if ctx.round.Proposer() == ctx.encodedAddr && ctx.chain.ChainAddress() != "" {
-			putBlockToParentChain(ctx.rootChainAPI, ctx.chain.ChainAddress(), ctx.priKey, ctx.encodedAddr, pendingBlock)
+			putBlockToParentChain(ctx.rootChainAPI, ctx.chain.ChainAddress(), ctx.signer, ctx.encodedAddr, pendingBlock)
 		}
 	} else {
 		ctx.logger().Panic(
@@ -466,6 +712,11 @@ func (ctx *rollDPoSCtx) Active() bool {
 ///////////////////////////////////////////
 
 func (ctx *rollDPoSCtx) mintBlock() (*EndorsedConsensusMessage, error) {
+	if endorsed := ctx.wal.Endorsed(ctx.round.Number(), proposalSlot); endorsed != nil {
+		// the node has proposed in this round before a restart
+		ctx.timeline.AddProposal(ctx.round.Height(), ctx.round.Number(), ctx.clock.Now())
+		return endorsed, nil
+	}
 	var proposal *blockProposal
 	if ctx.round.IsLocked() {
 		proposal = newBlockProposal(
@@ -488,7 +739,7 @@ func (ctx *rollDPoSCtx) mintBlock() (*EndorsedConsensusMessage, error) {
 		}
 		proposal = newBlockProposal(blk, proofOfUnlock)
 	}
-	en, err := endorsement.Endorse(ctx.priKey, proposal, ctx.round.StartTime())
+	en, err := ctx.endorse(proposal, ctx.round.StartTime())
 	if err != nil {
 		return nil, err
 	}
@@ -498,6 +749,10 @@ func (ctx *rollDPoSCtx) mintBlock() (*EndorsedConsensusMessage, error) {
 		zap.Uint64("height", ctx.round.Height()),
 		zap.Int("actions", len(proposal.block.Actions)),
 	)
+	if endorsedProposal, err = ctx.wal.AppendMessage(ctx.round.Number(), endorsedProposal); err != nil {
+		return nil, err
+	}
+	ctx.timeline.AddProposal(ctx.round.Height(), ctx.round.Number(), ctx.clock.Now())
 
 	return endorsedProposal, nil
 }
@@ -584,10 +839,49 @@ func (ctx *rollDPoSCtx) newEndorsement(
 		blkHash,
 		topic,
 	)
-	en, err := endorsement.Endorse(ctx.priKey, vote, timestamp)
+	en, err := ctx.endorse(vote, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	endorsed, err := ctx.wal.AppendMessage(ctx.round.Number(), NewEndorsedConsensusMessage(ctx.round.Height(), vote, en))
 	if err != nil {
 		return nil, err
 	}
+	ctx.timeline.AddEndorsement(ctx.round.Height(), ctx.round.Number(), ctx.encodedAddr, topic, ctx.clock.Now())
+
+	return endorsed, nil
+}
 
-	return NewEndorsedConsensusMessage(ctx.round.Height(), vote, en), nil
+// endorse endorses a block proposal or a vote in the current round, which the signer may refuse if it conflicts with a
+// message signed before
+func (ctx *rollDPoSCtx) endorse(doc endorsement.Document, timestamp time.Time) (*endorsement.Endorsement, error) {
+	msg := signer.ConsensusMessage{Height: ctx.round.Height()}
+	switch d := doc.(type) {
+	case *blockProposal:
+		pb, err := d.Proto()
+		if err != nil {
+			return nil, err
+		}
+		if msg.Proposal, err = proto.Marshal(pb); err != nil {
+			return nil, err
+		}
+	case *ConsensusVote:
+		pb, err := d.Proto()
+		if err != nil {
+			return nil, err
+		}
+		if msg.Vote, err = proto.Marshal(pb); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Errorf("unexpected consensus document %T", doc)
+	}
+	return endorsement.EndorseWith(
+		ctx.signer.PublicKey(),
+		func([]byte) ([]byte, error) {
+			return ctx.signer.SignConsensus(msg, timestamp)
+		},
+		doc,
+		timestamp,
+	)
 }
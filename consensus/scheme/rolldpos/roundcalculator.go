@@ -125,12 +125,23 @@ func (c *roundCalculator) roundInfo(
 	now time.Time,
 	withToleration bool,
 ) (roundNum uint32, roundStartTime time.Time, err error) {
-	lastBlockTime := time.Unix(c.chain.GenesisTimestamp(), 0)
+	var lastBlock *block.Footer
 	if height > 1 {
-		var lastBlock *block.Footer
 		if lastBlock, err = c.chain.BlockFooterByHeight(height - 1); err != nil {
 			return
 		}
+	}
+	return c.roundInfoAfter(lastBlock, now, withToleration)
+}
+
+// roundInfoAfter calculates the round following the last block, which is nil for the genesis block
+func (c *roundCalculator) roundInfoAfter(
+	lastBlock *block.Footer,
+	now time.Time,
+	withToleration bool,
+) (roundNum uint32, roundStartTime time.Time, err error) {
+	lastBlockTime := time.Unix(c.chain.GenesisTimestamp(), 0)
+	if lastBlock != nil {
 		lastBlockCommitTime := lastBlock.CommitTime()
 		lastBlockTime = lastBlockTime.Add(lastBlockCommitTime.Sub(lastBlockTime) / c.blockInterval * c.blockInterval)
 	}
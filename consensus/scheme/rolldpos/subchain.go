@@ -21,16 +21,17 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 func putBlockToParentChain(
 	rootChainAPI explorerapi.Explorer,
 	subChainAddr string,
-	senderPrvKey keypair.PrivateKey,
+	sender signer.HashSigner,
 	senderAddr string,
 	b *block.Block,
 ) {
-	if err := putBlockToParentChainTask(rootChainAPI, subChainAddr, senderPrvKey, b); err != nil {
+	if err := putBlockToParentChainTask(rootChainAPI, subChainAddr, sender, b); err != nil {
 		log.L().Error("Failed to put block merkle roots to parent chain.",
 			zap.String("subChainAddress", subChainAddr),
 			zap.String("senderAddress", senderAddr),
@@ -47,10 +48,10 @@ func putBlockToParentChain(
 func putBlockToParentChainTask(
 	rootChainAPI explorerapi.Explorer,
 	subChainAddr string,
-	senderPrvKey keypair.PrivateKey,
+	sender signer.HashSigner,
 	b *block.Block,
 ) error {
-	req, err := constructPutSubChainBlockRequest(rootChainAPI, subChainAddr, senderPrvKey.PublicKey(), senderPrvKey, b)
+	req, err := constructPutSubChainBlockRequest(rootChainAPI, subChainAddr, sender.PublicKey(), sender, b)
 	if err != nil {
 		return errors.Wrap(err, "fail to construct PutSubChainBlockRequest")
 	}
@@ -65,7 +66,7 @@ func constructPutSubChainBlockRequest(
 	rootChainAPI explorerapi.Explorer,
 	subChainAddr string,
 	senderPubKey keypair.PublicKey,
-	senderPriKey keypair.PrivateKey,
+	sender signer.HashSigner,
 	b *block.Block,
 ) (explorerapi.PutSubChainBlockRequest, error) {
 	senderPCAddr, err := address.FromBytes(senderPubKey.Hash())
@@ -98,7 +99,7 @@ func constructPutSubChainBlockRequest(
 		SetAction(pb).Build()
 
 	// sign action
-	selp, err := action.Sign(elp, senderPriKey)
+	selp, err := action.Sign(elp, sender)
 	if err != nil {
 		return explorerapi.PutSubChainBlockRequest{}, errors.Wrap(err, "fail to sign put block action")
 	}
@@ -0,0 +1,321 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+type (
+	// Timeline records the events of the latest consensus rounds observed by the node in a ring buffer, and counts
+	// the proposals and endorsements missed by the delegates in the rounds which are over
+	Timeline struct {
+		mutex   sync.RWMutex
+		rounds  []*roundRecord
+		next    int
+		current *roundRecord
+		// proposalDeadline and phaseDeadlines are the offsets from the start of a round by which the proposal and
+		// the endorsements of each phase are expected
+		proposalDeadline time.Duration
+		phaseDeadlines   map[ConsensusVoteTopic]time.Duration
+		liveness         map[string]*iotextypes.DelegateLiveness
+	}
+
+	roundRecord struct {
+		height       uint64
+		round        uint32
+		proposer     string
+		delegates    []string
+		startTime    time.Time
+		proposalTime time.Time
+		endorsements []*endorsementArrival
+		outcome      iotextypes.ConsensusRound_Outcome
+		endTime      time.Time
+		counted      bool
+	}
+
+	endorsementArrival struct {
+		endorser string
+		topic    ConsensusVoteTopic
+		time     time.Time
+	}
+)
+
+// NewTimeline creates a timeline keeping the latest size rounds, whose phases end as timed by the consensus FSM
+func NewTimeline(size int, timing consensusfsm.Config) *Timeline {
+	if size <= 0 {
+		size = 1
+	}
+	proposalDeadline := timing.AcceptBlockTTL
+	lockDeadline := proposalDeadline + timing.AcceptProposalEndorsementTTL
+	commitDeadline := lockDeadline + timing.AcceptLockEndorsementTTL
+	return &Timeline{
+		rounds:           make([]*roundRecord, size),
+		proposalDeadline: proposalDeadline,
+		phaseDeadlines: map[ConsensusVoteTopic]time.Duration{
+			PROPOSAL: lockDeadline,
+			LOCK:     commitDeadline,
+			COMMIT:   commitDeadline + timing.CommitTTL,
+		},
+		liveness: map[string]*iotextypes.DelegateLiveness{},
+	}
+}
+
+// StartRound records the start of a round. The previous round is finished, as synced if the height is higher, or
+// timed out otherwise, unless it has been committed. Since the phases of the previous round are over as well, the
+// proposal and endorsements missed in it are counted.
+func (t *Timeline) StartRound(
+	height uint64,
+	round uint32,
+	proposer string,
+	delegates []string,
+	startTime time.Time,
+) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if prev := t.current; prev != nil && (prev.height != height || prev.round != round) {
+		if prev.outcome == iotextypes.ConsensusRound_PENDING {
+			outcome := iotextypes.ConsensusRound_TIMEOUT
+			if height > prev.height {
+				outcome = iotextypes.ConsensusRound_SYNCED
+			}
+			t.finish(prev, outcome, startTime)
+		}
+		t.countMissed(prev)
+	}
+	r := t.record(height, round, true)
+	r.proposer = proposer
+	r.delegates = delegates
+	r.startTime = startTime
+	t.current = r
+}
+
+// AddProposal records the arrival of the proposal of a round
+func (t *Timeline) AddProposal(height uint64, round uint32, ts time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if r := t.record(height, round, false); r != nil && r.proposalTime.IsZero() {
+		r.proposalTime = ts
+	}
+}
+
+// AddEndorsement records the arrival of the endorsement of a delegate in a phase of a round
+func (t *Timeline) AddEndorsement(height uint64, round uint32, endorser string, topic ConsensusVoteTopic, ts time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	r := t.record(height, round, false)
+	if r == nil {
+		return
+	}
+	for _, a := range r.endorsements {
+		if a.endorser == endorser && a.topic == topic {
+			return
+		}
+	}
+	r.endorsements = append(r.endorsements, &endorsementArrival{endorser: endorser, topic: topic, time: ts})
+}
+
+// Commit records that the block is committed by the node in a round
+func (t *Timeline) Commit(height uint64, round uint32, ts time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if r := t.find(height, round); r != nil && r.outcome == iotextypes.ConsensusRound_PENDING {
+		t.finish(r, iotextypes.ConsensusRound_COMMITTED, ts)
+	}
+}
+
+// Rounds returns the latest count rounds in the timeline, sorted by height and round
+func (t *Timeline) Rounds(count uint64) ([]*iotextypes.ConsensusRound, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	var records []*roundRecord
+	for _, r := range t.rounds {
+		if r != nil {
+			records = append(records, r)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].before(records[j].height, records[j].round)
+	})
+	if uint64(len(records)) > count {
+		records = records[uint64(len(records))-count:]
+	}
+	rounds := make([]*iotextypes.ConsensusRound, 0, len(records))
+	for _, r := range records {
+		rPb, err := r.Proto()
+		if err != nil {
+			return nil, err
+		}
+		rounds = append(rounds, rPb)
+	}
+	return rounds, nil
+}
+
+// Liveness returns the numbers of proposals and endorsements missed by the delegates of the rounds which are over,
+// sorted by address. An endorsement is counted as missed once for each phase.
+func (t *Timeline) Liveness() []*iotextypes.DelegateLiveness {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	liveness := make([]*iotextypes.DelegateLiveness, 0, len(t.liveness))
+	for _, l := range t.liveness {
+		liveness = append(liveness, &iotextypes.DelegateLiveness{
+			Address:            l.Address,
+			MissedProposals:    l.MissedProposals,
+			MissedEndorsements: l.MissedEndorsements,
+		})
+	}
+	sort.Slice(liveness, func(i, j int) bool {
+		return liveness[i].Address < liveness[j].Address
+	})
+	return liveness
+}
+
+// record returns the record of a round. A new record is created if create is true, or if the round is after the
+// current one, since a message may arrive before the node starts the round.
+func (t *Timeline) record(height uint64, round uint32, create bool) *roundRecord {
+	if r := t.find(height, round); r != nil {
+		return r
+	}
+	if !create && t.current != nil && !t.current.before(height, round) {
+		return nil
+	}
+	r := &roundRecord{height: height, round: round}
+	t.rounds[t.next] = r
+	t.next = (t.next + 1) % len(t.rounds)
+	return r
+}
+
+func (t *Timeline) find(height uint64, round uint32) *roundRecord {
+	for i := 1; i <= len(t.rounds); i++ {
+		r := t.rounds[(t.next-i+len(t.rounds))%len(t.rounds)]
+		if r != nil && r.height == height && r.round == round {
+			return r
+		}
+	}
+	if r := t.current; r != nil && r.height == height && r.round == round {
+		// the current round may have been evicted by the future ones
+		return r
+	}
+	return nil
+}
+
+// finish sets the outcome of a round
+func (t *Timeline) finish(r *roundRecord, outcome iotextypes.ConsensusRound_Outcome, ts time.Time) {
+	r.outcome = outcome
+	r.endTime = ts
+	roundMtc.WithLabelValues(outcome.String()).Inc()
+}
+
+// countMissed counts the proposal and endorsements missed by the delegates in a round which is over. A synced round
+// isn't counted, since the node doesn't take part in it. The endorsements of a phase are only expected if the phase
+// before it completes by its deadline, i.e., the proposal arrives, or a majority endorses the previous phase, and are
+// missed if they don't arrive by the deadline of the phase. A round committed by the node at the quorum still counts
+// the endorsements arriving until the deadlines.
+func (t *Timeline) countMissed(r *roundRecord) {
+	if r.counted || r.outcome == iotextypes.ConsensusRound_SYNCED || len(r.delegates) == 0 {
+		return
+	}
+	r.counted = true
+	for _, d := range r.delegates {
+		// the delegates of the rounds counted are listed, even if nothing is missed
+		t.delegateLiveness(d)
+	}
+	if r.proposalTime.IsZero() || r.proposalTime.After(r.startTime.Add(t.proposalDeadline)) {
+		t.delegateLiveness(r.proposer).MissedProposals++
+		missedProposalMtc.WithLabelValues(r.proposer).Inc()
+		return
+	}
+	for _, phase := range []ConsensusVoteTopic{PROPOSAL, LOCK, COMMIT} {
+		deadline := r.startTime.Add(t.phaseDeadlines[phase])
+		endorsed := map[string]bool{}
+		for _, a := range r.endorsements {
+			if a.topic == phase && !a.time.After(deadline) {
+				endorsed[a.endorser] = true
+			}
+		}
+		numEndorsed := 0
+		for _, d := range r.delegates {
+			if endorsed[d] {
+				numEndorsed++
+				continue
+			}
+			t.delegateLiveness(d).MissedEndorsements++
+			missedEndorsementMtc.WithLabelValues(d).Inc()
+		}
+		if 3*numEndorsed <= 2*len(r.delegates) {
+			// the later phases can't complete without a majority
+			return
+		}
+	}
+}
+
+func (t *Timeline) delegateLiveness(addr string) *iotextypes.DelegateLiveness {
+	l, ok := t.liveness[addr]
+	if !ok {
+		l = &iotextypes.DelegateLiveness{Address: addr}
+		t.liveness[addr] = l
+	}
+	return l
+}
+
+// before returns true if the record is of a round before the given one
+func (r *roundRecord) before(height uint64, round uint32) bool {
+	return r.height < height || (r.height == height && r.round < round)
+}
+
+// Proto converts the record of a round to a protobuf message
+func (r *roundRecord) Proto() (*iotextypes.ConsensusRound, error) {
+	rPb := &iotextypes.ConsensusRound{
+		Height:    r.height,
+		Round:     r.round,
+		Proposer:  r.proposer,
+		Delegates: append([]string{}, r.delegates...),
+		Outcome:   r.outcome,
+	}
+	var err error
+	if rPb.StartTime, err = timestampProto(r.startTime); err != nil {
+		return nil, err
+	}
+	if rPb.ProposalTime, err = timestampProto(r.proposalTime); err != nil {
+		return nil, err
+	}
+	if rPb.EndTime, err = timestampProto(r.endTime); err != nil {
+		return nil, err
+	}
+	for _, a := range r.endorsements {
+		vote, err := NewConsensusVote(nil, a.topic).Proto()
+		if err != nil {
+			return nil, err
+		}
+		ts, err := timestampProto(a.time)
+		if err != nil {
+			return nil, err
+		}
+		rPb.Endorsements = append(rPb.Endorsements, &iotextypes.ConsensusEndorsementArrival{
+			Endorser: a.endorser,
+			Topic:    vote.Topic,
+			Time:     ts,
+		})
+	}
+	return rPb, nil
+}
+
+// timestampProto converts a time to a protobuf timestamp, which is nil for the zero time
+func timestampProto(t time.Time) (*timestamp.Timestamp, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	return ptypes.TimestampProto(t)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+func TestTimeline(t *testing.T) {
+	require := require.New(t)
+	delegates := []string{"a", "b", "c"}
+	ts := time.Unix(1500000000, 0)
+	// the proposal is expected in 4 seconds, and the endorsements of the phases in 6, 8 and 10 seconds
+	tl := NewTimeline(3, consensusfsm.Config{
+		AcceptBlockTTL:               4 * time.Second,
+		AcceptProposalEndorsementTTL: 2 * time.Second,
+		AcceptLockEndorsementTTL:     2 * time.Second,
+		CommitTTL:                    2 * time.Second,
+	})
+
+	// round 0 of height 10 times out, without the endorsement of c
+	tl.StartRound(10, 0, "a", delegates, ts)
+	tl.AddProposal(10, 0, ts.Add(time.Second))
+	tl.AddEndorsement(10, 0, "a", PROPOSAL, ts.Add(2*time.Second))
+	tl.AddEndorsement(10, 0, "b", PROPOSAL, ts.Add(2*time.Second))
+	tl.AddEndorsement(10, 0, "b", LOCK, ts.Add(3*time.Second))
+	tl.AddEndorsement(10, 0, "b", PROPOSAL, ts.Add(4*time.Second))
+	// round 1 receives nothing, and the block is synced
+	tl.StartRound(10, 1, "b", delegates, ts.Add(10*time.Second))
+	// a message may arrive before the round starts
+	tl.AddEndorsement(11, 0, "c", PROPOSAL, ts.Add(19*time.Second))
+	tl.StartRound(11, 0, "c", delegates, ts.Add(20*time.Second))
+	tl.AddProposal(11, 0, ts.Add(21*time.Second))
+	tl.AddEndorsement(11, 0, "a", PROPOSAL, ts.Add(22*time.Second))
+	tl.AddEndorsement(11, 0, "b", PROPOSAL, ts.Add(22*time.Second))
+	for _, d := range delegates {
+		tl.AddEndorsement(11, 0, d, LOCK, ts.Add(25*time.Second))
+	}
+	tl.AddEndorsement(11, 0, "a", COMMIT, ts.Add(29*time.Second))
+	tl.AddEndorsement(11, 0, "b", COMMIT, ts.Add(29*time.Second))
+	// the block is committed at the quorum, and the endorsement of c arrives in time after it
+	tl.Commit(11, 0, ts.Add(29*time.Second))
+	tl.AddEndorsement(11, 0, "c", COMMIT, ts.Add(29500*time.Millisecond))
+	// a late message of a finished round is recorded, but a message of an unknown past round is not
+	tl.AddEndorsement(10, 0, "c", COMMIT, ts.Add(24*time.Second))
+	tl.AddEndorsement(9, 0, "c", COMMIT, ts.Add(24*time.Second))
+
+	rounds, err := tl.Rounds(10)
+	require.NoError(err)
+	require.Equal(3, len(rounds))
+	require.Equal(uint64(10), rounds[0].Height)
+	require.Equal(uint32(0), rounds[0].Round)
+	require.Equal("a", rounds[0].Proposer)
+	require.Equal(delegates, rounds[0].Delegates)
+	require.Equal(iotextypes.ConsensusRound_TIMEOUT, rounds[0].Outcome)
+	require.Equal(ts.Unix()+1, rounds[0].ProposalTime.Seconds)
+	require.Equal(ts.Unix()+10, rounds[0].EndTime.Seconds)
+	require.Equal(4, len(rounds[0].Endorsements))
+	require.Equal("b", rounds[0].Endorsements[2].Endorser)
+	require.Equal(iotextypes.ConsensusVote_LOCK, rounds[0].Endorsements[2].Topic)
+	require.Equal(ts.Unix()+3, rounds[0].Endorsements[2].Time.Seconds)
+	require.Equal(iotextypes.ConsensusRound_SYNCED, rounds[1].Outcome)
+	require.Nil(rounds[1].ProposalTime)
+	require.Equal(0, len(rounds[1].Endorsements))
+	require.Equal(uint64(11), rounds[2].Height)
+	require.Equal(iotextypes.ConsensusRound_COMMITTED, rounds[2].Outcome)
+	require.Equal(9, len(rounds[2].Endorsements))
+
+	rounds, err = tl.Rounds(1)
+	require.NoError(err)
+	require.Equal(1, len(rounds))
+	require.Equal(uint64(11), rounds[0].Height)
+
+	// c misses the proposal phase of round 0 of height 10, after which the round can't reach a majority, and the
+	// synced round 1 isn't counted
+	checkLiveness := func(expected map[string][2]uint64) {
+		liveness := tl.Liveness()
+		require.Equal(len(expected), len(liveness))
+		for _, l := range liveness {
+			require.Equal(expected[l.Address][0], l.MissedProposals, l.Address)
+			require.Equal(expected[l.Address][1], l.MissedEndorsements, l.Address)
+		}
+	}
+	checkLiveness(map[string][2]uint64{"a": {0, 0}, "b": {0, 0}, "c": {0, 1}})
+
+	// nothing is missed in round 0 of height 11, and the oldest round is evicted
+	tl.StartRound(12, 0, "b", delegates, ts.Add(30*time.Second))
+	checkLiveness(map[string][2]uint64{"a": {0, 0}, "b": {0, 0}, "c": {0, 1}})
+	rounds, err = tl.Rounds(10)
+	require.NoError(err)
+	require.Equal(3, len(rounds))
+	require.Equal(uint64(10), rounds[0].Height)
+	require.Equal(uint32(1), rounds[0].Round)
+	require.Equal(iotextypes.ConsensusRound_PENDING, rounds[2].Outcome)
+	require.Nil(rounds[2].EndTime)
+
+	// b misses the proposal of round 0 of height 12
+	tl.StartRound(12, 1, "c", delegates, ts.Add(40*time.Second))
+	checkLiveness(map[string][2]uint64{"a": {0, 0}, "b": {1, 0}, "c": {0, 1}})
+}
@@ -0,0 +1,305 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// maxWALRecordSize is the maximal size of a record in the write-ahead log
+const maxWALRecordSize = 32 * 1024 * 1024
+
+// ErrConflictingEndorsement indicates that the node has endorsed a different block in the same slot of the round
+var ErrConflictingEndorsement = errors.New("conflicting with an endorsed message")
+
+// wal is the write-ahead log of the messages endorsed by the node and the block locked by it in the current height.
+// Each record is synced to disk before the message is broadcast, and the log is replayed on startup, so that a
+// delegate never endorses two conflicting messages in the same round across a crash. Each record is a
+// ConsensusWALRecord in protobuf prefixed by its 4-byte length. The endorsed messages are kept in memory only if the
+// path is empty, which is meant for the delegates simulated in the same process.
+type wal struct {
+	mutex    sync.Mutex
+	path     string
+	writer   *os.File
+	height   uint64
+	endorsed map[string]*EndorsedConsensusMessage
+}
+
+// newWAL creates a write-ahead log at the given path
+func newWAL(path string) *wal {
+	return &wal{path: path, endorsed: map[string]*EndorsedConsensusMessage{}}
+}
+
+// load reads the records in the log. A corrupted tail of the log, left by a crash in the middle of a write, is
+// ignored.
+func (w *wal) load() ([]*iotextypes.ConsensusWALRecord, error) {
+	if w.path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(w.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open consensus WAL %s", w.path)
+	}
+	defer f.Close()
+
+	var records []*iotextypes.ConsensusWALRecord
+	r := bufio.NewReader(f)
+	for {
+		record, err := readWALRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Logger("consensus").Warn(
+				"Stop loading corrupted consensus WAL.",
+				zap.String("path", w.path),
+				zap.Error(err),
+			)
+			break
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// reset regenerates the log of the height with the given records, and opens it for appending. The endorsed
+// messages are forgotten, and should be remembered again if they are of the height.
+func (w *wal) reset(height uint64, records []*iotextypes.ConsensusWALRecord) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.height = height
+	w.endorsed = map[string]*EndorsedConsensusMessage{}
+	if w.path == "" {
+		return nil
+	}
+	if w.writer != nil {
+		if err := w.writer.Close(); err != nil {
+			return errors.Wrapf(err, "failed to close consensus WAL %s", w.path)
+		}
+		w.writer = nil
+	}
+	tmpPath := w.path + ".new"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create consensus WAL %s", tmpPath)
+	}
+	bw := bufio.NewWriter(f)
+	for _, record := range records {
+		if err := writeWALRecord(bw, record); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to write consensus WAL %s", tmpPath)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to sync consensus WAL %s", tmpPath)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close consensus WAL %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, w.path); err != nil {
+		return errors.Wrapf(err, "failed to replace consensus WAL %s", w.path)
+	}
+	// the rename is durable only once the directory is synced
+	if err := syncDir(filepath.Dir(w.path)); err != nil {
+		return errors.Wrapf(err, "failed to sync the directory of consensus WAL %s", w.path)
+	}
+	if w.writer, err = os.OpenFile(w.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644); err != nil {
+		return errors.Wrapf(err, "failed to open consensus WAL %s", w.path)
+	}
+	return nil
+}
+
+// Height returns the height of the log
+func (w *wal) Height() uint64 {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.height
+}
+
+// Endorsed returns the message endorsed in the slot of the round, or nil if there is none
+func (w *wal) Endorsed(round uint32, kind string) *EndorsedConsensusMessage {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.endorsed[walSlotKey(round, kind)]
+}
+
+// Remember records a message endorsed in the round without writing it to the log
+func (w *wal) Remember(round uint32, msg *EndorsedConsensusMessage) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	_, err := w.remember(round, msg)
+
+	return err
+}
+
+// AppendMessage writes a message endorsed in the round to the log before it is broadcast. If the node has endorsed
+// the same block in the slot of the round, the endorsed message is returned instead, and if it has endorsed a
+// different block, ErrConflictingEndorsement is returned.
+func (w *wal) AppendMessage(round uint32, msg *EndorsedConsensusMessage) (*EndorsedConsensusMessage, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if msg.Height() != w.height {
+		return nil, errors.Errorf("message of height %d is not of the log height %d", msg.Height(), w.height)
+	}
+	endorsed, err := w.remember(round, msg)
+	if err != nil || endorsed != msg {
+		return endorsed, err
+	}
+	pb, err := msg.Proto()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.append(&iotextypes.ConsensusWALRecord{
+		Height: msg.Height(),
+		Record: &iotextypes.ConsensusWALRecord_Message{Message: pb},
+	}); err != nil {
+		kind, _, _ := slotOf(msg)
+		delete(w.endorsed, walSlotKey(round, kind))
+		return nil, err
+	}
+	return msg, nil
+}
+
+// AppendLock writes the block locked by the node with its proof of lock to the log
+func (w *wal) AppendLock(lock *blockProposal) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if lock.Height() != w.height {
+		return errors.Errorf("lock of height %d is not of the log height %d", lock.Height(), w.height)
+	}
+	pb, err := lock.Proto()
+	if err != nil {
+		return err
+	}
+	return w.append(&iotextypes.ConsensusWALRecord{
+		Height: lock.Height(),
+		Record: &iotextypes.ConsensusWALRecord_Lock{Lock: pb},
+	})
+}
+
+// close closes the log
+func (w *wal) close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.writer == nil {
+		return nil
+	}
+	err := w.writer.Close()
+	w.writer = nil
+	return err
+}
+
+func (w *wal) remember(round uint32, msg *EndorsedConsensusMessage) (*EndorsedConsensusMessage, error) {
+	kind, blkHash, err := slotOf(msg)
+	if err != nil {
+		return nil, err
+	}
+	key := walSlotKey(round, kind)
+	endorsed, ok := w.endorsed[key]
+	if !ok {
+		w.endorsed[key] = msg
+		return msg, nil
+	}
+	_, endorsedHash, err := slotOf(endorsed)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(endorsedHash, blkHash) {
+		return nil, errors.Wrapf(ErrConflictingEndorsement, "%s of round %d", kind, round)
+	}
+	return endorsed, nil
+}
+
+func (w *wal) append(record *iotextypes.ConsensusWALRecord) error {
+	if w.path == "" {
+		return nil
+	}
+	if w.writer == nil {
+		return errors.Errorf("consensus WAL %s is not open", w.path)
+	}
+	if err := writeWALRecord(w.writer, record); err != nil {
+		return err
+	}
+	return errors.Wrapf(w.writer.Sync(), "failed to sync consensus WAL %s", w.path)
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		d.Close()
+		return err
+	}
+	return d.Close()
+}
+
+func walSlotKey(round uint32, kind string) string {
+	return fmt.Sprintf("%d/%s", round, kind)
+}
+
+func writeWALRecord(w io.Writer, record *iotextypes.ConsensusWALRecord) error {
+	data, err := proto.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal consensus WAL record")
+	}
+	buf := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(buf, uint32(len(data)))
+	copy(buf[4:], data)
+	if _, err := w.Write(buf); err != nil {
+		return errors.Wrap(err, "failed to write consensus WAL record")
+	}
+	return nil
+}
+
+func readWALRecord(r io.Reader) (*iotextypes.ConsensusWALRecord, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, errors.Wrap(err, "truncated record length")
+		}
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxWALRecordSize {
+		return nil, errors.Errorf("record size %d exceeds limit", n)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, errors.Wrap(err, "truncated record")
+	}
+	record := &iotextypes.ConsensusWALRecord{}
+	if err := proto.Unmarshal(data, record); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensus WAL record")
+	}
+	return record, nil
+}
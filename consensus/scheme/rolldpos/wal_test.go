@@ -0,0 +1,212 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func newTestWALPath(t *testing.T) string {
+	f, err := ioutil.TempFile("", "consensus.wal")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	return f.Name()
+}
+
+func TestWAL(t *testing.T) {
+	require := require.New(t)
+	path := newTestWALPath(t)
+	defer testutil.CleanupPath(t, path)
+	ts := time.Unix(1500000000, 0)
+	hashA := hash.Hash256b([]byte("a"))
+	hashB := hash.Hash256b([]byte("b"))
+	a := makeVote(t, identityset.PrivateKey(0), 9, hashA, PROPOSAL, ts)
+	b := makeVote(t, identityset.PrivateKey(0), 9, hashB, PROPOSAL, ts.Add(time.Second))
+	blk, err := block.NewTestingBuilder().
+		SetHeight(9).
+		SetTimeStamp(ts).
+		SignAndBuild(identityset.PrivateKey(0).PublicKey(), identityset.PrivateKey(0))
+	require.NoError(err)
+
+	w := newWAL(path)
+	records, err := w.load()
+	require.NoError(err)
+	require.Equal(0, len(records))
+	require.NoError(w.reset(9, nil))
+
+	endorsed, err := w.AppendMessage(0, a)
+	require.NoError(err)
+	require.Equal(a, endorsed)
+	// the endorsed message is returned for the same block
+	endorsed, err = w.AppendMessage(0, makeVote(t, identityset.PrivateKey(0), 9, hashA, PROPOSAL, ts.Add(time.Second)))
+	require.NoError(err)
+	require.Equal(a, endorsed)
+	require.Equal(a, w.Endorsed(0, "vote-0"))
+	// a conflicting message is refused
+	_, err = w.AppendMessage(0, b)
+	require.Equal(ErrConflictingEndorsement, errors.Cause(err))
+	// but another round is fine
+	endorsed, err = w.AppendMessage(1, b)
+	require.NoError(err)
+	require.Equal(b, endorsed)
+	_, err = w.AppendMessage(0, makeVote(t, identityset.PrivateKey(0), 10, hashA, PROPOSAL, ts))
+	require.Error(err)
+	require.NoError(w.AppendLock(newBlockProposal(&blk, nil)))
+	require.NoError(w.close())
+
+	// a corrupted tail is ignored
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(err)
+	_, err = f.Write([]byte{0, 0, 1})
+	require.NoError(err)
+	require.NoError(f.Close())
+	w = newWAL(path)
+	records, err = w.load()
+	require.NoError(err)
+	require.Equal(3, len(records))
+	for _, record := range records {
+		require.Equal(uint64(9), record.Height)
+	}
+	require.NotNil(records[0].GetMessage())
+	require.NotNil(records[1].GetMessage())
+	require.NotNil(records[2].GetLock())
+
+	// the log is regenerated with the given records, and the endorsed messages are forgotten
+	require.NoError(w.reset(9, records[:1]))
+	require.Nil(w.Endorsed(0, "vote-0"))
+	require.NoError(w.Remember(0, a))
+	require.Error(w.Remember(0, makeVote(t, identityset.PrivateKey(0), 9, hashB, PROPOSAL, ts)))
+	require.NoError(w.close())
+	records, err = newWAL(path).load()
+	require.NoError(err)
+	require.Equal(1, len(records))
+
+	// an in-memory log only remembers the endorsed messages
+	w = newWAL("")
+	require.NoError(w.reset(9, nil))
+	_, err = w.AppendMessage(0, a)
+	require.NoError(err)
+	_, err = w.AppendMessage(0, b)
+	require.Equal(ErrConflictingEndorsement, errors.Cause(err))
+	records, err = w.load()
+	require.NoError(err)
+	require.Equal(0, len(records))
+}
+
+func TestRollDPoS_RestoreFromWAL(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	path := newTestWALPath(t)
+	defer testutil.CleanupPath(t, path)
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().GenesisTimestamp().Return(int64(1500000000)).AnyTimes()
+	footer := &block.Footer{}
+	commitTime, err := ptypes.TimestampProto(time.Unix(1500000100, 0))
+	require.NoError(err)
+	require.NoError(footer.ConvertFromBlockFooterPb(&iotextypes.BlockFooter{Timestamp: commitTime}))
+	chain.EXPECT().BlockFooterByHeight(gomock.Any()).Return(footer, nil).AnyTimes()
+	candidates := make([]*state.Candidate, 5)
+	for i := range candidates {
+		candidates[i] = &state.Candidate{Address: identityset.Address(i).String()}
+	}
+	chain.EXPECT().CandidatesByHeight(gomock.Any()).Return(candidates, nil).AnyTimes()
+	chain.EXPECT().TipHeight().Return(uint64(8)).AnyTimes()
+	chain.EXPECT().ValidateBlock(gomock.Any()).Return(nil).Times(1)
+	cfg := config.Default
+	cfg.Genesis.NumDelegates = 4
+	cfg.Genesis.NumSubEpochs = 1
+	cfg.Genesis.BlockInterval = 10 * time.Second
+	cfg.Consensus.RollDPoS.WALPath = path
+	clk := clock.NewMock()
+	clk.Add(time.Unix(1500000205, 0).Sub(clk.Now()))
+	r, err := NewRollDPoSBuilder().
+		SetConfig(cfg).
+		SetAddr(identityset.Address(0).String()).
+		SetPriKey(identityset.PrivateKey(0)).
+		SetBlockchain(chain).
+		SetActPool(mock_actpool.NewMockActPool(ctrl)).
+		SetBroadcast(func(proto.Message) error { return nil }).
+		SetClock(clk).
+		RegisterProtocol(rolldpos.NewProtocol(
+			cfg.Genesis.NumCandidateDelegates,
+			cfg.Genesis.NumDelegates,
+			cfg.Genesis.NumSubEpochs,
+		)).
+		Build()
+	require.NoError(err)
+
+	// the node locked a block of height 9 before a crash
+	round, err := r.ctx.roundCalc.NewRoundWithToleration(9, clk.Now())
+	require.NoError(err)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(9).
+		SetTimeStamp(round.StartTime()).
+		SignAndBuild(identityset.PrivateKey(1).PublicKey(), identityset.PrivateKey(1))
+	require.NoError(err)
+	blkHash := blk.HashBlock()
+	ts := round.StartTime().Add(cfg.Consensus.RollDPoS.FSM.AcceptBlockTTL)
+	stale, err := makeVote(t, identityset.PrivateKey(0), 8, blkHash, PROPOSAL, ts).Proto()
+	require.NoError(err)
+	w := newWAL(path)
+	require.NoError(w.reset(9, []*iotextypes.ConsensusWALRecord{
+		{Height: 8, Record: &iotextypes.ConsensusWALRecord_Message{Message: stale}},
+	}))
+	require.NoError(w.AppendLock(newBlockProposal(&blk, nil)))
+	_, err = w.AppendMessage(round.Number(), makeVote(t, identityset.PrivateKey(0), 9, blkHash, PROPOSAL, ts))
+	require.NoError(err)
+	require.NoError(w.close())
+
+	require.NoError(r.ctx.Restore())
+	defer func() {
+		require.NoError(r.ctx.wal.close())
+	}()
+	require.Equal(uint64(9), r.ctx.round.Height())
+	require.True(r.ctx.round.IsLocked())
+	require.Equal(blkHash[:], r.ctx.round.HashOfBlockInLock())
+	require.NotNil(r.ctx.round.Block(blkHash[:]))
+
+	// the node refuses to endorse another block in the same round, and endorses the same block identically
+	otherHash := hash.Hash256b([]byte("other"))
+	_, err = r.ctx.newEndorsement(otherHash[:], PROPOSAL, ts)
+	require.Equal(ErrConflictingEndorsement, errors.Cause(err))
+	en, err := r.ctx.newEndorsement(blkHash[:], PROPOSAL, ts.Add(time.Second))
+	require.NoError(err)
+	require.True(ts.Equal(en.Endorsement().Timestamp()))
+	_, err = r.ctx.newEndorsement(blkHash[:], LOCK, ts)
+	require.NoError(err)
+
+	// the stale record is dropped, and the new endorsement is appended
+	records, err := newWAL(path).load()
+	require.NoError(err)
+	require.Equal(3, len(records))
+	require.NotNil(records[0].GetLock())
+	require.NotNil(records[1].GetMessage())
+	require.Equal(iotextypes.ConsensusVote_LOCK, records[2].GetMessage().GetVote().Topic)
+}
@@ -36,6 +36,7 @@ type Scheme interface {
 	HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error
 	Calibrate(uint64)
 	ValidateBlockFooter(*block.Block) error
+	ValidateHeaderFooter(header *block.Header, footer *block.Footer, prevFooter *block.Footer) error
 	Metrics() (ConsensusMetrics, error)
 	Activate(bool)
 	Active() bool
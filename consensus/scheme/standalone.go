@@ -87,6 +87,11 @@ func (s *Standalone) ValidateBlockFooter(*block.Block) error {
 	return nil
 }
 
+// ValidateHeaderFooter validates the footer of a block header ahead of the block
+func (s *Standalone) ValidateHeaderFooter(*block.Header, *block.Footer, *block.Footer) error {
+	return nil
+}
+
 // Metrics is not implemented for standalone scheme
 func (s *Standalone) Metrics() (ConsensusMetrics, error) {
 	return ConsensusMetrics{}, errors.Wrapf(
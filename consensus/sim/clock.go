@@ -0,0 +1,126 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package sim
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/facebookgo/clock"
+)
+
+type (
+	// scheduler runs the scheduled actions one by one in the order of virtual time, and then of scheduling, so that
+	// a simulation is deterministic
+	scheduler struct {
+		now   time.Time
+		seq   uint64
+		queue actionQueue
+	}
+
+	action struct {
+		at  time.Time
+		seq uint64
+		fn  func()
+	}
+
+	actionQueue []*action
+
+	// nodeClock is the clock of a node in virtual time. Sleep suspends the node, so that the other nodes run in the
+	// meantime. The timers cannot be stopped, which RollDPoS does not need.
+	nodeClock struct {
+		node *node
+	}
+)
+
+func newScheduler(start time.Time) *scheduler {
+	return &scheduler{now: start}
+}
+
+func (s *scheduler) schedule(d time.Duration, fn func()) {
+	if d < 0 {
+		d = 0
+	}
+	s.seq++
+	heap.Push(&s.queue, &action{at: s.now.Add(d), seq: s.seq, fn: fn})
+}
+
+// next runs the next action scheduled no later than the deadline, and returns false if there is none, in which case
+// the time is moved to the deadline
+func (s *scheduler) next(deadline time.Time) bool {
+	if len(s.queue) == 0 || s.queue[0].at.After(deadline) {
+		s.now = deadline
+		return false
+	}
+	a := heap.Pop(&s.queue).(*action)
+	s.now = a.at
+	a.fn()
+	return true
+}
+
+func (q actionQueue) Len() int { return len(q) }
+
+func (q actionQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at.Before(q[j].at)
+}
+
+func (q actionQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *actionQueue) Push(x interface{}) { *q = append(*q, x.(*action)) }
+
+func (q *actionQueue) Pop() interface{} {
+	old := *q
+	a := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return a
+}
+
+func (c *nodeClock) After(d time.Duration) <-chan time.Time {
+	return c.Timer(d).C
+}
+
+func (c *nodeClock) AfterFunc(d time.Duration, f func()) *clock.Timer {
+	c.node.sim.scheduler.schedule(d, f)
+	return &clock.Timer{}
+}
+
+func (c *nodeClock) Now() time.Time {
+	return c.node.sim.scheduler.now
+}
+
+func (c *nodeClock) Sleep(d time.Duration) {
+	c.node.sleep(d)
+}
+
+func (c *nodeClock) Tick(d time.Duration) <-chan time.Time {
+	return c.Ticker(d).C
+}
+
+func (c *nodeClock) Ticker(d time.Duration) *clock.Ticker {
+	ch := make(chan time.Time, 1)
+	var tick func()
+	tick = func() {
+		select {
+		case ch <- c.Now():
+		default:
+		}
+		c.node.sim.scheduler.schedule(d, tick)
+	}
+	c.node.sim.scheduler.schedule(d, tick)
+	return &clock.Ticker{C: ch}
+}
+
+func (c *nodeClock) Timer(d time.Duration) *clock.Timer {
+	ch := make(chan time.Time, 1)
+	c.node.sim.scheduler.schedule(d, func() {
+		ch <- c.Now()
+	})
+	return &clock.Timer{C: ch}
+}
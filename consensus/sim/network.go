@@ -0,0 +1,105 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package sim
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+type (
+	// NetworkConfig defines how the in-memory network drops, delays, reorders and partitions the messages
+	NetworkConfig struct {
+		// MinDelay and MaxDelay bound the uniformly distributed delay of a message
+		MinDelay time.Duration
+		MaxDelay time.Duration
+		// DropRate is the probability of a message being dropped
+		DropRate float64
+		// ReorderRate is the probability of a message being held back by an extra delay up to ReorderDelay, so that
+		// it is overtaken by the messages sent after it
+		ReorderRate  float64
+		ReorderDelay time.Duration
+		Partitions   []Partition
+	}
+
+	// Partition splits the nodes into groups from Start to End since the beginning of a simulation. The messages
+	// sent between the groups are dropped, and the nodes in none of the groups are isolated.
+	Partition struct {
+		Start  time.Duration
+		End    time.Duration
+		Groups [][]int
+	}
+
+	// NetworkStats counts the messages sent through the network
+	NetworkStats struct {
+		Sent      int
+		Dropped   int
+		Delivered int
+	}
+
+	network struct {
+		cfg     NetworkConfig
+		sim     *Simulation
+		rand    *rand.Rand
+		stats   NetworkStats
+		stopped bool
+	}
+)
+
+// send sends a message from a node to another one, unless it is dropped
+func (n *network) send(from, to int, msg proto.Message) {
+	if n.stopped {
+		return
+	}
+	n.stats.Sent++
+	if n.partitioned(from, to) || n.rand.Float64() < n.cfg.DropRate {
+		n.stats.Dropped++
+		return
+	}
+	delay := n.cfg.MinDelay
+	if n.cfg.MaxDelay > n.cfg.MinDelay {
+		delay += time.Duration(n.rand.Int63n(int64(n.cfg.MaxDelay - n.cfg.MinDelay)))
+	}
+	if n.cfg.ReorderDelay > 0 && n.rand.Float64() < n.cfg.ReorderRate {
+		delay += time.Duration(n.rand.Int63n(int64(n.cfg.ReorderDelay)))
+	}
+	// every receiver decodes its own copy
+	msg = proto.Clone(msg)
+	n.sim.scheduler.schedule(delay, func() {
+		if n.stopped {
+			return
+		}
+		n.stats.Delivered++
+		n.sim.deliver(from, to, msg)
+	})
+}
+
+func (n *network) partitioned(from, to int) bool {
+	elapsed := n.sim.scheduler.now.Sub(n.sim.start)
+	for _, p := range n.cfg.Partitions {
+		if elapsed < p.Start || elapsed >= p.End {
+			continue
+		}
+		if group(p, from) < 0 || group(p, from) != group(p, to) {
+			return true
+		}
+	}
+	return false
+}
+
+func group(p Partition, node int) int {
+	for i, g := range p.Groups {
+		for _, n := range g {
+			if n == node {
+				return i
+			}
+		}
+	}
+	return -1
+}
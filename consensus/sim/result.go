@@ -0,0 +1,86 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package sim
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// Result is the outcome of a simulation
+type Result struct {
+	Seed int64
+	// Chains are the hashes of the blocks committed by the delegates, from height 1
+	Chains    [][]hash.Hash256
+	Byzantine map[int]Behavior
+	Stats     NetworkStats
+	// Evidences are the numbers of equivocations detected by the delegates
+	Evidences []int
+	// SyncFailures counts the blocks broadcast by the peers which fail to be committed
+	SyncFailures int
+}
+
+// CheckSafety returns an error if two different blocks are committed at the same height
+func (r *Result) CheckSafety() error {
+	committed := map[int]hash.Hash256{}
+	for i, chain := range r.Chains {
+		for j, blkHash := range chain {
+			if expected, ok := committed[j]; !ok {
+				committed[j] = blkHash
+			} else if expected != blkHash {
+				return errors.Errorf(
+					"seed %d: delegate %d commits block %s at height %d, while block %s is committed by another one",
+					r.Seed,
+					i,
+					hex.EncodeToString(blkHash[:]),
+					j+1,
+					hex.EncodeToString(expected[:]),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckLiveness returns an error if an honest delegate commits fewer than minHeight blocks
+func (r *Result) CheckLiveness(minHeight uint64) error {
+	for i, chain := range r.Chains {
+		if _, ok := r.Byzantine[i]; ok {
+			continue
+		}
+		if uint64(len(chain)) < minHeight {
+			return errors.Errorf(
+				"seed %d: delegate %d commits %d blocks, fewer than %d",
+				r.Seed,
+				i,
+				len(chain),
+				minHeight,
+			)
+		}
+	}
+	return nil
+}
+
+// Height returns the lowest height reached by the honest delegates
+func (r *Result) Height() uint64 {
+	height := -1
+	for i, chain := range r.Chains {
+		if _, ok := r.Byzantine[i]; ok {
+			continue
+		}
+		if height < 0 || len(chain) < height {
+			height = len(chain)
+		}
+	}
+	if height < 0 {
+		return 0
+	}
+	return uint64(height)
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package sim
+
+import (
+	"flag"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// runs is the number of seeded runs with random faults. go test runs a few of them, while make simulation runs
+// thousands of them, e.g. go test -run TestSimulationRandomFaults -timeout 0 ./consensus/sim -sim.runs 5000
+var runs = flag.Int("sim.runs", 20, "number of seeded consensus simulations with random faults")
+
+func TestSimulationDeterminism(t *testing.T) {
+	require := require.New(t)
+	defer zap.ReplaceGlobals(zap.NewNop())()
+
+	cfg := DefaultConfig(7)
+	cfg.Network.DropRate = 0.05
+	cfg.Network.ReorderRate = 0.2
+	cfg.Network.ReorderDelay = time.Second
+	cfg.Byzantine = map[int]Behavior{3: Equivocating}
+	r1 := run(t, cfg)
+	r2 := run(t, cfg)
+	require.Equal(r1, r2)
+}
+
+func TestSimulationScenarios(t *testing.T) {
+	defer zap.ReplaceGlobals(zap.NewNop())()
+
+	for _, scenario := range []struct {
+		name      string
+		configure func(*Config)
+		minHeight uint64
+		// equivocation is true if every honest delegate should detect an equivocation
+		equivocation bool
+	}{
+		{
+			name:      "reliable",
+			configure: func(*Config) {},
+			// a block per interval
+			minHeight: 30,
+		},
+		{
+			name: "lossy and reordering",
+			configure: func(cfg *Config) {
+				cfg.Network.DropRate = 0.05
+				cfg.Network.ReorderRate = 0.3
+				cfg.Network.ReorderDelay = 2 * time.Second
+			},
+			minHeight: 10,
+		},
+		{
+			name: "partitioned and healed",
+			configure: func(cfg *Config) {
+				cfg.Network.Partitions = []Partition{{
+					Start:  time.Minute,
+					End:    2 * time.Minute,
+					Groups: [][]int{{0, 1}, {2, 3}},
+				}}
+			},
+			// no quorum in either half during the partition
+			minHeight: 20,
+		},
+		{
+			name: "isolated delegate",
+			configure: func(cfg *Config) {
+				cfg.Network.Partitions = []Partition{{
+					Start:  0,
+					End:    3 * time.Minute,
+					Groups: [][]int{{0, 1, 2}},
+				}}
+			},
+			minHeight: 20,
+		},
+		{
+			name: "silent delegate",
+			configure: func(cfg *Config) {
+				cfg.Byzantine = map[int]Behavior{3: Silent}
+			},
+			// the rounds proposed by the silent delegate time out
+			minHeight: 20,
+		},
+		{
+			name: "equivocating delegates",
+			configure: func(cfg *Config) {
+				cfg.NumDelegates = 7
+				cfg.Duration = 2 * time.Minute
+				cfg.Byzantine = map[int]Behavior{5: Equivocating, 6: Equivocating}
+			},
+			minHeight:    10,
+			equivocation: true,
+		},
+	} {
+		t.Run(scenario.name, func(t *testing.T) {
+			for seed := int64(1); seed <= 3; seed++ {
+				cfg := DefaultConfig(seed)
+				scenario.configure(&cfg)
+				r := run(t, cfg)
+				require.NoError(t, r.CheckSafety())
+				require.NoError(t, r.CheckLiveness(scenario.minHeight))
+				for i, evidences := range r.Evidences {
+					if _, ok := r.Byzantine[i]; ok {
+						continue
+					}
+					if scenario.equivocation {
+						require.True(t, evidences > 0, "delegate %d detects no equivocation", i)
+					} else {
+						require.Equal(t, 0, evidences)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestSimulationRandomFaults runs the simulations with random faults, in none of which the honest delegates fork or
+// stall
+func TestSimulationRandomFaults(t *testing.T) {
+	defer zap.ReplaceGlobals(zap.NewNop())()
+
+	n := *runs
+	if testing.Short() {
+		n = 5
+	}
+	for seed := int64(1); seed <= int64(n); seed++ {
+		cfg := randomConfig(seed)
+		r := run(t, cfg)
+		require.NoError(t, r.CheckSafety())
+		require.NoError(t, r.CheckLiveness(minHeight(cfg)))
+	}
+}
+
+// minHeight returns the blocks which the honest delegates commit at least in a simulation with random faults. The
+// blocks are committed in the rounds after the partition heals, of which the proposers are honest, and at least a
+// quarter of such rounds reach a quorum despite the lost and late messages.
+func minHeight(cfg Config) uint64 {
+	var healed time.Duration
+	for _, p := range cfg.Network.Partitions {
+		if p.End > healed {
+			healed = p.End
+		}
+	}
+	rounds := int((cfg.Duration - healed) / cfg.Chain.Genesis.BlockInterval)
+	honest := cfg.NumDelegates - len(cfg.Byzantine)
+	if h := rounds * honest / cfg.NumDelegates / 4; h > 1 {
+		return uint64(h)
+	}
+	return 1
+}
+
+// quorum returns the number of the endorsements needed for a consensus among n delegates, i.e., more than 2/3 of them
+func quorum(n int) int {
+	return 2*n/3 + 1
+}
+
+func run(t *testing.T, cfg Config) *Result {
+	s, err := New(cfg)
+	require.NoError(t, err)
+	r, err := s.Run()
+	require.NoError(t, err, "seed %d", cfg.Seed)
+	return r
+}
+
+// randomConfig returns the config of a simulation through a lossy network, with a partition which heals by the half
+// of the simulation, and with less than a third of the delegates being byzantine
+func randomConfig(seed int64) Config {
+	rnd := rand.New(rand.NewSource(seed))
+	cfg := DefaultConfig(seed)
+	cfg.Duration = 4 * time.Minute
+	cfg.NumDelegates = 4 + rnd.Intn(4)
+	cfg.Network.MaxDelay = time.Duration(1+rnd.Intn(1000)) * time.Millisecond
+	cfg.Network.DropRate = rnd.Float64() * 0.1
+	cfg.Network.ReorderRate = rnd.Float64() * 0.5
+	cfg.Network.ReorderDelay = time.Duration(rnd.Intn(3000)) * time.Millisecond
+	if rnd.Intn(2) == 0 {
+		start := time.Duration(rnd.Int63n(int64(cfg.Duration / 4)))
+		p := Partition{Start: start, End: start + time.Duration(rnd.Int63n(int64(cfg.Duration/4)))}
+		groups := map[int][]int{}
+		for i := 0; i < cfg.NumDelegates; i++ {
+			g := rnd.Intn(3)
+			groups[g] = append(groups[g], i)
+		}
+		for g := 0; g < 3; g++ {
+			p.Groups = append(p.Groups, groups[g])
+		}
+		cfg.Network.Partitions = []Partition{p}
+	}
+	cfg.Byzantine = map[int]Behavior{}
+	for _, i := range rnd.Perm(cfg.NumDelegates)[:rnd.Intn((cfg.NumDelegates-1)/3+1)] {
+		cfg.Byzantine[i] = Behavior(1 + rnd.Intn(2))
+	}
+	// the delegates do not resend the messages, so that a round stalls once an endorsement is dropped if every honest
+	// delegate is needed for a quorum
+	if cfg.NumDelegates-len(cfg.Byzantine) <= quorum(cfg.NumDelegates) {
+		cfg.Network.DropRate = 0
+	}
+	return cfg
+}
@@ -0,0 +1,502 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package sim simulates the roll-DPoS consensus among delegates deterministically. The delegates run in virtual time
+// one at a time, and communicate through an in-memory network which could drop, delay, reorder and partition the
+// messages, so that a run is reproducible with its seed.
+package sim
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"math/rand"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	dpos "github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+// maxStepsPerInstant bounds the events handled without the time moving forward, beyond which the delegates are
+// considered in a livelock
+const maxStepsPerInstant = 100000
+
+// Behavior is how a delegate deviates from the protocol
+type Behavior int
+
+const (
+	// Honest delegates follow the protocol
+	Honest Behavior = iota
+	// Silent delegates send nothing, as if they have crashed
+	Silent
+	// Equivocating delegates endorse a random block besides the one voted on, and send both votes to the peers in
+	// different orders
+	Equivocating
+)
+
+type (
+	// Config defines a simulation
+	Config struct {
+		Seed         int64
+		NumDelegates int
+		// Duration is the virtual time the simulation runs
+		Duration  time.Duration
+		Network   NetworkConfig
+		Byzantine map[int]Behavior
+		// Chain is the base config of the delegates, whose consensus scheme and delegates are overwritten
+		Chain config.Config
+	}
+
+	// Simulation runs a number of RollDPoS delegates with a mock clock and an in-memory network
+	Simulation struct {
+		cfg       Config
+		start     time.Time
+		scheduler *scheduler
+		network   *network
+		rand      *rand.Rand
+		nodes     []*node
+	}
+
+	node struct {
+		index        int
+		sim          *Simulation
+		sk           keypair.PrivateKey
+		behavior     Behavior
+		chain        blockchain.Blockchain
+		actPool      actpool.ActPool
+		consensus    *dpos.RollDPoS
+		evidencePool *dpos.EvidencePool
+		turn         chan struct{}
+		yield        chan bool
+		sleeping     bool
+		syncFailures int
+	}
+)
+
+// DefaultConfig returns the config of a simulation among 4 delegates through a reliable network
+func DefaultConfig(seed int64) Config {
+	cfg := config.Default
+	cfg.Consensus.RollDPoS.Delay = 0
+	// the delegates simulated in the same process keep their WALs in memory
+	cfg.Consensus.RollDPoS.WALPath = ""
+	cfg.Genesis.BlockInterval = 10 * time.Second
+	cfg.Genesis.NumSubEpochs = 1
+	// the proposer of a height rotates by round, so that the silent proposers do not stall the chain
+	cfg.Genesis.TimeBasedRotation = true
+	return Config{
+		Seed:         seed,
+		NumDelegates: 4,
+		Duration:     5 * time.Minute,
+		Network: NetworkConfig{
+			MinDelay: 10 * time.Millisecond,
+			MaxDelay: 200 * time.Millisecond,
+		},
+		Chain: cfg,
+	}
+}
+
+// New creates a simulation
+func New(cfg Config) (*Simulation, error) {
+	if cfg.NumDelegates <= 0 {
+		return nil, errors.New("no delegate to simulate")
+	}
+	if cfg.NumDelegates > identityset.Size() {
+		return nil, errors.Errorf(
+			"at most %d delegates could be simulated, %d requested",
+			identityset.Size(),
+			cfg.NumDelegates,
+		)
+	}
+	chainCfg := cfg.Chain
+	chainCfg.Consensus.Scheme = config.RollDPoSScheme
+	chainCfg.Genesis.NumDelegates = uint64(cfg.NumDelegates)
+	if chainCfg.Genesis.NumCandidateDelegates < chainCfg.Genesis.NumDelegates {
+		chainCfg.Genesis.NumCandidateDelegates = chainCfg.Genesis.NumDelegates
+	}
+	cfg.Chain = chainCfg
+	start := time.Unix(chainCfg.Genesis.Timestamp, 0).Add(chainCfg.Genesis.BlockInterval)
+	rnd := rand.New(rand.NewSource(cfg.Seed))
+	s := &Simulation{
+		cfg:       cfg,
+		start:     start,
+		scheduler: newScheduler(start),
+		rand:      rand.New(rand.NewSource(rnd.Int63())),
+	}
+	s.network = &network{cfg: cfg.Network, sim: s, rand: rand.New(rand.NewSource(rnd.Int63()))}
+	candidates := make([]*state.Candidate, cfg.NumDelegates)
+	for i := range candidates {
+		candidates[i] = &state.Candidate{Address: identityset.Address(i).String()}
+	}
+	for i := 0; i < cfg.NumDelegates; i++ {
+		n, err := s.newNode(i, candidates)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create delegate %d", i)
+		}
+		s.nodes = append(s.nodes, n)
+	}
+	return s, nil
+}
+
+// Run runs the simulation for its duration, and returns the chains of the delegates
+func (s *Simulation) Run() (*Result, error) {
+	ctx := context.Background()
+	for _, n := range s.nodes {
+		if err := n.chain.Start(ctx); err != nil {
+			return nil, err
+		}
+		go n.run()
+		if err := n.consensus.StartStepping(ctx); err != nil {
+			return nil, err
+		}
+		n.poll()
+	}
+	deadline := s.start.Add(s.cfg.Duration)
+	var err error
+	for err = s.drain(); err == nil && s.scheduler.next(deadline); {
+		err = s.drain()
+	}
+	s.network.stopped = true
+	for _, n := range s.nodes {
+		// the sleeping delegates finish the events being handled before they stop
+		for n.sleeping {
+			n.resume()
+		}
+		close(n.turn)
+		if stopErr := n.consensus.Stop(ctx); stopErr != nil && err == nil {
+			err = stopErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.result()
+	for _, n := range s.nodes {
+		if stopErr := n.chain.Stop(ctx); stopErr != nil && err == nil {
+			err = stopErr
+		}
+	}
+	return result, err
+}
+
+func (s *Simulation) newNode(index int, candidates []*state.Candidate) (*node, error) {
+	ctx := context.Background()
+	cfg := s.cfg.Chain
+	sk := identityset.PrivateKey(index)
+	cfg.Chain.ProducerPrivKey = hex.EncodeToString(sk.Bytes())
+	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+	if err != nil {
+		return nil, err
+	}
+	if err := sf.Start(ctx); err != nil {
+		return nil, err
+	}
+	ws, err := sf.NewWorkingSet()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range candidates {
+		if _, err := accountutil.LoadOrCreateAccount(ws, c.Address, big.NewInt(0)); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := ws.RunActions(protocol.WithRunActionsCtx(ctx, protocol.RunActionsCtx{
+		Producer: identityset.Address(index),
+		GasLimit: cfg.Genesis.BlockGasLimit,
+	}), 0, nil); err != nil {
+		return nil, err
+	}
+	if err := sf.Commit(ws); err != nil {
+		return nil, err
+	}
+	registry := protocol.Registry{}
+	if err := registry.Register(account.ProtocolID, account.NewProtocol()); err != nil {
+		return nil, err
+	}
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	if err := registry.Register(rolldpos.ProtocolID, rp); err != nil {
+		return nil, err
+	}
+	chain := blockchain.NewBlockchain(
+		cfg,
+		blockchain.InMemDaoOption(),
+		blockchain.PrecreatedStateFactoryOption(sf),
+		blockchain.RegistryOption(&registry),
+	)
+	if err := registry.Register(vote.ProtocolID, vote.NewProtocol(chain)); err != nil {
+		return nil, err
+	}
+	chain.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(chain, cfg.Genesis.ActionGasLimit))
+	chain.Validator().AddActionValidators(account.NewProtocol())
+	actPool, err := actpool.NewActPool(chain, cfg.ActPool)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &node{
+		index:        index,
+		sim:          s,
+		sk:           sk,
+		behavior:     s.cfg.Byzantine[index],
+		chain:        chain,
+		actPool:      actPool,
+		evidencePool: dpos.NewEvidencePool(db.NewMemKVStore()),
+		turn:         make(chan struct{}),
+		yield:        make(chan bool),
+	}
+	n.consensus, err = dpos.NewRollDPoSBuilder().
+		SetAddr(identityset.Address(index).String()).
+		SetPriKey(sk).
+		SetConfig(cfg).
+		SetBlockchain(chain).
+		SetActPool(actPool).
+		SetBroadcast(n.broadcast).
+		SetEvidencePool(n.evidencePool).
+		SetClock(&nodeClock{node: n}).
+		SetCandidatesByHeightFunc(func(uint64) ([]*state.Candidate, error) {
+			return candidates, nil
+		}).
+		RegisterProtocol(rp).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// drain lets the delegates handle their pending events in turn, until none of them has any
+func (s *Simulation) drain() error {
+	for steps := 0; ; {
+		stepped := false
+		for _, n := range s.nodes {
+			if n.sleeping || n.consensus.NumPendingEvts() == 0 {
+				continue
+			}
+			n.step()
+			stepped = true
+			steps++
+		}
+		if !stepped {
+			return nil
+		}
+		if steps > maxStepsPerInstant {
+			return errors.Errorf("delegates are in a livelock at %s", s.scheduler.now)
+		}
+	}
+}
+
+// deliver hands a message over to a delegate
+func (s *Simulation) deliver(from, to int, msg proto.Message) {
+	n := s.nodes[to]
+	switch m := msg.(type) {
+	case *iotextypes.ConsensusMessage:
+		// invalid messages are rejected by the delegate, as they would be by the p2p handler
+		_ = n.consensus.HandleConsensusMsg(m)
+	case *iotextypes.Block:
+		n.sync(s.nodes[from], m)
+	}
+}
+
+func (s *Simulation) result() (*Result, error) {
+	r := &Result{
+		Seed:      s.cfg.Seed,
+		Chains:    make([][]hash.Hash256, len(s.nodes)),
+		Evidences: make([]int, len(s.nodes)),
+		Byzantine: map[int]Behavior{},
+		Stats:     s.network.stats,
+	}
+	for _, n := range s.nodes {
+		if n.behavior != Honest {
+			r.Byzantine[n.index] = n.behavior
+		}
+		tip := n.chain.TipHeight()
+		for h := uint64(1); h <= tip; h++ {
+			blkHash, err := n.chain.GetHashByHeight(h)
+			if err != nil {
+				return nil, err
+			}
+			r.Chains[n.index] = append(r.Chains[n.index], blkHash)
+		}
+		evidences, err := n.evidencePool.Evidences(1, tip+1)
+		if err != nil {
+			return nil, err
+		}
+		r.Evidences[n.index] = len(evidences)
+		r.SyncFailures += n.syncFailures
+	}
+	return r, nil
+}
+
+// run handles an event of the delegate at each turn, until the delegate stops
+func (n *node) run() {
+	for range n.turn {
+		n.consensus.Step()
+		n.yield <- true
+	}
+}
+
+// step lets the delegate handle an event, and waits until it is handled or the delegate sleeps
+func (n *node) step() {
+	n.turn <- struct{}{}
+	n.await()
+}
+
+// resume wakes up the sleeping delegate
+func (n *node) resume() {
+	n.sleeping = false
+	n.turn <- struct{}{}
+	n.await()
+}
+
+func (n *node) await() {
+	n.sleeping = !<-n.yield
+}
+
+// sleep suspends the delegate in the event being handled, until it is resumed after d
+func (n *node) sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	n.sim.scheduler.schedule(d, n.resume)
+	n.yield <- false
+	<-n.turn
+}
+
+func (n *node) broadcast(msg proto.Message) error {
+	switch n.behavior {
+	case Silent:
+		return nil
+	case Equivocating:
+		if cm, ok := msg.(*iotextypes.ConsensusMessage); ok && cm.GetVote() != nil {
+			conflicting, err := n.conflictingVote(cm)
+			if err != nil {
+				return err
+			}
+			for _, peer := range n.sim.nodes {
+				if peer.index == n.index {
+					continue
+				}
+				if peer.index%2 == 0 {
+					n.sim.network.send(n.index, peer.index, msg)
+					n.sim.network.send(n.index, peer.index, conflicting)
+				} else {
+					n.sim.network.send(n.index, peer.index, conflicting)
+					n.sim.network.send(n.index, peer.index, msg)
+				}
+			}
+			return nil
+		}
+	}
+	for _, peer := range n.sim.nodes {
+		if peer.index != n.index {
+			n.sim.network.send(n.index, peer.index, msg)
+		}
+	}
+	return nil
+}
+
+// conflictingVote endorses a random block with the same topic and timestamp as a vote
+func (n *node) conflictingVote(msg *iotextypes.ConsensusMessage) (*iotextypes.ConsensusMessage, error) {
+	ecm := &dpos.EndorsedConsensusMessage{}
+	if err := ecm.LoadProto(msg); err != nil {
+		return nil, err
+	}
+	vote, ok := ecm.Document().(*dpos.ConsensusVote)
+	if !ok {
+		return nil, errors.New("invalid vote")
+	}
+	var random hash.Hash256
+	n.sim.rand.Read(random[:])
+	conflicting := dpos.NewConsensusVote(random[:], vote.Topic())
+	en, err := endorsement.Endorse(n.sk, conflicting, ecm.Endorsement().Timestamp())
+	if err != nil {
+		return nil, err
+	}
+	return dpos.NewEndorsedConsensusMessage(ecm.Height(), conflicting, en).Proto()
+}
+
+// sync commits the blocks up to a block broadcast by a peer, fetching the missing ones from the peer, as block sync
+// would do
+func (n *node) sync(peer *node, blkPb *iotextypes.Block) {
+	blk := &block.Block{}
+	if err := blk.ConvertFromBlockPb(blkPb); err != nil {
+		n.syncFailures++
+		return
+	}
+	for h := n.chain.TipHeight() + 1; h <= blk.Height(); h++ {
+		next := blk
+		if h < blk.Height() {
+			peerBlk, err := peer.chain.GetBlockByHeight(h)
+			if err != nil {
+				n.syncFailures++
+				return
+			}
+			next = &block.Block{}
+			if err := next.ConvertFromBlockPb(peerBlk.ConvertToBlockPb()); err != nil {
+				n.syncFailures++
+				return
+			}
+		}
+		if err := n.commitBlock(next); err != nil {
+			n.syncFailures++
+			return
+		}
+	}
+}
+
+// poll fetches the tip block of a random peer at each sync interval, as block sync would do, so that a delegate which
+// misses the broadcast blocks catches up
+func (n *node) poll() {
+	interval := n.sim.cfg.Chain.BlockSync.Interval
+	if interval <= 0 {
+		return
+	}
+	n.sim.scheduler.schedule(interval, func() {
+		peer := n.sim.nodes[n.sim.rand.Intn(len(n.sim.nodes))]
+		if peer != n && peer.behavior != Silent && peer.chain.TipHeight() > n.chain.TipHeight() {
+			blk, err := peer.chain.GetBlockByHeight(peer.chain.TipHeight())
+			if err != nil {
+				n.syncFailures++
+			} else {
+				n.sim.network.send(peer.index, n.index, blk.ConvertToBlockPb())
+			}
+		}
+		n.poll()
+	})
+}
+
+func (n *node) commitBlock(blk *block.Block) error {
+	if err := n.consensus.ValidateBlockFooter(blk); err != nil {
+		return err
+	}
+	if err := n.chain.ValidateBlock(blk); err != nil {
+		return err
+	}
+	if err := n.chain.CommitBlock(blk); err != nil {
+		return err
+	}
+	n.consensus.Calibrate(blk.Height())
+	n.actPool.Reset()
+	return nil
+}
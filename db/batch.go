@@ -102,6 +102,21 @@ const (
 	Delete int32 = 1
 )
 
+// WriteType returns the type of the write, Put or Delete
+func (wi *writeInfo) WriteType() int32 {
+	return wi.writeType
+}
+
+// Namespace returns the namespace of the write
+func (wi *writeInfo) Namespace() string {
+	return wi.namespace
+}
+
+// Key returns the key of the write
+func (wi *writeInfo) Key() []byte {
+	return wi.key
+}
+
 func (wi *writeInfo) serialize() []byte {
 	bytes := make([]byte, 0)
 	bytes = append(bytes, []byte(wi.namespace)...)
@@ -8,6 +8,7 @@ package db
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -39,6 +40,13 @@ type KVStore interface {
 	Delete(string, []byte) error
 	// Commit commits a batch
 	Commit(KVStoreBatch) error
+	// Iterate returns an iterator over the records of a namespace whose keys have the prefix
+	Iterate(string, []byte) (Iterator, error)
+	// Range returns an iterator over the records of a namespace whose keys are in [start, end). A nil end means the
+	// end of the namespace.
+	Range(string, []byte, []byte) (Iterator, error)
+	// Snapshot takes a read-only snapshot of the store
+	Snapshot() (Snapshot, error)
 }
 
 const (
@@ -124,8 +132,64 @@ func (m *memKVStore) Commit(b KVStoreBatch) (e error) {
 	return e
 }
 
+// Iterate returns an iterator over the records of a namespace whose keys have the prefix
+func (m *memKVStore) Iterate(namespace string, prefix []byte) (Iterator, error) {
+	start, end := prefixRange(prefix)
+	return m.Range(namespace, start, end)
+}
+
+// Range returns an iterator over the records of a namespace whose keys are in [start, end)
+func (m *memKVStore) Range(namespace string, start, end []byte) (Iterator, error) {
+	var keys, values [][]byte
+	nsPrefix := namespace + keyDelimiter
+	m.data.Range(func(k, v interface{}) bool {
+		key := k.(string)
+		if !strings.HasPrefix(key, nsPrefix) {
+			return true
+		}
+		if rk := []byte(key[len(nsPrefix):]); inRange(rk, start, end) {
+			keys = append(keys, rk)
+			values = append(values, v.([]byte))
+		}
+		return true
+	})
+	return newSliceIterator(keys, values), nil
+}
+
+// Snapshot copies the records into a new in-memory store
+func (m *memKVStore) Snapshot() (Snapshot, error) {
+	snapshot := &memKVStore{
+		bucket: &sync.Map{},
+		data:   &sync.Map{},
+	}
+	m.bucket.Range(func(k, v interface{}) bool {
+		snapshot.bucket.Store(k, v)
+		return true
+	})
+	m.data.Range(func(k, v interface{}) bool {
+		snapshot.data.Store(k, v)
+		return true
+	})
+	return &memSnapshot{snapshot}, nil
+}
+
+// memSnapshot is a copy of an in-memory store
+type memSnapshot struct {
+	*memKVStore
+}
+
+func (s *memSnapshot) Release() {}
+
 // NewOnDiskDB instantiates an on-disk KV store
 func NewOnDiskDB(cfg config.DB) KVStore {
+	switch cfg.Backend {
+	case config.BadgerDBBackend:
+		return &badgerDB{db: nil, path: cfg.DbPath, config: cfg}
+	case config.LevelDBBackend:
+		return &levelDB{db: nil, path: cfg.DbPath, config: cfg}
+	case config.BoltDBBackend:
+		return &boltDB{db: nil, path: cfg.DbPath, config: cfg}
+	}
 	if cfg.UseBadgerDB {
 		return &badgerDB{db: nil, path: cfg.DbPath, config: cfg}
 	}
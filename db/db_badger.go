@@ -7,6 +7,7 @@
 package db
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/dgraph-io/badger"
@@ -149,6 +150,133 @@ func (b *badgerDB) Commit(batch KVStoreBatch) (err error) {
 	return err
 }
 
+// Iterate returns an iterator over the records of a namespace whose keys have the prefix. The namespace is the prefix
+// of the keys in badger DB, so a namespace must not be a prefix of another.
+func (b *badgerDB) Iterate(namespace string, prefix []byte) (Iterator, error) {
+	start, end := prefixRange(prefix)
+	return b.Range(namespace, start, end)
+}
+
+// Range returns an iterator over the records of a namespace whose keys are in [start, end)
+func (b *badgerDB) Range(namespace string, start, end []byte) (Iterator, error) {
+	return newBadgerIterator(b.db.NewTransaction(false), nil, namespace, start, end), nil
+}
+
+// Snapshot starts a read-only transaction as the snapshot
+func (b *badgerDB) Snapshot() (Snapshot, error) {
+	return &badgerSnapshot{txn: b.db.NewTransaction(false)}, nil
+}
+
+// badgerSnapshot is a snapshot of badger DB based on a read-only transaction, on which only one iterator can be open
+// at a time
+type badgerSnapshot struct {
+	txn       *badger.Txn
+	iterating bool
+}
+
+func (s *badgerSnapshot) Get(namespace string, key []byte) ([]byte, error) {
+	k := append([]byte(namespace), key...)
+	item, err := s.txn.Get(k)
+	if err == badger.ErrKeyNotFound {
+		return nil, errors.Wrap(ErrNotExist, err.Error())
+	}
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return value, nil
+}
+
+func (s *badgerSnapshot) Iterate(namespace string, prefix []byte) (Iterator, error) {
+	start, end := prefixRange(prefix)
+	return s.Range(namespace, start, end)
+}
+
+func (s *badgerSnapshot) Range(namespace string, start, end []byte) (Iterator, error) {
+	if s.iterating {
+		return nil, errors.New("another iterator of the snapshot is open")
+	}
+	s.iterating = true
+	return newBadgerIterator(s.txn, s, namespace, start, end), nil
+}
+
+func (s *badgerSnapshot) Release() {
+	s.txn.Discard()
+}
+
+// badgerIterator iterates over the keys prefixed by the namespace
+type badgerIterator struct {
+	txn      *badger.Txn
+	snapshot *badgerSnapshot
+	it       *badger.Iterator
+	ns       []byte
+	start    []byte
+	end      []byte
+	seeked   bool
+	value    []byte
+	err      error
+}
+
+// newBadgerIterator creates an iterator in the transaction, which is discarded along with the iterator unless it
+// belongs to a snapshot
+func newBadgerIterator(
+	txn *badger.Txn,
+	snapshot *badgerSnapshot,
+	namespace string,
+	start, end []byte,
+) *badgerIterator {
+	return &badgerIterator{
+		txn:      txn,
+		snapshot: snapshot,
+		it:       txn.NewIterator(badger.DefaultIteratorOptions),
+		ns:       []byte(namespace),
+		start:    start,
+		end:      end,
+	}
+}
+
+func (it *badgerIterator) Next() bool {
+	if it.it == nil || it.err != nil {
+		return false
+	}
+	if it.seeked {
+		it.it.Next()
+	} else {
+		it.it.Seek(append(append([]byte{}, it.ns...), it.start...))
+		it.seeked = true
+	}
+	if !it.it.ValidForPrefix(it.ns) || (it.end != nil && bytes.Compare(it.Key(), it.end) >= 0) {
+		return false
+	}
+	if it.value, it.err = it.it.Item().Value(); it.err != nil {
+		it.err = errors.Wrap(ErrIO, it.err.Error())
+		return false
+	}
+	return true
+}
+
+func (it *badgerIterator) Key() []byte { return it.it.Item().Key()[len(it.ns):] }
+
+func (it *badgerIterator) Value() []byte { return it.value }
+
+func (it *badgerIterator) Error() error { return it.err }
+
+func (it *badgerIterator) Close() {
+	if it.it == nil {
+		return
+	}
+	it.it.Close()
+	it.it = nil
+	if it.snapshot != nil {
+		it.snapshot.iterating = false
+		return
+	}
+	it.txn.Discard()
+}
+
 //======================================
 // private functions
 //======================================
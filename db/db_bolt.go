@@ -7,12 +7,15 @@
 package db
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/pkg/errors"
 	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/log"
 )
 
 const fileMode = 0600
@@ -164,6 +167,121 @@ func (b *boltDB) Commit(batch KVStoreBatch) (err error) {
 	return err
 }
 
+// Iterate returns an iterator over the records of a namespace whose keys have the prefix
+func (b *boltDB) Iterate(namespace string, prefix []byte) (Iterator, error) {
+	start, end := prefixRange(prefix)
+	return b.Range(namespace, start, end)
+}
+
+// Range returns an iterator over the records of a namespace whose keys are in [start, end). The iterator holds a
+// read transaction, which blocks the DB file from growing, so close it as soon as possible.
+func (b *boltDB) Range(namespace string, start, end []byte) (Iterator, error) {
+	tx, err := b.db.Begin(false)
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return newBoltIterator(tx, true, namespace, start, end), nil
+}
+
+// Snapshot starts a read transaction as the snapshot, which blocks the DB file from growing until it is released
+func (b *boltDB) Snapshot() (Snapshot, error) {
+	tx, err := b.db.Begin(false)
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return &boltSnapshot{tx: tx}, nil
+}
+
+// boltSnapshot is a snapshot of bolt DB based on a read transaction
+type boltSnapshot struct {
+	tx *bolt.Tx
+}
+
+func (s *boltSnapshot) Get(namespace string, key []byte) ([]byte, error) {
+	bucket := s.tx.Bucket([]byte(namespace))
+	if bucket == nil {
+		return nil, errors.Wrapf(ErrNotExist, "bucket = %s doesn't exist", namespace)
+	}
+	v := bucket.Get(key)
+	if v == nil {
+		return nil, errors.Wrapf(ErrNotExist, "key = %x doesn't exist", key)
+	}
+	value := make([]byte, len(v))
+	copy(value, v)
+	return value, nil
+}
+
+func (s *boltSnapshot) Iterate(namespace string, prefix []byte) (Iterator, error) {
+	start, end := prefixRange(prefix)
+	return s.Range(namespace, start, end)
+}
+
+func (s *boltSnapshot) Range(namespace string, start, end []byte) (Iterator, error) {
+	return newBoltIterator(s.tx, false, namespace, start, end), nil
+}
+
+func (s *boltSnapshot) Release() {
+	if err := s.tx.Rollback(); err != nil {
+		log.L().Warn("Failed to release bolt snapshot.", zap.Error(err))
+	}
+}
+
+// boltIterator iterates over a bucket with a cursor
+type boltIterator struct {
+	tx     *bolt.Tx
+	ownTx  bool
+	cursor *bolt.Cursor
+	start  []byte
+	end    []byte
+	seeked bool
+	key    []byte
+	value  []byte
+}
+
+// newBoltIterator creates an iterator over the bucket in the transaction, the transaction is closed along with the
+// iterator if ownTx is set
+func newBoltIterator(tx *bolt.Tx, ownTx bool, namespace string, start, end []byte) *boltIterator {
+	it := &boltIterator{tx: tx, ownTx: ownTx, start: start, end: end}
+	if bucket := tx.Bucket([]byte(namespace)); bucket != nil {
+		it.cursor = bucket.Cursor()
+	}
+	return it
+}
+
+func (it *boltIterator) Next() bool {
+	if it.cursor == nil {
+		return false
+	}
+	if it.seeked {
+		it.key, it.value = it.cursor.Next()
+	} else {
+		it.key, it.value = it.cursor.Seek(it.start)
+		it.seeked = true
+	}
+	if it.key == nil || (it.end != nil && bytes.Compare(it.key, it.end) >= 0) {
+		it.cursor = nil
+		return false
+	}
+	return true
+}
+
+func (it *boltIterator) Key() []byte { return it.key }
+
+func (it *boltIterator) Value() []byte { return it.value }
+
+func (it *boltIterator) Error() error { return nil }
+
+func (it *boltIterator) Close() {
+	it.cursor = nil
+	if !it.ownTx || it.tx == nil {
+		return
+	}
+	if err := it.tx.Rollback(); err != nil {
+		log.L().Warn("Failed to close bolt iterator.", zap.Error(err))
+	}
+	it.tx = nil
+}
+
 //======================================
 // private functions
 //======================================
@@ -0,0 +1,251 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+// maxNamespaceLength is the maximum length of a namespace in level DB, which is encoded in one byte
+const maxNamespaceLength = 0xff
+
+// levelDB is KVStore implementation based on level DB, a log-structured merge-tree. All the namespaces share one key
+// space, where a key is prefixed by the length of its namespace and the namespace, so that the keys of a namespace
+// are contiguous and no namespace is a prefix of another.
+type levelDB struct {
+	db     *leveldb.DB
+	path   string
+	config config.DB
+}
+
+// levelDBReader reads level DB or a snapshot of it
+type levelDBReader interface {
+	Get([]byte, *opt.ReadOptions) ([]byte, error)
+	NewIterator(*util.Range, *opt.ReadOptions) iterator.Iterator
+}
+
+// Start opens the level DB (creates new files if not existing yet)
+func (l *levelDB) Start(_ context.Context) error {
+	db, err := leveldb.OpenFile(l.path, nil)
+	if err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	l.db = db
+	return nil
+}
+
+// Stop closes the level DB
+func (l *levelDB) Stop(_ context.Context) error {
+	if l.db != nil {
+		if err := l.db.Close(); err != nil {
+			return errors.Wrap(ErrIO, err.Error())
+		}
+	}
+	return nil
+}
+
+// Put inserts a <key, value> record
+func (l *levelDB) Put(namespace string, key, value []byte) (err error) {
+	k, err := levelDBKey(namespace, key)
+	if err != nil {
+		return err
+	}
+	for c := uint8(0); c < l.config.NumRetries; c++ {
+		if err = l.db.Put(k, value, nil); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		err = errors.Wrap(ErrIO, err.Error())
+	}
+	return err
+}
+
+// Get retrieves a record
+func (l *levelDB) Get(namespace string, key []byte) ([]byte, error) {
+	return levelDBGet(l.db, namespace, key)
+}
+
+// Delete deletes a record
+func (l *levelDB) Delete(namespace string, key []byte) (err error) {
+	k, err := levelDBKey(namespace, key)
+	if err != nil {
+		return err
+	}
+	for c := uint8(0); c < l.config.NumRetries; c++ {
+		if err = l.db.Delete(k, nil); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		err = errors.Wrap(ErrIO, err.Error())
+	}
+	return err
+}
+
+// Commit commits a batch atomically
+func (l *levelDB) Commit(batch KVStoreBatch) (err error) {
+	succeed := true
+	batch.Lock()
+	defer func() {
+		if succeed {
+			// clear the batch if commit succeeds
+			batch.ClearAndUnlock()
+		} else {
+			batch.Unlock()
+		}
+	}()
+
+	b := new(leveldb.Batch)
+	for i := 0; i < batch.Size(); i++ {
+		write, err := batch.Entry(i)
+		if err != nil {
+			succeed = false
+			return err
+		}
+		k, err := levelDBKey(write.namespace, write.key)
+		if err != nil {
+			succeed = false
+			return errors.Wrapf(err, write.errorFormat, write.errorArgs)
+		}
+		if write.writeType == Put {
+			b.Put(k, write.value)
+		} else if write.writeType == Delete {
+			b.Delete(k)
+		}
+	}
+	for c := uint8(0); c < l.config.NumRetries; c++ {
+		if err = l.db.Write(b, nil); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		succeed = false
+		err = errors.Wrap(ErrIO, err.Error())
+	}
+	return err
+}
+
+// Iterate returns an iterator over the records of a namespace whose keys have the prefix
+func (l *levelDB) Iterate(namespace string, prefix []byte) (Iterator, error) {
+	start, end := prefixRange(prefix)
+	return levelDBRange(l.db, namespace, start, end)
+}
+
+// Range returns an iterator over the records of a namespace whose keys are in [start, end)
+func (l *levelDB) Range(namespace string, start, end []byte) (Iterator, error) {
+	return levelDBRange(l.db, namespace, start, end)
+}
+
+// Snapshot takes a snapshot of the level DB
+func (l *levelDB) Snapshot() (Snapshot, error) {
+	snapshot, err := l.db.GetSnapshot()
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return &levelDBSnapshot{snapshot}, nil
+}
+
+// levelDBSnapshot is a snapshot of level DB
+type levelDBSnapshot struct {
+	snapshot *leveldb.Snapshot
+}
+
+func (s *levelDBSnapshot) Get(namespace string, key []byte) ([]byte, error) {
+	return levelDBGet(s.snapshot, namespace, key)
+}
+
+func (s *levelDBSnapshot) Iterate(namespace string, prefix []byte) (Iterator, error) {
+	start, end := prefixRange(prefix)
+	return levelDBRange(s.snapshot, namespace, start, end)
+}
+
+func (s *levelDBSnapshot) Range(namespace string, start, end []byte) (Iterator, error) {
+	return levelDBRange(s.snapshot, namespace, start, end)
+}
+
+func (s *levelDBSnapshot) Release() {
+	s.snapshot.Release()
+}
+
+// levelDBIterator strips the namespace from the keys
+type levelDBIterator struct {
+	it     iterator.Iterator
+	nsSize int
+}
+
+func (it *levelDBIterator) Next() bool { return it.it.Next() }
+
+func (it *levelDBIterator) Key() []byte { return it.it.Key()[it.nsSize:] }
+
+func (it *levelDBIterator) Value() []byte { return it.it.Value() }
+
+func (it *levelDBIterator) Error() error {
+	if err := it.it.Error(); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return nil
+}
+
+func (it *levelDBIterator) Close() { it.it.Release() }
+
+//======================================
+// private functions
+//======================================
+
+// levelDBKey prefixes the key with the namespace
+func levelDBKey(namespace string, key []byte) ([]byte, error) {
+	if len(namespace) > maxNamespaceLength {
+		return nil, errors.Errorf("namespace %s is longer than %d bytes", namespace, maxNamespaceLength)
+	}
+	k := make([]byte, 0, 1+len(namespace)+len(key))
+	k = append(k, byte(len(namespace)))
+	k = append(k, namespace...)
+	return append(k, key...), nil
+}
+
+func levelDBGet(r levelDBReader, namespace string, key []byte) ([]byte, error) {
+	k, err := levelDBKey(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := r.Get(k, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, errors.Wrapf(ErrNotExist, "key = %x doesn't exist", key)
+	}
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return value, nil
+}
+
+func levelDBRange(r levelDBReader, namespace string, start, end []byte) (Iterator, error) {
+	startKey, err := levelDBKey(namespace, start)
+	if err != nil {
+		return nil, err
+	}
+	var limit []byte
+	if end == nil {
+		// the end of the namespace
+		ns, _ := levelDBKey(namespace, nil)
+		_, limit = prefixRange(ns)
+	} else if limit, err = levelDBKey(namespace, end); err != nil {
+		return nil, err
+	}
+	return &levelDBIterator{
+		it:     r.NewIterator(&util.Range{Start: startKey, Limit: limit}, nil),
+		nsSize: 1 + len(namespace),
+	}, nil
+}
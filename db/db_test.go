@@ -7,6 +7,7 @@
 package db
 
 import (
+	"bytes"
 	"context"
 	"io/ioutil"
 	"os"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/testutil"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -75,6 +77,17 @@ func TestKVStorePutGet(t *testing.T) {
 	t.Run("Badger DB", func(t *testing.T) {
 		testKVStorePutGet(NewOnDiskDB(cfg), t)
 	})
+
+	path = "test-kv-store.leveldb"
+	testPath, _ = ioutil.TempDir(os.TempDir(), path)
+	levelDBCfg := cfg
+	levelDBCfg.DbPath = testPath
+	levelDBCfg.UseBadgerDB = false
+	levelDBCfg.Backend = config.LevelDBBackend
+	t.Run("Level DB", func(t *testing.T) {
+		defer testutil.CleanupPath(t, testPath)
+		testKVStorePutGet(NewOnDiskDB(levelDBCfg), t)
+	})
 }
 
 func TestBatchRollback(t *testing.T) {
@@ -263,6 +276,17 @@ func TestDBBatch(t *testing.T) {
 	t.Run("Badger DB", func(t *testing.T) {
 		testBatchRollback(NewOnDiskDB(cfg), t)
 	})
+
+	path = "test-batch-commit.leveldb"
+	testPath, _ = ioutil.TempDir(os.TempDir(), path)
+	levelDBCfg := cfg
+	levelDBCfg.DbPath = testPath
+	levelDBCfg.UseBadgerDB = false
+	levelDBCfg.Backend = config.LevelDBBackend
+	t.Run("Level DB", func(t *testing.T) {
+		defer testutil.CleanupPath(t, testPath)
+		testBatchRollback(NewOnDiskDB(levelDBCfg), t)
+	})
 }
 
 func TestCacheKV(t *testing.T) {
@@ -323,4 +347,136 @@ func TestCacheKV(t *testing.T) {
 	t.Run("Badger DB", func(t *testing.T) {
 		testFunc(NewOnDiskDB(cfg), t)
 	})
+
+	path = "test-cache-kv.leveldb"
+	testPath, _ = ioutil.TempDir(os.TempDir(), path)
+	levelDBCfg := cfg
+	levelDBCfg.DbPath = testPath
+	levelDBCfg.UseBadgerDB = false
+	levelDBCfg.Backend = config.LevelDBBackend
+	t.Run("Level DB", func(t *testing.T) {
+		defer testutil.CleanupPath(t, testPath)
+		testFunc(NewOnDiskDB(levelDBCfg), t)
+	})
+}
+
+func TestKVStoreIterate(t *testing.T) {
+	testIterate := func(kvStore KVStore, t *testing.T) {
+		require := require.New(t)
+		ctx := context.Background()
+
+		require.NoError(kvStore.Start(ctx))
+		defer func() {
+			require.NoError(kvStore.Stop(ctx))
+		}()
+
+		keys := [][]byte{{1}, {1, 0}, {1, 0xff}, {2}, {2, 1}, {0xff}, {0xff, 0xff}}
+		batch := NewBatch()
+		for i, k := range keys {
+			batch.Put(bucket1, k, []byte{byte(i)}, "")
+		}
+		batch.Put(bucket2, []byte{1, 1}, []byte("other"), "")
+		require.NoError(kvStore.Commit(batch))
+
+		collect := func(it Iterator, err error) [][]byte {
+			require.NoError(err)
+			defer it.Close()
+			var res [][]byte
+			for it.Next() {
+				require.Equal([]byte{byte(indexOf(keys, it.Key()))}, it.Value())
+				res = append(res, append([]byte{}, it.Key()...))
+			}
+			require.NoError(it.Error())
+			return res
+		}
+
+		require.Equal(keys, collect(kvStore.Iterate(bucket1, nil)))
+		require.Equal(keys[:3], collect(kvStore.Iterate(bucket1, []byte{1})))
+		require.Equal(keys[5:], collect(kvStore.Iterate(bucket1, []byte{0xff})))
+		require.Equal(keys[6:], collect(kvStore.Iterate(bucket1, []byte{0xff, 0xff})))
+		require.Empty(collect(kvStore.Iterate(bucket1, []byte{3})))
+		require.Empty(collect(kvStore.Iterate(bucket3, nil)))
+		require.Equal(keys[1:4], collect(kvStore.Range(bucket1, []byte{1, 0}, []byte{2, 0})))
+		require.Equal(keys[4:], collect(kvStore.Range(bucket1, []byte{2, 1}, nil)))
+		require.Equal(keys[:2], collect(kvStore.Range(bucket1, nil, []byte{1, 1})))
+
+		// a snapshot isn't affected by the later writes
+		snapshot, err := kvStore.Snapshot()
+		require.NoError(err)
+		written := make(chan error, 1)
+		write := func() {
+			if err := kvStore.Put(bucket1, []byte{1, 1}, []byte{0xff}); err != nil {
+				written <- err
+				return
+			}
+			written <- kvStore.Delete(bucket1, keys[0])
+		}
+		if _, ok := kvStore.(*boltDB); ok {
+			// the writes growing bolt DB wait for the snapshot to be released
+			go write()
+		} else {
+			write()
+			require.NoError(<-written)
+		}
+		value, err := snapshot.Get(bucket1, keys[0])
+		require.NoError(err)
+		require.Equal([]byte{0}, value)
+		_, err = snapshot.Get(bucket1, []byte{1, 1})
+		require.Equal(ErrNotExist, errors.Cause(err))
+		require.Equal(keys[:3], collect(snapshot.Iterate(bucket1, []byte{1})))
+		require.Equal(keys[3:5], collect(snapshot.Range(bucket1, []byte{2}, []byte{3})))
+		snapshot.Release()
+		if _, ok := kvStore.(*boltDB); ok {
+			require.NoError(<-written)
+		}
+
+		require.Equal([][]byte{{1, 0}, {1, 1}, {1, 0xff}}, collect(kvStore.Range(bucket1, nil, []byte{2})))
+		value, err = kvStore.Get(bucket1, []byte{1, 1})
+		require.NoError(err)
+		require.Equal([]byte{0xff}, value)
+	}
+
+	t.Run("In-memory KV Store", func(t *testing.T) {
+		testIterate(NewMemKVStore(), t)
+	})
+
+	testFile, _ := ioutil.TempFile(os.TempDir(), "test-kv-iterate.bolt")
+	testPath := testFile.Name()
+	boltDBCfg := cfg
+	boltDBCfg.DbPath = testPath
+	boltDBCfg.Backend = config.BoltDBBackend
+	boltDBCfg.UseBadgerDB = false
+	t.Run("Bolt DB", func(t *testing.T) {
+		testutil.CleanupPath(t, testPath)
+		defer testutil.CleanupPath(t, testPath)
+		testIterate(NewOnDiskDB(boltDBCfg), t)
+	})
+
+	testPath, _ = ioutil.TempDir(os.TempDir(), "test-kv-iterate.badger")
+	badgerDBCfg := cfg
+	badgerDBCfg.DbPath = testPath
+	badgerDBCfg.Backend = config.BadgerDBBackend
+	t.Run("Badger DB", func(t *testing.T) {
+		defer testutil.CleanupPath(t, testPath)
+		testIterate(NewOnDiskDB(badgerDBCfg), t)
+	})
+
+	testPath, _ = ioutil.TempDir(os.TempDir(), "test-kv-iterate.leveldb")
+	levelDBCfg := cfg
+	levelDBCfg.DbPath = testPath
+	levelDBCfg.Backend = config.LevelDBBackend
+	levelDBCfg.UseBadgerDB = false
+	t.Run("Level DB", func(t *testing.T) {
+		defer testutil.CleanupPath(t, testPath)
+		testIterate(NewOnDiskDB(levelDBCfg), t)
+	})
+}
+
+func indexOf(keys [][]byte, key []byte) int {
+	for i, k := range keys {
+		if bytes.Equal(k, key) {
+			return i
+		}
+	}
+	return -1
 }
@@ -0,0 +1,98 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"bytes"
+	"sort"
+)
+
+// Iterator iterates over the records of a namespace in the ascending order of the keys. The key and value returned
+// are only valid until the next call of Next, so copy them to retain. An iterator must be closed after use.
+type Iterator interface {
+	// Next moves to the next record, and returns false if there is no more record or an error occurs
+	Next() bool
+	// Key returns the key of the current record
+	Key() []byte
+	// Value returns the value of the current record
+	Value() []byte
+	// Error returns the error occurred during the iteration
+	Error() error
+	// Close releases the resources held by the iterator
+	Close()
+}
+
+// Snapshot is a read-only view of a KVStore at the time it is taken, which isn't affected by the later writes. A
+// snapshot isn't safe for concurrent use, and must be released after use.
+type Snapshot interface {
+	// Get gets a record by (namespace, key)
+	Get(string, []byte) ([]byte, error)
+	// Iterate returns an iterator over the records of a namespace whose keys have the prefix
+	Iterate(string, []byte) (Iterator, error)
+	// Range returns an iterator over the records of a namespace whose keys are in [start, end). A nil end means the
+	// end of the namespace.
+	Range(string, []byte, []byte) (Iterator, error)
+	// Release releases the resources held by the snapshot
+	Release()
+}
+
+// prefixRange returns the key range covering all the keys with the prefix
+func prefixRange(prefix []byte) ([]byte, []byte) {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return prefix, end[:i+1]
+		}
+	}
+	// the prefix is empty or all 0xff, no key is greater than the keys with the prefix
+	return prefix, nil
+}
+
+// inRange returns whether the key is in [start, end)
+func inRange(key, start, end []byte) bool {
+	return bytes.Compare(key, start) >= 0 && (end == nil || bytes.Compare(key, end) < 0)
+}
+
+// sliceIterator iterates over the records in memory
+type sliceIterator struct {
+	keys   [][]byte
+	values [][]byte
+	index  int
+}
+
+// newSliceIterator creates an iterator over the records, which are sorted by the keys
+func newSliceIterator(keys, values [][]byte) *sliceIterator {
+	it := &sliceIterator{keys: keys, values: values, index: -1}
+	sort.Sort(it)
+	return it
+}
+
+func (it *sliceIterator) Len() int { return len(it.keys) }
+
+func (it *sliceIterator) Less(i, j int) bool { return bytes.Compare(it.keys[i], it.keys[j]) < 0 }
+
+func (it *sliceIterator) Swap(i, j int) {
+	it.keys[i], it.keys[j] = it.keys[j], it.keys[i]
+	it.values[i], it.values[j] = it.values[j], it.values[i]
+}
+
+func (it *sliceIterator) Next() bool {
+	if it.index < len(it.keys) {
+		it.index++
+	}
+	return it.index < len(it.keys)
+}
+
+func (it *sliceIterator) Key() []byte { return it.keys[it.index] }
+
+func (it *sliceIterator) Value() []byte { return it.values[it.index] }
+
+func (it *sliceIterator) Error() error { return nil }
+
+func (it *sliceIterator) Close() {}
@@ -0,0 +1,63 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package db
+
+// KVStoreForArchive is a KVStore which never deletes records in the archived namespaces, so that the historical
+// data, e.g., the nodes of the past state tries, are always retained
+type KVStoreForArchive struct {
+	KVStore
+	namespaces map[string]struct{}
+}
+
+// NewKVStoreForArchive wraps the kv store to retain the records in the given namespaces
+func NewKVStoreForArchive(kv KVStore, namespaces ...string) *KVStoreForArchive {
+	s := &KVStoreForArchive{
+		KVStore:    kv,
+		namespaces: make(map[string]struct{}, len(namespaces)),
+	}
+	for _, ns := range namespaces {
+		s.namespaces[ns] = struct{}{}
+	}
+	return s
+}
+
+// Delete deletes a record by (namespace, key) unless the namespace is archived
+func (s *KVStoreForArchive) Delete(namespace string, key []byte) error {
+	if s.archived(namespace) {
+		return nil
+	}
+	return s.KVStore.Delete(namespace, key)
+}
+
+// Commit commits a batch, skipping the deletions in the archived namespaces
+func (s *KVStoreForArchive) Commit(batch KVStoreBatch) error {
+	batch.Lock()
+	filtered := &baseKVStoreBatch{}
+	for i := 0; i < batch.Size(); i++ {
+		write, err := batch.Entry(i)
+		if err != nil {
+			batch.Unlock()
+			return err
+		}
+		if write.writeType == Delete && s.archived(write.namespace) {
+			continue
+		}
+		filtered.writeQueue = append(filtered.writeQueue, *write)
+	}
+	if err := s.KVStore.Commit(filtered); err != nil {
+		batch.Unlock()
+		return err
+	}
+	// clear the batch if commit succeeds
+	batch.ClearAndUnlock()
+	return nil
+}
+
+func (s *KVStoreForArchive) archived(namespace string) bool {
+	_, ok := s.namespaces[namespace]
+	return ok
+}
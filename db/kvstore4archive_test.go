@@ -0,0 +1,51 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKVStoreForArchive(t *testing.T) {
+	require := require.New(t)
+
+	kv := NewKVStoreForArchive(NewMemKVStore(), "archived")
+	require.NoError(kv.Start(context.Background()))
+	defer func() {
+		require.NoError(kv.Stop(context.Background()))
+	}()
+
+	for _, ns := range []string{"archived", "other"} {
+		require.NoError(kv.Put(ns, []byte("k1"), []byte("v1")))
+		require.NoError(kv.Put(ns, []byte("k2"), []byte("v2")))
+		require.NoError(kv.Put(ns, []byte("k3"), []byte("v3")))
+	}
+
+	require.NoError(kv.Delete("archived", []byte("k1")))
+	require.NoError(kv.Delete("other", []byte("k1")))
+	cb := NewCachedBatch()
+	cb.Delete("archived", []byte("k2"), "")
+	cb.Delete("other", []byte("k2"), "")
+	cb.Put("archived", []byte("k3"), []byte("v4"), "")
+	require.NoError(kv.Commit(cb))
+	require.Equal(0, cb.Size())
+
+	for _, k := range []string{"k1", "k2"} {
+		v, err := kv.Get("archived", []byte(k))
+		require.NoError(err)
+		require.Equal("v"+k[1:], string(v))
+		_, err = kv.Get("other", []byte(k))
+		require.Equal(ErrNotExist, errors.Cause(err))
+	}
+	v, err := kv.Get("archived", []byte("k3"))
+	require.NoError(err)
+	require.Equal([]byte("v4"), v)
+}
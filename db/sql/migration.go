@@ -0,0 +1,95 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package sql
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// migrationTable records the versions of the migrations applied
+const migrationTable = "schema_migrations"
+
+// ErrSchemaTooNew indicates the schema of the database is migrated by a newer version of the node
+var ErrSchemaTooNew = errors.New("schema version is newer than the known migrations")
+
+// Migration is a versioned change of the schema
+type Migration struct {
+	// Version is the schema version after the migration, which starts from 1 and increases with each migration
+	Version uint64
+	// Description describes the change
+	Description string
+	// Statements returns the statements of the migration in the SQL dialect of the driver
+	Statements func(driverName string) []string
+}
+
+// SchemaVersion returns the version of the last migration applied, 0 if none
+func SchemaVersion(store Store) (uint64, error) {
+	if err := createMigrationTable(store.GetDB()); err != nil {
+		return 0, err
+	}
+	var version sql.NullInt64
+	if err := store.GetDB().QueryRow("SELECT MAX(version) FROM " + migrationTable).Scan(&version); err != nil {
+		return 0, errors.Wrap(err, "failed to get schema version")
+	}
+	return uint64(version.Int64), nil
+}
+
+// Migrate applies the migrations newer than the schema version in the order of the versions. Each migration is
+// applied in a transaction along with its version. Note that MySQL commits DDL statements implicitly, so a migration
+// failed on MySQL may be partially applied and should be written to be rerunnable, e.g., with IF NOT EXISTS.
+func Migrate(store Store, migrations []Migration) error {
+	for i, m := range migrations {
+		if m.Version != uint64(i+1) {
+			return errors.Errorf("migration %d has version %d, migrations should be numbered from 1", i, m.Version)
+		}
+	}
+	current, err := SchemaVersion(store)
+	if err != nil {
+		return err
+	}
+	if current > uint64(len(migrations)) {
+		return errors.Wrapf(ErrSchemaTooNew, "schema version %d, latest known version %d", current, len(migrations))
+	}
+	for _, m := range migrations[current:] {
+		if err := store.Transact(func(tx *sql.Tx) error {
+			for _, stmt := range m.Statements(store.DriverName()) {
+				if _, err := tx.Exec(stmt); err != nil {
+					return errors.Wrapf(err, "failed to execute %s", stmt)
+				}
+			}
+			_, err := tx.Exec(
+				"INSERT INTO "+migrationTable+" (version, description, applied_at) VALUES (?, ?, ?)",
+				m.Version,
+				m.Description,
+				time.Now().Unix(),
+			)
+			return err
+		}); err != nil {
+			return errors.Wrapf(err, "failed to apply migration %d", m.Version)
+		}
+		log.L().Info("Applied schema migration.",
+			zap.Uint64("version", m.Version),
+			zap.String("description", m.Description))
+	}
+	return nil
+}
+
+func createMigrationTable(db *sql.DB) error {
+	if _, err := db.Exec("CREATE TABLE IF NOT EXISTS " + migrationTable + " (" +
+		"version BIGINT NOT NULL PRIMARY KEY, " +
+		"description VARCHAR(255) NOT NULL, " +
+		"applied_at BIGINT NOT NULL)"); err != nil {
+		return errors.Wrap(err, "failed to create migration table")
+	}
+	return nil
+}
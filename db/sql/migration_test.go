@@ -0,0 +1,92 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+func TestMigrate(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	testFile, err := ioutil.TempFile(os.TempDir(), path)
+	require.NoError(err)
+	testPath := testFile.Name()
+	defer func() {
+		require.NoError(os.Remove(testPath))
+	}()
+	store := NewSQLite3(config.SQLITE3{SQLite3File: testPath})
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+	require.Equal(SQLite3Driver, store.DriverName())
+
+	migrations := []Migration{
+		{
+			Version:     1,
+			Description: "create table a",
+			Statements: func(string) []string {
+				return []string{"CREATE TABLE a (id INTEGER NOT NULL PRIMARY KEY)"}
+			},
+		},
+		{
+			Version:     2,
+			Description: "add column b",
+			Statements: func(driverName string) []string {
+				require.Equal(SQLite3Driver, driverName)
+				return []string{"ALTER TABLE a ADD COLUMN b TEXT"}
+			},
+		},
+	}
+	version, err := SchemaVersion(store)
+	require.NoError(err)
+	require.Equal(uint64(0), version)
+
+	require.NoError(Migrate(store, migrations[:1]))
+	version, err = SchemaVersion(store)
+	require.NoError(err)
+	require.Equal(uint64(1), version)
+	_, err = store.GetDB().Exec("INSERT INTO a (id, b) VALUES (1, 'b')")
+	require.Error(err)
+
+	// only the new migrations are applied
+	require.NoError(Migrate(store, migrations))
+	require.NoError(Migrate(store, migrations))
+	version, err = SchemaVersion(store)
+	require.NoError(err)
+	require.Equal(uint64(2), version)
+	_, err = store.GetDB().Exec("INSERT INTO a (id, b) VALUES (1, 'b')")
+	require.NoError(err)
+
+	// a failed migration is rolled back
+	failed := append(migrations, Migration{
+		Version:     3,
+		Description: "failed",
+		Statements: func(string) []string {
+			return []string{"CREATE TABLE c (id INTEGER)", "INSERT INTO d VALUES (1)"}
+		},
+	})
+	require.Error(Migrate(store, failed))
+	version, err = SchemaVersion(store)
+	require.NoError(err)
+	require.Equal(uint64(2), version)
+	_, err = store.GetDB().Exec("INSERT INTO c VALUES (1)")
+	require.Error(err)
+
+	require.Equal(ErrSchemaTooNew, errors.Cause(Migrate(store, migrations[:1])))
+	require.Error(Migrate(store, []Migration{migrations[1]}))
+}
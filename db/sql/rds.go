@@ -15,10 +15,13 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 )
 
+// MySQLDriver is the name of the mysql driver used by aws rds
+const MySQLDriver = "mysql"
+
 // NewAwsRDS instantiates an aws rds
 func NewAwsRDS(cfg config.RDS) Store {
 	connectStr := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s",
 		cfg.AwsRDSUser, cfg.AwsPass, cfg.AwsRDSEndpoint, cfg.AwsRDSPort, cfg.AwsDBName,
 	)
-	return newStoreBase("mysql", connectStr)
+	return newStoreBase(MySQLDriver, connectStr)
 }
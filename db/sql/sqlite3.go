@@ -13,7 +13,10 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 )
 
+// SQLite3Driver is the name of the sqlite3 driver
+const SQLite3Driver = "sqlite3"
+
 // NewSQLite3 instantiates an sqlite3
 func NewSQLite3(cfg config.SQLITE3) Store {
-	return newStoreBase("sqlite3", cfg.SQLite3File)
+	return newStoreBase(SQLite3Driver, cfg.SQLite3File)
 }
@@ -158,6 +158,10 @@ func (tr *branchRootTrie) loadNodeFromDB(key []byte) (Node, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get key %x", key)
 	}
+	return deserializeNode(s)
+}
+
+func deserializeNode(s []byte) (Node, error) {
 	pb := triepb.NodePb{}
 	if err := proto.Unmarshal(s, &pb); err != nil {
 		return nil, err
@@ -0,0 +1,110 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trie
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidProof indicates the proof does not match the root hash or the key
+var ErrInvalidProof = errors.New("invalid merkle proof")
+
+// VerifyProof verifies the proof generated by Trie.Prove against the root hash, and returns the value of the key.
+// ErrNotExist is returned if the proof is valid and shows the key does not exist in the trie.
+func VerifyProof(rootHash []byte, key []byte, proof [][]byte) ([]byte, error) {
+	return VerifyProofWithHashFunc(rootHash, key, proof, DefaultHashFunc)
+}
+
+// VerifyProofWithHashFunc verifies the proof with the hash func used to build the trie
+func VerifyProofWithHashFunc(rootHash []byte, key []byte, proof [][]byte, hashFunc HashFunc) ([]byte, error) {
+	expected := rootHash
+	offset := uint8(0)
+	for i, ser := range proof {
+		if !bytes.Equal(hashFunc(ser), expected) {
+			return nil, errors.Wrapf(ErrInvalidProof, "hash mismatch of node %d", i)
+		}
+		node, err := deserializeNode(ser)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidProof, "failed to deserialize node %d: %v", i, err)
+		}
+		if i == 0 && node.Type() != BRANCH {
+			return nil, errors.Wrap(ErrInvalidProof, "root should be a branch")
+		}
+		childHash, next, err := pathChild(node, key, offset)
+		if err != nil {
+			return nil, err
+		}
+		if childHash != nil {
+			expected, offset = childHash, next
+			continue
+		}
+		// the path ends at this node
+		if i != len(proof)-1 {
+			return nil, errors.Wrapf(ErrInvalidProof, "unexpected node after node %d", i)
+		}
+		if node.Type() == LEAF && bytes.Equal(node.Key(), key) {
+			return node.Value(), nil
+		}
+		return nil, errors.Wrapf(ErrNotExist, "key %x does not exist", key)
+	}
+
+	return nil, errors.Wrap(ErrInvalidProof, "incomplete proof")
+}
+
+func (tr *branchRootTrie) Prove(key []byte) ([][]byte, error) {
+	trieMtc.WithLabelValues("root", "Prove").Inc()
+	kt, err := tr.checkKeyType(key)
+	if err != nil {
+		return nil, err
+	}
+	var node Node = tr.root
+	offset := uint8(0)
+	proof := [][]byte{}
+	for {
+		proof = append(proof, node.serialize())
+		childHash, next, err := pathChild(node, kt, offset)
+		if err != nil {
+			return nil, err
+		}
+		if childHash == nil {
+			return proof, nil
+		}
+		if node, err = tr.loadNodeFromDB(childHash); err != nil {
+			return nil, err
+		}
+		offset = next
+	}
+}
+
+// pathChild returns the hash of the child of node along the path of key, together with the offset of the key
+// at the child. A nil hash means the path ends at node.
+func pathChild(node Node, key []byte, offset uint8) ([]byte, uint8, error) {
+	if int(offset) > len(key) {
+		return nil, 0, errors.Wrapf(ErrInvalidProof, "offset %d exceeds key length %d", offset, len(key))
+	}
+	switch n := node.(type) {
+	case *branchNode:
+		if int(offset) == len(key) {
+			return nil, 0, errors.Wrap(ErrInvalidProof, "branch at the end of key")
+		}
+		return n.hashes[key[offset]], offset + 1, nil
+	case *extensionNode:
+		if len(n.path) > len(key)-int(offset) {
+			return nil, 0, errors.Wrap(ErrInvalidProof, "extension path exceeds key length")
+		}
+		if n.commonPrefixLength(key[offset:]) != uint8(len(n.path)) {
+			return nil, 0, nil
+		}
+		return n.childHash, offset + uint8(len(n.path)), nil
+	case *leafNode:
+		return nil, 0, nil
+	default:
+		return nil, 0, errors.Wrapf(ErrInvalidTrie, "unknown node type %T", node)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProof(t *testing.T) {
+	require := require.New(t)
+
+	tr, err := NewTrie(KeyLengthOption(8))
+	require.NoError(err)
+	require.NoError(tr.Start(context.Background()))
+	defer func() {
+		require.NoError(tr.Stop(context.Background()))
+	}()
+
+	// proof of absence in an empty trie
+	proof, err := tr.Prove(cat)
+	require.NoError(err)
+	require.Equal(1, len(proof))
+	_, err = VerifyProof(tr.RootHash(), cat, proof)
+	require.Equal(ErrNotExist, errors.Cause(err))
+
+	keys := [][]byte{ham, car, cat, egg, dog, fox, cow, ant}
+	for i, k := range keys {
+		require.NoError(tr.Upsert(k, testV[i]))
+	}
+	root := tr.RootHash()
+	for i, k := range keys {
+		proof, err := tr.Prove(k)
+		require.NoError(err)
+		v, err := VerifyProof(root, k, proof)
+		require.NoError(err)
+		require.Equal(testV[i], v)
+		// the proof doesn't work for another root or key
+		_, err = VerifyProof(make([]byte, len(root)), k, proof)
+		require.Equal(ErrInvalidProof, errors.Cause(err))
+		if i > 0 {
+			_, err = VerifyProof(root, keys[i-1], proof)
+			require.Error(err)
+		}
+	}
+
+	// proof of absence, ending at a branch, an extension and a leaf respectively
+	for _, k := range [][]byte{rat, br1, cl1, {1, 2, 3, 4, 5, 6, 9, 9}, {1, 2, 5, 6, 7, 8, 9, 1}} {
+		proof, err := tr.Prove(k)
+		require.NoError(err)
+		_, err = VerifyProof(root, k, proof)
+		require.Equal(ErrNotExist, errors.Cause(err))
+	}
+
+	// tampered proof
+	proof, err = tr.Prove(cat)
+	require.NoError(err)
+	require.True(len(proof) > 1)
+	_, err = VerifyProof(root, cat, proof[:len(proof)-1])
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+	tampered := make([][]byte, len(proof))
+	copy(tampered, proof)
+	tampered[len(tampered)-1] = append([]byte{}, proof[len(proof)-1]...)
+	tampered[len(tampered)-1][len(tampered[len(tampered)-1])-1]++
+	_, err = VerifyProof(root, cat, tampered)
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+	_, err = VerifyProof(root, cat, append(proof, proof[0]))
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+
+	// invalid key length
+	_, err = tr.Prove([]byte{1, 2, 3})
+	require.Error(err)
+}
@@ -0,0 +1,332 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trie
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// PrunerNameSpace is the bucket name for the journal of stale trie nodes
+const PrunerNameSpace = "TriePruner"
+
+var (
+	prunedHeightKey  = []byte("prunedHeight")
+	staleKeyPrefix   = []byte("stale.")
+	pendingKeyPrefix = []byte("pending.")
+
+	triePrunerMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_trie_pruner",
+			Help: "IoTeX Trie Pruner",
+		},
+		[]string{"type"},
+	)
+	triePrunedHeightMtc = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "iotex_trie_pruned_height",
+			Help: "Height below which the stale trie nodes have been pruned",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(triePrunerMtc)
+	prometheus.MustRegister(triePrunedHeightMtc)
+}
+
+// Pruner deletes the trie nodes which are unreachable from the state roots of the last N heights.
+//
+// The deletions of trie nodes are withheld from the DB (see db.KVStoreForArchive), and journaled by Track at the
+// height they become stale instead. A node staled at height h is only referenced by the roots below h, so it is
+// deleted in the background once h falls out of the retention window, unless the same node has been written again
+// in the meantime.
+type Pruner struct {
+	mutex        sync.Mutex
+	locker       sync.Locker
+	kvStore      db.KVStore
+	namespaces   map[string]struct{}
+	retention    uint64
+	prunedHeight uint64
+	tipHeight    uint64
+	notify       chan struct{}
+	quit         chan struct{}
+	wg           sync.WaitGroup
+}
+
+// NewPruner creates a pruner which keeps the trie nodes of the last retention heights in the given namespaces. The
+// locker must be held while a batch is tracked and committed, and it is acquired by the pruner while deleting nodes
+func NewPruner(kvStore db.KVStore, retention uint64, locker sync.Locker, namespaces ...string) (*Pruner, error) {
+	if retention == 0 {
+		return nil, errors.New("retention window of trie pruner should be greater than 0")
+	}
+	p := &Pruner{
+		locker:     locker,
+		kvStore:    kvStore,
+		namespaces: make(map[string]struct{}, len(namespaces)),
+		retention:  retention,
+		notify:     make(chan struct{}, 1),
+	}
+	for _, ns := range namespaces {
+		p.namespaces[ns] = struct{}{}
+	}
+	return p, nil
+}
+
+// Start loads the pruned height and starts pruning in the background
+func (p *Pruner) Start(_ context.Context) error {
+	switch data, err := p.kvStore.Get(PrunerNameSpace, prunedHeightKey); errors.Cause(err) {
+	case nil:
+		p.prunedHeight = byteutil.BytesToUint64(data)
+	case db.ErrNotExist:
+		p.prunedHeight = 0
+	default:
+		return errors.Wrap(err, "failed to load pruned height")
+	}
+	triePrunedHeightMtc.Set(float64(p.prunedHeight))
+	p.quit = make(chan struct{})
+	p.wg.Add(1)
+	go p.run()
+	return nil
+}
+
+// Stop stops pruning, it must not be called with the locker held
+func (p *Pruner) Stop(_ context.Context) error {
+	if p.quit == nil {
+		// the pruner is not started
+		return nil
+	}
+	close(p.quit)
+	p.wg.Wait()
+	p.quit = nil
+	return nil
+}
+
+// Track journals the trie nodes deleted in the batch as stale at the given height. It should be called right before
+// the batch is committed, and the journal is committed along with the batch
+func (p *Pruner) Track(batch db.KVStoreBatch, height uint64) error {
+	type write struct {
+		namespace string
+		key       []byte
+		writeType int32
+	}
+	var (
+		order  []string
+		writes = make(map[string]*write)
+	)
+	for i := 0; i < batch.Size(); i++ {
+		entry, err := batch.Entry(i)
+		if err != nil {
+			return errors.Wrap(err, "failed to read batch")
+		}
+		if _, ok := p.namespaces[entry.Namespace()]; !ok {
+			continue
+		}
+		pk := string(pendingKey(entry.Namespace(), entry.Key()))
+		w, ok := writes[pk]
+		if !ok {
+			w = &write{namespace: entry.Namespace(), key: entry.Key()}
+			writes[pk] = w
+			order = append(order, pk)
+		}
+		// only the last write to the node matters
+		w.writeType = entry.WriteType()
+	}
+	stale := make(map[string][][]byte)
+	for _, pk := range order {
+		w := writes[pk]
+		if w.writeType == db.Delete {
+			batch.Put(PrunerNameSpace, []byte(pk), byteutil.Uint64ToBytes(height), "failed to put pending key %x", pk)
+			stale[w.namespace] = append(stale[w.namespace], w.key)
+			continue
+		}
+		// the node is written again, so it is not stale any more
+		switch _, err := p.kvStore.Get(PrunerNameSpace, []byte(pk)); errors.Cause(err) {
+		case nil:
+			batch.Delete(PrunerNameSpace, []byte(pk), "failed to delete pending key %x", pk)
+		case db.ErrNotExist:
+		default:
+			return errors.Wrapf(err, "failed to get pending key %x", pk)
+		}
+	}
+	for ns, keys := range stale {
+		sk := staleKey(ns, height)
+		batch.Put(PrunerNameSpace, sk, encodeKeys(keys), "failed to put stale keys %x", sk)
+		triePrunerMtc.WithLabelValues("staled").Add(float64(len(keys)))
+	}
+	return nil
+}
+
+// SetTipHeight updates the tip height after a batch is committed, which may trigger pruning
+func (p *Pruner) SetTipHeight(height uint64) {
+	p.mutex.Lock()
+	p.tipHeight = height
+	p.mutex.Unlock()
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+// PrunedHeight returns the height up to which the stale nodes have been pruned
+func (p *Pruner) PrunedHeight() uint64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.prunedHeight
+}
+
+func (p *Pruner) run() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.quit:
+			return
+		case <-p.notify:
+		}
+		for {
+			height, ok := p.nextHeight()
+			if !ok {
+				break
+			}
+			select {
+			case <-p.quit:
+				return
+			default:
+			}
+			if err := p.prune(height); err != nil {
+				// retry when the next tip height is set
+				log.L().Error("Failed to prune stale trie nodes.", zap.Uint64("height", height), zap.Error(err))
+				break
+			}
+		}
+	}
+}
+
+// nextHeight returns the next height to prune. Nodes staled at height h are referenced by roots below h only, so
+// they could be pruned once root h-1 falls out of the retention window
+func (p *Pruner) nextHeight() (uint64, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.tipHeight < p.retention {
+		return 0, false
+	}
+	if p.prunedHeight >= p.tipHeight-p.retention+1 {
+		return 0, false
+	}
+	return p.prunedHeight + 1, true
+}
+
+// prune deletes the nodes staled at the given height, which have not been written again since then
+func (p *Pruner) prune(height uint64) error {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+
+	var (
+		batch     = db.NewBatch()
+		pruned    int
+		reclaimed int
+	)
+	for ns := range p.namespaces {
+		sk := staleKey(ns, height)
+		data, err := p.kvStore.Get(PrunerNameSpace, sk)
+		switch errors.Cause(err) {
+		case nil:
+		case db.ErrNotExist:
+			continue
+		default:
+			return errors.Wrapf(err, "failed to get stale keys %x", sk)
+		}
+		keys, err := decodeKeys(data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode stale keys %x", sk)
+		}
+		for _, key := range keys {
+			pk := pendingKey(ns, key)
+			staledAt, err := p.kvStore.Get(PrunerNameSpace, pk)
+			switch errors.Cause(err) {
+			case nil:
+			case db.ErrNotExist:
+				continue
+			default:
+				return errors.Wrapf(err, "failed to get pending key %x", pk)
+			}
+			if byteutil.BytesToUint64(staledAt) != height {
+				// staled again at a later height
+				continue
+			}
+			if value, err := p.kvStore.Get(ns, key); err == nil {
+				reclaimed += len(key) + len(value)
+			}
+			batch.Delete(ns, key, "failed to delete stale node %x", key)
+			batch.Delete(PrunerNameSpace, pk, "failed to delete pending key %x", pk)
+			pruned++
+		}
+		batch.Delete(PrunerNameSpace, sk, "failed to delete stale keys %x", sk)
+	}
+	batch.Put(PrunerNameSpace, prunedHeightKey, byteutil.Uint64ToBytes(height), "failed to put pruned height")
+	if err := p.kvStore.Commit(batch); err != nil {
+		return errors.Wrap(err, "failed to commit pruned nodes")
+	}
+
+	p.mutex.Lock()
+	p.prunedHeight = height
+	p.mutex.Unlock()
+	triePrunerMtc.WithLabelValues("pruned").Add(float64(pruned))
+	triePrunerMtc.WithLabelValues("reclaimedBytes").Add(float64(reclaimed))
+	triePrunedHeightMtc.Set(float64(height))
+	return nil
+}
+
+func pendingKey(namespace string, key []byte) []byte {
+	k := append([]byte{}, pendingKeyPrefix...)
+	k = append(k, namespace...)
+	k = append(k, '.')
+	return append(k, key...)
+}
+
+func staleKey(namespace string, height uint64) []byte {
+	k := append([]byte{}, staleKeyPrefix...)
+	k = append(k, namespace...)
+	k = append(k, '.')
+	return append(k, byteutil.Uint64ToBytes(height)...)
+}
+
+// encodeKeys serializes the keys, each prefixed by its length
+func encodeKeys(keys [][]byte) []byte {
+	var data []byte
+	for _, key := range keys {
+		data = append(data, byteutil.Uint32ToBytes(uint32(len(key)))...)
+		data = append(data, key...)
+	}
+	return data
+}
+
+func decodeKeys(data []byte) ([][]byte, error) {
+	var keys [][]byte
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return nil, errors.New("invalid length of key")
+		}
+		size := int(enc.MachineEndian.Uint32(data[:4]))
+		data = data[4:]
+		if len(data) < size {
+			return nil, errors.New("invalid length of key")
+		}
+		keys = append(keys, data[:size])
+		data = data[size:]
+	}
+	return keys, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trie
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db"
+)
+
+func TestPruner(t *testing.T) {
+	require := require.New(t)
+
+	var mutex sync.Mutex
+	_, err := NewPruner(db.NewMemKVStore(), 0, &mutex, "test")
+	require.Error(err)
+
+	kv := db.NewMemKVStore()
+	p, err := NewPruner(kv, 2, &mutex, "test")
+	require.NoError(err)
+	ctx := context.Background()
+	// a pruner not started yet can be stopped
+	require.NoError(p.Stop(ctx))
+	require.NoError(kv.Start(ctx))
+	require.NoError(p.Start(ctx))
+	defer func() {
+		require.NoError(p.Stop(ctx))
+	}()
+	dao := db.NewKVStoreForArchive(kv, "test")
+
+	var root []byte
+	commit := func(height uint64, key, value []byte) []byte {
+		mutex.Lock()
+		defer mutex.Unlock()
+		cb := db.NewCachedBatch()
+		kvStore, err := db.NewKVStoreForTrie("test", dao, db.CachedBatchOption(cb))
+		require.NoError(err)
+		tr, err := NewTrie(KVStoreOption(kvStore), RootHashOption(root), KeyLengthOption(8))
+		require.NoError(err)
+		require.NoError(tr.Start(ctx))
+		require.NoError(tr.Upsert(key, value))
+		require.NoError(p.Track(cb, height))
+		require.NoError(dao.Commit(cb))
+		p.SetTipHeight(height)
+		root = tr.RootHash()
+		return root
+	}
+	get := func(root, key []byte) ([]byte, error) {
+		kvStore, err := db.NewKVStoreForTrie("test", kv)
+		require.NoError(err)
+		tr, err := NewTrie(KVStoreOption(kvStore), KeyLengthOption(8))
+		require.NoError(err)
+		if err := tr.SetRootHash(root); err != nil {
+			return nil, err
+		}
+		return tr.Get(key)
+	}
+
+	root1 := commit(1, cat, testV[2])
+	root2 := commit(2, cat, testV[3])
+	// the nodes of root1 staled at height 2 are written again
+	root3 := commit(3, cat, testV[2])
+	require.Equal(root1, root3)
+	root4 := commit(4, car, testV[1])
+	// the nodes of root4 are not pruned until height 6
+	root5 := commit(5, egg, testV[4])
+	for i := 0; i < 100 && p.PrunedHeight() < 4; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(uint64(4), p.PrunedHeight())
+
+	_, err = get(root2, cat)
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+	for _, root := range [][]byte{root4, root5} {
+		v, err := get(root, cat)
+		require.NoError(err)
+		require.Equal(testV[2], v)
+		v, err = get(root, car)
+		require.NoError(err)
+		require.Equal(testV[1], v)
+	}
+	v, err := get(root5, egg)
+	require.NoError(err)
+	require.Equal(testV[4], v)
+
+	// the pruned height is persisted
+	require.NoError(p.Stop(ctx))
+	p, err = NewPruner(kv, 2, &mutex, "test")
+	require.NoError(err)
+	require.NoError(p.Start(ctx))
+	require.Equal(uint64(4), p.PrunedHeight())
+}
+
+func TestEncodeKeys(t *testing.T) {
+	require := require.New(t)
+
+	keys, err := decodeKeys(encodeKeys([][]byte{cat, {}, ham[:3]}))
+	require.NoError(err)
+	require.Equal([][]byte{cat, {}, ham[:3]}, keys)
+	_, err = decodeKeys([]byte{1, 2})
+	require.Error(err)
+	_, err = decodeKeys(append(encodeKeys([][]byte{cat}), 9, 0, 0, 0, 1))
+	require.Error(err)
+}
@@ -59,6 +59,8 @@ type Trie interface {
 	RootHash() []byte
 	// SetRootHash sets a new root to trie
 	SetRootHash([]byte) error
+	// Prove returns the serialized nodes on the path from root to the key, which could be verified by VerifyProof
+	Prove([]byte) ([][]byte, error)
 	// DB returns the KVStore storing the node data
 	DB() KVStore
 	// deleteNodeFromDB deletes the data of node from db
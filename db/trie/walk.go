@@ -0,0 +1,62 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trie
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+)
+
+// WalkNodes visits the serialized nodes of the trie from the root in depth-first order, and verifies that every node
+// matches its hash. The empty root is never stored, so an empty trie has no node to visit
+func WalkNodes(tr Trie, f func(key, value []byte) error) error {
+	t, ok := tr.(*branchRootTrie)
+	if !ok {
+		return errors.New("invalid trie type")
+	}
+	return t.walkNodes(f)
+}
+
+func (tr *branchRootTrie) walkNodes(f func(key, value []byte) error) error {
+	tr.mutex.RLock()
+	defer tr.mutex.RUnlock()
+
+	if tr.isEmptyRootHash(tr.rootHash) {
+		return nil
+	}
+	stack := [][]byte{tr.rootHash}
+	for len(stack) > 0 {
+		key := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		value, err := tr.kvStore.Get(key)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get node %x", key)
+		}
+		if !bytes.Equal(tr.hashFunc(value), key) {
+			return errors.Wrapf(ErrInvalidTrie, "node %x doesn't match its hash", key)
+		}
+		node, err := deserializeNode(value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deserialize node %x", key)
+		}
+		switch n := node.(type) {
+		case *branchNode:
+			for i := 255; i >= 0; i-- {
+				if h, ok := n.hashes[byte(i)]; ok {
+					stack = append(stack, h)
+				}
+			}
+		case *extensionNode:
+			stack = append(stack, n.childHash)
+		}
+		if err := f(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
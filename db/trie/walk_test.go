@@ -0,0 +1,60 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkNodes(t *testing.T) {
+	require := require.New(t)
+
+	kvStore := newInMemKVStore().(*inMemKVStore)
+	tr, err := NewTrie(KVStoreOption(kvStore), KeyLengthOption(8))
+	require.NoError(err)
+	require.NoError(tr.Start(context.Background()))
+
+	// nothing is stored for an empty trie
+	require.NoError(WalkNodes(tr, func(key, value []byte) error {
+		return errors.New("unexpected node")
+	}))
+
+	keys := [][]byte{ham, car, cat, egg, dog, fox, cow, ant}
+	for i, k := range keys {
+		require.NoError(tr.Upsert(k, testV[i]))
+	}
+	nodes := make(map[mKeyType][]byte)
+	require.NoError(WalkNodes(tr, func(key, value []byte) error {
+		nodes[castKeyType(key)] = value
+		return nil
+	}))
+	// all the nodes of the current trie are visited, and they are enough to rebuild the trie
+	copied := newInMemKVStore().(*inMemKVStore)
+	copied.kvpairs = nodes
+	trCopy, err := NewTrie(KVStoreOption(copied), RootHashOption(tr.RootHash()), KeyLengthOption(8))
+	require.NoError(err)
+	require.NoError(trCopy.Start(context.Background()))
+	for i, k := range keys {
+		v, err := trCopy.Get(k)
+		require.NoError(err)
+		require.Equal(testV[i], v)
+	}
+
+	// a tampered node is detected
+	for k, v := range copied.kvpairs {
+		copied.kvpairs[k] = append(v[:len(v):len(v)], 0)
+		break
+	}
+	err = WalkNodes(trCopy, func(key, value []byte) error {
+		return nil
+	})
+	require.Equal(ErrInvalidTrie, errors.Cause(err))
+}
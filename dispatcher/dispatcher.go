@@ -13,6 +13,7 @@ import (
 	"sync/atomic"
 
 	"github.com/golang/protobuf/proto"
+	p2p "github.com/iotexproject/go-p2p"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -21,6 +22,7 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/ratelimit"
 	"github.com/iotexproject/iotex-core/protogen"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
@@ -30,7 +32,8 @@ import (
 type Subscriber interface {
 	HandleAction(context.Context, *iotextypes.Action) error
 	HandleBlock(context.Context, *iotextypes.Block) error
-	HandleBlockSync(context.Context, *iotextypes.Block) error
+	HandleBlockSync(context.Context, peerstore.PeerInfo, *iotextypes.Block) error
+	HandleBlockHeaders(context.Context, peerstore.PeerInfo, *iotexrpc.BlockHeaders) error
 	HandleSyncRequest(context.Context, peerstore.PeerInfo, *iotexrpc.BlockSync) error
 	HandleConsensusMsg(*iotextypes.ConsensusMessage) error
 }
@@ -84,6 +87,19 @@ func (m blockSyncMsg) ChainID() uint32 {
 	return m.chainID
 }
 
+// syncResponseMsg packages a block or the block headers sent by a peer in response to a block sync request.
+type syncResponseMsg struct {
+	ctx     context.Context
+	chainID uint32
+	peer    peerstore.PeerInfo
+	block   *iotextypes.Block
+	headers *iotexrpc.BlockHeaders
+}
+
+func (m syncResponseMsg) ChainID() uint32 {
+	return m.chainID
+}
+
 // actionMsg packages a proto action message.
 type actionMsg struct {
 	ctx     context.Context
@@ -107,6 +123,7 @@ type IotxDispatcher struct {
 
 	subscribers   map[uint32]Subscriber
 	subscribersMU sync.RWMutex
+	peerLimiter   *ratelimit.Limiter
 }
 
 // NewDispatcher creates a new Dispatcher
@@ -116,6 +133,7 @@ func NewDispatcher(cfg config.Config) (Dispatcher, error) {
 		eventAudit:  make(map[iotexrpc.MessageType]int),
 		quit:        make(chan struct{}),
 		subscribers: make(map[uint32]Subscriber),
+		peerLimiter: ratelimit.New("dispatcher_peer", cfg.ActPool.PeerRateLimit),
 	}
 	return d, nil
 }
@@ -182,6 +200,8 @@ loop:
 				d.handleBlockMsg(msg)
 			case *blockSyncMsg:
 				d.handleBlockSyncMsg(msg)
+			case *syncResponseMsg:
+				d.handleSyncResponseMsg(msg)
 
 			default:
 				log.L().Warn("Invalid message type in block handler.", zap.Any("msg", msg))
@@ -199,6 +219,15 @@ loop:
 // handleActionMsg handles actionMsg from all peers.
 func (d *IotxDispatcher) handleActionMsg(m *actionMsg) {
 	d.updateEventAudit(iotexrpc.MessageType_ACTION)
+	// The actions broadcast by the peers are rate limited by the originating peer
+	if msg, ok := p2p.GetBroadcastMsg(m.ctx); ok {
+		peerID := msg.GetFrom().Pretty()
+		if err := d.peerLimiter.Allow(peerID); err != nil {
+			requestMtc.WithLabelValues("AddAction", "false").Inc()
+			log.L().Debug("Drop action from rate limited peer.", zap.String("peer", peerID), zap.Error(err))
+			return
+		}
+	}
 	if subscriber, ok := d.subscribers[m.ChainID()]; ok {
 		if err := subscriber.HandleAction(m.ctx, m.action); err != nil {
 			requestMtc.WithLabelValues("AddAction", "false").Inc()
@@ -241,6 +270,28 @@ func (d *IotxDispatcher) handleBlockSyncMsg(m *blockSyncMsg) {
 	}
 }
 
+// handleSyncResponseMsg handles the blocks and block headers sent by peers in response to block sync requests.
+func (d *IotxDispatcher) handleSyncResponseMsg(m *syncResponseMsg) {
+	d.subscribersMU.RLock()
+	defer d.subscribersMU.RUnlock()
+	subscriber, ok := d.subscribers[m.ChainID()]
+	if !ok {
+		log.L().Info("No subscriber specified in the dispatcher.", zap.Uint32("chainID", m.ChainID()))
+		return
+	}
+	if m.headers != nil {
+		d.updateEventAudit(iotexrpc.MessageType_BLOCK_HEADERS)
+		if err := subscriber.HandleBlockHeaders(m.ctx, m.peer, m.headers); err != nil {
+			log.L().Error("Failed to handle block headers.", zap.Error(err))
+		}
+		return
+	}
+	d.updateEventAudit(iotexrpc.MessageType_BLOCK)
+	if err := subscriber.HandleBlockSync(m.ctx, m.peer, m.block); err != nil {
+		log.L().Error("Failed to handle the synced block.", zap.Error(err))
+	}
+}
+
 // dispatchAction adds the passed action message to the news handling queue.
 func (d *IotxDispatcher) dispatchAction(ctx context.Context, chainID uint32, msg proto.Message) {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
@@ -278,6 +329,26 @@ func (d *IotxDispatcher) dispatchBlockSyncReq(ctx context.Context, chainID uint3
 	})
 }
 
+// dispatchSyncResponse adds the passed block or block headers sent in response to a sync request to the news handling
+// queue.
+func (d *IotxDispatcher) dispatchSyncResponse(ctx context.Context, chainID uint32, peer peerstore.PeerInfo, msg proto.Message) {
+	if atomic.LoadInt32(&d.shutdown) != 0 {
+		return
+	}
+	m := &syncResponseMsg{
+		ctx:     ctx,
+		chainID: chainID,
+		peer:    peer,
+	}
+	switch msg := msg.(type) {
+	case *iotextypes.Block:
+		m.block = msg
+	case *iotexrpc.BlockHeaders:
+		m.headers = msg
+	}
+	d.enqueueEvent(m)
+}
+
 // HandleBroadcast handles incoming broadcast message
 func (d *IotxDispatcher) HandleBroadcast(ctx context.Context, chainID uint32, message proto.Message) {
 	msgType, err := protogen.GetTypeFromRPCMsg(message)
@@ -316,8 +387,8 @@ func (d *IotxDispatcher) HandleTell(ctx context.Context, chainID uint32, peer pe
 	switch msgType {
 	case iotexrpc.MessageType_BLOCK_REQUEST:
 		d.dispatchBlockSyncReq(ctx, chainID, peer, message)
-	case iotexrpc.MessageType_BLOCK:
-		d.dispatchBlockCommit(ctx, chainID, message)
+	case iotexrpc.MessageType_BLOCK, iotexrpc.MessageType_BLOCK_HEADERS:
+		d.dispatchSyncResponse(ctx, chainID, peer, message)
 	default:
 		log.L().Warn("Unexpected msgType handled by HandleTell.", zap.Any("msgType", msgType))
 	}
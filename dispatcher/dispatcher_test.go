@@ -90,7 +90,13 @@ type DummySubscriber struct{}
 
 func (s *DummySubscriber) HandleBlock(context.Context, *iotextypes.Block) error { return nil }
 
-func (s *DummySubscriber) HandleBlockSync(context.Context, *iotextypes.Block) error { return nil }
+func (s *DummySubscriber) HandleBlockSync(context.Context, peerstore.PeerInfo, *iotextypes.Block) error {
+	return nil
+}
+
+func (s *DummySubscriber) HandleBlockHeaders(context.Context, peerstore.PeerInfo, *iotexrpc.BlockHeaders) error {
+	return nil
+}
 
 func (s *DummySubscriber) HandleSyncRequest(context.Context, peerstore.PeerInfo, *iotexrpc.BlockSync) error {
 	return nil
@@ -136,6 +136,10 @@ func TestBlockEpochReward(t *testing.T) {
 		dbFilePaths = append(dbFilePaths, chainDBPath)
 		trieDBPath := fmt.Sprintf("./trie%d.db", i+1)
 		dbFilePaths = append(dbFilePaths, trieDBPath)
+		walPath := fmt.Sprintf("./consensus%d.wal", i+1)
+		dbFilePaths = append(dbFilePaths, walPath)
+		evidenceDBPath := fmt.Sprintf("./evidence%d.db", i+1)
+		dbFilePaths = append(dbFilePaths, evidenceDBPath)
 		networkPort := 4689 + i
 		apiPort := 14014 + i
 		config := newConfig(chainDBPath, trieDBPath, identityset.PrivateKey(i),
@@ -144,6 +148,8 @@ func TestBlockEpochReward(t *testing.T) {
 			config.Network.BootstrapNodes = []string{}
 			config.Network.MasterKey = "bootnode"
 		}
+		config.Consensus.RollDPoS.WALPath = walPath
+		config.Consensus.RollDPoS.EvidenceDBPath = evidenceDBPath
 
 		//Set Operator and Reward address
 		config.Genesis.Delegates[i].RewardAddrStr = identityset.Address(i + numNodes).String()
@@ -15,6 +15,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 type (
@@ -65,7 +66,17 @@ func NewEndorsement(
 
 // Endorse endorses a document
 func Endorse(
-	signer keypair.PrivateKey,
+	endorser signer.HashSigner,
+	doc Document,
+	ts time.Time,
+) (*Endorsement, error) {
+	return EndorseWith(endorser.PublicKey(), endorser.Sign, doc, ts)
+}
+
+// EndorseWith endorses a document with the sign function of the endorser
+func EndorseWith(
+	endorserPubKey keypair.PublicKey,
+	sign func([]byte) ([]byte, error),
 	doc Document,
 	ts time.Time,
 ) (*Endorsement, error) {
@@ -73,11 +84,11 @@ func Endorse(
 	if err != nil {
 		return nil, err
 	}
-	sig, err := signer.Sign(hash)
+	sig, err := sign(hash)
 	if err != nil {
 		return nil, err
 	}
-	return NewEndorsement(ts, signer.PublicKey(), sig), nil
+	return NewEndorsement(ts, endorserPubKey, sig), nil
 }
 
 // VerifyEndorsedDocument checks an endorsed document
@@ -9,8 +9,14 @@ package indexservice
 import (
 	"database/sql"
 	"encoding/hex"
-	"fmt"
-
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-address/address"
@@ -18,276 +24,381 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	s "github.com/iotexproject/iotex-core/db/sql"
+	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 )
 
-// If you want to add a new index table, please:
-// 1. add a index unique identifier in indexconfig
-// 2. add this index unique identifier to one of table list in index config
+// To change the index schema, append a migration in schema.go
+
+var (
+	// ErrNotExist indicates certain item does not exist in Blockchain database
+	ErrNotExist = errors.New("not exist in DB")
+	// ErrAlreadyExist indicates certain item already exists in Blockchain database
+	ErrAlreadyExist = errors.New("already exist in DB")
+	// ErrInvalidQuery indicates the query is malformed
+	ErrInvalidQuery = errors.New("invalid query")
+
+	// transferEventTopic is the topic of the ERC20 Transfer(address,address,uint256) event
+	transferEventTopic = hash.BytesToHash256(crypto.Keccak256([]byte("Transfer(address,address,uint256)")))
+)
 
 type (
-	// BlockByIndex defines the base schema of "index to block" table
-	BlockByIndex struct {
-		NodeAddress string
-		IndexHash   []byte
-		BlockHash   []byte
+	// AddressQuery filters and pages the history of an address in the ascending order of the heights
+	AddressQuery struct {
+		Address string
+		// ActionTypes are the types of the actions to return, e.g., "Transfer" and "Execution", or all if empty. It
+		// doesn't apply to the token transfers.
+		ActionTypes []string
+		// StartTime and EndTime are the range [StartTime, EndTime) of the block time, a zero time means unbounded
+		StartTime time.Time
+		EndTime   time.Time
+		// Cursor is the NextCursor of the previous page, or empty for the first page
+		Cursor string
+		// Offset is the number of records to skip when there is no cursor
+		Offset uint64
+		// Limit is the max number of records in a page
+		Limit uint64
+	}
+
+	// ActionRecord is an action in the history of an address
+	ActionRecord struct {
+		ActionHash  hash.Hash256
+		ActionType  string
+		BlockHeight uint64
+		Timestamp   time.Time
+	}
+
+	// ActionPage is a page of the action history of an address
+	ActionPage struct {
+		Actions []*ActionRecord
+		// NextCursor is the cursor of the next page, or empty if there is no more
+		NextCursor string
+	}
+
+	// TokenTransfer is an ERC20 token transfer emitted by a contract
+	TokenTransfer struct {
+		ActionHash  hash.Hash256
+		LogIndex    uint32
+		BlockHeight uint64
+		Timestamp   time.Time
+		Token       string
+		Sender      string
+		Recipient   string
+		Amount      *big.Int
 	}
-	// IndexHistory defines the schema of "index history" table
-	IndexHistory struct {
-		NodeAddress string
-		UserAddress string
-		IndexHash   string
+
+	// TokenTransferPage is a page of the token transfers of an address
+	TokenTransferPage struct {
+		Transfers []*TokenTransfer
+		// NextCursor is the cursor of the next page, or empty if there is no more
+		NextCursor string
 	}
 )
 
 // Indexer handles the index build for blocks
 type Indexer struct {
-	cfg                config.Indexer
-	store              s.Store
-	hexEncodedNodeAddr string
+	cfg   config.Indexer
+	store s.Store
+	// mutex serializes the index builds of the committed blocks and the back-filled ones, since sqlite3 fails
+	// concurrent write transactions
+	mutex sync.Mutex
 }
 
-var (
-	// ErrNotExist indicates certain item does not exist in Blockchain database
-	ErrNotExist = errors.New("not exist in DB")
-	// ErrAlreadyExist indicates certain item already exists in Blockchain database
-	ErrAlreadyExist = errors.New("already exist in DB")
-)
-
 // HandleBlock is an implementation of interface BlockCreationSubscriber
 func (idx *Indexer) HandleBlock(blk *block.Block) error {
 	return idx.BuildIndex(blk)
 }
 
-// BuildIndex builds the index for a block
+// BuildIndex builds the index for a block. Indexing a block again overwrites its records.
 func (idx *Indexer) BuildIndex(blk *block.Block) error {
-	if err := idx.store.Transact(func(tx *sql.Tx) error {
-		transfers, votes, executions := action.ClassifyActions(blk.Actions)
-		// log transfer index
-		for _, transfer := range transfers {
-			callerAddr, err := address.FromBytes(transfer.SrcPubkey().Hash())
-			if err != nil {
-				return err
-			}
-			// put new transfer for sender
-			if err := idx.UpdateIndexHistory(blk, tx, config.IndexTransfer, callerAddr.String(), transfer.Hash()); err != nil {
-				return errors.Wrapf(err, "failed to update transfer to transfer history table")
-			}
-			// put new transfer for recipient
-			if err := idx.UpdateIndexHistory(blk, tx, config.IndexTransfer, transfer.Recipient(), transfer.Hash()); err != nil {
-				return errors.Wrapf(err, "failed to update transfer to transfer history table")
-			}
-			// map transfer to block
-			if err := idx.UpdateBlockByIndex(blk, tx, config.IndexTransfer, transfer.Hash(), blk.HashBlock()); err != nil {
-				return errors.Wrapf(err, "failed to update transfer to block")
-			}
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+	return idx.store.Transact(func(tx *sql.Tx) error {
+		blkHash := blk.HashBlock()
+		height := blk.Height()
+		ts := blk.Timestamp().Unix()
+		if _, err := tx.Exec(
+			"REPLACE INTO blocks (height, hash, producer, timestamp, num_actions) VALUES (?, ?, ?, ?, ?)",
+			height, blkHash[:], blk.ProducerAddress(), ts, len(blk.Actions),
+		); err != nil {
+			return errors.Wrapf(err, "failed to index block %d", height)
 		}
-
-		// log vote index
-		for _, vote := range votes {
-			callerAddr, err := address.FromBytes(vote.SrcPubkey().Hash())
+		// actionIndexes maps the action hash to its position in the block
+		actionIndexes := make(map[hash.Hash256]int, len(blk.Actions))
+		for i, selp := range blk.Actions {
+			actHash := selp.Hash()
+			actionIndexes[actHash] = i
+			sender, err := address.FromBytes(selp.SrcPubkey().Hash())
 			if err != nil {
 				return err
 			}
-			// put new vote for sender
-			if err := idx.UpdateIndexHistory(blk, tx, config.IndexVote, callerAddr.String(), vote.Hash()); err != nil {
-				return errors.Wrapf(err, "failed to update vote to vote history table")
+			recipient, _ := selp.Destination()
+			actType := actionType(selp)
+			if _, err := tx.Exec(
+				"REPLACE INTO actions (hash, block_height, action_index, action_type, sender, recipient, nonce, "+
+					"gas_limit, gas_price) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+				actHash[:], height, i, actType, sender.String(), recipient, selp.Nonce(), selp.GasLimit(),
+				selp.GasPrice().String(),
+			); err != nil {
+				return errors.Wrapf(err, "failed to index action %x", actHash)
 			}
-			// put new vote for recipient
-			if err := idx.UpdateIndexHistory(blk, tx, config.IndexVote, vote.Votee(), vote.Hash()); err != nil {
-				return errors.Wrapf(err, "failed to update vote to vote history table")
-			}
-			// map vote to block
-			if err := idx.UpdateBlockByIndex(blk, tx, config.IndexVote, vote.Hash(), blk.HashBlock()); err != nil {
-				return errors.Wrapf(err, "failed to update transfer to block")
-			}
-		}
-
-		// log execution index
-		for _, execution := range executions {
-			callerAddr, err := address.FromBytes(execution.SrcPubkey().Hash())
-			if err != nil {
-				return err
-			}
-			// put new execution for executor
-			if err := idx.UpdateIndexHistory(blk, tx, config.IndexExecution, callerAddr.String(), execution.Hash()); err != nil {
-				return errors.Wrapf(err, "failed to update execution to execution history table")
-			}
-			// put new execution for contract
-			if err := idx.UpdateIndexHistory(blk, tx, config.IndexExecution, execution.Contract(), execution.Hash()); err != nil {
-				return errors.Wrapf(err, "failed to update execution to execution history table")
-			}
-			// map execution to block
-			if err := idx.UpdateBlockByIndex(blk, tx, config.IndexExecution, execution.Hash(), blk.HashBlock()); err != nil {
-				return errors.Wrapf(err, "failed to update transfer to block")
+			for _, addr := range []string{sender.String(), recipient} {
+				if addr == "" {
+					continue
+				}
+				if _, err := tx.Exec(
+					"REPLACE INTO address_actions (address, block_height, action_index, action_hash, action_type, "+
+						"timestamp) VALUES (?, ?, ?, ?, ?, ?)",
+					addr, height, i, actHash[:], actType, ts,
+				); err != nil {
+					return errors.Wrapf(err, "failed to index action %x of address %s", actHash, addr)
+				}
 			}
 		}
-
-		// log action index
-		for _, selp := range blk.Actions {
-			callerAddr, err := address.FromBytes(selp.SrcPubkey().Hash())
-			if err != nil {
-				return err
-			}
-			// put new action for sender
-			if err := idx.UpdateIndexHistory(blk, tx, config.IndexAction, callerAddr.String(), selp.Hash()); err != nil {
-				return errors.Wrapf(err, "failed to update action to action history table")
+		for _, r := range blk.Receipts {
+			if _, err := tx.Exec(
+				"REPLACE INTO receipts (action_hash, block_height, status, gas_consumed, contract_address) "+
+					"VALUES (?, ?, ?, ?, ?)",
+				r.ActionHash[:], height, r.Status, r.GasConsumed, r.ContractAddress,
+			); err != nil {
+				return errors.Wrapf(err, "failed to index receipt of action %x", r.ActionHash)
 			}
-			// put new transfer for recipient
-			dst, ok := selp.Destination()
-			if ok {
-				if err := idx.UpdateIndexHistory(blk, tx, config.IndexAction, dst, selp.Hash()); err != nil {
-					return errors.Wrapf(err, "failed to update action to action history table")
+			for i, l := range r.Logs {
+				topics := make([]byte, 0, len(l.Topics)*len(hash.ZeroHash256))
+				for _, topic := range l.Topics {
+					topics = append(topics, topic[:]...)
+				}
+				data := l.Data
+				if data == nil {
+					data = []byte{}
+				}
+				if _, err := tx.Exec(
+					"REPLACE INTO logs (action_hash, log_index, block_height, address, topics, data) "+
+						"VALUES (?, ?, ?, ?, ?, ?)",
+					r.ActionHash[:], i, height, l.Address, topics, data,
+				); err != nil {
+					return errors.Wrapf(err, "failed to index log %d of action %x", i, r.ActionHash)
+				}
+				sender, recipient, amount, ok := decodeTokenTransfer(l)
+				if !ok {
+					continue
+				}
+				if _, err := tx.Exec(
+					"REPLACE INTO token_transfers (action_hash, log_index, block_height, action_index, timestamp, "+
+						"token, sender, recipient, amount) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+					r.ActionHash[:], i, height, actionIndexes[r.ActionHash], ts, l.Address, sender, recipient,
+					amount.String(),
+				); err != nil {
+					return errors.Wrapf(err, "failed to index token transfer %d of action %x", i, r.ActionHash)
 				}
-			}
-			// map action to block
-			if err := idx.UpdateBlockByIndex(blk, tx, config.IndexAction, selp.Hash(), blk.HashBlock()); err != nil {
-				return errors.Wrapf(err, "failed to update transfer to block")
-			}
-		}
-
-		// log receipt index
-		for _, receipt := range blk.Receipts {
-			// map receipt to block
-			if err := idx.UpdateBlockByIndex(blk, tx, config.IndexReceipt, receipt.Hash(), blk.HashBlock()); err != nil {
-				return errors.Wrapf(err, "failed to update receipt to block")
 			}
 		}
-
 		return nil
-	}); err != nil {
-		return err
-	}
-	return nil
+	})
 }
 
-// UpdateBlockByIndex maps index hash to block hash
-func (idx *Indexer) UpdateBlockByIndex(blk *block.Block, tx *sql.Tx, indexIdentifier string, indexHash hash.Hash256,
-	blockHash hash.Hash256) error {
-	insertQuery := fmt.Sprintf("INSERT INTO %s (node_address,index_hash,block_hash) VALUES (?, ?, ?)",
-		idx.getBlockByIndexTableName(indexIdentifier))
-	if _, err := tx.Exec(insertQuery, idx.hexEncodedNodeAddr, hex.EncodeToString(indexHash[:]), blockHash[:]); err != nil {
-		return err
+// GetBlockHashByActionHash returns the hash of the block containing the action
+func (idx *Indexer) GetBlockHashByActionHash(actHash hash.Hash256) (hash.Hash256, error) {
+	var value []byte
+	if err := idx.store.GetDB().QueryRow(
+		"SELECT blocks.hash FROM actions JOIN blocks ON actions.block_height = blocks.height WHERE actions.hash = ?",
+		actHash[:],
+	).Scan(&value); err != nil {
+		if err == sql.ErrNoRows {
+			return hash.ZeroHash256, errors.Wrapf(ErrNotExist, "action %x", actHash)
+		}
+		return hash.ZeroHash256, errors.Wrapf(err, "failed to get block hash of action %x", actHash)
 	}
-	return nil
+	return hash.BytesToHash256(value), nil
 }
 
-// UpdateIndexHistory stores index information into index history table
-func (idx *Indexer) UpdateIndexHistory(blk *block.Block, tx *sql.Tx, indexIdentifier string, userAddr string,
-	indexHash hash.Hash256) error {
-	insertQuery := fmt.Sprintf("INSERT INTO %s (node_address,user_address,index_hash) VALUES (?, ?, ?)",
-		idx.getIndexHistoryTableName(indexIdentifier))
-	if _, err := tx.Exec(insertQuery, idx.hexEncodedNodeAddr, userAddr, indexHash[:]); err != nil {
-		return err
+// GetActionCountByAddress returns the number of actions sent or received by the address
+func (idx *Indexer) GetActionCountByAddress(addr string) (uint64, error) {
+	var count uint64
+	if err := idx.store.GetDB().QueryRow(
+		"SELECT COUNT(*) FROM address_actions WHERE address = ?", addr,
+	).Scan(&count); err != nil {
+		return 0, errors.Wrapf(err, "failed to count actions of address %s", addr)
 	}
-	return nil
+	return count, nil
 }
 
-// GetIndexHistory gets index history
-func (idx *Indexer) GetIndexHistory(indexIdentifier string, userAddr string) ([]hash.Hash256, error) {
-	getQuery := fmt.Sprintf("SELECT * FROM %s WHERE node_address=? AND user_address=?",
-		idx.getIndexHistoryTableName(indexIdentifier))
-	indexHashes, err := idx.getIndexHistory(getQuery, userAddr)
+// GetActionsByAddress returns a page of the actions sent or received by the address
+func (idx *Indexer) GetActionsByAddress(q *AddressQuery) (*ActionPage, error) {
+	conds, args, err := q.conditions("address = ?", q.Address)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get index history")
+		return nil, err
 	}
-	return indexHashes, nil
-}
-
-// GetBlockByIndex returns block hash by index hash
-func (idx *Indexer) GetBlockByIndex(indexIdentifier string, indexHash hash.Hash256) (hash.Hash256, error) {
-	getQuery := fmt.Sprintf("SELECT * FROM %s WHERE node_address=? AND index_hash=?",
-		idx.getBlockByIndexTableName(indexIdentifier))
-	blkHash, err := idx.blockByIndex(getQuery, indexHash)
+	if len(q.ActionTypes) > 0 {
+		conds = append(conds, "action_type IN (?"+strings.Repeat(", ?", len(q.ActionTypes)-1)+")")
+		for _, t := range q.ActionTypes {
+			args = append(args, t)
+		}
+	}
+	rows, err := idx.store.GetDB().Query(
+		"SELECT action_hash, action_type, block_height, action_index, timestamp FROM address_actions WHERE "+
+			strings.Join(conds, " AND ")+" ORDER BY block_height, action_index"+q.pageClause(),
+		args...,
+	)
 	if err != nil {
-		return hash.ZeroHash256, errors.Wrapf(err, "failed to get block hash by index hash")
+		return nil, errors.Wrapf(err, "failed to get actions of address %s", q.Address)
+	}
+	defer rows.Close()
+	page := &ActionPage{}
+	var height, index uint64
+	for rows.Next() {
+		var (
+			actHash []byte
+			ts      int64
+			record  ActionRecord
+		)
+		if err := rows.Scan(&actHash, &record.ActionType, &height, &index, &ts); err != nil {
+			return nil, errors.Wrap(err, "failed to parse results")
+		}
+		record.ActionHash = hash.BytesToHash256(actHash)
+		record.BlockHeight = height
+		record.Timestamp = time.Unix(ts, 0)
+		page.Actions = append(page.Actions, &record)
 	}
-	return blkHash, nil
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse results")
+	}
+	if uint64(len(page.Actions)) == q.Limit {
+		page.NextCursor = encodeCursor(height, index, 0)
+	}
+	return page, nil
 }
 
-// blockByIndex returns block by receipt hash
-func (idx *Indexer) blockByIndex(getQuery string, actionHash hash.Hash256) (hash.Hash256, error) {
-	db := idx.store.GetDB()
-
-	stmt, err := db.Prepare(getQuery)
+// GetTokenTransfersByAddress returns a page of the token transfers sent or received by the address
+func (idx *Indexer) GetTokenTransfersByAddress(q *AddressQuery) (*TokenTransferPage, error) {
+	conds, args, err := q.conditions("(sender = ? OR recipient = ?)", q.Address, q.Address)
 	if err != nil {
-		return hash.ZeroHash256, errors.Wrapf(err, "failed to prepare get query")
+		return nil, err
 	}
-
-	rows, err := stmt.Query(idx.hexEncodedNodeAddr, hex.EncodeToString(actionHash[:]))
+	rows, err := idx.store.GetDB().Query(
+		"SELECT action_hash, log_index, block_height, action_index, timestamp, token, sender, recipient, amount "+
+			"FROM token_transfers WHERE "+strings.Join(conds, " AND ")+
+			" ORDER BY block_height, action_index, log_index"+q.pageClause(),
+		args...,
+	)
 	if err != nil {
-		return hash.ZeroHash256, errors.Wrapf(err, "failed to execute get query")
+		return nil, errors.Wrapf(err, "failed to get token transfers of address %s", q.Address)
 	}
-
-	var blockByIndex BlockByIndex
-	parsedRows, err := s.ParseSQLRows(rows, &blockByIndex)
-	if err != nil {
-		return hash.ZeroHash256, errors.Wrapf(err, "failed to parse results")
+	defer rows.Close()
+	page := &TokenTransferPage{}
+	var height, index uint64
+	for rows.Next() {
+		var (
+			actHash []byte
+			ts      int64
+			amount  string
+			tt      TokenTransfer
+		)
+		if err := rows.Scan(
+			&actHash, &tt.LogIndex, &height, &index, &ts, &tt.Token, &tt.Sender, &tt.Recipient, &amount,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to parse results")
+		}
+		var ok bool
+		if tt.Amount, ok = new(big.Int).SetString(amount, 10); !ok {
+			return nil, errors.Errorf("invalid amount %s of token transfer", amount)
+		}
+		tt.ActionHash = hash.BytesToHash256(actHash)
+		tt.BlockHeight = height
+		tt.Timestamp = time.Unix(ts, 0)
+		page.Transfers = append(page.Transfers, &tt)
 	}
-
-	if len(parsedRows) == 0 {
-		return hash.ZeroHash256, ErrNotExist
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse results")
 	}
-
-	var hash hash.Hash256
-	copy(hash[:], parsedRows[0].(*BlockByIndex).BlockHash)
-	return hash, nil
+	if n := len(page.Transfers); uint64(n) == q.Limit {
+		page.NextCursor = encodeCursor(height, index, uint64(page.Transfers[n-1].LogIndex)+1)
+	}
+	return page, nil
 }
 
-// getIndexHistory gets index history
-func (idx *Indexer) getIndexHistory(getQuery string, userAddr string) ([]hash.Hash256, error) {
-	db := idx.store.GetDB()
-
-	stmt, err := db.Prepare(getQuery)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to prepare get query")
+// conditions returns the WHERE conditions and arguments of the query, starting from the given ones
+func (q *AddressQuery) conditions(cond string, args ...interface{}) ([]string, []interface{}, error) {
+	if q.Limit == 0 {
+		return nil, nil, errors.Wrap(ErrInvalidQuery, "limit should be greater than 0")
 	}
-
-	rows, err := stmt.Query(idx.hexEncodedNodeAddr, userAddr)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to execute get query")
+	conds := []string{cond}
+	if !q.StartTime.IsZero() {
+		conds = append(conds, "timestamp >= ?")
+		args = append(args, q.StartTime.Unix())
 	}
-
-	var indexHistory IndexHistory
-	parsedRows, err := s.ParseSQLRows(rows, &indexHistory)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to parse results")
+	if !q.EndTime.IsZero() {
+		conds = append(conds, "timestamp < ?")
+		args = append(args, q.EndTime.Unix())
 	}
-
-	indexHashes := make([]hash.Hash256, 0, len(parsedRows))
-	for _, parsedRow := range parsedRows {
-		var hash hash.Hash256
-		copy(hash[:], parsedRow.(*IndexHistory).IndexHash)
-		indexHashes = append(indexHashes, hash)
+	if q.Cursor != "" {
+		height, index, logIndex, err := decodeCursor(q.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		// the records after (height, index), or after (height, index, logIndex - 1) for the token transfers
+		if logIndex == 0 {
+			conds = append(conds, "(block_height > ? OR (block_height = ? AND action_index > ?))")
+			args = append(args, height, height, index)
+		} else {
+			conds = append(conds, "(block_height > ? OR (block_height = ? AND (action_index > ? OR "+
+				"(action_index = ? AND log_index >= ?))))")
+			args = append(args, height, height, index, index, logIndex)
+		}
 	}
-	return indexHashes, nil
+	return conds, args, nil
 }
 
-func (idx *Indexer) getBlockByIndexTableName(indexIndentifier string) string {
-	return fmt.Sprintf("block_by_index_%s", indexIndentifier)
+// pageClause returns the LIMIT and OFFSET clause of the query
+func (q *AddressQuery) pageClause() string {
+	if q.Cursor != "" || q.Offset == 0 {
+		return " LIMIT " + strconv.FormatUint(q.Limit, 10)
+	}
+	return " LIMIT " + strconv.FormatUint(q.Limit, 10) + " OFFSET " + strconv.FormatUint(q.Offset, 10)
 }
 
-func (idx *Indexer) getIndexHistoryTableName(indexIndentifier string) string {
-	return fmt.Sprintf("index_history_%s", indexIndentifier)
+// encodeCursor encodes the position of the last record of a page. The log index is 1-based for the token transfers,
+// and 0 for the actions.
+func encodeCursor(height, index, logIndex uint64) string {
+	b := make([]byte, 24)
+	enc.MachineEndian.PutUint64(b, height)
+	enc.MachineEndian.PutUint64(b[8:], index)
+	enc.MachineEndian.PutUint64(b[16:], logIndex)
+	return hex.EncodeToString(b)
 }
 
-// CreateTablesIfNotExist creates tables in local database
-func (idx *Indexer) CreateTablesIfNotExist() error {
-	// create block by index tables
-	for _, indexIdentifier := range idx.cfg.BlockByIndexList {
-		if _, err := idx.store.GetDB().Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ([node_address] TEXT NOT NULL, "+
-			"[index_hash] BLOB(32) NOT NULL, [block_hash] BLOB(32) NOT NULL)", idx.getBlockByIndexTableName(indexIdentifier))); err != nil {
-			return err
-		}
+func decodeCursor(cursor string) (uint64, uint64, uint64, error) {
+	b, err := hex.DecodeString(cursor)
+	if err != nil || len(b) != 24 {
+		return 0, 0, 0, errors.Wrapf(ErrInvalidQuery, "invalid cursor %s", cursor)
 	}
+	return enc.MachineEndian.Uint64(b), enc.MachineEndian.Uint64(b[8:]), enc.MachineEndian.Uint64(b[16:]), nil
+}
 
-	// create index history tables
-	for _, indexIdentifier := range idx.cfg.IndexHistoryList {
-		if _, err := idx.store.GetDB().Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ([node_address] TEXT NOT NULL, "+
-			"[user_address] TEXT NOT NULL, [index_hash] BLOB(32) NOT NULL)", idx.getIndexHistoryTableName(indexIdentifier))); err != nil {
-			return err
-		}
+// actionType returns the type name of the action, e.g., "Transfer"
+func actionType(selp action.SealedEnvelope) string {
+	t := reflect.TypeOf(selp.Action())
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
 	}
+	return t.Name()
+}
 
-	return nil
+// decodeTokenTransfer decodes the sender, recipient and amount of an ERC20 Transfer event
+func decodeTokenTransfer(l *action.Log) (string, string, *big.Int, bool) {
+	if len(l.Topics) != 3 || l.Topics[0] != transferEventTopic || len(l.Data) != 32 {
+		return "", "", nil, false
+	}
+	sender, err := address.FromBytes(l.Topics[1][12:])
+	if err != nil {
+		return "", "", nil, false
+	}
+	recipient, err := address.FromBytes(l.Topics[2][12:])
+	if err != nil {
+		return "", "", nil, false
+	}
+	return sender.String(), recipient.String(), new(big.Int).SetBytes(l.Data), true
 }
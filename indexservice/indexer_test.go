@@ -8,12 +8,14 @@ package indexservice
 
 import (
 	"context"
-	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
@@ -26,38 +28,19 @@ import (
 	"github.com/iotexproject/iotex-core/test/testaddress"
 )
 
-func testSQLite3StorePutGet(store sql.Store, t *testing.T) {
+func testBlock(t *testing.T, height uint64, ts time.Time, nonce uint64) *block.Block {
 	require := require.New(t)
-	ctx := context.Background()
-
-	err := store.Start(ctx)
-	require.Nil(err)
-	defer func() {
-		err = store.Stop(ctx)
-		require.Nil(err)
-	}()
-
-	nodeAddr := "aaa"
-	addr1 := testaddress.Addrinfo["alfa"].String()
 	pubKey1 := testaddress.Keyinfo["alfa"].PubKey
 	addr2 := testaddress.Addrinfo["bravo"].String()
-	cfg := config.Default
-	idx := Indexer{
-		cfg:                cfg.Indexer,
-		store:              store,
-		hexEncodedNodeAddr: nodeAddr,
-	}
-	err = idx.CreateTablesIfNotExist()
-	require.Nil(err)
-
+	timestamp, err := ptypes.TimestampProto(ts)
+	require.NoError(err)
 	blk := block.Block{}
-
-	err = blk.ConvertFromBlockPb(&iotextypes.Block{
+	require.NoError(blk.ConvertFromBlockPb(&iotextypes.Block{
 		Header: &iotextypes.BlockHeader{
 			Core: &iotextypes.BlockHeaderCore{
 				Version:   version.ProtocolVersion,
-				Height:    123456789,
-				Timestamp: ptypes.TimestampNow(),
+				Height:    height,
+				Timestamp: timestamp,
 			},
 			ProducerPubkey: pubKey1.Bytes(),
 		},
@@ -66,10 +49,11 @@ func testSQLite3StorePutGet(store sql.Store, t *testing.T) {
 				{
 					Core: &iotextypes.ActionCore{
 						Action: &iotextypes.ActionCore_Transfer{
-							Transfer: &iotextypes.Transfer{Recipient: addr2},
+							Transfer: &iotextypes.Transfer{Recipient: addr2, Amount: "1"},
 						},
-						Version: version.ProtocolVersion,
-						Nonce:   101,
+						Version:  version.ProtocolVersion,
+						Nonce:    nonce,
+						GasPrice: "0",
 					},
 					SenderPubKey: pubKey1.Bytes(),
 				},
@@ -78,144 +62,193 @@ func testSQLite3StorePutGet(store sql.Store, t *testing.T) {
 						Action: &iotextypes.ActionCore_Vote{
 							Vote: &iotextypes.Vote{VoteeAddress: addr2},
 						},
-						Version: version.ProtocolVersion,
-						Nonce:   103,
+						Version:  version.ProtocolVersion,
+						Nonce:    nonce + 1,
+						GasPrice: "0",
 					},
 					SenderPubKey: pubKey1.Bytes(),
 				},
 				{
 					Core: &iotextypes.ActionCore{
 						Action: &iotextypes.ActionCore_Execution{
-							Execution: &iotextypes.Execution{Contract: addr2},
+							Execution: &iotextypes.Execution{Contract: addr2, Amount: "0"},
 						},
-						Version: version.ProtocolVersion,
-						Nonce:   104,
+						Version:  version.ProtocolVersion,
+						Nonce:    nonce + 2,
+						GasPrice: "0",
 					},
 					SenderPubKey: pubKey1.Bytes(),
 				},
 			},
 		},
-	})
-	require.NoError(err)
-	receipts := []*action.Receipt{
+	}))
+	// the execution emits a token transfer from alfa to charlie
+	topic := func(b []byte) hash.Hash256 {
+		var h hash.Hash256
+		copy(h[12:], b)
+		return h
+	}
+	var amount hash.Hash256
+	amount[31] = byte(height)
+	blk.Receipts = []*action.Receipt{
 		{
-			ActionHash:      hash.Hash256b([]byte("1")),
-			Status:          1,
-			GasConsumed:     1,
-			ContractAddress: "1",
-			Logs:            []*action.Log{},
+			ActionHash:  blk.Actions[0].Hash(),
+			Status:      1,
+			GasConsumed: 10000,
 		},
 		{
-			ActionHash:      hash.Hash256b([]byte("2")),
-			Status:          2,
-			GasConsumed:     2,
-			ContractAddress: "2",
-			Logs:            []*action.Log{},
+			ActionHash:      blk.Actions[2].Hash(),
+			Status:          1,
+			GasConsumed:     20000,
+			ContractAddress: addr2,
+			Logs: []*action.Log{
+				{
+					Address: addr2,
+					Topics:  []hash.Hash256{hash.BytesToHash256([]byte("other event"))},
+				},
+				{
+					Address: addr2,
+					Topics: []hash.Hash256{
+						transferEventTopic,
+						topic(testaddress.Addrinfo["alfa"].Bytes()),
+						topic(testaddress.Addrinfo["charlie"].Bytes()),
+					},
+					Data: amount[:],
+				},
+			},
 		},
 	}
-	blk.Receipts = make([]*action.Receipt, 0)
-	/*for _, receipt := range receipts {
-		blk.Receipts = append(blk.Receipts, receipt)
-	}*/
-	blk.Receipts = append(blk.Receipts, receipts...)
-
-	err = idx.BuildIndex(&blk)
-	require.Nil(err)
-
-	db := store.GetDB()
-
-	transfers, votes, executions := action.ClassifyActions(blk.Actions)
-
-	// get receipt
-	blkHash, err := idx.GetBlockByIndex(config.IndexReceipt, receipts[0].Hash())
-	require.Nil(err)
-	require.Equal(blkHash, blk.HashBlock())
-
-	blkHash, err = idx.GetBlockByIndex(config.IndexReceipt, receipts[1].Hash())
-	require.Nil(err)
-	require.Equal(blkHash, blk.HashBlock())
-
-	// get transfer
-	transferHashes, err := idx.GetIndexHistory(config.IndexTransfer, addr1)
-	require.Nil(err)
-	require.Equal(1, len(transferHashes))
-	transfer := transfers[0].Hash()
-	require.Equal(transfer, transferHashes[0])
-
-	// get vote
-	voteHashes, err := idx.GetIndexHistory(config.IndexVote, addr1)
-	require.Nil(err)
-	require.Equal(1, len(voteHashes))
-	vote := votes[0].Hash()
-	require.Equal(vote, voteHashes[0])
-
-	// get execution
-	executionHashes, err := idx.GetIndexHistory(config.IndexExecution, addr1)
-	require.Nil(err)
-	require.Equal(1, len(executionHashes))
-	execution := executions[0].Hash()
-	require.Equal(execution, executionHashes[0])
-
-	// get action
-	actionHashes, err := idx.GetIndexHistory(config.IndexAction, addr1)
-	require.Nil(err)
-	require.Equal(3, len(actionHashes))
-	action := blk.Actions[0].Hash()
-	require.Equal(action, actionHashes[0])
-
-	// transfer map to block
-	blkHash1, err := idx.GetBlockByIndex(config.IndexTransfer, transfers[0].Hash())
-	require.Nil(err)
-	require.Equal(blkHash1, blk.HashBlock())
-
-	// vote map to block
-	blkHash2, err := idx.GetBlockByIndex(config.IndexVote, votes[0].Hash())
-	require.Nil(err)
-	require.Equal(blkHash2, blk.HashBlock())
-
-	// execution map to block
-	blkHash3, err := idx.GetBlockByIndex(config.IndexExecution, executions[0].Hash())
-	require.Nil(err)
-	require.Equal(blkHash3, blk.HashBlock())
-
-	// action map to block
-	blkHash4, err := idx.GetBlockByIndex(config.IndexAction, blk.Actions[0].Hash())
-	require.Nil(err)
-	require.Equal(blkHash4, blk.HashBlock())
-
-	// create block by index tables
-	for _, indexIdentifier := range idx.cfg.BlockByIndexList {
-		stmt, err := db.Prepare(fmt.Sprintf("DELETE FROM %s WHERE node_address=?",
-			idx.getBlockByIndexTableName(indexIdentifier)))
-		require.Nil(err)
-		_, err = stmt.Exec(nodeAddr)
-		require.Nil(err)
+	return &blk
+}
+
+func testIndexer(store sql.Store, t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+	require.NoError(sql.Migrate(store, migrations))
+	idx := Indexer{
+		cfg:   config.Default.Indexer,
+		store: store,
 	}
 
-	// create index history tables
-	for _, indexIdentifier := range idx.cfg.IndexHistoryList {
-		stmt, err := db.Prepare(fmt.Sprintf("DELETE FROM %s WHERE node_address=?",
-			idx.getIndexHistoryTableName(indexIdentifier)))
-		require.Nil(err)
-		_, err = stmt.Exec(nodeAddr)
-		require.Nil(err)
+	addr1 := testaddress.Addrinfo["alfa"].String()
+	addr2 := testaddress.Addrinfo["bravo"].String()
+	addr3 := testaddress.Addrinfo["charlie"].String()
+	start := time.Unix(1546300800, 0)
+	var blks []*block.Block
+	for i := uint64(1); i <= 3; i++ {
+		blk := testBlock(t, i, start.Add(time.Duration(i)*time.Hour), i*10)
+		require.NoError(idx.BuildIndex(blk))
+		blks = append(blks, blk)
 	}
+	// indexing a block again doesn't duplicate records
+	require.NoError(idx.BuildIndex(blks[1]))
+
+	blkHash, err := idx.GetBlockHashByActionHash(blks[1].Actions[2].Hash())
+	require.NoError(err)
+	require.Equal(blks[1].HashBlock(), blkHash)
+	_, err = idx.GetBlockHashByActionHash(hash.ZeroHash256)
+	require.Equal(ErrNotExist, errors.Cause(err))
+
+	count, err := idx.GetActionCountByAddress(addr1)
+	require.NoError(err)
+	require.Equal(uint64(9), count)
+	count, err = idx.GetActionCountByAddress(addr2)
+	require.NoError(err)
+	require.Equal(uint64(9), count)
+
+	// page through the history with the cursor
+	var hashes []hash.Hash256
+	q := &AddressQuery{Address: addr1, Limit: 2}
+	for {
+		page, err := idx.GetActionsByAddress(q)
+		require.NoError(err)
+		for _, a := range page.Actions {
+			hashes = append(hashes, a.ActionHash)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		q.Cursor = page.NextCursor
+	}
+	require.Equal(9, len(hashes))
+	for i, blk := range blks {
+		for j, selp := range blk.Actions {
+			require.Equal(selp.Hash(), hashes[i*3+j])
+		}
+	}
+
+	// the offset is used without a cursor
+	page, err := idx.GetActionsByAddress(&AddressQuery{Address: addr1, Offset: 4, Limit: 2})
+	require.NoError(err)
+	require.Equal(2, len(page.Actions))
+	require.Equal(blks[1].Actions[1].Hash(), page.Actions[0].ActionHash)
+	require.Equal("Vote", page.Actions[0].ActionType)
+	require.Equal(uint64(2), page.Actions[0].BlockHeight)
+	require.Equal(start.Add(2*time.Hour).Unix(), page.Actions[0].Timestamp.Unix())
+
+	// filter by the time range and the action types
+	page, err = idx.GetActionsByAddress(&AddressQuery{
+		Address:     addr2,
+		ActionTypes: []string{"Transfer", "Execution"},
+		StartTime:   start.Add(2 * time.Hour),
+		EndTime:     start.Add(3 * time.Hour),
+		Limit:       10,
+	})
+	require.NoError(err)
+	require.Equal(2, len(page.Actions))
+	require.Equal(blks[1].Actions[0].Hash(), page.Actions[0].ActionHash)
+	require.Equal(blks[1].Actions[2].Hash(), page.Actions[1].ActionHash)
+	require.Equal("", page.NextCursor)
+
+	// token transfers
+	tq := &AddressQuery{Address: addr3, Limit: 2}
+	tpage, err := idx.GetTokenTransfersByAddress(tq)
+	require.NoError(err)
+	require.Equal(2, len(tpage.Transfers))
+	tt := tpage.Transfers[0]
+	require.Equal(blks[0].Actions[2].Hash(), tt.ActionHash)
+	require.Equal(uint32(1), tt.LogIndex)
+	require.Equal(addr2, tt.Token)
+	require.Equal(addr1, tt.Sender)
+	require.Equal(addr3, tt.Recipient)
+	require.Equal(big.NewInt(1), tt.Amount)
+	tq.Cursor = tpage.NextCursor
+	tpage, err = idx.GetTokenTransfersByAddress(tq)
+	require.NoError(err)
+	require.Equal(1, len(tpage.Transfers))
+	require.Equal(big.NewInt(3), tpage.Transfers[0].Amount)
+	require.Equal("", tpage.NextCursor)
+	tpage, err = idx.GetTokenTransfersByAddress(&AddressQuery{Address: addr2, Limit: 2})
+	require.NoError(err)
+	require.Equal(0, len(tpage.Transfers))
+
+	// invalid queries
+	_, err = idx.GetActionsByAddress(&AddressQuery{Address: addr1})
+	require.Equal(ErrInvalidQuery, errors.Cause(err))
+	_, err = idx.GetActionsByAddress(&AddressQuery{Address: addr1, Cursor: "xyz", Limit: 1})
+	require.Equal(ErrInvalidQuery, errors.Cause(err))
 }
 
 func TestIndexServiceOnSqlite3(t *testing.T) {
 	path := "explorer.db"
 	testFile, _ := ioutil.TempFile(os.TempDir(), path)
 	testPath := testFile.Name()
+	defer os.Remove(testPath)
 	cfg := config.Default.DB
 	cfg.SQLITE3.SQLite3File = testPath
 	t.Run("Indexer", func(t *testing.T) {
-		testSQLite3StorePutGet(sql.NewSQLite3(cfg.SQLITE3), t)
+		testIndexer(sql.NewSQLite3(cfg.SQLITE3), t)
 	})
 }
 
 func TestIndexServiceOnAwsRDS(t *testing.T) {
 	t.Skip("Skipping when RDS credentail not provided.")
 	t.Run("Indexer", func(t *testing.T) {
-		testSQLite3StorePutGet(sql.NewAwsRDS(config.Default.DB.RDS), t)
+		testIndexer(sql.NewAwsRDS(config.Default.DB.RDS), t)
 	})
 }
@@ -0,0 +1,162 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package indexservice
+
+import (
+	"fmt"
+
+	s "github.com/iotexproject/iotex-core/db/sql"
+)
+
+// To change the schema, append a migration with the next version. Never modify a migration already released, since
+// it won't be applied again to the existing databases.
+
+// migrations are the versioned changes of the index schema
+var migrations = []s.Migration{
+	{
+		Version:     1,
+		Description: "create blocks, actions, address history, receipts, logs and token transfers tables",
+		Statements:  createTablesV1,
+	},
+	// the records of the dropped tables are back-filled into the tables of version 1 from the chain by
+	// Server.StartBackfill
+	{
+		Version:     2,
+		Description: "drop the index tables keyed by node address",
+		Statements: func(string) []string {
+			var stmts []string
+			for _, id := range []string{"transfer", "vote", "execution", "action", "receipt"} {
+				stmts = append(stmts, "DROP TABLE IF EXISTS block_by_index_"+id)
+				if id != "receipt" {
+					stmts = append(stmts, "DROP TABLE IF EXISTS index_history_"+id)
+				}
+			}
+			return stmts
+		},
+	},
+	{
+		Version:     3,
+		Description: "create back-fill progress table",
+		Statements: func(string) []string {
+			return []string{"CREATE TABLE IF NOT EXISTS backfill_progress (" +
+				"id INTEGER NOT NULL PRIMARY KEY, " +
+				"next_height BIGINT NOT NULL, " +
+				"end_height BIGINT NOT NULL)"}
+		},
+	},
+}
+
+// columnTypes are the column types of a SQL dialect
+type columnTypes struct {
+	// hash is a 32-byte hash, which could be a key
+	hash string
+	// blob is a variable length byte array
+	blob string
+}
+
+func dialect(driverName string) columnTypes {
+	if driverName == s.MySQLDriver {
+		// a BLOB column can't be a key in MySQL without a prefix length
+		return columnTypes{hash: "BINARY(32)", blob: "LONGBLOB"}
+	}
+	return columnTypes{hash: "BLOB", blob: "BLOB"}
+}
+
+// index is a secondary index of a table
+type index struct {
+	name    string
+	unique  bool
+	columns string
+}
+
+// createTable returns the statements creating a table with its indexes, each of which could be rerun. As MySQL
+// commits each DDL statement implicitly and has no CREATE INDEX IF NOT EXISTS, the indexes are declared inside the
+// CREATE TABLE statement on MySQL, so that the table and its indexes are created at once.
+func createTable(driverName string, table string, columns string, indexes ...index) []string {
+	var stmts []string
+	for _, idx := range indexes {
+		key := "INDEX"
+		if idx.unique {
+			key = "UNIQUE INDEX"
+		}
+		if driverName == s.MySQLDriver {
+			columns += fmt.Sprintf(", %s %s (%s)", key, idx.name, idx.columns)
+			continue
+		}
+		stmts = append(stmts, fmt.Sprintf("CREATE %s IF NOT EXISTS %s ON %s (%s)", key, idx.name, table, idx.columns))
+	}
+	return append([]string{fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", table, columns)}, stmts...)
+}
+
+func createTablesV1(driverName string) []string {
+	t := dialect(driverName)
+	var stmts []string
+	stmts = append(stmts, createTable(driverName, "blocks",
+		"height BIGINT NOT NULL PRIMARY KEY, "+
+			"hash "+t.hash+" NOT NULL, "+
+			"producer VARCHAR(64) NOT NULL, "+
+			"timestamp BIGINT NOT NULL, "+
+			"num_actions INTEGER NOT NULL",
+		index{name: "blocks_hash", unique: true, columns: "hash"},
+		index{name: "blocks_timestamp", columns: "timestamp"},
+	)...)
+	stmts = append(stmts, createTable(driverName, "actions",
+		"hash "+t.hash+" NOT NULL PRIMARY KEY, "+
+			"block_height BIGINT NOT NULL, "+
+			"action_index INTEGER NOT NULL, "+
+			"action_type VARCHAR(32) NOT NULL, "+
+			"sender VARCHAR(64) NOT NULL, "+
+			"recipient VARCHAR(64) NOT NULL, "+
+			"nonce BIGINT NOT NULL, "+
+			"gas_limit BIGINT NOT NULL, "+
+			"gas_price VARCHAR(80) NOT NULL",
+		index{name: "actions_block", columns: "block_height, action_index"},
+	)...)
+	// the history of an address is denormalized, so that a page of it is read from one index
+	stmts = append(stmts, createTable(driverName, "address_actions",
+		"address VARCHAR(64) NOT NULL, "+
+			"block_height BIGINT NOT NULL, "+
+			"action_index INTEGER NOT NULL, "+
+			"action_hash "+t.hash+" NOT NULL, "+
+			"action_type VARCHAR(32) NOT NULL, "+
+			"timestamp BIGINT NOT NULL, "+
+			"PRIMARY KEY (address, block_height, action_index)",
+		index{name: "address_actions_timestamp", columns: "address, timestamp"},
+	)...)
+	stmts = append(stmts, createTable(driverName, "receipts",
+		"action_hash "+t.hash+" NOT NULL PRIMARY KEY, "+
+			"block_height BIGINT NOT NULL, "+
+			"status BIGINT NOT NULL, "+
+			"gas_consumed BIGINT NOT NULL, "+
+			"contract_address VARCHAR(64) NOT NULL",
+	)...)
+	stmts = append(stmts, createTable(driverName, "logs",
+		"action_hash "+t.hash+" NOT NULL, "+
+			"log_index INTEGER NOT NULL, "+
+			"block_height BIGINT NOT NULL, "+
+			"address VARCHAR(64) NOT NULL, "+
+			"topics "+t.blob+" NOT NULL, "+
+			"data "+t.blob+" NOT NULL, "+
+			"PRIMARY KEY (action_hash, log_index)",
+		index{name: "logs_address", columns: "address, block_height"},
+	)...)
+	stmts = append(stmts, createTable(driverName, "token_transfers",
+		"action_hash "+t.hash+" NOT NULL, "+
+			"log_index INTEGER NOT NULL, "+
+			"block_height BIGINT NOT NULL, "+
+			"action_index INTEGER NOT NULL, "+
+			"timestamp BIGINT NOT NULL, "+
+			"token VARCHAR(64) NOT NULL, "+
+			"sender VARCHAR(64) NOT NULL, "+
+			"recipient VARCHAR(64) NOT NULL, "+
+			"amount VARCHAR(80) NOT NULL, "+
+			"PRIMARY KEY (action_hash, log_index)",
+		index{name: "token_transfers_sender", columns: "sender, block_height, action_index, log_index"},
+		index{name: "token_transfers_recipient", columns: "recipient, block_height, action_index, log_index"},
+	)...)
+	return stmts
+}
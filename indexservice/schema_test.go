@@ -0,0 +1,60 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package indexservice
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db/sql"
+)
+
+func TestCreateTablesV1(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	testFile, err := ioutil.TempFile(os.TempDir(), "schema")
+	require.NoError(err)
+	require.NoError(testFile.Close())
+	defer func() {
+		require.NoError(os.Remove(testFile.Name()))
+	}()
+	store := sql.NewSQLite3(config.SQLITE3{SQLite3File: testFile.Name()})
+	require.NoError(store.Start(ctx))
+	defer func() {
+		require.NoError(store.Stop(ctx))
+	}()
+
+	// each statement could be rerun, e.g., after a migration is partially applied
+	for i := 0; i < 2; i++ {
+		for _, stmt := range createTablesV1(store.DriverName()) {
+			_, err := store.GetDB().Exec(stmt)
+			require.NoError(err)
+		}
+	}
+	_, err = store.GetDB().Exec(
+		"INSERT INTO blocks (height, hash, producer, timestamp, num_actions) VALUES (1, X'01', 'a', 0, 0)")
+	require.NoError(err)
+	_, err = store.GetDB().Exec(
+		"INSERT INTO blocks (height, hash, producer, timestamp, num_actions) VALUES (2, X'01', 'b', 0, 0)")
+	require.Error(err)
+
+	// the indexes are created along with the tables on MySQL
+	stmts := createTablesV1(sql.MySQLDriver)
+	require.Equal(6, len(stmts))
+	for _, stmt := range stmts {
+		require.True(strings.HasPrefix(stmt, "CREATE TABLE IF NOT EXISTS "))
+	}
+	require.Contains(stmts[0], "UNIQUE INDEX blocks_hash (hash)")
+	require.Contains(stmts[5], "INDEX token_transfers_recipient (recipient, block_height, action_index, log_index)")
+}
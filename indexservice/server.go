@@ -7,21 +7,30 @@
 package indexservice
 
 import (
+	dbsql "database/sql"
+
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
 
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/db/sql"
 	"github.com/iotexproject/iotex-core/pkg/log"
 )
 
+// backfillLogInterval is the number of blocks back-filled between the progress logs
+const backfillLogInterval = 1000
+
 // Server is the container of the index service
 type Server struct {
 	cfg config.Config
 	idx *Indexer
 	bc  blockchain.Blockchain
+	// cancel stops the back-fill, and done is closed once it returns
+	cancel context.CancelFunc
+	done   chan struct{}
 }
 
 // NewServer instantiates an index service
@@ -30,9 +39,8 @@ func NewServer(
 	bc blockchain.Blockchain,
 ) *Server {
 	indexer := &Indexer{
-		cfg:                cfg.Indexer,
-		store:              nil,
-		hexEncodedNodeAddr: "",
+		cfg:   cfg.Indexer,
+		store: nil,
 	}
 	if err := bc.AddSubscriber(indexer); err != nil {
 		log.L().Error("Error when subscribe to block.", zap.Error(err))
@@ -48,12 +56,6 @@ func NewServer(
 
 // Start starts the explorer server
 func (s *Server) Start(ctx context.Context) error {
-	addr := s.cfg.Indexer.NodeAddr
-	if addr == "" {
-		addr = s.cfg.ProducerAddress().String()
-	}
-	s.idx.hexEncodedNodeAddr = addr
-
 	if s.cfg.Indexer.WhetherLocalStore {
 		// local store use sqlite3
 		s.idx.store = sql.NewSQLite3(s.cfg.DB.SQLITE3)
@@ -65,16 +67,124 @@ func (s *Server) Start(ctx context.Context) error {
 		return errors.Wrap(err, "error when start store")
 	}
 
-	// create local table
-	if err := s.idx.CreateTablesIfNotExist(); err != nil {
-		return errors.Wrap(err, "error when initial tables")
+	// migrate the schema to the latest version
+	if err := sql.Migrate(s.idx.store, migrations); err != nil {
+		return errors.Wrap(err, "error when migrating tables")
+	}
+
+	return nil
+}
+
+// StartBackfill starts indexing the blocks missed by the index service in the background, i.e., the blocks committed
+// while the index service is down, or all the blocks if the index tables are freshly created, e.g., by the migration
+// dropping the tables of the old schema. It should be called after the chain is started and before any new block is
+// committed, since the new blocks are indexed as they are committed. The range to back-fill is stored along with its
+// progress, so that an interrupted back-fill resumes on the next start.
+func (s *Server) StartBackfill(ctx context.Context) error {
+	start, end, err := s.backfillRange()
+	if err != nil {
+		return err
+	}
+	if start > end {
+		return nil
+	}
+	log.L().Info("Back-fill the index.", zap.Uint64("start", start), zap.Uint64("end", end))
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		if err := s.backfill(ctx, start, end); err != nil {
+			log.L().Error("Failed to back-fill the index.", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// backfillRange returns the range of the heights to back-fill and stores it. The range starts from the next height
+// of an interrupted back-fill, or the height after the highest one indexed, and ends at the tip height.
+func (s *Server) backfillRange() (uint64, uint64, error) {
+	var indexed dbsql.NullInt64
+	if err := s.idx.store.GetDB().QueryRow("SELECT MAX(height) FROM blocks").Scan(&indexed); err != nil {
+		return 0, 0, errors.Wrap(err, "failed to get the highest block indexed")
+	}
+	start := uint64(indexed.Int64) + 1
+	var next, end uint64
+	err := s.idx.store.GetDB().QueryRow(
+		"SELECT next_height, end_height FROM backfill_progress WHERE id = 0",
+	).Scan(&next, &end)
+	switch err {
+	case nil:
+		// the blocks after the interrupted back-fill may be indexed as they were committed
+		if next <= end && next < start {
+			start = next
+		}
+	case dbsql.ErrNoRows:
+	default:
+		return 0, 0, errors.Wrap(err, "failed to get the back-fill progress")
+	}
+	if lowest := s.bc.LowestAvailableHeight(); start < lowest {
+		log.L().Warn("Blocks are pruned, skip indexing them.",
+			zap.Uint64("start", start),
+			zap.Uint64("lowestAvailableHeight", lowest))
+		start = lowest
 	}
+	tipHeight := s.bc.TipHeight()
+	if start > tipHeight {
+		return start, tipHeight, nil
+	}
+	if err := s.putBackfillProgress(start, tipHeight); err != nil {
+		return 0, 0, err
+	}
+	return start, tipHeight, nil
+}
 
+// backfill indexes the blocks in [start, end], recording the next height to index after each block
+func (s *Server) backfill(ctx context.Context, start, end uint64) error {
+	for height := start; height <= end; height++ {
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "back-fill stopped at height %d", height)
+		default:
+		}
+		blk, err := s.bc.GetBlockByHeight(height)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block %d", height)
+		}
+		receipts, err := s.bc.GetReceiptsByHeight(height)
+		if err != nil && errors.Cause(err) != db.ErrNotExist {
+			return errors.Wrapf(err, "failed to get receipts of block %d", height)
+		}
+		blk.Receipts = receipts
+		if err := s.idx.BuildIndex(blk); err != nil {
+			return err
+		}
+		if err := s.putBackfillProgress(height+1, end); err != nil {
+			return err
+		}
+		if height%backfillLogInterval == 0 {
+			log.L().Info("Back-filled the index.", zap.Uint64("height", height), zap.Uint64("end", end))
+		}
+	}
+	log.L().Info("Back-filled the index.", zap.Uint64("start", start), zap.Uint64("end", end))
+	return nil
+}
+
+func (s *Server) putBackfillProgress(next, end uint64) error {
+	if _, err := s.idx.store.GetDB().Exec(
+		"REPLACE INTO backfill_progress (id, next_height, end_height) VALUES (0, ?, ?)",
+		next, end,
+	); err != nil {
+		return errors.Wrap(err, "failed to put the back-fill progress")
+	}
 	return nil
 }
 
 // Stop stops the explorer server
 func (s *Server) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+		<-s.done
+	}
 	if err := s.idx.store.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when shutting down explorer http server")
 	}
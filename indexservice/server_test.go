@@ -7,12 +7,22 @@ package indexservice
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/sql"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/testaddress"
 )
 
 func TestServer(t *testing.T) {
@@ -28,9 +38,108 @@ func TestServer(t *testing.T) {
 	db := svr.idx.store.GetDB()
 
 	// get
-	_, err = db.Prepare("SELECT * FROM index_history_transfer WHERE node_address=?")
+	_, err = db.Prepare("SELECT * FROM address_actions WHERE address=?")
 	require.Nil(err)
+	version, err := sql.SchemaVersion(svr.idx.store)
+	require.Nil(err)
+	require.Equal(uint64(len(migrations)), version)
 
 	err = svr.Stop(context.Background())
 	require.Nil(err)
 }
+
+func TestServerBackfill(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	testFile, err := ioutil.TempFile(os.TempDir(), "backfill")
+	require.NoError(err)
+	require.NoError(testFile.Close())
+	defer func() {
+		require.NoError(os.Remove(testFile.Name()))
+	}()
+	cfg := config.Default
+	cfg.DB.SQLITE3.SQLite3File = testFile.Name()
+
+	now := time.Now()
+	blks := []*block.Block{
+		testBlock(t, 1, now, 1),
+		testBlock(t, 2, now, 4),
+		testBlock(t, 3, now, 7),
+		testBlock(t, 4, now, 10),
+		testBlock(t, 5, now, 13),
+	}
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().AddSubscriber(gomock.Any()).Return(nil).Times(1)
+	bc.EXPECT().LowestAvailableHeight().Return(uint64(1)).AnyTimes()
+	tipHeight := uint64(2)
+	bc.EXPECT().TipHeight().DoAndReturn(func() uint64 { return tipHeight }).AnyTimes()
+	for _, blk := range blks[:3] {
+		// the receipts are read from the chain
+		receipts := blk.Receipts
+		noReceipts := *blk
+		noReceipts.Receipts = nil
+		bc.EXPECT().GetBlockByHeight(blk.Height()).Return(&noReceipts, nil).Times(1)
+		bc.EXPECT().GetReceiptsByHeight(blk.Height()).Return(receipts, nil).Times(1)
+	}
+
+	svr := NewServer(cfg, bc)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.idx.store.Stop(ctx))
+	}()
+	backfill := func() {
+		svr.done = nil
+		require.NoError(svr.StartBackfill(ctx))
+		if svr.done != nil {
+			<-svr.done
+		}
+	}
+	checkIndexed := func(heights ...uint64) {
+		var count int
+		require.NoError(svr.idx.store.GetDB().QueryRow("SELECT COUNT(*) FROM blocks").Scan(&count))
+		require.Equal(len(heights), count)
+		for _, height := range heights {
+			actHash := blks[height-1].Actions[2].Hash()
+			blkHash, err := svr.idx.GetBlockHashByActionHash(actHash)
+			require.NoError(err)
+			require.Equal(blks[height-1].HashBlock(), blkHash)
+		}
+		page, err := svr.idx.GetTokenTransfersByAddress(&AddressQuery{
+			Address: testaddress.Addrinfo["charlie"].String(),
+			Limit:   10,
+		})
+		require.NoError(err)
+		require.Equal(len(heights), len(page.Transfers))
+	}
+
+	// all the blocks are indexed into the fresh tables
+	backfill()
+	checkIndexed(1, 2)
+	// only the blocks after the highest one indexed are
+	tipHeight = 3
+	backfill()
+	checkIndexed(1, 2, 3)
+	backfill()
+	checkIndexed(1, 2, 3)
+
+	// the back-fill fails on the missing block 4, and block 5 is indexed as it is committed
+	tipHeight = 4
+	bc.EXPECT().GetBlockByHeight(uint64(4)).Return(nil, errors.Wrap(db.ErrNotExist, "block 4")).Times(1)
+	backfill()
+	checkIndexed(1, 2, 3)
+	tipHeight = 5
+	require.NoError(svr.idx.HandleBlock(blks[4]))
+	checkIndexed(1, 2, 3, 5)
+	// the interrupted back-fill resumes from block 4
+	bc.EXPECT().GetBlockByHeight(uint64(4)).Return(blks[3], nil).Times(1)
+	bc.EXPECT().GetReceiptsByHeight(uint64(4)).Return(blks[3].Receipts, nil).Times(1)
+	bc.EXPECT().GetBlockByHeight(uint64(5)).Return(blks[4], nil).Times(1)
+	bc.EXPECT().GetReceiptsByHeight(uint64(5)).Return(blks[4].Receipts, nil).Times(1)
+	backfill()
+	checkIndexed(1, 2, 3, 4, 5)
+	backfill()
+	checkIndexed(1, 2, 3, 4, 5)
+}
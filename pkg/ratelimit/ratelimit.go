@@ -0,0 +1,182 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// pruneInterval is the interval to drop the buckets of idle keys
+const pruneInterval = time.Minute
+
+var (
+	// ErrThrottled indicates the request exceeds the rate limit of the key
+	ErrThrottled = errors.New("request is throttled")
+	// ErrBanned indicates the key is temporarily banned for exceeding the rate limit repeatedly
+	ErrBanned = errors.New("request source is temporarily banned")
+
+	rejectedMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_rate_limit_rejected",
+			Help: "Number of requests rejected by rate limiters.",
+		},
+		[]string{"limiter", "reason"},
+	)
+	bannedMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_rate_limit_banned",
+			Help: "Number of keys banned by rate limiters.",
+		},
+		[]string{"limiter"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(rejectedMtc)
+	prometheus.MustRegister(bannedMtc)
+}
+
+// Config is the config of a rate limiter
+type Config struct {
+	// Rate is the number of requests per second admitted for each key, 0 disables the limiter
+	Rate float64 `yaml:"rate"`
+	// Burst is the maximal number of requests admitted at once for each key
+	Burst int `yaml:"burst"`
+	// BanThreshold is the number of throttled requests before the bucket is full again which gets a key banned, 0
+	// disables banning
+	BanThreshold int `yaml:"banThreshold"`
+	// BanDuration is how long a key is banned
+	BanDuration time.Duration `yaml:"banDuration"`
+}
+
+// Option is option to Limiter
+type Option func(*Limiter)
+
+// WithClock returns an option to overwrite clock
+func WithClock(c clock.Clock) Option {
+	return func(l *Limiter) {
+		l.clock = c
+	}
+}
+
+// Limiter admits the requests of each key by a token bucket, and temporarily bans the keys which keep exceeding the
+// rate
+type Limiter struct {
+	mu        sync.Mutex
+	name      string
+	cfg       Config
+	clock     clock.Clock
+	buckets   map[string]*bucket
+	lastPrune time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// throttled is the number of throttled requests since the bucket was full
+	throttled   int
+	bannedUntil time.Time
+}
+
+// New creates a rate limiter, the name labels its metrics
+func New(name string, cfg Config, opts ...Option) *Limiter {
+	l := &Limiter{
+		name:    name,
+		cfg:     cfg,
+		clock:   clock.New(),
+		buckets: make(map[string]*bucket),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	l.lastPrune = l.clock.Now()
+	return l
+}
+
+// Allow takes a token from the bucket of the key, and returns an error if the request should be rejected
+func (l *Limiter) Allow(key string) error {
+	if l == nil || l.cfg.Rate <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if now.Sub(l.lastPrune) >= pruneInterval {
+		l.prune(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.cfg.Burst), last: now}
+		l.buckets[key] = b
+	}
+	if now.Before(b.bannedUntil) {
+		rejectedMtc.WithLabelValues(l.name, "banned").Inc()
+		return ErrBanned
+	}
+	l.refill(b, now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return nil
+	}
+	b.throttled++
+	if l.cfg.BanThreshold > 0 && b.throttled >= l.cfg.BanThreshold {
+		b.throttled = 0
+		b.bannedUntil = now.Add(l.cfg.BanDuration)
+		bannedMtc.WithLabelValues(l.name).Inc()
+		rejectedMtc.WithLabelValues(l.name, "banned").Inc()
+		return ErrBanned
+	}
+	rejectedMtc.WithLabelValues(l.name, "throttled").Inc()
+	return ErrThrottled
+}
+
+// Banned returns whether the key is banned
+func (l *Limiter) Banned(key string) bool {
+	if l == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	return ok && l.clock.Now().Before(b.bannedUntil)
+}
+
+// refill adds the tokens generated since the last refill, the throttled count is cleared once the bucket is full
+// again, i.e., the key has backed off
+func (l *Limiter) refill(b *bucket, now time.Time) {
+	elapsed := now.Sub(b.last)
+	if elapsed <= 0 {
+		return
+	}
+	b.last = now
+	b.tokens += elapsed.Seconds() * l.cfg.Rate
+	if b.tokens >= float64(l.cfg.Burst) {
+		b.tokens = float64(l.cfg.Burst)
+		b.throttled = 0
+	}
+}
+
+// prune drops the buckets which are not banned and have been refilled to full, which are the same as new ones
+func (l *Limiter) prune(now time.Time) {
+	l.lastPrune = now
+	for key, b := range l.buckets {
+		if now.Before(b.bannedUntil) {
+			continue
+		}
+		l.refill(b, now)
+		if b.tokens >= float64(l.cfg.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	require := require.New(t)
+	c := clock.NewMock()
+	l := New("test", Config{Rate: 2, Burst: 2, BanThreshold: 3, BanDuration: time.Minute}, WithClock(c))
+
+	// the burst is admitted at once
+	require.NoError(l.Allow("a"))
+	require.NoError(l.Allow("a"))
+	require.Equal(ErrThrottled, l.Allow("a"))
+	// the keys have their own buckets
+	require.NoError(l.Allow("b"))
+
+	// a token is refilled every 500ms
+	c.Add(500 * time.Millisecond)
+	require.NoError(l.Allow("a"))
+	require.Equal(ErrThrottled, l.Allow("a"))
+	// the key is banned on the third throttled request before the bucket is full again
+	require.Equal(ErrBanned, l.Allow("a"))
+	require.True(l.Banned("a"))
+	c.Add(30 * time.Second)
+	require.Equal(ErrBanned, l.Allow("a"))
+	c.Add(30 * time.Second)
+	require.False(l.Banned("a"))
+	require.NoError(l.Allow("a"))
+
+	// the throttled count is cleared once the key backs off
+	require.NoError(l.Allow("b"))
+	require.NoError(l.Allow("b"))
+	require.Equal(ErrThrottled, l.Allow("b"))
+	require.Equal(ErrThrottled, l.Allow("b"))
+	c.Add(time.Second)
+	require.NoError(l.Allow("b"))
+	require.NoError(l.Allow("b"))
+	require.Equal(ErrThrottled, l.Allow("b"))
+	require.False(l.Banned("b"))
+
+	// the idle keys are pruned
+	c.Add(pruneInterval)
+	require.NoError(l.Allow("c"))
+	require.Equal(1, len(l.buckets))
+
+	// a limiter without rate admits everything
+	l = New("test", Config{})
+	for i := 0; i < 10; i++ {
+		require.NoError(l.Allow("a"))
+	}
+	var nilLimiter *Limiter
+	require.NoError(nilLimiter.Allow("a"))
+}
@@ -25,7 +25,10 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type GetAccountRequest struct {
-	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// height defaults to the tip height, a past height requires the node in archive mode
+	// pendingNonce and numActions in the response always reflect the tip
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -63,6 +66,13 @@ func (m *GetAccountRequest) GetAddress() string {
 	return ""
 }
 
+func (m *GetAccountRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 type GetAccountResponse struct {
 	AccountMeta          *iotextypes.AccountMeta `protobuf:"bytes,1,opt,name=accountMeta,proto3" json:"accountMeta,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
@@ -322,12 +332,17 @@ func (m *GetActionByHashRequest) GetCheckPending() bool {
 }
 
 type GetActionsByAddressRequest struct {
-	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Start                uint64   `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
-	Count                uint64   `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Start   uint64 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	Count   uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// the following fields are only supported by the nodes indexing to a relational database
+	Cursor               string               `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	ActionTypes          []string             `protobuf:"bytes,5,rep,name=actionTypes,proto3" json:"actionTypes,omitempty"`
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,7,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *GetActionsByAddressRequest) Reset()         { *m = GetActionsByAddressRequest{} }
@@ -376,6 +391,34 @@ func (m *GetActionsByAddressRequest) GetCount() uint64 {
 	return 0
 }
 
+func (m *GetActionsByAddressRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *GetActionsByAddressRequest) GetActionTypes() []string {
+	if m != nil {
+		return m.ActionTypes
+	}
+	return nil
+}
+
+func (m *GetActionsByAddressRequest) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *GetActionsByAddressRequest) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
 type GetUnconfirmedActionsByAddressRequest struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Start                uint64   `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
@@ -550,11 +593,13 @@ func (m *ActionInfo) GetTimestamp() *timestamp.Timestamp {
 }
 
 type ReceiptInfo struct {
-	Receipt              *iotextypes.Receipt `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
-	BlkHash              string              `protobuf:"bytes,2,opt,name=blkHash,proto3" json:"blkHash,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Receipt *iotextypes.Receipt `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	BlkHash string              `protobuf:"bytes,2,opt,name=blkHash,proto3" json:"blkHash,omitempty"`
+	// revert reason decoded from the revert data of the receipt
+	RevertReason         string   `protobuf:"bytes,3,opt,name=revertReason,proto3" json:"revertReason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ReceiptInfo) Reset()         { *m = ReceiptInfo{} }
@@ -596,6 +641,13 @@ func (m *ReceiptInfo) GetBlkHash() string {
 	return ""
 }
 
+func (m *ReceiptInfo) GetRevertReason() string {
+	if m != nil {
+		return m.RevertReason
+	}
+	return ""
+}
+
 type BlockProducerInfo struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Votes                string   `protobuf:"bytes,2,opt,name=votes,proto3" json:"votes,omitempty"`
@@ -661,6 +713,7 @@ func (m *BlockProducerInfo) GetProduction() uint64 {
 
 type GetActionsResponse struct {
 	ActionInfo           []*ActionInfo `protobuf:"bytes,1,rep,name=actionInfo,proto3" json:"actionInfo,omitempty"`
+	NextCursor           string        `protobuf:"bytes,2,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
 	XXX_unrecognized     []byte        `json:"-"`
 	XXX_sizecache        int32         `json:"-"`
@@ -698,6 +751,13 @@ func (m *GetActionsResponse) GetActionInfo() []*ActionInfo {
 	return nil
 }
 
+func (m *GetActionsResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
 type GetBlockMetasRequest struct {
 	// Types that are valid to be assigned to Lookup:
 	//	*GetBlockMetasRequest_ByIndex
@@ -1006,6 +1066,7 @@ var xxx_messageInfo_GetServerMetaRequest proto.InternalMessageInfo
 
 type GetServerMetaResponse struct {
 	ServerMeta           *iotextypes.ServerMeta `protobuf:"bytes,1,opt,name=serverMeta,proto3" json:"serverMeta,omitempty"`
+	SyncStatus           *SyncStatus            `protobuf:"bytes,2,opt,name=syncStatus,proto3" json:"syncStatus,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
 	XXX_sizecache        int32                  `json:"-"`
@@ -1043,6 +1104,171 @@ func (m *GetServerMetaResponse) GetServerMeta() *iotextypes.ServerMeta {
 	return nil
 }
 
+func (m *GetServerMetaResponse) GetSyncStatus() *SyncStatus {
+	if m != nil {
+		return m.SyncStatus
+	}
+	return nil
+}
+
+type SyncPeerStatus struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Score                int64    `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	Blocks               uint64   `protobuf:"varint,3,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	BlocksPerSecond      float64  `protobuf:"fixed64,4,opt,name=blocksPerSecond,proto3" json:"blocksPerSecond,omitempty"`
+	Banned               bool     `protobuf:"varint,5,opt,name=banned,proto3" json:"banned,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncPeerStatus) Reset()         { *m = SyncPeerStatus{} }
+func (m *SyncPeerStatus) String() string { return proto.CompactTextString(m) }
+func (*SyncPeerStatus) ProtoMessage()    {}
+func (*SyncPeerStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{20}
+}
+
+func (m *SyncPeerStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncPeerStatus.Unmarshal(m, b)
+}
+func (m *SyncPeerStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncPeerStatus.Marshal(b, m, deterministic)
+}
+func (m *SyncPeerStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncPeerStatus.Merge(m, src)
+}
+func (m *SyncPeerStatus) XXX_Size() int {
+	return xxx_messageInfo_SyncPeerStatus.Size(m)
+}
+func (m *SyncPeerStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncPeerStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncPeerStatus proto.InternalMessageInfo
+
+func (m *SyncPeerStatus) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SyncPeerStatus) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SyncPeerStatus) GetBlocks() uint64 {
+	if m != nil {
+		return m.Blocks
+	}
+	return 0
+}
+
+func (m *SyncPeerStatus) GetBlocksPerSecond() float64 {
+	if m != nil {
+		return m.BlocksPerSecond
+	}
+	return 0
+}
+
+func (m *SyncPeerStatus) GetBanned() bool {
+	if m != nil {
+		return m.Banned
+	}
+	return false
+}
+
+type SyncStatus struct {
+	StartHeight          uint64            `protobuf:"varint,1,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	TipHeight            uint64            `protobuf:"varint,2,opt,name=tipHeight,proto3" json:"tipHeight,omitempty"`
+	HeaderHeight         uint64            `protobuf:"varint,3,opt,name=headerHeight,proto3" json:"headerHeight,omitempty"`
+	TargetHeight         uint64            `protobuf:"varint,4,opt,name=targetHeight,proto3" json:"targetHeight,omitempty"`
+	BlocksPerSecond      float64           `protobuf:"fixed64,5,opt,name=blocksPerSecond,proto3" json:"blocksPerSecond,omitempty"`
+	EtaSeconds           uint64            `protobuf:"varint,6,opt,name=etaSeconds,proto3" json:"etaSeconds,omitempty"`
+	Peers                []*SyncPeerStatus `protobuf:"bytes,7,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SyncStatus) Reset()         { *m = SyncStatus{} }
+func (m *SyncStatus) String() string { return proto.CompactTextString(m) }
+func (*SyncStatus) ProtoMessage()    {}
+func (*SyncStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{21}
+}
+
+func (m *SyncStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncStatus.Unmarshal(m, b)
+}
+func (m *SyncStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncStatus.Marshal(b, m, deterministic)
+}
+func (m *SyncStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncStatus.Merge(m, src)
+}
+func (m *SyncStatus) XXX_Size() int {
+	return xxx_messageInfo_SyncStatus.Size(m)
+}
+func (m *SyncStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncStatus proto.InternalMessageInfo
+
+func (m *SyncStatus) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetTipHeight() uint64 {
+	if m != nil {
+		return m.TipHeight
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetHeaderHeight() uint64 {
+	if m != nil {
+		return m.HeaderHeight
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetTargetHeight() uint64 {
+	if m != nil {
+		return m.TargetHeight
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetBlocksPerSecond() float64 {
+	if m != nil {
+		return m.BlocksPerSecond
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetEtaSeconds() uint64 {
+	if m != nil {
+		return m.EtaSeconds
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetPeers() []*SyncPeerStatus {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
 type SendActionRequest struct {
 	Action               *iotextypes.Action `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
@@ -1054,7 +1280,7 @@ func (m *SendActionRequest) Reset()         { *m = SendActionRequest{} }
 func (m *SendActionRequest) String() string { return proto.CompactTextString(m) }
 func (*SendActionRequest) ProtoMessage()    {}
 func (*SendActionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{20}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{22}
 }
 
 func (m *SendActionRequest) XXX_Unmarshal(b []byte) error {
@@ -1093,7 +1319,7 @@ func (m *SendActionResponse) Reset()         { *m = SendActionResponse{} }
 func (m *SendActionResponse) String() string { return proto.CompactTextString(m) }
 func (*SendActionResponse) ProtoMessage()    {}
 func (*SendActionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{21}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{23}
 }
 
 func (m *SendActionResponse) XXX_Unmarshal(b []byte) error {
@@ -1132,7 +1358,7 @@ func (m *GetReceiptByActionRequest) Reset()         { *m = GetReceiptByActionReq
 func (m *GetReceiptByActionRequest) String() string { return proto.CompactTextString(m) }
 func (*GetReceiptByActionRequest) ProtoMessage()    {}
 func (*GetReceiptByActionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{22}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{24}
 }
 
 func (m *GetReceiptByActionRequest) XXX_Unmarshal(b []byte) error {
@@ -1171,7 +1397,7 @@ func (m *GetReceiptByActionResponse) Reset()         { *m = GetReceiptByActionRe
 func (m *GetReceiptByActionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetReceiptByActionResponse) ProtoMessage()    {}
 func (*GetReceiptByActionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{23}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{25}
 }
 
 func (m *GetReceiptByActionResponse) XXX_Unmarshal(b []byte) error {
@@ -1200,17 +1426,19 @@ func (m *GetReceiptByActionResponse) GetReceiptInfo() *ReceiptInfo {
 }
 
 type ReadContractRequest struct {
-	Action               *iotextypes.Action `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	Action *iotextypes.Action `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	// height defaults to the tip height, a past height requires the node in archive mode
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ReadContractRequest) Reset()         { *m = ReadContractRequest{} }
 func (m *ReadContractRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadContractRequest) ProtoMessage()    {}
 func (*ReadContractRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{24}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{26}
 }
 
 func (m *ReadContractRequest) XXX_Unmarshal(b []byte) error {
@@ -1238,6 +1466,13 @@ func (m *ReadContractRequest) GetAction() *iotextypes.Action {
 	return nil
 }
 
+func (m *ReadContractRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 type ReadContractResponse struct {
 	Data                 string              `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	Receipt              *iotextypes.Receipt `protobuf:"bytes,2,opt,name=receipt,proto3" json:"receipt,omitempty"`
@@ -1250,7 +1485,7 @@ func (m *ReadContractResponse) Reset()         { *m = ReadContractResponse{} }
 func (m *ReadContractResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadContractResponse) ProtoMessage()    {}
 func (*ReadContractResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{25}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{27}
 }
 
 func (m *ReadContractResponse) XXX_Unmarshal(b []byte) error {
@@ -1295,7 +1530,7 @@ func (m *SuggestGasPriceRequest) Reset()         { *m = SuggestGasPriceRequest{}
 func (m *SuggestGasPriceRequest) String() string { return proto.CompactTextString(m) }
 func (*SuggestGasPriceRequest) ProtoMessage()    {}
 func (*SuggestGasPriceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{26}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{28}
 }
 
 func (m *SuggestGasPriceRequest) XXX_Unmarshal(b []byte) error {
@@ -1327,7 +1562,7 @@ func (m *SuggestGasPriceResponse) Reset()         { *m = SuggestGasPriceResponse
 func (m *SuggestGasPriceResponse) String() string { return proto.CompactTextString(m) }
 func (*SuggestGasPriceResponse) ProtoMessage()    {}
 func (*SuggestGasPriceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{27}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{29}
 }
 
 func (m *SuggestGasPriceResponse) XXX_Unmarshal(b []byte) error {
@@ -1366,7 +1601,7 @@ func (m *EstimateGasForActionRequest) Reset()         { *m = EstimateGasForActio
 func (m *EstimateGasForActionRequest) String() string { return proto.CompactTextString(m) }
 func (*EstimateGasForActionRequest) ProtoMessage()    {}
 func (*EstimateGasForActionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{28}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{30}
 }
 
 func (m *EstimateGasForActionRequest) XXX_Unmarshal(b []byte) error {
@@ -1405,7 +1640,7 @@ func (m *EstimateGasForActionResponse) Reset()         { *m = EstimateGasForActi
 func (m *EstimateGasForActionResponse) String() string { return proto.CompactTextString(m) }
 func (*EstimateGasForActionResponse) ProtoMessage()    {}
 func (*EstimateGasForActionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{29}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{31}
 }
 
 func (m *EstimateGasForActionResponse) XXX_Unmarshal(b []byte) error {
@@ -1434,9 +1669,11 @@ func (m *EstimateGasForActionResponse) GetGas() uint64 {
 }
 
 type ReadStateRequest struct {
-	ProtocolID           []byte   `protobuf:"bytes,1,opt,name=protocolID,proto3" json:"protocolID,omitempty"`
-	MethodName           []byte   `protobuf:"bytes,2,opt,name=methodName,proto3" json:"methodName,omitempty"`
-	Arguments            [][]byte `protobuf:"bytes,3,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	ProtocolID []byte   `protobuf:"bytes,1,opt,name=protocolID,proto3" json:"protocolID,omitempty"`
+	MethodName []byte   `protobuf:"bytes,2,opt,name=methodName,proto3" json:"methodName,omitempty"`
+	Arguments  [][]byte `protobuf:"bytes,3,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	// height defaults to the tip height, a past height requires the node in archive mode
+	Height               uint64   `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1446,7 +1683,7 @@ func (m *ReadStateRequest) Reset()         { *m = ReadStateRequest{} }
 func (m *ReadStateRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadStateRequest) ProtoMessage()    {}
 func (*ReadStateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{30}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{32}
 }
 
 func (m *ReadStateRequest) XXX_Unmarshal(b []byte) error {
@@ -1488,6 +1725,13 @@ func (m *ReadStateRequest) GetArguments() [][]byte {
 	return nil
 }
 
+func (m *ReadStateRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 type ReadStateResponse struct {
 	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1499,7 +1743,7 @@ func (m *ReadStateResponse) Reset()         { *m = ReadStateResponse{} }
 func (m *ReadStateResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadStateResponse) ProtoMessage()    {}
 func (*ReadStateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{31}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{33}
 }
 
 func (m *ReadStateResponse) XXX_Unmarshal(b []byte) error {
@@ -1538,7 +1782,7 @@ func (m *GetEpochMetaRequest) Reset()         { *m = GetEpochMetaRequest{} }
 func (m *GetEpochMetaRequest) String() string { return proto.CompactTextString(m) }
 func (*GetEpochMetaRequest) ProtoMessage()    {}
 func (*GetEpochMetaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{32}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{34}
 }
 
 func (m *GetEpochMetaRequest) XXX_Unmarshal(b []byte) error {
@@ -1579,7 +1823,7 @@ func (m *GetEpochMetaResponse) Reset()         { *m = GetEpochMetaResponse{} }
 func (m *GetEpochMetaResponse) String() string { return proto.CompactTextString(m) }
 func (*GetEpochMetaResponse) ProtoMessage()    {}
 func (*GetEpochMetaResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca6d5bbc959d58c0, []int{33}
+	return fileDescriptor_ca6d5bbc959d58c0, []int{35}
 }
 
 func (m *GetEpochMetaResponse) XXX_Unmarshal(b []byte) error {
@@ -1621,152 +1865,1792 @@ func (m *GetEpochMetaResponse) GetBlockProducersInfo() []*BlockProducerInfo {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*GetAccountRequest)(nil), "iotexapi.GetAccountRequest")
-	proto.RegisterType((*GetAccountResponse)(nil), "iotexapi.GetAccountResponse")
-	proto.RegisterType((*GetActionsRequest)(nil), "iotexapi.GetActionsRequest")
-	proto.RegisterType((*GetActionsByIndexRequest)(nil), "iotexapi.GetActionsByIndexRequest")
-	proto.RegisterType((*GetActionByHashRequest)(nil), "iotexapi.GetActionByHashRequest")
-	proto.RegisterType((*GetActionsByAddressRequest)(nil), "iotexapi.GetActionsByAddressRequest")
-	proto.RegisterType((*GetUnconfirmedActionsByAddressRequest)(nil), "iotexapi.GetUnconfirmedActionsByAddressRequest")
-	proto.RegisterType((*GetActionsByBlockRequest)(nil), "iotexapi.GetActionsByBlockRequest")
-	proto.RegisterType((*ActionInfo)(nil), "iotexapi.ActionInfo")
-	proto.RegisterType((*ReceiptInfo)(nil), "iotexapi.ReceiptInfo")
-	proto.RegisterType((*BlockProducerInfo)(nil), "iotexapi.BlockProducerInfo")
-	proto.RegisterType((*GetActionsResponse)(nil), "iotexapi.GetActionsResponse")
-	proto.RegisterType((*GetBlockMetasRequest)(nil), "iotexapi.GetBlockMetasRequest")
-	proto.RegisterType((*GetBlockMetasByIndexRequest)(nil), "iotexapi.GetBlockMetasByIndexRequest")
-	proto.RegisterType((*GetBlockMetaByHashRequest)(nil), "iotexapi.GetBlockMetaByHashRequest")
-	proto.RegisterType((*GetBlockMetasResponse)(nil), "iotexapi.GetBlockMetasResponse")
-	proto.RegisterType((*GetChainMetaRequest)(nil), "iotexapi.GetChainMetaRequest")
-	proto.RegisterType((*GetChainMetaResponse)(nil), "iotexapi.GetChainMetaResponse")
-	proto.RegisterType((*GetServerMetaRequest)(nil), "iotexapi.GetServerMetaRequest")
-	proto.RegisterType((*GetServerMetaResponse)(nil), "iotexapi.GetServerMetaResponse")
-	proto.RegisterType((*SendActionRequest)(nil), "iotexapi.SendActionRequest")
-	proto.RegisterType((*SendActionResponse)(nil), "iotexapi.SendActionResponse")
-	proto.RegisterType((*GetReceiptByActionRequest)(nil), "iotexapi.GetReceiptByActionRequest")
-	proto.RegisterType((*GetReceiptByActionResponse)(nil), "iotexapi.GetReceiptByActionResponse")
-	proto.RegisterType((*ReadContractRequest)(nil), "iotexapi.ReadContractRequest")
-	proto.RegisterType((*ReadContractResponse)(nil), "iotexapi.ReadContractResponse")
-	proto.RegisterType((*SuggestGasPriceRequest)(nil), "iotexapi.SuggestGasPriceRequest")
-	proto.RegisterType((*SuggestGasPriceResponse)(nil), "iotexapi.SuggestGasPriceResponse")
-	proto.RegisterType((*EstimateGasForActionRequest)(nil), "iotexapi.EstimateGasForActionRequest")
-	proto.RegisterType((*EstimateGasForActionResponse)(nil), "iotexapi.EstimateGasForActionResponse")
-	proto.RegisterType((*ReadStateRequest)(nil), "iotexapi.ReadStateRequest")
-	proto.RegisterType((*ReadStateResponse)(nil), "iotexapi.ReadStateResponse")
-	proto.RegisterType((*GetEpochMetaRequest)(nil), "iotexapi.GetEpochMetaRequest")
-	proto.RegisterType((*GetEpochMetaResponse)(nil), "iotexapi.GetEpochMetaResponse")
+type StreamBlocksRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("proto/api/api.proto", fileDescriptor_ca6d5bbc959d58c0) }
+func (m *StreamBlocksRequest) Reset()         { *m = StreamBlocksRequest{} }
+func (m *StreamBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamBlocksRequest) ProtoMessage()    {}
+func (*StreamBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{36}
+}
 
-var fileDescriptor_ca6d5bbc959d58c0 = []byte{
-	// 1294 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x6d, 0x6f, 0x1b, 0x45,
-	0x10, 0x76, 0xe2, 0x34, 0xb1, 0xc7, 0x46, 0x34, 0x9b, 0xd4, 0x35, 0x97, 0xd0, 0x86, 0xa5, 0x85,
-	0x0a, 0xa9, 0x36, 0xb4, 0x69, 0x0b, 0x45, 0x14, 0xd9, 0x6d, 0x92, 0xba, 0x15, 0x4d, 0x74, 0xa1,
-	0x08, 0x10, 0x12, 0xdc, 0xcb, 0xc6, 0x3e, 0x62, 0xdf, 0x1e, 0x77, 0xeb, 0xa8, 0x11, 0xff, 0x86,
-	0x0f, 0xfc, 0x08, 0x7e, 0x58, 0x3f, 0xa3, 0xdb, 0xdd, 0xbb, 0x9b, 0x3b, 0x9f, 0x1d, 0x52, 0xf1,
-	0xa1, 0x52, 0x76, 0xe6, 0x99, 0x97, 0x7d, 0x76, 0xf6, 0xd9, 0x73, 0x61, 0x23, 0x08, 0xb9, 0xe0,
-	0x5d, 0x2b, 0xf0, 0xe2, 0x7f, 0x1d, 0xb9, 0x22, 0x35, 0x8f, 0x0b, 0xf6, 0xc6, 0x0a, 0x3c, 0xa3,
-	0xad, 0xdc, 0xe2, 0x3c, 0x60, 0x51, 0xd7, 0x72, 0x84, 0xc7, 0x7d, 0x85, 0x31, 0xb6, 0xb1, 0xc7,
-	0x1e, 0x73, 0xe7, 0xd4, 0x19, 0x59, 0x5e, 0xe2, 0x6d, 0x61, 0xaf, 0xcf, 0x5d, 0xa6, 0xed, 0x37,
-	0x87, 0x9c, 0x0f, 0xc7, 0xac, 0x2b, 0x57, 0xf6, 0xf4, 0xa4, 0x2b, 0xbc, 0x09, 0x8b, 0x84, 0x35,
-	0x09, 0x14, 0x80, 0xde, 0x85, 0xf5, 0x03, 0x26, 0x7a, 0x8e, 0xc3, 0xa7, 0xbe, 0x30, 0xd9, 0x1f,
-	0x53, 0x16, 0x09, 0xd2, 0x86, 0x35, 0xcb, 0x75, 0x43, 0x16, 0x45, 0xed, 0xa5, 0x9d, 0xa5, 0x3b,
-	0x75, 0x33, 0x59, 0xd2, 0x43, 0x20, 0x18, 0x1e, 0x05, 0xdc, 0x8f, 0x18, 0xf9, 0x0a, 0x1a, 0x96,
-	0x32, 0x7d, 0xc7, 0x84, 0x25, 0x63, 0x1a, 0xf7, 0xae, 0x77, 0xe4, 0xae, 0x64, 0x4b, 0x9d, 0x5e,
-	0xe6, 0x36, 0x31, 0x96, 0xbe, 0x5d, 0xd6, 0x0d, 0xc4, 0x5b, 0x8d, 0x92, 0x06, 0x9e, 0xc0, 0x9a,
-	0x7d, 0x3e, 0xf0, 0x5d, 0xf6, 0x46, 0x27, 0xa3, 0x9d, 0x84, 0xa2, 0x4e, 0x86, 0xee, 0x2b, 0x88,
-	0x0e, 0x7a, 0x5e, 0x31, 0x93, 0x20, 0xf2, 0x18, 0x56, 0xed, 0xf3, 0xe7, 0x56, 0x34, 0x6a, 0x2f,
-	0xcb, 0xf0, 0x9d, 0x92, 0xf0, 0xbe, 0x04, 0x64, 0xc1, 0x3a, 0x82, 0x3c, 0x89, 0x63, 0x7b, 0xae,
-	0x1b, 0xb6, 0xab, 0x32, 0xf6, 0x56, 0x79, 0xe9, 0x9e, 0x62, 0x24, 0x17, 0x1f, 0xdb, 0xc8, 0xaf,
-	0xb0, 0x3e, 0xf5, 0x1d, 0xee, 0x9f, 0x78, 0xe1, 0x84, 0xb9, 0x0a, 0xd8, 0x5e, 0x91, 0xa9, 0xba,
-	0xb9, 0x54, 0xaf, 0x33, 0xd4, 0xfc, 0xac, 0xb3, 0xb9, 0xc8, 0x63, 0xb8, 0x62, 0x9f, 0xf7, 0xc7,
-	0xa7, 0xed, 0x2b, 0x8b, 0xa8, 0xe9, 0xc7, 0x23, 0x92, 0xe5, 0x51, 0x21, 0xfd, 0x1a, 0xac, 0x8e,
-	0x39, 0x3f, 0x9d, 0x06, 0x74, 0x1f, 0xda, 0xf3, 0x98, 0x24, 0x9b, 0x70, 0x25, 0x12, 0x56, 0x28,
-	0x24, 0xf9, 0x2b, 0xa6, 0x5a, 0xc4, 0x56, 0x79, 0x6e, 0x92, 0xd3, 0x15, 0x53, 0x2d, 0xe8, 0x2f,
-	0xd0, 0x2a, 0xa7, 0x94, 0xdc, 0x00, 0x50, 0x13, 0x2c, 0x0f, 0x42, 0x0d, 0x12, 0xb2, 0x10, 0x0a,
-	0x4d, 0x67, 0xc4, 0x9c, 0xd3, 0x23, 0xe6, 0xbb, 0x9e, 0x3f, 0x94, 0x69, 0x6b, 0x66, 0xce, 0x46,
-	0x6d, 0x30, 0xe6, 0x93, 0x3e, 0x7f, 0x4e, 0xb3, 0x1d, 0x2c, 0x97, 0xee, 0xa0, 0x8a, 0x77, 0x30,
-	0x81, 0xdb, 0xff, 0xe9, 0x34, 0xfe, 0xa7, 0x72, 0xbf, 0xe5, 0x89, 0xc7, 0xe7, 0x14, 0x57, 0xb0,
-	0xc7, 0xa7, 0x88, 0xaf, 0x64, 0x79, 0xa9, 0x0a, 0x7f, 0x2f, 0x01, 0xa8, 0xfc, 0x03, 0xff, 0x84,
-	0x93, 0xcf, 0x60, 0x55, 0xb1, 0xae, 0xef, 0x12, 0xc9, 0x5f, 0xcc, 0xd8, 0x63, 0x6a, 0x84, 0xdc,
-	0xa2, 0x23, 0xd2, 0x9b, 0x13, 0x6f, 0x51, 0x2d, 0x71, 0x6b, 0xd5, 0x7c, 0x6b, 0x5f, 0x42, 0x3d,
-	0x55, 0x15, 0x3d, 0xe8, 0x46, 0x47, 0xe9, 0x4e, 0x27, 0xd1, 0x9d, 0xce, 0xf7, 0x09, 0xc2, 0xcc,
-	0xc0, 0xf4, 0x07, 0x68, 0x98, 0xcc, 0x61, 0x5e, 0x20, 0x64, 0xa3, 0x77, 0x61, 0x2d, 0x54, 0x4b,
-	0xdd, 0xe9, 0x06, 0xee, 0x54, 0x23, 0xcd, 0x04, 0x83, 0x3b, 0x5a, 0xce, 0x75, 0x44, 0xff, 0x84,
-	0x75, 0x49, 0xeb, 0x51, 0xc8, 0xdd, 0xa9, 0xc3, 0x42, 0x99, 0x7d, 0xe1, 0xe9, 0x9d, 0x71, 0xc1,
-	0x22, 0x9d, 0x46, 0x2d, 0x48, 0x4b, 0xd1, 0x76, 0xc6, 0xe4, 0x7e, 0x6b, 0xa6, 0x5e, 0xc5, 0x63,
-	0x1d, 0xc8, 0xbc, 0x92, 0xd2, 0x15, 0x49, 0x3c, 0xb2, 0xd0, 0x17, 0x5a, 0x22, 0xb5, 0xa0, 0x69,
-	0x89, 0xdc, 0x4d, 0x2e, 0x43, 0xdc, 0x4b, 0x7b, 0x69, 0xa7, 0x7a, 0xa7, 0x71, 0x6f, 0x33, 0xbb,
-	0xb9, 0xd9, 0x71, 0x99, 0x08, 0x47, 0xff, 0x5a, 0x82, 0xcd, 0x03, 0x26, 0xe4, 0x66, 0x62, 0xb9,
-	0x4c, 0x47, 0xb1, 0x57, 0x14, 0xc8, 0xdb, 0x39, 0x15, 0xc8, 0x02, 0xe6, 0x6b, 0xe4, 0x37, 0x05,
-	0x8d, 0xfc, 0xb8, 0x3c, 0xc3, 0x1c, 0x99, 0x44, 0x4a, 0x32, 0x80, 0xad, 0x05, 0x25, 0x2f, 0x25,
-	0x26, 0x0f, 0xe0, 0x83, 0xb9, 0xb5, 0xe7, 0x5f, 0x0e, 0xfa, 0x02, 0xae, 0x15, 0x58, 0xd2, 0xac,
-	0x7f, 0x01, 0x35, 0x7b, 0xac, 0x6c, 0x9a, 0xf3, 0x6b, 0x78, 0xa4, 0xd2, 0x08, 0x33, 0x85, 0xd1,
-	0x6b, 0xb0, 0x71, 0xc0, 0xc4, 0xd3, 0xf8, 0x6d, 0x95, 0x1e, 0x55, 0x9c, 0xbe, 0x94, 0x07, 0x81,
-	0xcc, 0xba, 0xc2, 0x7d, 0xa8, 0x3b, 0x89, 0x51, 0x1f, 0x45, 0xae, 0x44, 0x16, 0x91, 0xe1, 0x68,
-	0x4b, 0x26, 0x3b, 0x66, 0xe1, 0x19, 0x0b, 0x71, 0x91, 0x43, 0xb9, 0x0f, 0x6c, 0xd7, 0x55, 0x1e,
-	0x02, 0x44, 0xa9, 0x55, 0x97, 0x69, 0xe1, 0x32, 0x28, 0x06, 0x21, 0xe9, 0xb7, 0xb0, 0x7e, 0xcc,
-	0x7c, 0x2d, 0x68, 0x09, 0x8f, 0x97, 0xd0, 0x03, 0xba, 0x0b, 0x04, 0x27, 0xd0, 0xed, 0x5c, 0xa0,
-	0xec, 0xf4, 0x6b, 0x79, 0x8c, 0xfa, 0xc2, 0xf6, 0xcf, 0xf3, 0xe5, 0x2f, 0x0a, 0x7e, 0x2d, 0x25,
-	0x7f, 0x26, 0x58, 0x97, 0x7e, 0x04, 0x8d, 0x30, 0x93, 0x8c, 0x3c, 0xe3, 0xf1, 0xe8, 0x22, 0x3d,
-	0x31, 0x31, 0x92, 0xf6, 0x60, 0xc3, 0x64, 0x96, 0xfb, 0x94, 0xfb, 0x22, 0xb4, 0x1c, 0xf1, 0x2e,
-	0x64, 0xfc, 0x04, 0x9b, 0xf9, 0x14, 0xba, 0x27, 0x02, 0x2b, 0xae, 0xa5, 0xcf, 0xa5, 0x6e, 0xca,
-	0xbf, 0xb1, 0x96, 0x2d, 0x5f, 0xac, 0x65, 0xb4, 0x0d, 0xad, 0xe3, 0xe9, 0x70, 0xc8, 0x22, 0x71,
-	0x60, 0x45, 0x47, 0xa1, 0xe7, 0xb0, 0x64, 0x26, 0x1e, 0xc0, 0xf5, 0x19, 0x8f, 0xae, 0x6b, 0x40,
-	0x6d, 0xa8, 0x6d, 0xfa, 0x72, 0xa5, 0xeb, 0xf8, 0x52, 0xee, 0x45, 0xc2, 0x9b, 0x58, 0x82, 0x1d,
-	0x58, 0xd1, 0x3e, 0x0f, 0xdf, 0x7d, 0x06, 0x3e, 0x87, 0xed, 0xf2, 0x54, 0xba, 0x8d, 0xab, 0x50,
-	0x1d, 0x5a, 0x91, 0xee, 0x20, 0xfe, 0x93, 0x06, 0x70, 0x35, 0x26, 0xea, 0x58, 0x58, 0x82, 0xa1,
-	0x63, 0x97, 0x8f, 0x81, 0xc3, 0xc7, 0x83, 0x67, 0x12, 0xdc, 0x34, 0x91, 0x25, 0xf6, 0x4f, 0x98,
-	0x18, 0x71, 0xf7, 0x95, 0x35, 0x61, 0x92, 0xb3, 0xa6, 0x89, 0x2c, 0x64, 0x1b, 0xea, 0x56, 0x38,
-	0x9c, 0x4e, 0x98, 0x2f, 0xa2, 0x76, 0x75, 0xa7, 0x7a, 0xa7, 0x69, 0x66, 0x06, 0xfa, 0x29, 0xac,
-	0xa3, 0x8a, 0x25, 0xe7, 0xd2, 0x54, 0xe7, 0x42, 0x1f, 0xc9, 0xeb, 0xbd, 0x17, 0x70, 0x67, 0x84,
-	0x6e, 0x1e, 0xd9, 0x81, 0x06, 0x8b, 0x6d, 0xaf, 0xa6, 0x13, 0x9b, 0x85, 0x7a, 0x2f, 0xd8, 0x44,
-	0xff, 0x51, 0x52, 0x8c, 0x22, 0x33, 0x05, 0x90, 0xb8, 0x67, 0x56, 0xb9, 0x02, 0xec, 0x25, 0x4e,
-	0x33, 0xc3, 0xc5, 0xf5, 0x04, 0x17, 0xd6, 0x58, 0x2a, 0x50, 0xa4, 0x45, 0x10, 0x9b, 0xc8, 0x4b,
-	0x20, 0x36, 0x7e, 0xc3, 0x22, 0x39, 0xef, 0x55, 0x29, 0x62, 0x5b, 0xd9, 0xbc, 0xcf, 0xbc, 0x73,
-	0x66, 0x49, 0xd8, 0xbd, 0xb7, 0x6b, 0x00, 0xbd, 0xa3, 0x41, 0xac, 0x12, 0x9e, 0xc3, 0xc8, 0x00,
-	0x20, 0xfb, 0x8a, 0x27, 0x5b, 0x85, 0x0f, 0x48, 0xfc, 0x53, 0xc0, 0xd8, 0x2e, 0x77, 0xaa, 0xbd,
-	0xd3, 0x4a, 0x9a, 0x4a, 0xbe, 0x76, 0x33, 0xa9, 0xf0, 0x47, 0xfd, 0x4c, 0xaa, 0xdc, 0x03, 0x49,
-	0x2b, 0xc4, 0x84, 0xf7, 0x72, 0x2a, 0x4e, 0x6e, 0xcc, 0x79, 0xd3, 0x92, 0x84, 0x37, 0xe7, 0xfa,
-	0xd3, 0x9c, 0x87, 0xd0, 0xc4, 0xb2, 0x4d, 0x3e, 0xcc, 0x85, 0x14, 0x55, 0xde, 0xb8, 0x31, 0xcf,
-	0x5d, 0x68, 0x32, 0x93, 0xdb, 0x42, 0x93, 0x33, 0x9a, 0x5e, 0x68, 0x72, 0x56, 0xdb, 0x15, 0x87,
-	0x99, 0xc8, 0x62, 0x0e, 0x67, 0xb4, 0x1b, 0x73, 0x38, 0xab, 0xcb, 0xb4, 0x42, 0x2c, 0xf9, 0xf1,
-	0x51, 0x10, 0x4f, 0x92, 0x7f, 0xda, 0xcb, 0x75, 0xd9, 0xb8, 0xb5, 0x18, 0x84, 0x29, 0xc5, 0x2a,
-	0x88, 0x29, 0x2d, 0x11, 0x58, 0x4c, 0x69, 0x99, 0x78, 0xd2, 0x0a, 0xf9, 0x11, 0xde, 0x2f, 0x28,
-	0x1c, 0x41, 0xbf, 0xd7, 0xca, 0x65, 0xd1, 0xf8, 0x68, 0x01, 0x22, 0xcd, 0x3c, 0x84, 0xcd, 0x32,
-	0xe5, 0x22, 0xe8, 0x63, 0x69, 0x81, 0x48, 0x1a, 0x9f, 0x5c, 0x04, 0x4b, 0x0b, 0xed, 0x43, 0x3d,
-	0x95, 0x1f, 0x62, 0xe4, 0x77, 0x8c, 0x55, 0xd0, 0xd8, 0x2a, 0xf5, 0x15, 0xc6, 0x35, 0xd5, 0x98,
-	0xc2, 0xb8, 0x16, 0x55, 0xab, 0x30, 0xae, 0x33, 0xd2, 0x44, 0x2b, 0xfd, 0x87, 0x3f, 0xef, 0x0e,
-	0x3d, 0x31, 0x9a, 0xda, 0x1d, 0x87, 0x4f, 0xba, 0x12, 0x1d, 0x84, 0xfc, 0x77, 0xe6, 0x08, 0xb5,
-	0xb8, 0xeb, 0xf0, 0x50, 0xff, 0xef, 0xc0, 0x90, 0xf9, 0xdd, 0x24, 0x9d, 0xbd, 0x2a, 0x4d, 0xf7,
-	0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x80, 0x85, 0x7c, 0x01, 0xaf, 0x10, 0x00, 0x00,
+func (m *StreamBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamBlocksRequest.Unmarshal(m, b)
+}
+func (m *StreamBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamBlocksRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamBlocksRequest.Merge(m, src)
+}
+func (m *StreamBlocksRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamBlocksRequest.Size(m)
+}
+func (m *StreamBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamBlocksRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_StreamBlocksRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type StreamBlocksResponse struct {
+	Block                *iotextypes.Block     `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Receipts             []*iotextypes.Receipt `protobuf:"bytes,2,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
 
-// APIServiceClient is the client API for APIService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type APIServiceClient interface {
-	// get the address detail of an address
-	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error)
-	// get action(s) by:
-	// 1. start index and action count
-	// 2. action hash
-	// 3. address with start index and action count
-	// 4. get unconfirmed actions by address with start index and action count
-	// 5. block hash with start index and action count
-	GetActions(ctx context.Context, in *GetActionsRequest, opts ...grpc.CallOption) (*GetActionsResponse, error)
-	// get block metadata(s) by:
+func (m *StreamBlocksResponse) Reset()         { *m = StreamBlocksResponse{} }
+func (m *StreamBlocksResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamBlocksResponse) ProtoMessage()    {}
+func (*StreamBlocksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{37}
+}
+
+func (m *StreamBlocksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamBlocksResponse.Unmarshal(m, b)
+}
+func (m *StreamBlocksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamBlocksResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamBlocksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamBlocksResponse.Merge(m, src)
+}
+func (m *StreamBlocksResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamBlocksResponse.Size(m)
+}
+func (m *StreamBlocksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamBlocksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamBlocksResponse proto.InternalMessageInfo
+
+func (m *StreamBlocksResponse) GetBlock() *iotextypes.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *StreamBlocksResponse) GetReceipts() []*iotextypes.Receipt {
+	if m != nil {
+		return m.Receipts
+	}
+	return nil
+}
+
+// LogsFilter matches logs emitted by any of the given contract addresses, where
+// topics[i] is the set of accepted values at topic position i (empty matches any)
+type LogsFilter struct {
+	Address              []string  `protobuf:"bytes,1,rep,name=address,proto3" json:"address,omitempty"`
+	Topics               []*Topics `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *LogsFilter) Reset()         { *m = LogsFilter{} }
+func (m *LogsFilter) String() string { return proto.CompactTextString(m) }
+func (*LogsFilter) ProtoMessage()    {}
+func (*LogsFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{38}
+}
+
+func (m *LogsFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogsFilter.Unmarshal(m, b)
+}
+func (m *LogsFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogsFilter.Marshal(b, m, deterministic)
+}
+func (m *LogsFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogsFilter.Merge(m, src)
+}
+func (m *LogsFilter) XXX_Size() int {
+	return xxx_messageInfo_LogsFilter.Size(m)
+}
+func (m *LogsFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogsFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogsFilter proto.InternalMessageInfo
+
+func (m *LogsFilter) GetAddress() []string {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *LogsFilter) GetTopics() []*Topics {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+type Topics struct {
+	Topic                [][]byte `protobuf:"bytes,1,rep,name=topic,proto3" json:"topic,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Topics) Reset()         { *m = Topics{} }
+func (m *Topics) String() string { return proto.CompactTextString(m) }
+func (*Topics) ProtoMessage()    {}
+func (*Topics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{39}
+}
+
+func (m *Topics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Topics.Unmarshal(m, b)
+}
+func (m *Topics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Topics.Marshal(b, m, deterministic)
+}
+func (m *Topics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Topics.Merge(m, src)
+}
+func (m *Topics) XXX_Size() int {
+	return xxx_messageInfo_Topics.Size(m)
+}
+func (m *Topics) XXX_DiscardUnknown() {
+	xxx_messageInfo_Topics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Topics proto.InternalMessageInfo
+
+func (m *Topics) GetTopic() [][]byte {
+	if m != nil {
+		return m.Topic
+	}
+	return nil
+}
+
+type StreamLogsRequest struct {
+	Filter               *LogsFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *StreamLogsRequest) Reset()         { *m = StreamLogsRequest{} }
+func (m *StreamLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamLogsRequest) ProtoMessage()    {}
+func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{40}
+}
+
+func (m *StreamLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamLogsRequest.Unmarshal(m, b)
+}
+func (m *StreamLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamLogsRequest.Merge(m, src)
+}
+func (m *StreamLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamLogsRequest.Size(m)
+}
+func (m *StreamLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamLogsRequest proto.InternalMessageInfo
+
+func (m *StreamLogsRequest) GetFilter() *LogsFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type StreamLogsResponse struct {
+	Log                  *iotextypes.Log `protobuf:"bytes,1,opt,name=log,proto3" json:"log,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *StreamLogsResponse) Reset()         { *m = StreamLogsResponse{} }
+func (m *StreamLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamLogsResponse) ProtoMessage()    {}
+func (*StreamLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{41}
+}
+
+func (m *StreamLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamLogsResponse.Unmarshal(m, b)
+}
+func (m *StreamLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamLogsResponse.Merge(m, src)
+}
+func (m *StreamLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamLogsResponse.Size(m)
+}
+func (m *StreamLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamLogsResponse proto.InternalMessageInfo
+
+func (m *StreamLogsResponse) GetLog() *iotextypes.Log {
+	if m != nil {
+		return m.Log
+	}
+	return nil
+}
+
+type GetLogsRequest struct {
+	Filter *LogsFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// fromBlock defaults to 1, toBlock defaults to the tip height
+	FromBlock            uint64   `protobuf:"varint,2,opt,name=fromBlock,proto3" json:"fromBlock,omitempty"`
+	ToBlock              uint64   `protobuf:"varint,3,opt,name=toBlock,proto3" json:"toBlock,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLogsRequest) Reset()         { *m = GetLogsRequest{} }
+func (m *GetLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLogsRequest) ProtoMessage()    {}
+func (*GetLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{42}
+}
+
+func (m *GetLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogsRequest.Unmarshal(m, b)
+}
+func (m *GetLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogsRequest.Merge(m, src)
+}
+func (m *GetLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLogsRequest.Size(m)
+}
+func (m *GetLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogsRequest proto.InternalMessageInfo
+
+func (m *GetLogsRequest) GetFilter() *LogsFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *GetLogsRequest) GetFromBlock() uint64 {
+	if m != nil {
+		return m.FromBlock
+	}
+	return 0
+}
+
+func (m *GetLogsRequest) GetToBlock() uint64 {
+	if m != nil {
+		return m.ToBlock
+	}
+	return 0
+}
+
+type GetLogsResponse struct {
+	Logs                 []*iotextypes.Log `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetLogsResponse) Reset()         { *m = GetLogsResponse{} }
+func (m *GetLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLogsResponse) ProtoMessage()    {}
+func (*GetLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{43}
+}
+
+func (m *GetLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogsResponse.Unmarshal(m, b)
+}
+func (m *GetLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogsResponse.Merge(m, src)
+}
+func (m *GetLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLogsResponse.Size(m)
+}
+func (m *GetLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogsResponse proto.InternalMessageInfo
+
+func (m *GetLogsResponse) GetLogs() []*iotextypes.Log {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+type GetAccountProofRequest struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// height defaults to the tip height
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAccountProofRequest) Reset()         { *m = GetAccountProofRequest{} }
+func (m *GetAccountProofRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountProofRequest) ProtoMessage()    {}
+func (*GetAccountProofRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{44}
+}
+
+func (m *GetAccountProofRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccountProofRequest.Unmarshal(m, b)
+}
+func (m *GetAccountProofRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccountProofRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAccountProofRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccountProofRequest.Merge(m, src)
+}
+func (m *GetAccountProofRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAccountProofRequest.Size(m)
+}
+func (m *GetAccountProofRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccountProofRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccountProofRequest proto.InternalMessageInfo
+
+func (m *GetAccountProofRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *GetAccountProofRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// GetAccountProofResponse carries the serialized trie nodes from the state root to the account,
+// where the value of the last node is the serialized account state if the account exists
+type GetAccountProofResponse struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	StateRoot            []byte   `protobuf:"bytes,2,opt,name=stateRoot,proto3" json:"stateRoot,omitempty"`
+	Proof                [][]byte `protobuf:"bytes,3,rep,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAccountProofResponse) Reset()         { *m = GetAccountProofResponse{} }
+func (m *GetAccountProofResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAccountProofResponse) ProtoMessage()    {}
+func (*GetAccountProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{45}
+}
+
+func (m *GetAccountProofResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccountProofResponse.Unmarshal(m, b)
+}
+func (m *GetAccountProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccountProofResponse.Marshal(b, m, deterministic)
+}
+func (m *GetAccountProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccountProofResponse.Merge(m, src)
+}
+func (m *GetAccountProofResponse) XXX_Size() int {
+	return xxx_messageInfo_GetAccountProofResponse.Size(m)
+}
+func (m *GetAccountProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccountProofResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccountProofResponse proto.InternalMessageInfo
+
+func (m *GetAccountProofResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetAccountProofResponse) GetStateRoot() []byte {
+	if m != nil {
+		return m.StateRoot
+	}
+	return nil
+}
+
+func (m *GetAccountProofResponse) GetProof() [][]byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+type TraceActionRequest struct {
+	ActionHash string `protobuf:"bytes,1,opt,name=actionHash,proto3" json:"actionHash,omitempty"`
+	// disable capturing the memory and stack in struct logs
+	DisableMemory        bool     `protobuf:"varint,2,opt,name=disableMemory,proto3" json:"disableMemory,omitempty"`
+	DisableStack         bool     `protobuf:"varint,3,opt,name=disableStack,proto3" json:"disableStack,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TraceActionRequest) Reset()         { *m = TraceActionRequest{} }
+func (m *TraceActionRequest) String() string { return proto.CompactTextString(m) }
+func (*TraceActionRequest) ProtoMessage()    {}
+func (*TraceActionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{46}
+}
+
+func (m *TraceActionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceActionRequest.Unmarshal(m, b)
+}
+func (m *TraceActionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceActionRequest.Marshal(b, m, deterministic)
+}
+func (m *TraceActionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceActionRequest.Merge(m, src)
+}
+func (m *TraceActionRequest) XXX_Size() int {
+	return xxx_messageInfo_TraceActionRequest.Size(m)
+}
+func (m *TraceActionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceActionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceActionRequest proto.InternalMessageInfo
+
+func (m *TraceActionRequest) GetActionHash() string {
+	if m != nil {
+		return m.ActionHash
+	}
+	return ""
+}
+
+func (m *TraceActionRequest) GetDisableMemory() bool {
+	if m != nil {
+		return m.DisableMemory
+	}
+	return false
+}
+
+func (m *TraceActionRequest) GetDisableStack() bool {
+	if m != nil {
+		return m.DisableStack
+	}
+	return false
+}
+
+// StructLog is the VM state prior to the execution of an opcode
+type StructLog struct {
+	Pc                   uint64   `protobuf:"varint,1,opt,name=pc,proto3" json:"pc,omitempty"`
+	Op                   string   `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`
+	Gas                  uint64   `protobuf:"varint,3,opt,name=gas,proto3" json:"gas,omitempty"`
+	GasCost              uint64   `protobuf:"varint,4,opt,name=gasCost,proto3" json:"gasCost,omitempty"`
+	Memory               []byte   `protobuf:"bytes,5,opt,name=memory,proto3" json:"memory,omitempty"`
+	Stack                []string `protobuf:"bytes,6,rep,name=stack,proto3" json:"stack,omitempty"`
+	Depth                int32    `protobuf:"varint,7,opt,name=depth,proto3" json:"depth,omitempty"`
+	Error                string   `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StructLog) Reset()         { *m = StructLog{} }
+func (m *StructLog) String() string { return proto.CompactTextString(m) }
+func (*StructLog) ProtoMessage()    {}
+func (*StructLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{47}
+}
+
+func (m *StructLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StructLog.Unmarshal(m, b)
+}
+func (m *StructLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StructLog.Marshal(b, m, deterministic)
+}
+func (m *StructLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StructLog.Merge(m, src)
+}
+func (m *StructLog) XXX_Size() int {
+	return xxx_messageInfo_StructLog.Size(m)
+}
+func (m *StructLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_StructLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StructLog proto.InternalMessageInfo
+
+func (m *StructLog) GetPc() uint64 {
+	if m != nil {
+		return m.Pc
+	}
+	return 0
+}
+
+func (m *StructLog) GetOp() string {
+	if m != nil {
+		return m.Op
+	}
+	return ""
+}
+
+func (m *StructLog) GetGas() uint64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func (m *StructLog) GetGasCost() uint64 {
+	if m != nil {
+		return m.GasCost
+	}
+	return 0
+}
+
+func (m *StructLog) GetMemory() []byte {
+	if m != nil {
+		return m.Memory
+	}
+	return nil
+}
+
+func (m *StructLog) GetStack() []string {
+	if m != nil {
+		return m.Stack
+	}
+	return nil
+}
+
+func (m *StructLog) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *StructLog) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// CallFrame is a node of the call tree of an execution
+type CallFrame struct {
+	Type                 string       `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	From                 string       `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   string       `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Value                string       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Gas                  uint64       `protobuf:"varint,5,opt,name=gas,proto3" json:"gas,omitempty"`
+	GasUsed              uint64       `protobuf:"varint,6,opt,name=gasUsed,proto3" json:"gasUsed,omitempty"`
+	Input                []byte       `protobuf:"bytes,7,opt,name=input,proto3" json:"input,omitempty"`
+	Output               []byte       `protobuf:"bytes,8,opt,name=output,proto3" json:"output,omitempty"`
+	Error                string       `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	Calls                []*CallFrame `protobuf:"bytes,10,rep,name=calls,proto3" json:"calls,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CallFrame) Reset()         { *m = CallFrame{} }
+func (m *CallFrame) String() string { return proto.CompactTextString(m) }
+func (*CallFrame) ProtoMessage()    {}
+func (*CallFrame) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{48}
+}
+
+func (m *CallFrame) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallFrame.Unmarshal(m, b)
+}
+func (m *CallFrame) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallFrame.Marshal(b, m, deterministic)
+}
+func (m *CallFrame) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallFrame.Merge(m, src)
+}
+func (m *CallFrame) XXX_Size() int {
+	return xxx_messageInfo_CallFrame.Size(m)
+}
+func (m *CallFrame) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallFrame.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallFrame proto.InternalMessageInfo
+
+func (m *CallFrame) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *CallFrame) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *CallFrame) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *CallFrame) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *CallFrame) GetGas() uint64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func (m *CallFrame) GetGasUsed() uint64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+func (m *CallFrame) GetInput() []byte {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+func (m *CallFrame) GetOutput() []byte {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *CallFrame) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CallFrame) GetCalls() []*CallFrame {
+	if m != nil {
+		return m.Calls
+	}
+	return nil
+}
+
+type TraceActionResponse struct {
+	Receipt              *iotextypes.Receipt `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	Output               []byte              `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	StructLogs           []*StructLog        `protobuf:"bytes,3,rep,name=structLogs,proto3" json:"structLogs,omitempty"`
+	CallTrace            *CallFrame          `protobuf:"bytes,4,opt,name=callTrace,proto3" json:"callTrace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *TraceActionResponse) Reset()         { *m = TraceActionResponse{} }
+func (m *TraceActionResponse) String() string { return proto.CompactTextString(m) }
+func (*TraceActionResponse) ProtoMessage()    {}
+func (*TraceActionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{49}
+}
+
+func (m *TraceActionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceActionResponse.Unmarshal(m, b)
+}
+func (m *TraceActionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceActionResponse.Marshal(b, m, deterministic)
+}
+func (m *TraceActionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceActionResponse.Merge(m, src)
+}
+func (m *TraceActionResponse) XXX_Size() int {
+	return xxx_messageInfo_TraceActionResponse.Size(m)
+}
+func (m *TraceActionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceActionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceActionResponse proto.InternalMessageInfo
+
+func (m *TraceActionResponse) GetReceipt() *iotextypes.Receipt {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+func (m *TraceActionResponse) GetOutput() []byte {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *TraceActionResponse) GetStructLogs() []*StructLog {
+	if m != nil {
+		return m.StructLogs
+	}
+	return nil
+}
+
+func (m *TraceActionResponse) GetCallTrace() *CallFrame {
+	if m != nil {
+		return m.CallTrace
+	}
+	return nil
+}
+
+type GetActPoolStatusRequest struct {
+	// address returns the status of a single sender, otherwise the senders in range [start, start+count)
+	// ordered by address are returned
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Start                uint64   `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	Count                uint64   `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetActPoolStatusRequest) Reset()         { *m = GetActPoolStatusRequest{} }
+func (m *GetActPoolStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetActPoolStatusRequest) ProtoMessage()    {}
+func (*GetActPoolStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{50}
+}
+
+func (m *GetActPoolStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetActPoolStatusRequest.Unmarshal(m, b)
+}
+func (m *GetActPoolStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetActPoolStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetActPoolStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetActPoolStatusRequest.Merge(m, src)
+}
+func (m *GetActPoolStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetActPoolStatusRequest.Size(m)
+}
+func (m *GetActPoolStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetActPoolStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetActPoolStatusRequest proto.InternalMessageInfo
+
+func (m *GetActPoolStatusRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *GetActPoolStatusRequest) GetStart() uint64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *GetActPoolStatusRequest) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// NonceRange is the range of nonces [start, end]
+type NonceRange struct {
+	Start                uint64   `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  uint64   `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NonceRange) Reset()         { *m = NonceRange{} }
+func (m *NonceRange) String() string { return proto.CompactTextString(m) }
+func (*NonceRange) ProtoMessage()    {}
+func (*NonceRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{51}
+}
+
+func (m *NonceRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NonceRange.Unmarshal(m, b)
+}
+func (m *NonceRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NonceRange.Marshal(b, m, deterministic)
+}
+func (m *NonceRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NonceRange.Merge(m, src)
+}
+func (m *NonceRange) XXX_Size() int {
+	return xxx_messageInfo_NonceRange.Size(m)
+}
+func (m *NonceRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_NonceRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NonceRange proto.InternalMessageInfo
+
+func (m *NonceRange) GetStart() uint64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *NonceRange) GetEnd() uint64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+// ActPoolAccountStatus is the status of the actions of a sender in the actpool, where the pending actions
+// are ready to be picked into the next block, and the queued actions wait for the nonce gaps before them
+type ActPoolAccountStatus struct {
+	Address              string        `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	PendingNonce         uint64        `protobuf:"varint,2,opt,name=pendingNonce,proto3" json:"pendingNonce,omitempty"`
+	PendingActionHashes  []string      `protobuf:"bytes,3,rep,name=pendingActionHashes,proto3" json:"pendingActionHashes,omitempty"`
+	QueuedActionHashes   []string      `protobuf:"bytes,4,rep,name=queuedActionHashes,proto3" json:"queuedActionHashes,omitempty"`
+	QueuedNonces         []uint64      `protobuf:"varint,5,rep,packed,name=queuedNonces,proto3" json:"queuedNonces,omitempty"`
+	NonceGaps            []*NonceRange `protobuf:"bytes,6,rep,name=nonceGaps,proto3" json:"nonceGaps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ActPoolAccountStatus) Reset()         { *m = ActPoolAccountStatus{} }
+func (m *ActPoolAccountStatus) String() string { return proto.CompactTextString(m) }
+func (*ActPoolAccountStatus) ProtoMessage()    {}
+func (*ActPoolAccountStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{52}
+}
+
+func (m *ActPoolAccountStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActPoolAccountStatus.Unmarshal(m, b)
+}
+func (m *ActPoolAccountStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActPoolAccountStatus.Marshal(b, m, deterministic)
+}
+func (m *ActPoolAccountStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActPoolAccountStatus.Merge(m, src)
+}
+func (m *ActPoolAccountStatus) XXX_Size() int {
+	return xxx_messageInfo_ActPoolAccountStatus.Size(m)
+}
+func (m *ActPoolAccountStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActPoolAccountStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActPoolAccountStatus proto.InternalMessageInfo
+
+func (m *ActPoolAccountStatus) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ActPoolAccountStatus) GetPendingNonce() uint64 {
+	if m != nil {
+		return m.PendingNonce
+	}
+	return 0
+}
+
+func (m *ActPoolAccountStatus) GetPendingActionHashes() []string {
+	if m != nil {
+		return m.PendingActionHashes
+	}
+	return nil
+}
+
+func (m *ActPoolAccountStatus) GetQueuedActionHashes() []string {
+	if m != nil {
+		return m.QueuedActionHashes
+	}
+	return nil
+}
+
+func (m *ActPoolAccountStatus) GetQueuedNonces() []uint64 {
+	if m != nil {
+		return m.QueuedNonces
+	}
+	return nil
+}
+
+func (m *ActPoolAccountStatus) GetNonceGaps() []*NonceRange {
+	if m != nil {
+		return m.NonceGaps
+	}
+	return nil
+}
+
+type GetActPoolStatusResponse struct {
+	Size                 uint64                  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Capacity             uint64                  `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	GasSize              uint64                  `protobuf:"varint,3,opt,name=gasSize,proto3" json:"gasSize,omitempty"`
+	GasCapacity          uint64                  `protobuf:"varint,4,opt,name=gasCapacity,proto3" json:"gasCapacity,omitempty"`
+	NumSenders           uint64                  `protobuf:"varint,5,opt,name=numSenders,proto3" json:"numSenders,omitempty"`
+	NumPending           uint64                  `protobuf:"varint,6,opt,name=numPending,proto3" json:"numPending,omitempty"`
+	NumQueued            uint64                  `protobuf:"varint,7,opt,name=numQueued,proto3" json:"numQueued,omitempty"`
+	Accounts             []*ActPoolAccountStatus `protobuf:"bytes,8,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetActPoolStatusResponse) Reset()         { *m = GetActPoolStatusResponse{} }
+func (m *GetActPoolStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetActPoolStatusResponse) ProtoMessage()    {}
+func (*GetActPoolStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{53}
+}
+
+func (m *GetActPoolStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetActPoolStatusResponse.Unmarshal(m, b)
+}
+func (m *GetActPoolStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetActPoolStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetActPoolStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetActPoolStatusResponse.Merge(m, src)
+}
+func (m *GetActPoolStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetActPoolStatusResponse.Size(m)
+}
+func (m *GetActPoolStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetActPoolStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetActPoolStatusResponse proto.InternalMessageInfo
+
+func (m *GetActPoolStatusResponse) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *GetActPoolStatusResponse) GetCapacity() uint64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *GetActPoolStatusResponse) GetGasSize() uint64 {
+	if m != nil {
+		return m.GasSize
+	}
+	return 0
+}
+
+func (m *GetActPoolStatusResponse) GetGasCapacity() uint64 {
+	if m != nil {
+		return m.GasCapacity
+	}
+	return 0
+}
+
+func (m *GetActPoolStatusResponse) GetNumSenders() uint64 {
+	if m != nil {
+		return m.NumSenders
+	}
+	return 0
+}
+
+func (m *GetActPoolStatusResponse) GetNumPending() uint64 {
+	if m != nil {
+		return m.NumPending
+	}
+	return 0
+}
+
+func (m *GetActPoolStatusResponse) GetNumQueued() uint64 {
+	if m != nil {
+		return m.NumQueued
+	}
+	return 0
+}
+
+func (m *GetActPoolStatusResponse) GetAccounts() []*ActPoolAccountStatus {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+type StreamPendingActionsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamPendingActionsRequest) Reset()         { *m = StreamPendingActionsRequest{} }
+func (m *StreamPendingActionsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamPendingActionsRequest) ProtoMessage()    {}
+func (*StreamPendingActionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{54}
+}
+
+func (m *StreamPendingActionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamPendingActionsRequest.Unmarshal(m, b)
+}
+func (m *StreamPendingActionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamPendingActionsRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamPendingActionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamPendingActionsRequest.Merge(m, src)
+}
+func (m *StreamPendingActionsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamPendingActionsRequest.Size(m)
+}
+func (m *StreamPendingActionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamPendingActionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamPendingActionsRequest proto.InternalMessageInfo
+
+type StreamPendingActionsResponse struct {
+	ActionHash           string   `protobuf:"bytes,1,opt,name=actionHash,proto3" json:"actionHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamPendingActionsResponse) Reset()         { *m = StreamPendingActionsResponse{} }
+func (m *StreamPendingActionsResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamPendingActionsResponse) ProtoMessage()    {}
+func (*StreamPendingActionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{55}
+}
+
+func (m *StreamPendingActionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamPendingActionsResponse.Unmarshal(m, b)
+}
+func (m *StreamPendingActionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamPendingActionsResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamPendingActionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamPendingActionsResponse.Merge(m, src)
+}
+func (m *StreamPendingActionsResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamPendingActionsResponse.Size(m)
+}
+func (m *StreamPendingActionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamPendingActionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamPendingActionsResponse proto.InternalMessageInfo
+
+func (m *StreamPendingActionsResponse) GetActionHash() string {
+	if m != nil {
+		return m.ActionHash
+	}
+	return ""
+}
+
+type GetConsensusEvidencesRequest struct {
+	// evidences of heights in range [startHeight, startHeight+count) are returned
+	StartHeight          uint64   `protobuf:"varint,1,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	Count                uint64   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConsensusEvidencesRequest) Reset()         { *m = GetConsensusEvidencesRequest{} }
+func (m *GetConsensusEvidencesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetConsensusEvidencesRequest) ProtoMessage()    {}
+func (*GetConsensusEvidencesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{56}
+}
+
+func (m *GetConsensusEvidencesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConsensusEvidencesRequest.Unmarshal(m, b)
+}
+func (m *GetConsensusEvidencesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConsensusEvidencesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetConsensusEvidencesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsensusEvidencesRequest.Merge(m, src)
+}
+func (m *GetConsensusEvidencesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetConsensusEvidencesRequest.Size(m)
+}
+func (m *GetConsensusEvidencesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsensusEvidencesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsensusEvidencesRequest proto.InternalMessageInfo
+
+func (m *GetConsensusEvidencesRequest) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *GetConsensusEvidencesRequest) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type GetConsensusEvidencesResponse struct {
+	Evidences            []*iotextypes.ConsensusEvidence `protobuf:"bytes,1,rep,name=evidences,proto3" json:"evidences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *GetConsensusEvidencesResponse) Reset()         { *m = GetConsensusEvidencesResponse{} }
+func (m *GetConsensusEvidencesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConsensusEvidencesResponse) ProtoMessage()    {}
+func (*GetConsensusEvidencesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{57}
+}
+
+func (m *GetConsensusEvidencesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConsensusEvidencesResponse.Unmarshal(m, b)
+}
+func (m *GetConsensusEvidencesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConsensusEvidencesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetConsensusEvidencesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsensusEvidencesResponse.Merge(m, src)
+}
+func (m *GetConsensusEvidencesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetConsensusEvidencesResponse.Size(m)
+}
+func (m *GetConsensusEvidencesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsensusEvidencesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsensusEvidencesResponse proto.InternalMessageInfo
+
+func (m *GetConsensusEvidencesResponse) GetEvidences() []*iotextypes.ConsensusEvidence {
+	if m != nil {
+		return m.Evidences
+	}
+	return nil
+}
+
+type GetConsensusTimelineRequest struct {
+	// the latest count rounds are returned
+	Count                uint64   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConsensusTimelineRequest) Reset()         { *m = GetConsensusTimelineRequest{} }
+func (m *GetConsensusTimelineRequest) String() string { return proto.CompactTextString(m) }
+func (*GetConsensusTimelineRequest) ProtoMessage()    {}
+func (*GetConsensusTimelineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{58}
+}
+
+func (m *GetConsensusTimelineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConsensusTimelineRequest.Unmarshal(m, b)
+}
+func (m *GetConsensusTimelineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConsensusTimelineRequest.Marshal(b, m, deterministic)
+}
+func (m *GetConsensusTimelineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsensusTimelineRequest.Merge(m, src)
+}
+func (m *GetConsensusTimelineRequest) XXX_Size() int {
+	return xxx_messageInfo_GetConsensusTimelineRequest.Size(m)
+}
+func (m *GetConsensusTimelineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsensusTimelineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsensusTimelineRequest proto.InternalMessageInfo
+
+func (m *GetConsensusTimelineRequest) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type GetConsensusTimelineResponse struct {
+	Rounds               []*iotextypes.ConsensusRound   `protobuf:"bytes,1,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	Liveness             []*iotextypes.DelegateLiveness `protobuf:"bytes,2,rep,name=liveness,proto3" json:"liveness,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *GetConsensusTimelineResponse) Reset()         { *m = GetConsensusTimelineResponse{} }
+func (m *GetConsensusTimelineResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConsensusTimelineResponse) ProtoMessage()    {}
+func (*GetConsensusTimelineResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{59}
+}
+
+func (m *GetConsensusTimelineResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConsensusTimelineResponse.Unmarshal(m, b)
+}
+func (m *GetConsensusTimelineResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConsensusTimelineResponse.Marshal(b, m, deterministic)
+}
+func (m *GetConsensusTimelineResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsensusTimelineResponse.Merge(m, src)
+}
+func (m *GetConsensusTimelineResponse) XXX_Size() int {
+	return xxx_messageInfo_GetConsensusTimelineResponse.Size(m)
+}
+func (m *GetConsensusTimelineResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsensusTimelineResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsensusTimelineResponse proto.InternalMessageInfo
+
+func (m *GetConsensusTimelineResponse) GetRounds() []*iotextypes.ConsensusRound {
+	if m != nil {
+		return m.Rounds
+	}
+	return nil
+}
+
+func (m *GetConsensusTimelineResponse) GetLiveness() []*iotextypes.DelegateLiveness {
+	if m != nil {
+		return m.Liveness
+	}
+	return nil
+}
+
+type GetTokenTransfersByAddressRequest struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Start   uint64 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	Count   uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// cursor is the nextCursor of the previous page, start is ignored if it is set
+	Cursor               string               `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,6,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetTokenTransfersByAddressRequest) Reset()         { *m = GetTokenTransfersByAddressRequest{} }
+func (m *GetTokenTransfersByAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTokenTransfersByAddressRequest) ProtoMessage()    {}
+func (*GetTokenTransfersByAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{60}
+}
+
+func (m *GetTokenTransfersByAddressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTokenTransfersByAddressRequest.Unmarshal(m, b)
+}
+func (m *GetTokenTransfersByAddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTokenTransfersByAddressRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTokenTransfersByAddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTokenTransfersByAddressRequest.Merge(m, src)
+}
+func (m *GetTokenTransfersByAddressRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTokenTransfersByAddressRequest.Size(m)
+}
+func (m *GetTokenTransfersByAddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTokenTransfersByAddressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTokenTransfersByAddressRequest proto.InternalMessageInfo
+
+func (m *GetTokenTransfersByAddressRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *GetTokenTransfersByAddressRequest) GetStart() uint64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *GetTokenTransfersByAddressRequest) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *GetTokenTransfersByAddressRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *GetTokenTransfersByAddressRequest) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *GetTokenTransfersByAddressRequest) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+type TokenTransfer struct {
+	ActionHash string               `protobuf:"bytes,1,opt,name=actionHash,proto3" json:"actionHash,omitempty"`
+	LogIndex   uint32               `protobuf:"varint,2,opt,name=logIndex,proto3" json:"logIndex,omitempty"`
+	BlkHeight  uint64               `protobuf:"varint,3,opt,name=blkHeight,proto3" json:"blkHeight,omitempty"`
+	Timestamp  *timestamp.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// token is the address of the token contract
+	Token                string   `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`
+	Sender               string   `protobuf:"bytes,6,opt,name=sender,proto3" json:"sender,omitempty"`
+	Recipient            string   `protobuf:"bytes,7,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Amount               string   `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenTransfer) Reset()         { *m = TokenTransfer{} }
+func (m *TokenTransfer) String() string { return proto.CompactTextString(m) }
+func (*TokenTransfer) ProtoMessage()    {}
+func (*TokenTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{61}
+}
+
+func (m *TokenTransfer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenTransfer.Unmarshal(m, b)
+}
+func (m *TokenTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenTransfer.Marshal(b, m, deterministic)
+}
+func (m *TokenTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenTransfer.Merge(m, src)
+}
+func (m *TokenTransfer) XXX_Size() int {
+	return xxx_messageInfo_TokenTransfer.Size(m)
+}
+func (m *TokenTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenTransfer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenTransfer proto.InternalMessageInfo
+
+func (m *TokenTransfer) GetActionHash() string {
+	if m != nil {
+		return m.ActionHash
+	}
+	return ""
+}
+
+func (m *TokenTransfer) GetLogIndex() uint32 {
+	if m != nil {
+		return m.LogIndex
+	}
+	return 0
+}
+
+func (m *TokenTransfer) GetBlkHeight() uint64 {
+	if m != nil {
+		return m.BlkHeight
+	}
+	return 0
+}
+
+func (m *TokenTransfer) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *TokenTransfer) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *TokenTransfer) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *TokenTransfer) GetRecipient() string {
+	if m != nil {
+		return m.Recipient
+	}
+	return ""
+}
+
+func (m *TokenTransfer) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+type GetTokenTransfersByAddressResponse struct {
+	Transfers []*TokenTransfer `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	// nextCursor is empty if there is no more
+	NextCursor           string   `protobuf:"bytes,2,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTokenTransfersByAddressResponse) Reset()         { *m = GetTokenTransfersByAddressResponse{} }
+func (m *GetTokenTransfersByAddressResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTokenTransfersByAddressResponse) ProtoMessage()    {}
+func (*GetTokenTransfersByAddressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{62}
+}
+
+func (m *GetTokenTransfersByAddressResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTokenTransfersByAddressResponse.Unmarshal(m, b)
+}
+func (m *GetTokenTransfersByAddressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTokenTransfersByAddressResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTokenTransfersByAddressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTokenTransfersByAddressResponse.Merge(m, src)
+}
+func (m *GetTokenTransfersByAddressResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTokenTransfersByAddressResponse.Size(m)
+}
+func (m *GetTokenTransfersByAddressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTokenTransfersByAddressResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTokenTransfersByAddressResponse proto.InternalMessageInfo
+
+func (m *GetTokenTransfersByAddressResponse) GetTransfers() []*TokenTransfer {
+	if m != nil {
+		return m.Transfers
+	}
+	return nil
+}
+
+func (m *GetTokenTransfersByAddressResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*GetAccountRequest)(nil), "iotexapi.GetAccountRequest")
+	proto.RegisterType((*GetAccountResponse)(nil), "iotexapi.GetAccountResponse")
+	proto.RegisterType((*GetActionsRequest)(nil), "iotexapi.GetActionsRequest")
+	proto.RegisterType((*GetActionsByIndexRequest)(nil), "iotexapi.GetActionsByIndexRequest")
+	proto.RegisterType((*GetActionByHashRequest)(nil), "iotexapi.GetActionByHashRequest")
+	proto.RegisterType((*GetActionsByAddressRequest)(nil), "iotexapi.GetActionsByAddressRequest")
+	proto.RegisterType((*GetUnconfirmedActionsByAddressRequest)(nil), "iotexapi.GetUnconfirmedActionsByAddressRequest")
+	proto.RegisterType((*GetActionsByBlockRequest)(nil), "iotexapi.GetActionsByBlockRequest")
+	proto.RegisterType((*ActionInfo)(nil), "iotexapi.ActionInfo")
+	proto.RegisterType((*ReceiptInfo)(nil), "iotexapi.ReceiptInfo")
+	proto.RegisterType((*BlockProducerInfo)(nil), "iotexapi.BlockProducerInfo")
+	proto.RegisterType((*GetActionsResponse)(nil), "iotexapi.GetActionsResponse")
+	proto.RegisterType((*GetBlockMetasRequest)(nil), "iotexapi.GetBlockMetasRequest")
+	proto.RegisterType((*GetBlockMetasByIndexRequest)(nil), "iotexapi.GetBlockMetasByIndexRequest")
+	proto.RegisterType((*GetBlockMetaByHashRequest)(nil), "iotexapi.GetBlockMetaByHashRequest")
+	proto.RegisterType((*GetBlockMetasResponse)(nil), "iotexapi.GetBlockMetasResponse")
+	proto.RegisterType((*GetChainMetaRequest)(nil), "iotexapi.GetChainMetaRequest")
+	proto.RegisterType((*GetChainMetaResponse)(nil), "iotexapi.GetChainMetaResponse")
+	proto.RegisterType((*GetServerMetaRequest)(nil), "iotexapi.GetServerMetaRequest")
+	proto.RegisterType((*GetServerMetaResponse)(nil), "iotexapi.GetServerMetaResponse")
+	proto.RegisterType((*SyncPeerStatus)(nil), "iotexapi.SyncPeerStatus")
+	proto.RegisterType((*SyncStatus)(nil), "iotexapi.SyncStatus")
+	proto.RegisterType((*SendActionRequest)(nil), "iotexapi.SendActionRequest")
+	proto.RegisterType((*SendActionResponse)(nil), "iotexapi.SendActionResponse")
+	proto.RegisterType((*GetReceiptByActionRequest)(nil), "iotexapi.GetReceiptByActionRequest")
+	proto.RegisterType((*GetReceiptByActionResponse)(nil), "iotexapi.GetReceiptByActionResponse")
+	proto.RegisterType((*ReadContractRequest)(nil), "iotexapi.ReadContractRequest")
+	proto.RegisterType((*ReadContractResponse)(nil), "iotexapi.ReadContractResponse")
+	proto.RegisterType((*SuggestGasPriceRequest)(nil), "iotexapi.SuggestGasPriceRequest")
+	proto.RegisterType((*SuggestGasPriceResponse)(nil), "iotexapi.SuggestGasPriceResponse")
+	proto.RegisterType((*EstimateGasForActionRequest)(nil), "iotexapi.EstimateGasForActionRequest")
+	proto.RegisterType((*EstimateGasForActionResponse)(nil), "iotexapi.EstimateGasForActionResponse")
+	proto.RegisterType((*ReadStateRequest)(nil), "iotexapi.ReadStateRequest")
+	proto.RegisterType((*ReadStateResponse)(nil), "iotexapi.ReadStateResponse")
+	proto.RegisterType((*GetEpochMetaRequest)(nil), "iotexapi.GetEpochMetaRequest")
+	proto.RegisterType((*GetEpochMetaResponse)(nil), "iotexapi.GetEpochMetaResponse")
+	proto.RegisterType((*StreamBlocksRequest)(nil), "iotexapi.StreamBlocksRequest")
+	proto.RegisterType((*StreamBlocksResponse)(nil), "iotexapi.StreamBlocksResponse")
+	proto.RegisterType((*LogsFilter)(nil), "iotexapi.LogsFilter")
+	proto.RegisterType((*Topics)(nil), "iotexapi.Topics")
+	proto.RegisterType((*StreamLogsRequest)(nil), "iotexapi.StreamLogsRequest")
+	proto.RegisterType((*StreamLogsResponse)(nil), "iotexapi.StreamLogsResponse")
+	proto.RegisterType((*GetLogsRequest)(nil), "iotexapi.GetLogsRequest")
+	proto.RegisterType((*GetLogsResponse)(nil), "iotexapi.GetLogsResponse")
+	proto.RegisterType((*GetAccountProofRequest)(nil), "iotexapi.GetAccountProofRequest")
+	proto.RegisterType((*GetAccountProofResponse)(nil), "iotexapi.GetAccountProofResponse")
+	proto.RegisterType((*TraceActionRequest)(nil), "iotexapi.TraceActionRequest")
+	proto.RegisterType((*StructLog)(nil), "iotexapi.StructLog")
+	proto.RegisterType((*CallFrame)(nil), "iotexapi.CallFrame")
+	proto.RegisterType((*TraceActionResponse)(nil), "iotexapi.TraceActionResponse")
+	proto.RegisterType((*GetActPoolStatusRequest)(nil), "iotexapi.GetActPoolStatusRequest")
+	proto.RegisterType((*NonceRange)(nil), "iotexapi.NonceRange")
+	proto.RegisterType((*ActPoolAccountStatus)(nil), "iotexapi.ActPoolAccountStatus")
+	proto.RegisterType((*GetActPoolStatusResponse)(nil), "iotexapi.GetActPoolStatusResponse")
+	proto.RegisterType((*StreamPendingActionsRequest)(nil), "iotexapi.StreamPendingActionsRequest")
+	proto.RegisterType((*StreamPendingActionsResponse)(nil), "iotexapi.StreamPendingActionsResponse")
+	proto.RegisterType((*GetConsensusEvidencesRequest)(nil), "iotexapi.GetConsensusEvidencesRequest")
+	proto.RegisterType((*GetConsensusEvidencesResponse)(nil), "iotexapi.GetConsensusEvidencesResponse")
+	proto.RegisterType((*GetConsensusTimelineRequest)(nil), "iotexapi.GetConsensusTimelineRequest")
+	proto.RegisterType((*GetConsensusTimelineResponse)(nil), "iotexapi.GetConsensusTimelineResponse")
+	proto.RegisterType((*GetTokenTransfersByAddressRequest)(nil), "iotexapi.GetTokenTransfersByAddressRequest")
+	proto.RegisterType((*TokenTransfer)(nil), "iotexapi.TokenTransfer")
+	proto.RegisterType((*GetTokenTransfersByAddressResponse)(nil), "iotexapi.GetTokenTransfersByAddressResponse")
+}
+
+func init() { proto.RegisterFile("proto/api/api.proto", fileDescriptor_ca6d5bbc959d58c0) }
+
+var fileDescriptor_ca6d5bbc959d58c0 = []byte{
+	// 2751 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x1a, 0x4d, 0x6f, 0xdc, 0xc6,
+	0x35, 0xfb, 0xa9, 0xdd, 0x27, 0x39, 0xb2, 0x46, 0xb2, 0xcc, 0x50, 0xb2, 0x22, 0x4f, 0xfc, 0xa1,
+	0xb6, 0x89, 0xe4, 0xc8, 0x9f, 0x75, 0x50, 0xb7, 0x92, 0x3f, 0x64, 0xc5, 0x8e, 0xa3, 0x8c, 0xe4,
+	0x22, 0x69, 0x0b, 0xa4, 0x5c, 0x72, 0xb4, 0xa2, 0xb5, 0xcb, 0x61, 0xc8, 0x59, 0xc1, 0x4a, 0x80,
+	0xde, 0x8a, 0xf6, 0x10, 0x14, 0x3d, 0xf6, 0x58, 0xf4, 0xd0, 0x7b, 0xaf, 0x3d, 0xf7, 0x0f, 0x15,
+	0x28, 0x7a, 0x2e, 0xe6, 0x83, 0xe4, 0x70, 0x97, 0xdc, 0xb5, 0x85, 0xa0, 0x07, 0x01, 0x7c, 0x9f,
+	0xf3, 0xe6, 0xcd, 0x7b, 0x6f, 0xde, 0xbc, 0x15, 0xcc, 0x87, 0x11, 0xe3, 0x6c, 0xc3, 0x09, 0x7d,
+	0xf1, 0xb7, 0x2e, 0x21, 0xd4, 0xf2, 0x19, 0xa7, 0xaf, 0x9d, 0xd0, 0xb7, 0x2d, 0x45, 0xe6, 0xa7,
+	0x21, 0x8d, 0x37, 0x1c, 0x97, 0xfb, 0x2c, 0x50, 0x3c, 0xf6, 0xb2, 0x49, 0xe9, 0xf4, 0x98, 0x7b,
+	0xec, 0x1e, 0x39, 0x7e, 0x42, 0x5d, 0x32, 0xa9, 0x2e, 0x0b, 0x62, 0x1a, 0xc4, 0x83, 0x58, 0x13,
+	0x17, 0x4d, 0x62, 0xc0, 0x3c, 0xaa, 0xf1, 0xef, 0x77, 0x19, 0xeb, 0xf6, 0xe8, 0x86, 0x84, 0x3a,
+	0x83, 0xc3, 0x0d, 0xee, 0xf7, 0x69, 0xcc, 0x9d, 0x7e, 0xa8, 0x18, 0xf0, 0x63, 0x98, 0xdb, 0xa1,
+	0x7c, 0xcb, 0x75, 0xd9, 0x20, 0xe0, 0x84, 0x7e, 0x33, 0xa0, 0x31, 0x47, 0x16, 0x4c, 0x39, 0x9e,
+	0x17, 0xd1, 0x38, 0xb6, 0x2a, 0xab, 0x95, 0xb5, 0x36, 0x49, 0x40, 0xb4, 0x08, 0xcd, 0x23, 0xea,
+	0x77, 0x8f, 0xb8, 0x55, 0x5d, 0xad, 0xac, 0xd5, 0x89, 0x86, 0xf0, 0xe7, 0x80, 0x4c, 0x35, 0x71,
+	0x28, 0xec, 0x43, 0x3f, 0x85, 0x69, 0x47, 0xa1, 0x3e, 0xa3, 0xdc, 0x91, 0xba, 0xa6, 0x37, 0x2f,
+	0xae, 0x4b, 0x57, 0x48, 0x53, 0xd7, 0xb7, 0x32, 0x32, 0x31, 0x79, 0xf1, 0x7f, 0xab, 0xda, 0x30,
+	0xe1, 0x9f, 0x38, 0x31, 0xec, 0x01, 0x4c, 0x75, 0x4e, 0x77, 0x03, 0x8f, 0xbe, 0xd6, 0xca, 0xf0,
+	0x7a, 0xe2, 0xd7, 0xf5, 0x8c, 0x7b, 0x5b, 0xb1, 0x68, 0xa1, 0xa7, 0xef, 0x90, 0x44, 0x08, 0xdd,
+	0x87, 0x66, 0xe7, 0xf4, 0xa9, 0x13, 0x1f, 0x49, 0xf3, 0xa7, 0x37, 0x57, 0x0b, 0xc4, 0xb7, 0x25,
+	0x43, 0x26, 0xac, 0x25, 0xd0, 0x03, 0x21, 0xbb, 0xe5, 0x79, 0x91, 0x55, 0x93, 0xb2, 0x57, 0x8a,
+	0x97, 0xde, 0x52, 0x9e, 0xca, 0xc9, 0x0b, 0x1c, 0xfa, 0x1a, 0xe6, 0x06, 0x81, 0xcb, 0x82, 0x43,
+	0x3f, 0xea, 0x53, 0x4f, 0x31, 0x5a, 0x75, 0xa9, 0x6a, 0x23, 0xa7, 0xea, 0x65, 0xc6, 0x55, 0xae,
+	0x75, 0x54, 0x17, 0xba, 0x0f, 0x8d, 0xce, 0xe9, 0x76, 0xef, 0xd8, 0x6a, 0x8c, 0x73, 0xcd, 0xb6,
+	0x88, 0xab, 0x4c, 0x8f, 0x12, 0xd9, 0x6e, 0x41, 0xb3, 0xc7, 0xd8, 0xf1, 0x20, 0xc4, 0x4f, 0xc0,
+	0x2a, 0xf3, 0x24, 0x5a, 0x80, 0x46, 0xcc, 0x9d, 0x88, 0x4b, 0xe7, 0xd7, 0x89, 0x02, 0x04, 0x56,
+	0x9e, 0x9b, 0x0e, 0x09, 0x05, 0xe0, 0xdf, 0xc0, 0x62, 0xb1, 0x4b, 0xd1, 0x0a, 0x80, 0x0a, 0x7b,
+	0x79, 0x10, 0x2a, 0xc0, 0x0c, 0x0c, 0xc2, 0x30, 0xe3, 0x1e, 0x51, 0xf7, 0x78, 0x8f, 0x06, 0x9e,
+	0x1f, 0x74, 0xa5, 0xda, 0x16, 0xc9, 0xe1, 0xf0, 0xf7, 0x55, 0xb0, 0xcb, 0xbd, 0x3e, 0x26, 0x80,
+	0xd3, 0x2d, 0x54, 0x0b, 0xb7, 0x50, 0x33, 0xb6, 0x20, 0x82, 0xdd, 0x1d, 0x44, 0x31, 0x53, 0xc7,
+	0xd4, 0x26, 0x1a, 0x42, 0xab, 0x22, 0xac, 0xc5, 0xc2, 0x07, 0x22, 0x86, 0xad, 0xc6, 0x6a, 0x6d,
+	0xad, 0x4d, 0x4c, 0x14, 0xba, 0x07, 0x6d, 0xa9, 0xf8, 0xc0, 0xef, 0x53, 0xab, 0x29, 0x8f, 0xc3,
+	0x5e, 0x57, 0xa9, 0xb8, 0x9e, 0xa4, 0xe2, 0xfa, 0x41, 0x92, 0x8a, 0x24, 0x63, 0x46, 0xb7, 0x60,
+	0x8a, 0x06, 0x9e, 0x94, 0x9b, 0x9a, 0x28, 0x97, 0xb0, 0xe2, 0x3e, 0x5c, 0x7d, 0xa3, 0xc0, 0xf9,
+	0x61, 0x1c, 0x83, 0x7f, 0x0b, 0x56, 0x59, 0x48, 0x89, 0x15, 0x3a, 0xbd, 0x63, 0xe3, 0x68, 0x13,
+	0xf0, 0xad, 0x56, 0xf8, 0x7b, 0x05, 0x40, 0xe9, 0xdf, 0x0d, 0x0e, 0x19, 0xfa, 0x31, 0x34, 0x95,
+	0x7b, 0x75, 0xda, 0xa3, 0x7c, 0x0d, 0x11, 0x14, 0xa2, 0x39, 0xe4, 0x16, 0x5d, 0x9e, 0x26, 0x79,
+	0x9b, 0x24, 0xa0, 0x69, 0x5a, 0x2d, 0x6f, 0xda, 0x3d, 0x68, 0xa7, 0x85, 0xd1, 0xaa, 0x4f, 0xf4,
+	0x7b, 0xc6, 0x8c, 0xbf, 0x85, 0x69, 0x42, 0x5d, 0xea, 0x87, 0x5c, 0x1a, 0xfa, 0x11, 0x4c, 0x45,
+	0x0a, 0xd4, 0x96, 0xce, 0x9b, 0x96, 0x6a, 0x4e, 0x92, 0xf0, 0x98, 0x16, 0x55, 0xf3, 0x16, 0x61,
+	0x98, 0x89, 0xe8, 0x09, 0x8d, 0x38, 0xa1, 0x4e, 0xcc, 0x02, 0x6d, 0x70, 0x0e, 0x87, 0xbf, 0x83,
+	0x39, 0xe9, 0xfa, 0xbd, 0x88, 0x79, 0x03, 0x97, 0x46, 0xd2, 0x82, 0xb1, 0x27, 0x7c, 0xc2, 0x38,
+	0x8d, 0xf5, 0x52, 0x0a, 0x10, 0x41, 0x2e, 0x1c, 0x77, 0x42, 0xe5, 0x12, 0x2d, 0xa2, 0x21, 0x91,
+	0xa5, 0xa1, 0xd4, 0x2b, 0xdd, 0x5e, 0x97, 0x87, 0x63, 0x60, 0xf0, 0x2b, 0x5d, 0xf1, 0x75, 0x7d,
+	0xd6, 0x15, 0xff, 0x56, 0x92, 0xdb, 0xc2, 0x16, 0xab, 0xb2, 0x5a, 0x5b, 0x9b, 0xde, 0x5c, 0xc8,
+	0x0a, 0x51, 0x76, 0xa4, 0xc4, 0xe0, 0x13, 0x6b, 0x05, 0xf4, 0x35, 0x7f, 0xa8, 0x92, 0x4d, 0x99,
+	0x67, 0x60, 0xf0, 0xdf, 0x2a, 0xb0, 0xb0, 0x43, 0xb9, 0xdc, 0xac, 0xb8, 0x1d, 0xd2, 0x70, 0xde,
+	0x1a, 0xbe, 0x0f, 0xae, 0xe6, 0x8a, 0x5e, 0x26, 0x50, 0x7e, 0x25, 0xfc, 0x6c, 0xe8, 0x4a, 0xf8,
+	0xa0, 0x58, 0x43, 0xc9, 0xad, 0x60, 0x14, 0xce, 0x5d, 0x58, 0x1a, 0xb3, 0xe4, 0x5b, 0xd5, 0xce,
+	0xdb, 0xf0, 0x5e, 0xe9, 0xda, 0xe5, 0x09, 0x86, 0x3f, 0x85, 0x0b, 0x43, 0x5e, 0xd2, 0xa7, 0xf2,
+	0x31, 0xb4, 0x3a, 0x3d, 0x85, 0xd3, 0x67, 0x72, 0xc1, 0x0c, 0xcb, 0x54, 0x82, 0xa4, 0x6c, 0xf8,
+	0x02, 0xcc, 0xef, 0x50, 0xfe, 0x50, 0xf4, 0x1f, 0x92, 0xa2, 0x16, 0xc7, 0xcf, 0x60, 0x21, 0x8f,
+	0xd6, 0x2b, 0xdc, 0x84, 0xb6, 0x9b, 0x20, 0xf5, 0x51, 0xe4, 0x96, 0xc8, 0x24, 0x32, 0x3e, 0xbc,
+	0x28, 0x95, 0xed, 0xd3, 0xe8, 0x84, 0x46, 0xe6, 0x22, 0xbf, 0xaf, 0xc0, 0x85, 0x21, 0x82, 0x5e,
+	0xe6, 0x0e, 0x40, 0x9c, 0x62, 0xf5, 0x3a, 0x8b, 0xe6, 0x3a, 0x86, 0x8c, 0xc1, 0x29, 0xc2, 0x32,
+	0x3e, 0x0d, 0xdc, 0x7d, 0xee, 0xf0, 0x41, 0xac, 0x0f, 0xda, 0x08, 0xcb, 0xfd, 0x94, 0x46, 0x0c,
+	0x3e, 0xfc, 0xe7, 0x0a, 0xbc, 0x2b, 0x48, 0x7b, 0x94, 0x46, 0x0a, 0x85, 0xde, 0x85, 0xaa, 0xef,
+	0x69, 0xbf, 0x57, 0x7d, 0x4f, 0x9e, 0xaa, 0xcb, 0x22, 0x2a, 0x75, 0xd6, 0x88, 0x02, 0x44, 0x4e,
+	0xc9, 0xf6, 0x2d, 0xd6, 0x45, 0x4d, 0x43, 0x68, 0x0d, 0x66, 0xd5, 0xd7, 0x1e, 0x8d, 0xf6, 0xa9,
+	0xcb, 0x02, 0x4f, 0x26, 0x56, 0x85, 0x0c, 0xa3, 0xa5, 0x06, 0x27, 0x08, 0xa8, 0x27, 0x2f, 0xf3,
+	0x16, 0xd1, 0x10, 0xfe, 0x53, 0x15, 0x20, 0xb3, 0x56, 0xdc, 0x44, 0x32, 0x8e, 0x9e, 0xaa, 0x9e,
+	0x4c, 0x85, 0x96, 0x89, 0x42, 0xcb, 0xa2, 0xb2, 0x85, 0x4f, 0xcd, 0x9e, 0x2d, 0x43, 0x88, 0x2a,
+	0x73, 0x44, 0x1d, 0x8f, 0x46, 0x9a, 0x41, 0x99, 0x9b, 0xc3, 0x09, 0x1e, 0xee, 0x44, 0x5d, 0x9a,
+	0x2c, 0xa2, 0x4a, 0x41, 0x0e, 0x57, 0xb4, 0xb1, 0x46, 0xf1, 0xc6, 0x56, 0x00, 0x28, 0x77, 0x14,
+	0x10, 0xcb, 0xab, 0xb1, 0x4e, 0x0c, 0x0c, 0x5a, 0x87, 0x46, 0x48, 0x69, 0x14, 0x5b, 0x53, 0x32,
+	0x4e, 0xad, 0xfc, 0x21, 0x65, 0x27, 0x41, 0x14, 0x1b, 0xfe, 0x39, 0xcc, 0xed, 0xd3, 0x40, 0xdf,
+	0x77, 0x49, 0x8a, 0xbc, 0xc5, 0x75, 0x81, 0x6f, 0x01, 0x32, 0x15, 0xe8, 0x40, 0x9b, 0xd0, 0xa3,
+	0xe0, 0x4f, 0x64, 0x86, 0xea, 0x7a, 0xbe, 0x7d, 0x9a, 0x5f, 0x7e, 0x92, 0xf0, 0x4b, 0xb0, 0x8b,
+	0x84, 0xf5, 0xd2, 0x77, 0x61, 0x3a, 0xca, 0x6e, 0x94, 0x7c, 0x32, 0x09, 0x3f, 0x18, 0xd7, 0x0d,
+	0x31, 0x39, 0xf1, 0x57, 0x30, 0x4f, 0xa8, 0xe3, 0x3d, 0x64, 0x01, 0x8f, 0x1c, 0x97, 0x9f, 0xc1,
+	0x19, 0xa5, 0xed, 0xfd, 0x57, 0xb0, 0x90, 0x57, 0xad, 0x6d, 0x45, 0x50, 0xf7, 0x1c, 0x9d, 0x89,
+	0x6d, 0x22, 0xbf, 0xcd, 0x2b, 0xb0, 0x3a, 0xf9, 0x0a, 0xc4, 0x16, 0x2c, 0xee, 0x0f, 0xba, 0x5d,
+	0x1a, 0xf3, 0x1d, 0x27, 0xde, 0x8b, 0x7c, 0x97, 0x26, 0x65, 0xe0, 0x36, 0x5c, 0x1c, 0xa1, 0xe8,
+	0x75, 0x6d, 0x68, 0x75, 0x35, 0x4e, 0x07, 0x7d, 0x0a, 0x8b, 0x3a, 0xfc, 0x38, 0xe6, 0x7e, 0xdf,
+	0xe1, 0x74, 0xc7, 0x89, 0x9f, 0xb0, 0xe8, 0xec, 0xb1, 0x71, 0x03, 0x96, 0x8b, 0x55, 0x69, 0x33,
+	0xce, 0x43, 0xad, 0xeb, 0xc4, 0xda, 0x02, 0xf1, 0x89, 0xff, 0x58, 0x81, 0xf3, 0xc2, 0x53, 0x22,
+	0x48, 0xa9, 0x11, 0x0f, 0xb2, 0x89, 0x70, 0x59, 0x6f, 0xf7, 0x91, 0xe4, 0x9e, 0x21, 0x06, 0x46,
+	0xd0, 0xfb, 0x94, 0x1f, 0x31, 0xef, 0x85, 0xd3, 0x57, 0x95, 0x64, 0x86, 0x18, 0x18, 0x91, 0xc3,
+	0x4e, 0xd4, 0x1d, 0xf4, 0x69, 0xc0, 0x45, 0x45, 0xa9, 0xad, 0xcd, 0x90, 0x0c, 0x61, 0x9c, 0x59,
+	0x3d, 0x77, 0x66, 0xd7, 0x61, 0xce, 0xb0, 0xa4, 0xe0, 0xc0, 0x66, 0xd4, 0x81, 0xe1, 0xbb, 0xb2,
+	0xd4, 0x3f, 0x0e, 0x99, 0x7b, 0x64, 0x54, 0x61, 0x51, 0x5b, 0xa8, 0xc0, 0xbd, 0x18, 0xf4, 0x3b,
+	0x34, 0x4a, 0x6a, 0x8b, 0x81, 0xc2, 0xff, 0x54, 0xd7, 0xb2, 0x21, 0x99, 0xdd, 0x06, 0x92, 0xef,
+	0x91, 0x53, 0x7c, 0x1b, 0x3c, 0x4e, 0x88, 0x24, 0xe3, 0x13, 0xeb, 0x71, 0xc6, 0x9d, 0xde, 0xb6,
+	0xaa, 0x9c, 0x2a, 0x00, 0x4d, 0x14, 0x7a, 0x06, 0xa8, 0x63, 0xf6, 0x3b, 0xb1, 0x4c, 0x90, 0x9a,
+	0x2c, 0x14, 0x4b, 0x59, 0x82, 0x8c, 0xf4, 0x44, 0xa4, 0x40, 0x4c, 0x5c, 0x70, 0xfb, 0x3c, 0xa2,
+	0x4e, 0x5f, 0x29, 0x4f, 0x82, 0x2e, 0x84, 0x85, 0x3c, 0x5a, 0x6f, 0xe9, 0x3a, 0x34, 0xa4, 0x12,
+	0xbd, 0x9d, 0xb9, 0x91, 0xfb, 0x93, 0x28, 0x3a, 0xda, 0x80, 0x96, 0x0e, 0x6d, 0xb1, 0x87, 0x5a,
+	0x59, 0xfc, 0xa7, 0x4c, 0x78, 0x0f, 0xe0, 0x39, 0xeb, 0xc6, 0x4f, 0xfc, 0x1e, 0xa7, 0x51, 0xbe,
+	0x7d, 0xab, 0x99, 0xed, 0xdb, 0x1a, 0x34, 0x39, 0x0b, 0x7d, 0x37, 0x51, 0x7b, 0x3e, 0xdb, 0xf1,
+	0x81, 0xc4, 0x13, 0x4d, 0xc7, 0x2b, 0xd0, 0x54, 0x18, 0x71, 0x3d, 0x49, 0x9c, 0xd4, 0x35, 0x43,
+	0x14, 0x80, 0xb7, 0x60, 0x4e, 0xed, 0x51, 0xac, 0x9b, 0x1c, 0xf7, 0x87, 0xd0, 0x3c, 0x94, 0x26,
+	0x58, 0x95, 0xe1, 0xeb, 0x31, 0x33, 0x8f, 0x68, 0x1e, 0x7c, 0x17, 0x90, 0xa9, 0x42, 0x3b, 0xe9,
+	0x32, 0xd4, 0x7a, 0xac, 0xab, 0x15, 0xcc, 0x9a, 0xdb, 0x7e, 0xce, 0xba, 0x44, 0xd0, 0xf0, 0x09,
+	0xbc, 0xbb, 0x43, 0xf9, 0x99, 0x17, 0x16, 0xb9, 0x70, 0x18, 0x31, 0x75, 0x3a, 0xc9, 0x7d, 0x96,
+	0x22, 0x84, 0xf7, 0x38, 0x53, 0x34, 0x75, 0x95, 0x25, 0x20, 0xbe, 0x03, 0xb3, 0xe9, 0xba, 0xda,
+	0xda, 0x0f, 0xa0, 0xde, 0x63, 0xdd, 0xa4, 0x23, 0x1a, 0x31, 0x57, 0x12, 0xf1, 0xa7, 0xfa, 0x19,
+	0x2b, 0x1b, 0xb3, 0xbd, 0x88, 0xb1, 0xc3, 0xb3, 0x0f, 0x49, 0x28, 0x5c, 0x1c, 0xd1, 0xa5, 0x6d,
+	0xc9, 0x44, 0x2a, 0xa6, 0x88, 0xd8, 0x6e, 0x2c, 0x13, 0x98, 0x31, 0xae, 0x2b, 0x43, 0x86, 0x10,
+	0xc7, 0x1b, 0x0a, 0x35, 0xba, 0x28, 0x28, 0x00, 0xff, 0x0e, 0xd0, 0x41, 0xe4, 0xb8, 0xf4, 0xad,
+	0x2e, 0x25, 0x74, 0x05, 0xce, 0x79, 0x7e, 0xec, 0x74, 0x7a, 0xf4, 0x33, 0xda, 0x67, 0xd1, 0xa9,
+	0x7e, 0x76, 0xe7, 0x91, 0xa2, 0x19, 0xd0, 0x88, 0x7d, 0xee, 0x68, 0x2f, 0xb7, 0x48, 0x0e, 0x87,
+	0xff, 0x51, 0x81, 0xf6, 0x3e, 0x8f, 0x06, 0xae, 0x70, 0xb7, 0xe8, 0x98, 0x42, 0x57, 0xef, 0xaa,
+	0x1a, 0xba, 0x02, 0x66, 0xa1, 0xee, 0xf1, 0xab, 0x2c, 0x4c, 0x6a, 0x68, 0x2d, 0xad, 0xa1, 0xc2,
+	0xb1, 0x5d, 0x27, 0x7e, 0xc8, 0xe2, 0xa4, 0xa2, 0x25, 0xa0, 0xf0, 0x52, 0x5f, 0x19, 0xd7, 0x90,
+	0xae, 0xd0, 0x90, 0xee, 0xad, 0xdd, 0x63, 0xab, 0x29, 0x53, 0x46, 0x01, 0x02, 0xeb, 0xd1, 0x90,
+	0x1f, 0xc9, 0x87, 0x74, 0x83, 0x28, 0x40, 0x60, 0x69, 0x14, 0xb1, 0xc8, 0x6a, 0xa9, 0x57, 0x90,
+	0x04, 0xf0, 0x7f, 0x2a, 0xd0, 0x7e, 0xe8, 0xf4, 0x7a, 0x4f, 0x22, 0x51, 0x70, 0x11, 0xd4, 0x45,
+	0x1c, 0x24, 0xd7, 0x9a, 0xf8, 0x16, 0x38, 0x11, 0x67, 0xda, 0x72, 0xf9, 0x2d, 0xf6, 0xc2, 0x99,
+	0x7e, 0x9a, 0x55, 0x39, 0x93, 0x2f, 0x2c, 0xa7, 0x37, 0xa0, 0x7a, 0x5e, 0xa0, 0x80, 0x64, 0x87,
+	0x8d, 0xe1, 0x1d, 0xbe, 0x8c, 0xa9, 0xa7, 0x3b, 0xa0, 0x04, 0x14, 0x1a, 0xfc, 0x20, 0x1c, 0x70,
+	0x69, 0xf3, 0x0c, 0x51, 0x80, 0xd8, 0x37, 0x1b, 0x70, 0x81, 0x6e, 0xa9, 0x7d, 0x2b, 0x28, 0xdb,
+	0x4b, 0xdb, 0xd8, 0x0b, 0xfa, 0x11, 0x34, 0x5c, 0xa7, 0xd7, 0x8b, 0x2d, 0x30, 0xcb, 0x8f, 0xc8,
+	0xa7, 0x74, 0x87, 0x44, 0x71, 0xe0, 0x7f, 0x55, 0x60, 0x3e, 0x17, 0x2b, 0x3a, 0x1c, 0xdf, 0xf2,
+	0x19, 0x9b, 0xd9, 0x57, 0xcd, 0xd9, 0x77, 0x13, 0x20, 0x4e, 0x02, 0x21, 0xd6, 0x85, 0xda, 0x30,
+	0x27, 0x0d, 0x12, 0x62, 0xb0, 0xa1, 0x8f, 0xa1, 0x2d, 0x8c, 0x93, 0x66, 0xe9, 0xb7, 0x78, 0xe1,
+	0x16, 0x32, 0x2e, 0xfc, 0xb5, 0x4e, 0x2c, 0xbe, 0xc7, 0x58, 0x4f, 0xf7, 0x87, 0x3f, 0xe8, 0xc0,
+	0xe3, 0x16, 0xc0, 0x0b, 0x16, 0xb8, 0x94, 0x38, 0x41, 0x97, 0x96, 0x3c, 0xe5, 0xce, 0x43, 0x8d,
+	0x06, 0x9e, 0xd6, 0x26, 0x3e, 0x45, 0xb3, 0xbe, 0xa0, 0x8d, 0xd2, 0x49, 0xaf, 0xdb, 0xf6, 0x72,
+	0xa3, 0x30, 0xcc, 0x84, 0x6a, 0xc4, 0x25, 0xd7, 0xd3, 0xda, 0x72, 0x38, 0x74, 0x03, 0xe6, 0x35,
+	0xbc, 0x95, 0xa6, 0x2f, 0x55, 0xee, 0x6d, 0x93, 0x22, 0x12, 0x5a, 0x07, 0xf4, 0xcd, 0x80, 0x0e,
+	0xa8, 0x67, 0x62, 0xad, 0xba, 0x14, 0x28, 0xa0, 0x08, 0x2b, 0x14, 0x56, 0x2e, 0xa8, 0x26, 0x5c,
+	0x75, 0x92, 0xc3, 0xa1, 0x4d, 0x68, 0x07, 0xe2, 0x6b, 0xc7, 0x09, 0x63, 0x99, 0x77, 0xb9, 0xca,
+	0x9d, 0x79, 0x8b, 0x64, 0x6c, 0xf8, 0xaf, 0xd5, 0x64, 0x70, 0x64, 0x1e, 0x54, 0xd6, 0x9a, 0xc4,
+	0xfe, 0xb7, 0x49, 0x3f, 0x27, 0xbf, 0x45, 0x9f, 0xe7, 0x3a, 0xa1, 0xe3, 0xfa, 0xfc, 0x54, 0xbb,
+	0x22, 0x85, 0x75, 0x12, 0xed, 0x0b, 0x91, 0x5a, 0x9a, 0x44, 0x02, 0x14, 0x9d, 0x84, 0xa8, 0x18,
+	0x89, 0xa0, 0x2a, 0x22, 0x26, 0x4a, 0x0e, 0x1c, 0x06, 0x7d, 0xd1, 0xf7, 0xd3, 0x28, 0xc9, 0x4c,
+	0x03, 0xa3, 0xe9, 0xc9, 0x00, 0xb2, 0x99, 0xd2, 0x35, 0x46, 0x94, 0xe5, 0x60, 0xd0, 0xff, 0x42,
+	0xfa, 0x43, 0xa6, 0x6a, 0x9d, 0x64, 0x08, 0x74, 0x1f, 0x5a, 0x7a, 0x94, 0x1d, 0x5b, 0x2d, 0xe9,
+	0x99, 0x95, 0xdc, 0x08, 0x64, 0x24, 0x20, 0x48, 0xca, 0x8f, 0x2f, 0xc1, 0x92, 0xba, 0x58, 0xf7,
+	0xcc, 0x73, 0x4c, 0xdb, 0x93, 0x07, 0xb0, 0x5c, 0x4c, 0x7e, 0xc3, 0x77, 0xcb, 0x2f, 0x61, 0x59,
+	0xbc, 0xdf, 0x93, 0x5f, 0x0f, 0x1e, 0x9f, 0xf8, 0x1e, 0x15, 0xc7, 0x69, 0x34, 0x7d, 0x13, 0x1e,
+	0x94, 0x65, 0xd3, 0xde, 0x4b, 0x25, 0x7a, 0xb5, 0x61, 0x9f, 0x40, 0x9b, 0x26, 0x48, 0x7d, 0xe3,
+	0x5e, 0xca, 0x0d, 0x08, 0x86, 0x45, 0x49, 0xc6, 0x8f, 0x6f, 0xca, 0xd1, 0x4a, 0xca, 0x22, 0x06,
+	0x71, 0x3d, 0x3f, 0xa0, 0xc6, 0x68, 0x45, 0x99, 0x54, 0x31, 0x4d, 0xfa, 0xbe, 0x02, 0xcb, 0xc5,
+	0x52, 0xda, 0xa4, 0x4d, 0x68, 0x46, 0x6c, 0x10, 0x78, 0x89, 0x3d, 0x76, 0xa1, 0x3d, 0x44, 0xb0,
+	0x10, 0xcd, 0x89, 0xee, 0x41, 0xab, 0xe7, 0x9f, 0xd0, 0x80, 0xc6, 0x49, 0x1b, 0xb6, 0x6c, 0x4a,
+	0x3d, 0xa2, 0x3d, 0xda, 0x75, 0x38, 0x7d, 0xae, 0x79, 0x48, 0xca, 0x8d, 0xff, 0x5d, 0x81, 0xcb,
+	0x3b, 0x94, 0x1f, 0xb0, 0x63, 0x1a, 0x1c, 0x44, 0x4e, 0x10, 0x1f, 0xd2, 0xe8, 0xff, 0x3d, 0xb8,
+	0xce, 0x8d, 0xa5, 0x1b, 0x67, 0x1c, 0x4b, 0x37, 0xdf, 0x7c, 0x2c, 0xfd, 0x87, 0x2a, 0x9c, 0xcb,
+	0x6d, 0x78, 0x62, 0x17, 0x62, 0x43, 0xab, 0xc7, 0xba, 0x6a, 0xa2, 0x27, 0x36, 0x7a, 0x8e, 0xa4,
+	0xb0, 0x48, 0x3a, 0x31, 0xe9, 0x32, 0x27, 0x15, 0x19, 0xe2, 0xec, 0x23, 0x5c, 0xd5, 0x24, 0x1f,
+	0xd3, 0x40, 0x7a, 0xa4, 0x4d, 0x14, 0x20, 0x7c, 0x18, 0xcb, 0x6a, 0x20, 0x37, 0xdc, 0x26, 0x1a,
+	0x12, 0x56, 0x44, 0xd4, 0xf5, 0x43, 0x9f, 0x06, 0xea, 0x96, 0x6e, 0x93, 0x0c, 0x21, 0xa4, 0x9c,
+	0xbe, 0x3c, 0x10, 0xd5, 0x5e, 0x68, 0x08, 0x7f, 0x07, 0x78, 0xdc, 0xe1, 0xeb, 0x88, 0xbc, 0x0d,
+	0x6d, 0x9e, 0x50, 0x75, 0x50, 0x5e, 0x34, 0xbb, 0x7c, 0x43, 0x9a, 0x64, 0x9c, 0x93, 0xc6, 0xa7,
+	0x9b, 0x7f, 0x99, 0x05, 0xd8, 0xda, 0xdb, 0x15, 0xb3, 0x31, 0xdf, 0xa5, 0x68, 0x17, 0x20, 0x6b,
+	0x43, 0xd1, 0xd2, 0xd0, 0xcf, 0x44, 0xe6, 0x0f, 0x81, 0xf6, 0x72, 0x31, 0x51, 0x99, 0x8b, 0xdf,
+	0x49, 0x55, 0xc9, 0x22, 0x34, 0xa2, 0xca, 0xac, 0x5c, 0xf6, 0x72, 0x31, 0x31, 0x55, 0x45, 0xe0,
+	0x5c, 0x6e, 0x78, 0x89, 0x56, 0x4a, 0x46, 0xb9, 0x89, 0xc2, 0xf7, 0x4b, 0xe9, 0xa9, 0xce, 0xcf,
+	0x61, 0xc6, 0x9c, 0x56, 0xa2, 0x4b, 0x39, 0x91, 0xe1, 0xe1, 0xa6, 0xbd, 0x52, 0x46, 0x1e, 0x32,
+	0x32, 0x1b, 0x32, 0x0e, 0x19, 0x39, 0x32, 0xca, 0xb4, 0xdf, 0x2f, 0xa5, 0x9b, 0x3e, 0xcc, 0x06,
+	0x50, 0xa6, 0x0f, 0x47, 0xe6, 0x5a, 0xf6, 0x72, 0x31, 0x31, 0x55, 0xe5, 0xc8, 0x99, 0xfc, 0xd0,
+	0x60, 0x09, 0xe5, 0x27, 0xda, 0xc5, 0x33, 0x2b, 0xfb, 0xca, 0x78, 0x26, 0xd3, 0xa5, 0xe6, 0x24,
+	0xc8, 0x74, 0x69, 0xc1, 0xf0, 0xc9, 0x5e, 0x29, 0x23, 0xa7, 0x0a, 0xbf, 0x84, 0xd9, 0xa1, 0x29,
+	0x0f, 0x32, 0x7e, 0x95, 0x2d, 0x1e, 0x0d, 0xd9, 0x97, 0xc7, 0x70, 0xa4, 0x9a, 0xbb, 0xb0, 0x50,
+	0x34, 0xbd, 0x41, 0xc6, 0x6f, 0x04, 0x63, 0x06, 0x45, 0xf6, 0xb5, 0x49, 0x6c, 0xe9, 0x42, 0x4f,
+	0xa0, 0x9d, 0x4e, 0x5a, 0x90, 0x9d, 0xdf, 0xb1, 0x39, 0x08, 0xb2, 0x97, 0x0a, 0x69, 0x43, 0xe1,
+	0x9a, 0x8e, 0x53, 0x86, 0xc2, 0x75, 0x78, 0x40, 0x63, 0xaf, 0x94, 0x91, 0x53, 0x85, 0xbf, 0x80,
+	0x29, 0xfd, 0xe8, 0x45, 0x56, 0x8e, 0xd9, 0x78, 0x7f, 0xdb, 0xef, 0x15, 0x50, 0x52, 0x0d, 0x5f,
+	0xc0, 0x8c, 0x39, 0x0e, 0x31, 0x4d, 0x2a, 0x98, 0x9e, 0xd8, 0x2b, 0x65, 0xe4, 0x44, 0xe1, 0x8d,
+	0x0a, 0x7a, 0x06, 0x90, 0x8d, 0x0e, 0x72, 0xf1, 0x3e, 0x3c, 0x93, 0xb0, 0x97, 0x8b, 0x89, 0x86,
+	0xb2, 0x2f, 0x61, 0x36, 0x2b, 0x4c, 0xf2, 0x49, 0x8d, 0x56, 0x8b, 0x6a, 0x96, 0xf9, 0x72, 0xb7,
+	0x2f, 0x8f, 0xe1, 0x48, 0x77, 0xfe, 0x1c, 0xa6, 0x8d, 0x97, 0x11, 0x32, 0x4c, 0x19, 0x7d, 0x5c,
+	0xdb, 0x97, 0x4a, 0xa8, 0xa9, 0xb6, 0x5f, 0xc3, 0xf9, 0xe1, 0xc6, 0x17, 0x0d, 0x9b, 0x31, 0xfa,
+	0x7a, 0xb1, 0xf1, 0x38, 0x96, 0x54, 0xb9, 0x0f, 0x0b, 0x45, 0x4d, 0xa1, 0x19, 0xe8, 0x63, 0x7a,
+	0x4a, 0xfb, 0xda, 0x24, 0x36, 0xc3, 0xdf, 0xaf, 0xe4, 0x2f, 0x33, 0xa3, 0x7d, 0x1e, 0xba, 0x96,
+	0xaf, 0x9d, 0x65, 0x0d, 0xa6, 0x7d, 0x7d, 0x22, 0x9f, 0x99, 0xbf, 0x45, 0xfd, 0x1b, 0xba, 0x5a,
+	0xac, 0x62, 0xa8, 0x2b, 0xb4, 0xaf, 0x4d, 0x62, 0x4b, 0x17, 0x3a, 0x95, 0xf3, 0xf8, 0x92, 0xcb,
+	0x19, 0xfd, 0x24, 0xa7, 0x67, 0x7c, 0xff, 0x66, 0x7f, 0xf8, 0x66, 0xcc, 0xc9, 0xd2, 0xdb, 0x77,
+	0x7e, 0x75, 0xab, 0xeb, 0xf3, 0xa3, 0x41, 0x67, 0xdd, 0x65, 0xfd, 0x0d, 0x29, 0x1b, 0x46, 0xec,
+	0x15, 0x75, 0xb9, 0x02, 0x3e, 0x72, 0x59, 0xa4, 0xff, 0x7b, 0xa7, 0x4b, 0x83, 0x8d, 0x44, 0x79,
+	0xa7, 0x29, 0x51, 0x37, 0xff, 0x37, 0x00, 0x4f, 0x72, 0x64, 0x53, 0x6c, 0x24, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// APIServiceClient is the client API for APIService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type APIServiceClient interface {
+	// get the address detail of an address
+	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error)
+	// get action(s) by:
+	// 1. start index and action count
+	// 2. action hash
+	// 3. address with start index and action count
+	// 4. get unconfirmed actions by address with start index and action count
+	// 5. block hash with start index and action count
+	GetActions(ctx context.Context, in *GetActionsRequest, opts ...grpc.CallOption) (*GetActionsResponse, error)
+	// get block metadata(s) by:
 	// 1. start index and block count
 	// 2. block hash
 	GetBlockMetas(ctx context.Context, in *GetBlockMetasRequest, opts ...grpc.CallOption) (*GetBlockMetasResponse, error)
@@ -1788,6 +3672,27 @@ type APIServiceClient interface {
 	ReadState(ctx context.Context, in *ReadStateRequest, opts ...grpc.CallOption) (*ReadStateResponse, error)
 	// get epoch metadata
 	GetEpochMeta(ctx context.Context, in *GetEpochMetaRequest, opts ...grpc.CallOption) (*GetEpochMetaResponse, error)
+	// get logs matching the filter within a range of blocks
+	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsResponse, error)
+	// stream newly committed blocks together with their receipts
+	StreamBlocks(ctx context.Context, in *StreamBlocksRequest, opts ...grpc.CallOption) (APIService_StreamBlocksClient, error)
+	// stream logs of newly committed blocks matching the filter
+	StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (APIService_StreamLogsClient, error)
+	// get the merkle proof of an account in the state trie at a given height
+	GetAccountProof(ctx context.Context, in *GetAccountProofRequest, opts ...grpc.CallOption) (*GetAccountProofResponse, error)
+	// re-execute an execution on the state of its parent block and return the EVM trace
+	TraceAction(ctx context.Context, in *TraceActionRequest, opts ...grpc.CallOption) (*TraceActionResponse, error)
+	// get the status of the actions waiting in the actpool
+	GetActPoolStatus(ctx context.Context, in *GetActPoolStatusRequest, opts ...grpc.CallOption) (*GetActPoolStatusResponse, error)
+	// stream the hashes of actions newly becoming pending in the actpool
+	StreamPendingActions(ctx context.Context, in *StreamPendingActionsRequest, opts ...grpc.CallOption) (APIService_StreamPendingActionsClient, error)
+	// get the evidences of delegates endorsing conflicting consensus messages
+	GetConsensusEvidences(ctx context.Context, in *GetConsensusEvidencesRequest, opts ...grpc.CallOption) (*GetConsensusEvidencesResponse, error)
+	// get the timeline of the latest consensus rounds observed by the node, and the liveness of the delegates
+	GetConsensusTimeline(ctx context.Context, in *GetConsensusTimelineRequest, opts ...grpc.CallOption) (*GetConsensusTimelineResponse, error)
+	// get the ERC20 token transfers sent or received by an address, which is only supported by the nodes indexing to a
+	// relational database
+	GetTokenTransfersByAddress(ctx context.Context, in *GetTokenTransfersByAddressRequest, opts ...grpc.CallOption) (*GetTokenTransfersByAddressResponse, error)
 }
 
 type aPIServiceClient struct {
@@ -1906,6 +3811,165 @@ func (c *aPIServiceClient) GetEpochMeta(ctx context.Context, in *GetEpochMetaReq
 	return out, nil
 }
 
+func (c *aPIServiceClient) GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsResponse, error) {
+	out := new(GetLogsResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) StreamBlocks(ctx context.Context, in *StreamBlocksRequest, opts ...grpc.CallOption) (APIService_StreamBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_APIService_serviceDesc.Streams[0], "/iotexapi.APIService/StreamBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIServiceStreamBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type APIService_StreamBlocksClient interface {
+	Recv() (*StreamBlocksResponse, error)
+	grpc.ClientStream
+}
+
+type aPIServiceStreamBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIServiceStreamBlocksClient) Recv() (*StreamBlocksResponse, error) {
+	m := new(StreamBlocksResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIServiceClient) StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (APIService_StreamLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_APIService_serviceDesc.Streams[1], "/iotexapi.APIService/StreamLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIServiceStreamLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type APIService_StreamLogsClient interface {
+	Recv() (*StreamLogsResponse, error)
+	grpc.ClientStream
+}
+
+type aPIServiceStreamLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIServiceStreamLogsClient) Recv() (*StreamLogsResponse, error) {
+	m := new(StreamLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIServiceClient) GetAccountProof(ctx context.Context, in *GetAccountProofRequest, opts ...grpc.CallOption) (*GetAccountProofResponse, error) {
+	out := new(GetAccountProofResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetAccountProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) TraceAction(ctx context.Context, in *TraceActionRequest, opts ...grpc.CallOption) (*TraceActionResponse, error) {
+	out := new(TraceActionResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/TraceAction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetActPoolStatus(ctx context.Context, in *GetActPoolStatusRequest, opts ...grpc.CallOption) (*GetActPoolStatusResponse, error) {
+	out := new(GetActPoolStatusResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetActPoolStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) StreamPendingActions(ctx context.Context, in *StreamPendingActionsRequest, opts ...grpc.CallOption) (APIService_StreamPendingActionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_APIService_serviceDesc.Streams[2], "/iotexapi.APIService/StreamPendingActions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIServiceStreamPendingActionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type APIService_StreamPendingActionsClient interface {
+	Recv() (*StreamPendingActionsResponse, error)
+	grpc.ClientStream
+}
+
+type aPIServiceStreamPendingActionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIServiceStreamPendingActionsClient) Recv() (*StreamPendingActionsResponse, error) {
+	m := new(StreamPendingActionsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIServiceClient) GetConsensusEvidences(ctx context.Context, in *GetConsensusEvidencesRequest, opts ...grpc.CallOption) (*GetConsensusEvidencesResponse, error) {
+	out := new(GetConsensusEvidencesResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetConsensusEvidences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetConsensusTimeline(ctx context.Context, in *GetConsensusTimelineRequest, opts ...grpc.CallOption) (*GetConsensusTimelineResponse, error) {
+	out := new(GetConsensusTimelineResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetConsensusTimeline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetTokenTransfersByAddress(ctx context.Context, in *GetTokenTransfersByAddressRequest, opts ...grpc.CallOption) (*GetTokenTransfersByAddressResponse, error) {
+	out := new(GetTokenTransfersByAddressResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetTokenTransfersByAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // APIServiceServer is the server API for APIService service.
 type APIServiceServer interface {
 	// get the address detail of an address
@@ -1939,6 +4003,27 @@ type APIServiceServer interface {
 	ReadState(context.Context, *ReadStateRequest) (*ReadStateResponse, error)
 	// get epoch metadata
 	GetEpochMeta(context.Context, *GetEpochMetaRequest) (*GetEpochMetaResponse, error)
+	// get logs matching the filter within a range of blocks
+	GetLogs(context.Context, *GetLogsRequest) (*GetLogsResponse, error)
+	// stream newly committed blocks together with their receipts
+	StreamBlocks(*StreamBlocksRequest, APIService_StreamBlocksServer) error
+	// stream logs of newly committed blocks matching the filter
+	StreamLogs(*StreamLogsRequest, APIService_StreamLogsServer) error
+	// get the merkle proof of an account in the state trie at a given height
+	GetAccountProof(context.Context, *GetAccountProofRequest) (*GetAccountProofResponse, error)
+	// re-execute an execution on the state of its parent block and return the EVM trace
+	TraceAction(context.Context, *TraceActionRequest) (*TraceActionResponse, error)
+	// get the status of the actions waiting in the actpool
+	GetActPoolStatus(context.Context, *GetActPoolStatusRequest) (*GetActPoolStatusResponse, error)
+	// stream the hashes of actions newly becoming pending in the actpool
+	StreamPendingActions(*StreamPendingActionsRequest, APIService_StreamPendingActionsServer) error
+	// get the evidences of delegates endorsing conflicting consensus messages
+	GetConsensusEvidences(context.Context, *GetConsensusEvidencesRequest) (*GetConsensusEvidencesResponse, error)
+	// get the timeline of the latest consensus rounds observed by the node, and the liveness of the delegates
+	GetConsensusTimeline(context.Context, *GetConsensusTimelineRequest) (*GetConsensusTimelineResponse, error)
+	// get the ERC20 token transfers sent or received by an address, which is only supported by the nodes indexing to a
+	// relational database
+	GetTokenTransfersByAddress(context.Context, *GetTokenTransfersByAddressRequest) (*GetTokenTransfersByAddressResponse, error)
 }
 
 func RegisterAPIServiceServer(s *grpc.Server, srv APIServiceServer) {
@@ -2161,6 +4246,195 @@ func _APIService_GetEpochMeta_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _APIService_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetLogs(ctx, req.(*GetLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_StreamBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServiceServer).StreamBlocks(m, &aPIServiceStreamBlocksServer{stream})
+}
+
+type APIService_StreamBlocksServer interface {
+	Send(*StreamBlocksResponse) error
+	grpc.ServerStream
+}
+
+type aPIServiceStreamBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIServiceStreamBlocksServer) Send(m *StreamBlocksResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _APIService_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServiceServer).StreamLogs(m, &aPIServiceStreamLogsServer{stream})
+}
+
+type APIService_StreamLogsServer interface {
+	Send(*StreamLogsResponse) error
+	grpc.ServerStream
+}
+
+type aPIServiceStreamLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIServiceStreamLogsServer) Send(m *StreamLogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _APIService_GetAccountProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetAccountProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetAccountProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetAccountProof(ctx, req.(*GetAccountProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_TraceAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TraceActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).TraceAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/TraceAction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).TraceAction(ctx, req.(*TraceActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetActPoolStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActPoolStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetActPoolStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetActPoolStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetActPoolStatus(ctx, req.(*GetActPoolStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_StreamPendingActions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPendingActionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServiceServer).StreamPendingActions(m, &aPIServiceStreamPendingActionsServer{stream})
+}
+
+type APIService_StreamPendingActionsServer interface {
+	Send(*StreamPendingActionsResponse) error
+	grpc.ServerStream
+}
+
+type aPIServiceStreamPendingActionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIServiceStreamPendingActionsServer) Send(m *StreamPendingActionsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _APIService_GetConsensusEvidences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsensusEvidencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetConsensusEvidences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetConsensusEvidences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetConsensusEvidences(ctx, req.(*GetConsensusEvidencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetConsensusTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsensusTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetConsensusTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetConsensusTimeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetConsensusTimeline(ctx, req.(*GetConsensusTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetTokenTransfersByAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenTransfersByAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetTokenTransfersByAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetTokenTransfersByAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetTokenTransfersByAddress(ctx, req.(*GetTokenTransfersByAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _APIService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "iotexapi.APIService",
 	HandlerType: (*APIServiceServer)(nil),
@@ -2213,7 +4487,51 @@ var _APIService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetEpochMeta",
 			Handler:    _APIService_GetEpochMeta_Handler,
 		},
+		{
+			MethodName: "GetLogs",
+			Handler:    _APIService_GetLogs_Handler,
+		},
+		{
+			MethodName: "GetAccountProof",
+			Handler:    _APIService_GetAccountProof_Handler,
+		},
+		{
+			MethodName: "TraceAction",
+			Handler:    _APIService_TraceAction_Handler,
+		},
+		{
+			MethodName: "GetActPoolStatus",
+			Handler:    _APIService_GetActPoolStatus_Handler,
+		},
+		{
+			MethodName: "GetConsensusEvidences",
+			Handler:    _APIService_GetConsensusEvidences_Handler,
+		},
+		{
+			MethodName: "GetConsensusTimeline",
+			Handler:    _APIService_GetConsensusTimeline_Handler,
+		},
+		{
+			MethodName: "GetTokenTransfersByAddress",
+			Handler:    _APIService_GetTokenTransfersByAddress_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBlocks",
+			Handler:       _APIService_StreamBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _APIService_StreamLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamPendingActions",
+			Handler:       _APIService_StreamPendingActions_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/api/api.proto",
 }
@@ -7,6 +7,7 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	iotextypes "github.com/iotexproject/iotex-core/protogen/iotextypes"
 	math "math"
 )
 
@@ -29,6 +30,7 @@ const (
 	MessageType_BLOCK         MessageType = 2
 	MessageType_CONSENSUS     MessageType = 3
 	MessageType_BLOCK_REQUEST MessageType = 4
+	MessageType_BLOCK_HEADERS MessageType = 5
 	MessageType_TEST          MessageType = 10001
 )
 
@@ -38,6 +40,7 @@ var MessageType_name = map[int32]string{
 	2:     "BLOCK",
 	3:     "CONSENSUS",
 	4:     "BLOCK_REQUEST",
+	5:     "BLOCK_HEADERS",
 	10001: "TEST",
 }
 
@@ -47,6 +50,7 @@ var MessageType_value = map[string]int32{
 	"BLOCK":         2,
 	"CONSENSUS":     3,
 	"BLOCK_REQUEST": 4,
+	"BLOCK_HEADERS": 5,
 	"TEST":          10001,
 }
 
@@ -59,8 +63,10 @@ func (MessageType) EnumDescriptor() ([]byte, []int) {
 }
 
 type BlockSync struct {
-	Start                uint64   `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
-	End                  uint64   `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	Start uint64 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   uint64 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// headers asks for the headers and footers of the blocks instead of the whole blocks
+	Headers              bool     `protobuf:"varint,4,opt,name=headers,proto3" json:"headers,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -105,6 +111,61 @@ func (m *BlockSync) GetEnd() uint64 {
 	return 0
 }
 
+func (m *BlockSync) GetHeaders() bool {
+	if m != nil {
+		return m.Headers
+	}
+	return false
+}
+
+// BlockHeaders is the response to a block sync request for headers, footers[i] is the footer of the block of headers[i]
+type BlockHeaders struct {
+	Headers              []*iotextypes.BlockHeader `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
+	Footers              []*iotextypes.BlockFooter `protobuf:"bytes,2,rep,name=footers,proto3" json:"footers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *BlockHeaders) Reset()         { *m = BlockHeaders{} }
+func (m *BlockHeaders) String() string { return proto.CompactTextString(m) }
+func (*BlockHeaders) ProtoMessage()    {}
+func (*BlockHeaders) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59d40974ffbedc26, []int{1}
+}
+
+func (m *BlockHeaders) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeaders.Unmarshal(m, b)
+}
+func (m *BlockHeaders) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeaders.Marshal(b, m, deterministic)
+}
+func (m *BlockHeaders) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeaders.Merge(m, src)
+}
+func (m *BlockHeaders) XXX_Size() int {
+	return xxx_messageInfo_BlockHeaders.Size(m)
+}
+func (m *BlockHeaders) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeaders.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeaders proto.InternalMessageInfo
+
+func (m *BlockHeaders) GetHeaders() []*iotextypes.BlockHeader {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *BlockHeaders) GetFooters() []*iotextypes.BlockFooter {
+	if m != nil {
+		return m.Footers
+	}
+	return nil
+}
+
 type BroadcastMsg struct {
 	ChainId              uint32               `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	MsgType              MessageType          `protobuf:"varint,2,opt,name=msg_type,json=msgType,proto3,enum=iotexrpc.MessageType" json:"msg_type,omitempty"`
@@ -120,7 +181,7 @@ func (m *BroadcastMsg) Reset()         { *m = BroadcastMsg{} }
 func (m *BroadcastMsg) String() string { return proto.CompactTextString(m) }
 func (*BroadcastMsg) ProtoMessage()    {}
 func (*BroadcastMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59d40974ffbedc26, []int{1}
+	return fileDescriptor_59d40974ffbedc26, []int{2}
 }
 
 func (m *BroadcastMsg) XXX_Unmarshal(b []byte) error {
@@ -192,7 +253,7 @@ func (m *UnicastMsg) Reset()         { *m = UnicastMsg{} }
 func (m *UnicastMsg) String() string { return proto.CompactTextString(m) }
 func (*UnicastMsg) ProtoMessage()    {}
 func (*UnicastMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59d40974ffbedc26, []int{2}
+	return fileDescriptor_59d40974ffbedc26, []int{3}
 }
 
 func (m *UnicastMsg) XXX_Unmarshal(b []byte) error {
@@ -258,6 +319,7 @@ func (m *UnicastMsg) GetTimestamp() *timestamp.Timestamp {
 func init() {
 	proto.RegisterEnum("iotexrpc.MessageType", MessageType_name, MessageType_value)
 	proto.RegisterType((*BlockSync)(nil), "iotexrpc.BlockSync")
+	proto.RegisterType((*BlockHeaders)(nil), "iotexrpc.BlockHeaders")
 	proto.RegisterType((*BroadcastMsg)(nil), "iotexrpc.BroadcastMsg")
 	proto.RegisterType((*UnicastMsg)(nil), "iotexrpc.UnicastMsg")
 }
@@ -265,31 +327,36 @@ func init() {
 func init() { proto.RegisterFile("proto/rpc/rpc.proto", fileDescriptor_59d40974ffbedc26) }
 
 var fileDescriptor_59d40974ffbedc26 = []byte{
-	// 411 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xcd, 0x8e, 0xd3, 0x30,
-	0x10, 0xc7, 0xf1, 0x36, 0xfd, 0xc8, 0x74, 0x8b, 0x82, 0x01, 0x51, 0xf6, 0x42, 0xd5, 0x53, 0x85,
-	0x44, 0x82, 0x76, 0x11, 0xe2, 0x4a, 0xaa, 0x1e, 0xaa, 0x65, 0x53, 0x91, 0xa4, 0x42, 0xe2, 0x40,
-	0x95, 0xd8, 0xc6, 0x1b, 0xd8, 0xc4, 0x96, 0xed, 0x95, 0xc8, 0x63, 0xf0, 0x58, 0x3c, 0x03, 0x2f,
-	0x83, 0xec, 0x10, 0x3e, 0x0e, 0x2b, 0xed, 0x1e, 0x2c, 0xfd, 0xff, 0x33, 0xe3, 0x99, 0xf9, 0x59,
-	0x86, 0x87, 0x52, 0x09, 0x23, 0x22, 0x25, 0x89, 0x3d, 0xa1, 0x73, 0x78, 0x52, 0x09, 0xc3, 0xbe,
-	0x29, 0x49, 0x4e, 0x9e, 0x71, 0x21, 0xf8, 0x15, 0x8b, 0x5c, 0xbc, 0xbc, 0xfe, 0x1c, 0x99, 0xaa,
-	0x66, 0xda, 0x14, 0xb5, 0xec, 0x4a, 0x97, 0x67, 0xe0, 0xc7, 0x57, 0x82, 0x7c, 0xcd, 0xda, 0x86,
-	0xe0, 0x47, 0x30, 0xd4, 0xa6, 0x50, 0x66, 0x7e, 0xb4, 0x40, 0x2b, 0x2f, 0xed, 0x0c, 0x0e, 0x60,
-	0xc0, 0x1a, 0x3a, 0x1f, 0xb8, 0x98, 0x95, 0xcb, 0x1f, 0x08, 0x8e, 0x63, 0x25, 0x0a, 0x4a, 0x0a,
-	0x6d, 0x2e, 0x34, 0xc7, 0x4f, 0x61, 0x42, 0x2e, 0x8b, 0xaa, 0x39, 0x54, 0x74, 0x8e, 0x16, 0x68,
-	0x35, 0x4b, 0xc7, 0xce, 0x6f, 0x29, 0x7e, 0x09, 0x93, 0x5a, 0xf3, 0x83, 0x69, 0x25, 0x73, 0x6d,
-	0xef, 0x9f, 0x3e, 0x0e, 0xfb, 0xf5, 0xc2, 0x0b, 0xa6, 0x75, 0xc1, 0x59, 0xde, 0x4a, 0x96, 0x8e,
-	0x6b, 0xcd, 0xad, 0xb0, 0xcd, 0xec, 0x8d, 0x52, 0xd0, 0xd6, 0x0d, 0x3d, 0x76, 0xa9, 0x58, 0xd0,
-	0x16, 0x3f, 0x81, 0xb1, 0x64, 0x4c, 0xd9, 0x31, 0xde, 0x02, 0xad, 0xfc, 0x74, 0x64, 0xed, 0x96,
-	0xe2, 0x37, 0xe0, 0xff, 0x21, 0x9b, 0x0f, 0x17, 0x68, 0x35, 0x3d, 0x3d, 0x09, 0x3b, 0xf6, 0xb0,
-	0x67, 0x0f, 0xf3, 0xbe, 0x22, 0xfd, 0x5b, 0xbc, 0xfc, 0x89, 0x00, 0xf6, 0x4d, 0x75, 0x0b, 0x12,
-	0x0c, 0x5e, 0x41, 0xa9, 0x72, 0x14, 0x7e, 0xea, 0xf4, 0x7f, 0x74, 0x83, 0x3b, 0xd3, 0x79, 0x37,
-	0xd2, 0x0d, 0x6f, 0xa6, 0x1b, 0xdd, 0x81, 0xee, 0xf9, 0x27, 0x98, 0xfe, 0xb3, 0x05, 0x9e, 0xc2,
-	0x78, 0x9f, 0x9c, 0x27, 0xbb, 0x0f, 0x49, 0x70, 0x0f, 0x03, 0x8c, 0xde, 0xae, 0xf3, 0xed, 0x2e,
-	0x09, 0x10, 0xf6, 0x61, 0x18, 0xbf, 0xdb, 0xad, 0xcf, 0x83, 0x23, 0x3c, 0x03, 0x7f, 0xbd, 0x4b,
-	0xb2, 0x4d, 0x92, 0xed, 0xb3, 0x60, 0x80, 0x1f, 0xc0, 0xcc, 0x65, 0x0e, 0xe9, 0xe6, 0xfd, 0x7e,
-	0x93, 0xe5, 0x81, 0x87, 0x7d, 0xf0, 0x72, 0xab, 0xbe, 0x27, 0xf1, 0xeb, 0x8f, 0xaf, 0x78, 0x65,
-	0x2e, 0xaf, 0xcb, 0x90, 0x88, 0x3a, 0x72, 0xe4, 0x52, 0x89, 0x2f, 0x8c, 0x98, 0xce, 0xbc, 0x20,
-	0x42, 0xfd, 0xfe, 0x7d, 0x9c, 0x35, 0x51, 0xff, 0x34, 0xe5, 0xc8, 0x85, 0xce, 0x7e, 0x05, 0x00,
-	0x00, 0xff, 0xff, 0x34, 0xa7, 0x38, 0x96, 0xbf, 0x02, 0x00, 0x00,
+	// 487 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xdd, 0x8a, 0xd3, 0x40,
+	0x14, 0x36, 0x6d, 0xfa, 0x93, 0xd3, 0x56, 0xe2, 0xa8, 0x6c, 0xb6, 0x08, 0x96, 0x5e, 0x15, 0xc1,
+	0x44, 0xab, 0x88, 0xb7, 0xdb, 0x5a, 0xd9, 0xb2, 0x36, 0xc5, 0x49, 0x8b, 0xe0, 0x4d, 0x49, 0x32,
+	0xb3, 0x69, 0x74, 0x93, 0x89, 0x33, 0xb3, 0x60, 0x1e, 0xc3, 0xc7, 0xf2, 0x19, 0x7c, 0x19, 0x99,
+	0x89, 0xd9, 0xae, 0x17, 0x85, 0xdd, 0x8b, 0xc0, 0xf9, 0xce, 0xf9, 0xce, 0xc9, 0xf9, 0xbe, 0xc3,
+	0xc0, 0xe3, 0x82, 0x33, 0xc9, 0x3c, 0x5e, 0xc4, 0xea, 0x73, 0x35, 0x42, 0xdd, 0x94, 0x49, 0xfa,
+	0x93, 0x17, 0xf1, 0xf0, 0x79, 0xc2, 0x58, 0x72, 0x45, 0x3d, 0x9d, 0x8f, 0xae, 0x2f, 0x3d, 0x99,
+	0x66, 0x54, 0xc8, 0x30, 0x2b, 0x2a, 0xea, 0xf0, 0x59, 0xd5, 0x2f, 0xcb, 0x82, 0x0a, 0x2f, 0xba,
+	0x62, 0xf1, 0xf7, 0x78, 0x1f, 0xa6, 0x79, 0x55, 0x1d, 0xaf, 0xc0, 0x9a, 0xa9, 0x5c, 0x50, 0xe6,
+	0x31, 0x7a, 0x02, 0x2d, 0x21, 0x43, 0x2e, 0x9d, 0xc6, 0xc8, 0x98, 0x98, 0xb8, 0x02, 0xc8, 0x86,
+	0x26, 0xcd, 0x89, 0xd3, 0xd4, 0x39, 0x15, 0x22, 0x07, 0x3a, 0x7b, 0x1a, 0x12, 0xca, 0x85, 0x63,
+	0x8e, 0x8c, 0x49, 0x17, 0xd7, 0x70, 0x2c, 0xa1, 0xaf, 0xc7, 0x9d, 0x57, 0x18, 0xbd, 0x3e, 0x30,
+	0x8d, 0x51, 0x73, 0xd2, 0x9b, 0x9e, 0xb8, 0x7a, 0x73, 0xbd, 0x8d, 0x7b, 0x8b, 0x7a, 0x33, 0x42,
+	0xb5, 0x5c, 0x32, 0x26, 0x55, 0x4b, 0xe3, 0x48, 0xcb, 0x47, 0x5d, 0xc7, 0x35, 0x6f, 0xfc, 0xdb,
+	0x80, 0xfe, 0x8c, 0xb3, 0x90, 0xc4, 0xa1, 0x90, 0x2b, 0x91, 0xa0, 0x53, 0xe8, 0x6a, 0x91, 0xbb,
+	0x94, 0x38, 0xc6, 0xc8, 0x98, 0x0c, 0x70, 0x47, 0xe3, 0x25, 0x41, 0xaf, 0xa0, 0x9b, 0x89, 0x64,
+	0xa7, 0xa6, 0x69, 0x99, 0x0f, 0xa7, 0x4f, 0xdd, 0xda, 0x4c, 0x77, 0x45, 0x85, 0x08, 0x13, 0xba,
+	0x29, 0x0b, 0x8a, 0x3b, 0x99, 0x48, 0x54, 0x80, 0x4e, 0xab, 0x8e, 0x88, 0x91, 0x52, 0x9b, 0xd0,
+	0xd7, 0xa5, 0x19, 0x23, 0x25, 0x3a, 0x81, 0x4e, 0x41, 0x29, 0x57, 0xbf, 0x51, 0x46, 0x58, 0xb8,
+	0xad, 0xe0, 0x92, 0xa0, 0xf7, 0x60, 0xdd, 0xdc, 0xc1, 0x69, 0x8d, 0x8c, 0x49, 0x6f, 0x3a, 0x74,
+	0xab, 0x4b, 0xb9, 0xf5, 0xa5, 0xdc, 0x4d, 0xcd, 0xc0, 0x07, 0xf2, 0xf8, 0x8f, 0x01, 0xb0, 0xcd,
+	0xd3, 0x3b, 0x28, 0x41, 0x60, 0x86, 0x84, 0x70, 0xad, 0xc2, 0xc2, 0x3a, 0xfe, 0x4f, 0x5d, 0xf3,
+	0xde, 0xea, 0xcc, 0xa3, 0xea, 0x5a, 0xc7, 0xd5, 0xb5, 0xef, 0xa1, 0xee, 0xc5, 0x0f, 0xe8, 0xdd,
+	0xda, 0x02, 0xf5, 0xa0, 0xb3, 0xf5, 0x2f, 0xfc, 0xf5, 0x17, 0xdf, 0x7e, 0x80, 0x00, 0xda, 0x67,
+	0xf3, 0xcd, 0x72, 0xed, 0xdb, 0x06, 0xb2, 0xa0, 0x35, 0xfb, 0xb4, 0x9e, 0x5f, 0xd8, 0x0d, 0x34,
+	0x00, 0x6b, 0xbe, 0xf6, 0x83, 0x85, 0x1f, 0x6c, 0x03, 0xbb, 0x89, 0x1e, 0xc1, 0x40, 0x57, 0x76,
+	0x78, 0xf1, 0x79, 0xbb, 0x08, 0x36, 0xb6, 0x79, 0x48, 0x9d, 0x2f, 0xce, 0x3e, 0x2c, 0x70, 0x60,
+	0xb7, 0x90, 0x05, 0xe6, 0x46, 0x15, 0x7f, 0xf9, 0xb3, 0x77, 0x5f, 0xdf, 0x26, 0xa9, 0xdc, 0x5f,
+	0x47, 0x6e, 0xcc, 0x32, 0x4f, 0x9b, 0x51, 0x70, 0xf6, 0x8d, 0xc6, 0xb2, 0x02, 0x2f, 0x63, 0xc6,
+	0xff, 0x3d, 0x9f, 0x84, 0xe6, 0x5e, 0xed, 0x56, 0xd4, 0xd6, 0xa9, 0x37, 0x7f, 0x07, 0x00, 0x2a,
+	0xaa, 0x4e, 0xaf, 0x80, 0x03, 0x00, 0x00,
 }
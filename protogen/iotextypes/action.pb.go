@@ -1729,12 +1729,14 @@ func (m *Action) GetSignature() []byte {
 }
 
 type Receipt struct {
-	Status               uint64   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	BlkHeight            uint64   `protobuf:"varint,2,opt,name=blkHeight,proto3" json:"blkHeight,omitempty"`
-	ActHash              []byte   `protobuf:"bytes,3,opt,name=actHash,proto3" json:"actHash,omitempty"`
-	GasConsumed          uint64   `protobuf:"varint,4,opt,name=gasConsumed,proto3" json:"gasConsumed,omitempty"`
-	ContractAddress      string   `protobuf:"bytes,5,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
-	Logs                 []*Log   `protobuf:"bytes,6,rep,name=logs,proto3" json:"logs,omitempty"`
+	Status          uint64 `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	BlkHeight       uint64 `protobuf:"varint,2,opt,name=blkHeight,proto3" json:"blkHeight,omitempty"`
+	ActHash         []byte `protobuf:"bytes,3,opt,name=actHash,proto3" json:"actHash,omitempty"`
+	GasConsumed     uint64 `protobuf:"varint,4,opt,name=gasConsumed,proto3" json:"gasConsumed,omitempty"`
+	ContractAddress string `protobuf:"bytes,5,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
+	Logs            []*Log `protobuf:"bytes,6,rep,name=logs,proto3" json:"logs,omitempty"`
+	// return data of a reverted execution, which is not part of the receipt hash
+	RevertData           []byte   `protobuf:"bytes,7,opt,name=revertData,proto3" json:"revertData,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1807,6 +1809,13 @@ func (m *Receipt) GetLogs() []*Log {
 	return nil
 }
 
+func (m *Receipt) GetRevertData() []byte {
+	if m != nil {
+		return m.RevertData
+	}
+	return nil
+}
+
 type Log struct {
 	ContractAddress      string   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
 	Topics               [][]byte `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
@@ -2064,111 +2073,112 @@ func init() {
 func init() { proto.RegisterFile("proto/types/action.proto", fileDescriptor_d4dd5ed50f883f28) }
 
 var fileDescriptor_d4dd5ed50f883f28 = []byte{
-	// 1685 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0x5f, 0x6f, 0x1b, 0x45,
-	0x10, 0xf7, 0xbf, 0xb8, 0xc9, 0xc4, 0x6e, 0x9c, 0x25, 0x75, 0x2f, 0x69, 0x69, 0xa3, 0x2b, 0xa0,
-	0x28, 0x14, 0x47, 0x0a, 0x6a, 0x95, 0x82, 0x54, 0xd1, 0xfc, 0xab, 0x81, 0x54, 0x98, 0x4d, 0xc4,
-	0x43, 0x41, 0x42, 0x97, 0xf3, 0xc6, 0x3e, 0x72, 0xbe, 0x3d, 0xdd, 0xed, 0xa5, 0x71, 0x1f, 0x78,
-	0x86, 0x8f, 0xc2, 0x47, 0xe0, 0x03, 0xf0, 0xc2, 0x1b, 0x1f, 0x08, 0x09, 0xed, 0x9f, 0x3b, 0xef,
-	0xfa, 0xce, 0x69, 0x53, 0x55, 0xe2, 0xcd, 0x33, 0xfb, 0xdb, 0xdf, 0xcc, 0xce, 0x8c, 0x77, 0xe6,
-	0x16, 0xac, 0x30, 0xa2, 0x8c, 0x6e, 0xb1, 0x71, 0x48, 0xe2, 0x2d, 0xc7, 0x65, 0x1e, 0x0d, 0x3a,
-	0x42, 0x85, 0xc0, 0xa3, 0x8c, 0x5c, 0x8a, 0x85, 0xb5, 0xfb, 0x03, 0x4a, 0x07, 0x3e, 0xd9, 0x12,
-	0x2b, 0xa7, 0xc9, 0xd9, 0x16, 0xf3, 0x46, 0x24, 0x66, 0xce, 0x28, 0x94, 0x60, 0xfb, 0x25, 0xcc,
-	0x9f, 0x44, 0x4e, 0x10, 0x9f, 0x91, 0x08, 0xb5, 0xa1, 0xee, 0x8c, 0x68, 0x12, 0x30, 0xab, 0xbc,
-	0x5e, 0xde, 0x58, 0xc0, 0x4a, 0x42, 0x77, 0x61, 0x21, 0x22, 0xae, 0x17, 0x7a, 0x24, 0x60, 0x56,
-	0x45, 0x2c, 0x4d, 0x14, 0xc8, 0x82, 0x1b, 0xa1, 0x33, 0xf6, 0xa9, 0xd3, 0xb7, 0xaa, 0xeb, 0xe5,
-	0x8d, 0x06, 0x4e, 0x45, 0xbb, 0x0f, 0xb5, 0x1f, 0x28, 0x23, 0x68, 0x07, 0x16, 0x32, 0xb3, 0x82,
-	0x7a, 0x71, 0x7b, 0xad, 0x23, 0x1d, 0xeb, 0xa4, 0x8e, 0x75, 0x4e, 0x52, 0x04, 0x9e, 0x80, 0x91,
-	0x0d, 0x8d, 0x0b, 0xca, 0x08, 0x79, 0xd6, 0xef, 0x47, 0x24, 0x8e, 0x95, 0x71, 0x43, 0x67, 0x8f,
-	0x61, 0x61, 0xcf, 0x09, 0xfa, 0x5e, 0xdf, 0x61, 0x84, 0x3b, 0xe3, 0x28, 0xac, 0x3c, 0x43, 0x2a,
-	0xa2, 0x15, 0x98, 0xe3, 0xdb, 0x24, 0x47, 0x03, 0x4b, 0x81, 0x1f, 0x39, 0x4c, 0x4e, 0xbf, 0x25,
-	0x63, 0xe5, 0xbb, 0x92, 0xd0, 0x47, 0xd0, 0x8c, 0xc8, 0x2b, 0x27, 0xea, 0xa7, 0x96, 0x6b, 0x82,
-	0xcd, 0x54, 0xda, 0x87, 0xd0, 0xcc, 0x4c, 0x1f, 0x79, 0x31, 0x43, 0x8f, 0x00, 0xdc, 0x54, 0xc1,
-	0x3d, 0xa8, 0x6e, 0x2c, 0x6e, 0xdf, 0xea, 0x4c, 0xf2, 0xd1, 0xc9, 0xe0, 0x58, 0x03, 0xda, 0xa7,
-	0xd0, 0xec, 0x25, 0xac, 0x47, 0x7d, 0x1f, 0x93, 0x38, 0xf1, 0x19, 0x77, 0x6b, 0x48, 0xbc, 0xc1,
-	0x50, 0x66, 0xa2, 0x86, 0x95, 0x84, 0x9e, 0x18, 0xfc, 0x15, 0x11, 0xca, 0xd5, 0x42, 0x7e, 0xee,
-	0x8e, 0x61, 0xe3, 0x18, 0x16, 0x0e, 0x2e, 0x89, 0x9b, 0xf0, 0x42, 0x99, 0x99, 0xe9, 0x35, 0x98,
-	0x77, 0x69, 0xc0, 0x22, 0xc7, 0x4d, 0x13, 0x9d, 0xc9, 0x08, 0x41, 0xad, 0xef, 0x30, 0x47, 0x05,
-	0x4a, 0xfc, 0xb6, 0xff, 0x29, 0x43, 0xf3, 0x98, 0x39, 0x11, 0x3b, 0x4e, 0x4e, 0xf7, 0x86, 0x8e,
-	0x17, 0xf0, 0x04, 0xb8, 0xfc, 0xc7, 0xd7, 0xfb, 0x82, 0xba, 0x89, 0x53, 0x11, 0x6d, 0xc0, 0x52,
-	0x4c, 0xdc, 0x24, 0xf2, 0xd8, 0x78, 0x9f, 0x84, 0x34, 0xf6, 0x52, 0x13, 0xd3, 0x6a, 0xb4, 0x09,
-	0x2d, 0x1a, 0x92, 0xc8, 0xe1, 0xae, 0xa6, 0xd0, 0xaa, 0x80, 0xe6, 0xf4, 0x68, 0x1d, 0x16, 0x63,
-	0xee, 0x40, 0x57, 0x86, 0xab, 0x26, 0xc2, 0xa5, 0xab, 0x50, 0x07, 0x50, 0xe8, 0x44, 0x24, 0x50,
-	0xf2, 0x77, 0x67, 0x67, 0x31, 0x61, 0xd6, 0x9c, 0x00, 0x16, 0xac, 0xd8, 0x11, 0x34, 0x8e, 0x19,
-	0x0d, 0xdf, 0xe2, 0x44, 0xf7, 0x00, 0x62, 0x46, 0x43, 0x65, 0xba, 0x22, 0x18, 0x35, 0x8d, 0x38,
-	0xb1, 0x62, 0x49, 0xcb, 0xa8, 0xaa, 0x4e, 0x6c, 0xaa, 0xed, 0xc7, 0x00, 0x2f, 0x48, 0x74, 0xee,
-	0x13, 0x4c, 0xa9, 0x88, 0x74, 0xe0, 0x8c, 0x88, 0xca, 0x8d, 0xf8, 0x2d, 0xca, 0xd7, 0xf1, 0x13,
-	0x92, 0x95, 0x2f, 0x17, 0xec, 0xd7, 0x30, 0xdf, 0x4b, 0xd8, 0xae, 0x4f, 0xdd, 0xf3, 0x22, 0x6b,
-	0xe5, 0x42, 0x6b, 0x5a, 0x75, 0x55, 0x8c, 0xea, 0x7a, 0x08, 0x73, 0x11, 0xa5, 0x8c, 0x7b, 0xc9,
-	0x0b, 0xb7, 0xad, 0x17, 0xd6, 0xc4, 0x3d, 0x2c, 0x41, 0xf6, 0xcf, 0xd0, 0xdc, 0x8b, 0x88, 0xc3,
-	0x48, 0x9a, 0x8a, 0xd9, 0x81, 0x9a, 0x94, 0x5b, 0x65, 0xf6, 0xc5, 0x52, 0x9d, 0xba, 0x58, 0xec,
-	0x1f, 0xa1, 0x79, 0x4c, 0x18, 0xf3, 0x33, 0x03, 0xef, 0x76, 0x3f, 0xad, 0xc0, 0x9c, 0x17, 0xf4,
-	0xc9, 0xa5, 0x30, 0x50, 0xc3, 0x52, 0xb0, 0x97, 0x61, 0x49, 0x7a, 0xdf, 0xf3, 0x93, 0x91, 0x88,
-	0x8e, 0xfd, 0x14, 0xd0, 0x09, 0x89, 0x46, 0x5e, 0xa0, 0x6b, 0xdf, 0x3e, 0xac, 0xf6, 0x5f, 0x65,
-	0x68, 0xf0, 0x7d, 0xef, 0x31, 0x23, 0x4f, 0xcc, 0x8c, 0x3c, 0xd0, 0x33, 0xa2, 0x9b, 0xea, 0xf0,
-	0xc4, 0xc4, 0x07, 0x01, 0x8b, 0xc6, 0x2a, 0x3d, 0x6b, 0x3b, 0x00, 0x13, 0x25, 0x6a, 0x41, 0xf5,
-	0x9c, 0x8c, 0x95, 0x79, 0xfe, 0xb3, 0xb8, 0xa0, 0xbe, 0xa8, 0xec, 0x94, 0xed, 0x18, 0x96, 0xc5,
-	0xf1, 0x8d, 0xe4, 0x5e, 0xeb, 0x2c, 0xef, 0x90, 0xec, 0x7f, 0x2b, 0xd0, 0xe4, 0x56, 0xc5, 0x6d,
-	0x72, 0x70, 0x79, 0x2d, 0x8b, 0x9b, 0xd0, 0x0a, 0x23, 0x72, 0xe1, 0xd1, 0x24, 0x4e, 0x7b, 0x99,
-	0x3a, 0x55, 0x4e, 0x8f, 0x9e, 0xc2, 0xda, 0xb4, 0x4e, 0x44, 0xb0, 0x17, 0x51, 0x7a, 0xa6, 0xee,
-	0xb6, 0x2b, 0x10, 0xe8, 0x2b, 0xb8, 0x53, 0xb8, 0x6a, 0xdc, 0x3f, 0x57, 0x41, 0x78, 0x4f, 0x23,
-	0x97, 0x1e, 0xcb, 0x3c, 0x9d, 0x13, 0x36, 0x0d, 0x1d, 0x7a, 0x0c, 0x6d, 0x5d, 0xd6, 0x3c, 0xac,
-	0x0b, 0xf4, 0x8c, 0x55, 0xb4, 0x03, 0xb7, 0x73, 0x2b, 0xca, 0xb3, 0x1b, 0xc2, 0xb3, 0x59, 0xcb,
-	0xf6, 0xef, 0x15, 0x95, 0xf5, 0xa1, 0xe3, 0xfb, 0x24, 0x18, 0x90, 0x6b, 0xe6, 0xa0, 0x0d, 0x75,
-	0x97, 0x8a, 0xff, 0xbe, 0xaa, 0x60, 0x29, 0xa1, 0x87, 0xb0, 0xec, 0xa6, 0x94, 0xd9, 0x91, 0x65,
-	0x98, 0xf3, 0x0b, 0x3c, 0xba, 0x39, 0xa5, 0x76, 0xf8, 0x9a, 0xd8, 0x77, 0x15, 0x04, 0xed, 0xc2,
-	0xdd, 0xe2, 0x65, 0x15, 0x06, 0x79, 0xef, 0x5f, 0x89, 0xb1, 0xff, 0xac, 0xc0, 0x2a, 0x8f, 0x05,
-	0x26, 0x71, 0x48, 0x83, 0x98, 0xfc, 0xbf, 0x31, 0xd9, 0x84, 0x56, 0xa4, 0x1c, 0xc9, 0xc0, 0x32,
-	0x10, 0x39, 0x3d, 0xaf, 0xee, 0x69, 0x9d, 0x16, 0x3e, 0x59, 0x69, 0x57, 0x20, 0xde, 0x54, 0xdd,
-	0xf5, 0x37, 0x56, 0xb7, 0x7d, 0x02, 0x2d, 0x1e, 0xba, 0x43, 0x2f, 0x70, 0x7c, 0xef, 0xf5, 0x7b,
-	0x8a, 0x98, 0xfd, 0xa9, 0x2c, 0xce, 0x5c, 0x3b, 0x50, 0xe0, 0xb2, 0x01, 0xfe, 0x55, 0x5e, 0xc3,
-	0xfa, 0x58, 0x5b, 0x84, 0xe3, 0x7f, 0xc4, 0x3e, 0x09, 0xa8, 0xb8, 0xf0, 0x3d, 0x1a, 0xa8, 0x2b,
-	0xc3, 0xd0, 0xf1, 0x5b, 0x92, 0xbe, 0x0a, 0x54, 0x7a, 0x16, 0xb0, 0x14, 0xcc, 0xab, 0xac, 0x36,
-	0x7d, 0x95, 0xfd, 0xd6, 0x04, 0x78, 0x26, 0x06, 0xf2, 0x3d, 0x1a, 0x89, 0x91, 0xf4, 0x82, 0x44,
-	0x31, 0xb7, 0xa0, 0xda, 0xa2, 0x12, 0x39, 0x79, 0x40, 0x03, 0x97, 0xa8, 0xc3, 0x4a, 0x81, 0xcf,
-	0x60, 0x03, 0x27, 0x3e, 0xf2, 0x46, 0x6a, 0xea, 0xa9, 0xe1, 0x4c, 0x56, 0x6b, 0xbd, 0xc8, 0x73,
-	0x89, 0xb2, 0x9b, 0xc9, 0x68, 0x1b, 0xe6, 0x59, 0x5a, 0x1f, 0x20, 0x26, 0xc3, 0x15, 0xbd, 0x5d,
-	0xa4, 0xe1, 0xe8, 0x96, 0x70, 0x86, 0x43, 0x9f, 0x40, 0x8d, 0xcf, 0xc1, 0xd6, 0xa2, 0xc0, 0xb7,
-	0x74, 0x3c, 0x9f, 0xdc, 0xbb, 0x25, 0x2c, 0xd6, 0xd1, 0x23, 0x58, 0x20, 0xe9, 0xf0, 0x68, 0x35,
-	0x04, 0xd8, 0x18, 0x6b, 0xb3, 0xc9, 0xb2, 0x5b, 0xc2, 0x13, 0x24, 0x7a, 0x06, 0xcd, 0x58, 0x9f,
-	0x0e, 0xad, 0x66, 0x7e, 0x62, 0x35, 0xc6, 0xc7, 0x6e, 0x09, 0x9b, 0x3b, 0xd0, 0x53, 0x68, 0xc4,
-	0xda, 0x34, 0x66, 0xdd, 0x14, 0x0c, 0x96, 0xc9, 0x30, 0x59, 0xef, 0x96, 0xb0, 0x81, 0xe7, 0x51,
-	0x09, 0x55, 0x93, 0xb4, 0x96, 0xf2, 0x51, 0x49, 0x1b, 0x28, 0x8f, 0x4a, 0x8a, 0xe3, 0x6e, 0xbb,
-	0x7a, 0xf3, 0xb3, 0x5a, 0x05, 0x83, 0xb6, 0x0e, 0xe0, 0x6e, 0x1b, 0x3b, 0xc4, 0xc9, 0xf5, 0x62,
-	0xb5, 0x96, 0x0b, 0x4e, 0xae, 0x03, 0xc4, 0xc9, 0x8d, 0xf2, 0x7e, 0x0e, 0x4b, 0xae, 0x39, 0xa1,
-	0x58, 0x48, 0x90, 0xdc, 0xc9, 0xfb, 0x91, 0x41, 0xba, 0x25, 0x3c, 0xbd, 0x0b, 0xf5, 0x00, 0xb1,
-	0xdc, 0x5c, 0x63, 0x7d, 0x20, 0xb8, 0xee, 0x19, 0x25, 0x92, 0x43, 0x75, 0x4b, 0xb8, 0x60, 0x2f,
-	0x4f, 0x4a, 0xa8, 0x4d, 0x1f, 0xd6, 0x4a, 0x3e, 0x29, 0xfa, 0x74, 0xc2, 0x93, 0xa2, 0xe3, 0xd1,
-	0x0b, 0x58, 0x0e, 0xa7, 0x27, 0x0c, 0xeb, 0x96, 0x20, 0xf9, 0x70, 0x9a, 0x64, 0x3a, 0xd0, 0xf9,
-	0x9d, 0x3c, 0xd8, 0xa1, 0x3e, 0x3a, 0x58, 0xed, 0x7c, 0xb0, 0x8d, 0xd9, 0x82, 0x07, 0xdb, 0xd8,
-	0x91, 0x79, 0xa4, 0xdf, 0xf4, 0xd6, 0xed, 0x19, 0x1e, 0xe9, 0xa0, 0xcc, 0x23, 0xa3, 0x47, 0x10,
-	0x58, 0x0d, 0x67, 0x35, 0x10, 0xcb, 0x12, 0xb4, 0x1f, 0x4f, 0xd3, 0x16, 0x82, 0xbb, 0x25, 0x3c,
-	0x9b, 0x09, 0x7d, 0x03, 0xad, 0x70, 0xea, 0xb2, 0xb5, 0x56, 0x05, 0xfb, 0xdd, 0x69, 0x76, 0x1d,
-	0xd3, 0x2d, 0xe1, 0xdc, 0xbe, 0x34, 0x02, 0x46, 0x51, 0x5a, 0x6b, 0xc5, 0x11, 0x98, 0xae, 0xdc,
-	0xfc, 0xce, 0xb4, 0x44, 0xb2, 0x8e, 0x75, 0xa7, 0xb8, 0x44, 0xb4, 0x5b, 0xc9, 0xc0, 0xa3, 0x9f,
-	0xa0, 0xdd, 0x97, 0x54, 0x27, 0x14, 0x8b, 0x8f, 0x6e, 0x2f, 0x18, 0x1c, 0x26, 0x41, 0xdf, 0xba,
-	0x27, 0x98, 0x6c, 0x9d, 0x69, 0xbf, 0x10, 0xd9, 0x2d, 0xe1, 0x19, 0x1c, 0x9c, 0xdd, 0xf5, 0x1d,
-	0x6f, 0x74, 0x18, 0xd1, 0x91, 0xc9, 0x7e, 0x3f, 0xcf, 0xbe, 0x57, 0x88, 0xe4, 0xec, 0xc5, 0x1c,
-	0xe8, 0x4b, 0x58, 0x1c, 0x44, 0x4e, 0xc0, 0xa4, 0xd6, 0x5a, 0x17, 0x94, 0xb7, 0x75, 0xca, 0xe7,
-	0x93, 0xe5, 0x6e, 0x09, 0xeb, 0x68, 0x51, 0xcc, 0xfa, 0x5b, 0x80, 0xb5, 0x5d, 0x50, 0xcc, 0x3a,
-	0x40, 0x14, 0xb3, 0xae, 0xd8, 0x9d, 0x87, 0xba, 0x7c, 0x10, 0xb2, 0x2f, 0xa0, 0x2e, 0x3b, 0x11,
-	0xda, 0x84, 0x9a, 0x4b, 0x23, 0xa2, 0x9e, 0x5f, 0x8c, 0x4f, 0xbb, 0x49, 0xaf, 0xc2, 0x02, 0xc3,
-	0x1b, 0x63, 0x4c, 0x82, 0x3e, 0x89, 0x7a, 0xf2, 0x69, 0x44, 0x35, 0x46, 0x5d, 0xc7, 0x5b, 0x60,
-	0xec, 0x0d, 0x02, 0x87, 0x25, 0x11, 0x51, 0xb3, 0xcb, 0x44, 0x61, 0xff, 0x5d, 0x86, 0x1b, 0x98,
-	0xb8, 0xc4, 0x0b, 0x45, 0x9b, 0x8e, 0x99, 0xc3, 0x92, 0x38, 0x6d, 0xbf, 0x52, 0xe2, 0x0c, 0xa7,
-	0xfe, 0xb9, 0xf1, 0xf1, 0x3c, 0x51, 0x88, 0x87, 0x1c, 0x97, 0x75, 0x9d, 0x78, 0x98, 0xbe, 0x2a,
-	0x29, 0x91, 0x7f, 0xf1, 0x0f, 0x9c, 0x78, 0x8f, 0x06, 0x71, 0x32, 0x22, 0xfd, 0xf4, 0x8b, 0x5f,
-	0x53, 0xf1, 0x79, 0x23, 0x7d, 0xb5, 0x48, 0xe7, 0x8d, 0x39, 0x39, 0x6f, 0x4c, 0xa9, 0xd1, 0x03,
-	0xa8, 0xf9, 0x74, 0x10, 0x5b, 0x75, 0xf1, 0x79, 0xb5, 0xa4, 0x47, 0xe5, 0x88, 0x0e, 0xb0, 0x58,
-	0xb4, 0xff, 0x28, 0x43, 0xf5, 0x88, 0x0e, 0x8a, 0x68, 0xcb, 0xc5, 0xb4, 0x6d, 0xa8, 0x33, 0x1a,
-	0x7a, 0x6e, 0x6c, 0x55, 0xd6, 0xab, 0x1b, 0x0d, 0xac, 0xa4, 0xa2, 0x27, 0x14, 0x33, 0x0c, 0xb5,
-	0x2b, 0xc2, 0x30, 0x67, 0x86, 0x21, 0xfb, 0xac, 0xad, 0x8b, 0xa1, 0x42, 0x7d, 0xd6, 0xee, 0x43,
-	0xbb, 0xf8, 0xcf, 0x30, 0xf3, 0xe3, 0x39, 0xf5, 0xa9, 0xa2, 0x3d, 0xeb, 0xec, 0x43, 0xbb, 0xb8,
-	0xe8, 0xaf, 0xc5, 0xf2, 0x3d, 0x2c, 0x6a, 0x75, 0xce, 0x2b, 0x90, 0x47, 0x56, 0x6c, 0xbc, 0x69,
-	0x56, 0xa0, 0x44, 0x9c, 0x8c, 0x43, 0x82, 0x05, 0x66, 0xd6, 0xf7, 0xf0, 0x66, 0x07, 0x60, 0x82,
-	0x45, 0x4b, 0xb0, 0x28, 0xfa, 0x89, 0x54, 0xb5, 0x4a, 0x5c, 0x71, 0x10, 0x52, 0x77, 0xa8, 0x14,
-	0xe5, 0xdd, 0x9d, 0x97, 0x8f, 0x07, 0x1e, 0x1b, 0x26, 0xa7, 0x1d, 0x97, 0x8e, 0xb6, 0x84, 0xc5,
-	0x30, 0xa2, 0xbf, 0x10, 0x97, 0x49, 0xe1, 0x33, 0x5e, 0xef, 0xf2, 0x71, 0x74, 0x40, 0x82, 0xad,
-	0x89, 0x4b, 0xa7, 0x75, 0xa1, 0xfc, 0xfc, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xf4, 0x4c, 0xaa,
-	0x42, 0x67, 0x15, 0x00, 0x00,
+	// 1700 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xdd, 0x6e, 0xdc, 0xb6,
+	0x12, 0xde, 0x3f, 0xaf, 0xed, 0xb1, 0x37, 0x5e, 0xf3, 0x38, 0x1b, 0xd9, 0xc9, 0x49, 0x0c, 0xe5,
+	0x9c, 0xc2, 0x70, 0xd3, 0x35, 0xe0, 0x22, 0x81, 0xd3, 0x02, 0x41, 0xe3, 0xbf, 0x6c, 0x5b, 0x07,
+	0xdd, 0xca, 0x46, 0x2f, 0xd2, 0x02, 0x85, 0xac, 0xa5, 0xd7, 0xaa, 0xb5, 0xa2, 0x40, 0x51, 0x8e,
+	0x37, 0x17, 0xbd, 0x6e, 0x1f, 0xa5, 0x8f, 0xd0, 0x07, 0xe8, 0x03, 0xf4, 0x35, 0xfa, 0x0e, 0x05,
+	0x0a, 0xfe, 0x48, 0x4b, 0x4a, 0x5a, 0x27, 0x0e, 0x02, 0xf4, 0x4e, 0x33, 0xfc, 0xf8, 0xcd, 0x70,
+	0x66, 0x44, 0x0e, 0x09, 0x56, 0x44, 0x09, 0x23, 0x5b, 0x6c, 0x1c, 0xe1, 0x78, 0xcb, 0xf5, 0x98,
+	0x4f, 0xc2, 0xae, 0x50, 0x21, 0xf0, 0x09, 0xc3, 0x57, 0x62, 0x60, 0xed, 0xc1, 0x90, 0x90, 0x61,
+	0x80, 0xb7, 0xc4, 0xc8, 0x69, 0x72, 0xb6, 0xc5, 0xfc, 0x11, 0x8e, 0x99, 0x3b, 0x8a, 0x24, 0xd8,
+	0x7e, 0x05, 0x73, 0x27, 0xd4, 0x0d, 0xe3, 0x33, 0x4c, 0x51, 0x07, 0x9a, 0xee, 0x88, 0x24, 0x21,
+	0xb3, 0xaa, 0xeb, 0xd5, 0x8d, 0x79, 0x47, 0x49, 0xe8, 0x1e, 0xcc, 0x53, 0xec, 0xf9, 0x91, 0x8f,
+	0x43, 0x66, 0xd5, 0xc4, 0xd0, 0x44, 0x81, 0x2c, 0x98, 0x8d, 0xdc, 0x71, 0x40, 0xdc, 0x81, 0x55,
+	0x5f, 0xaf, 0x6e, 0x2c, 0x3a, 0xa9, 0x68, 0x0f, 0xa0, 0xf1, 0x1d, 0x61, 0x18, 0xed, 0xc0, 0x7c,
+	0x66, 0x56, 0x50, 0x2f, 0x6c, 0xaf, 0x75, 0xa5, 0x63, 0xdd, 0xd4, 0xb1, 0xee, 0x49, 0x8a, 0x70,
+	0x26, 0x60, 0x64, 0xc3, 0xe2, 0x25, 0x61, 0x18, 0x3f, 0x1f, 0x0c, 0x28, 0x8e, 0x63, 0x65, 0xdc,
+	0xd0, 0xd9, 0x63, 0x98, 0xdf, 0x73, 0xc3, 0x81, 0x3f, 0x70, 0x19, 0xe6, 0xce, 0xb8, 0x0a, 0x2b,
+	0xd7, 0x90, 0x8a, 0x68, 0x05, 0x66, 0xf8, 0x34, 0xc9, 0xb1, 0xe8, 0x48, 0x81, 0x2f, 0x39, 0x4a,
+	0x4e, 0xbf, 0xc6, 0x63, 0xe5, 0xbb, 0x92, 0xd0, 0xff, 0xa0, 0x45, 0xf1, 0x6b, 0x97, 0x0e, 0x52,
+	0xcb, 0x0d, 0xc1, 0x66, 0x2a, 0xed, 0x43, 0x68, 0x65, 0xa6, 0x8f, 0xfc, 0x98, 0xa1, 0xc7, 0x00,
+	0x5e, 0xaa, 0xe0, 0x1e, 0xd4, 0x37, 0x16, 0xb6, 0x6f, 0x77, 0x27, 0xf9, 0xe8, 0x66, 0x70, 0x47,
+	0x03, 0xda, 0xa7, 0xd0, 0xea, 0x27, 0xac, 0x4f, 0x82, 0xc0, 0xc1, 0x71, 0x12, 0x30, 0xee, 0xd6,
+	0x39, 0xf6, 0x87, 0xe7, 0x32, 0x13, 0x0d, 0x47, 0x49, 0xe8, 0xa9, 0xc1, 0x5f, 0x13, 0xa1, 0x5c,
+	0x2d, 0xe5, 0xe7, 0xee, 0x18, 0x36, 0x8e, 0x61, 0xfe, 0xe0, 0x0a, 0x7b, 0x09, 0x2f, 0x94, 0xa9,
+	0x99, 0x5e, 0x83, 0x39, 0x8f, 0x84, 0x8c, 0xba, 0x5e, 0x9a, 0xe8, 0x4c, 0x46, 0x08, 0x1a, 0x03,
+	0x97, 0xb9, 0x2a, 0x50, 0xe2, 0xdb, 0xfe, 0xb3, 0x0a, 0xad, 0x63, 0xe6, 0x52, 0x76, 0x9c, 0x9c,
+	0xee, 0x9d, 0xbb, 0x7e, 0xc8, 0x13, 0xe0, 0xf1, 0x8f, 0x2f, 0xf7, 0x05, 0x75, 0xcb, 0x49, 0x45,
+	0xb4, 0x01, 0x4b, 0x31, 0xf6, 0x12, 0xea, 0xb3, 0xf1, 0x3e, 0x8e, 0x48, 0xec, 0xa7, 0x26, 0xf2,
+	0x6a, 0xb4, 0x09, 0x6d, 0x12, 0x61, 0xea, 0x72, 0x57, 0x53, 0x68, 0x5d, 0x40, 0x0b, 0x7a, 0xb4,
+	0x0e, 0x0b, 0x31, 0x77, 0xa0, 0x27, 0xc3, 0xd5, 0x10, 0xe1, 0xd2, 0x55, 0xa8, 0x0b, 0x28, 0x72,
+	0x29, 0x0e, 0x95, 0xfc, 0xcd, 0xd9, 0x59, 0x8c, 0x99, 0x35, 0x23, 0x80, 0x25, 0x23, 0x36, 0x85,
+	0xc5, 0x63, 0x46, 0xa2, 0x77, 0x58, 0xd1, 0x7d, 0x80, 0x98, 0x91, 0x48, 0x99, 0xae, 0x09, 0x46,
+	0x4d, 0x23, 0x56, 0xac, 0x58, 0xd2, 0x32, 0xaa, 0xab, 0x15, 0x9b, 0x6a, 0xfb, 0x09, 0xc0, 0x4b,
+	0x4c, 0x2f, 0x02, 0xec, 0x10, 0x22, 0x22, 0x1d, 0xba, 0x23, 0xac, 0x72, 0x23, 0xbe, 0x45, 0xf9,
+	0xba, 0x41, 0x82, 0xb3, 0xf2, 0xe5, 0x82, 0xfd, 0x06, 0xe6, 0xfa, 0x09, 0xdb, 0x0d, 0x88, 0x77,
+	0x51, 0x66, 0xad, 0x5a, 0x6a, 0x4d, 0xab, 0xae, 0x9a, 0x51, 0x5d, 0x8f, 0x60, 0x86, 0x12, 0xc2,
+	0xb8, 0x97, 0xbc, 0x70, 0x3b, 0x7a, 0x61, 0x4d, 0xdc, 0x73, 0x24, 0xc8, 0xfe, 0x11, 0x5a, 0x7b,
+	0x14, 0xbb, 0x0c, 0xa7, 0xa9, 0x98, 0x1e, 0xa8, 0x49, 0xb9, 0xd5, 0xa6, 0x6f, 0x2c, 0xf5, 0xdc,
+	0xc6, 0x62, 0x7f, 0x0f, 0xad, 0x63, 0xcc, 0x58, 0x90, 0x19, 0x78, 0xbf, 0xfd, 0x69, 0x05, 0x66,
+	0xfc, 0x70, 0x80, 0xaf, 0x84, 0x81, 0x86, 0x23, 0x05, 0x7b, 0x19, 0x96, 0xa4, 0xf7, 0xfd, 0x20,
+	0x19, 0x89, 0xe8, 0xd8, 0xcf, 0x00, 0x9d, 0x60, 0x3a, 0xf2, 0x43, 0x5d, 0xfb, 0xee, 0x61, 0xb5,
+	0xff, 0xa8, 0xc2, 0x22, 0x9f, 0xf7, 0x01, 0x33, 0xf2, 0xd4, 0xcc, 0xc8, 0x43, 0x3d, 0x23, 0xba,
+	0xa9, 0x2e, 0x4f, 0x4c, 0x7c, 0x10, 0x32, 0x3a, 0x56, 0xe9, 0x59, 0xdb, 0x01, 0x98, 0x28, 0x51,
+	0x1b, 0xea, 0x17, 0x78, 0xac, 0xcc, 0xf3, 0xcf, 0xf2, 0x82, 0xfa, 0xac, 0xb6, 0x53, 0xb5, 0x63,
+	0x58, 0x16, 0xcb, 0x37, 0x92, 0x7b, 0xa3, 0xb5, 0xbc, 0x47, 0xb2, 0xff, 0xae, 0x41, 0x8b, 0x5b,
+	0x15, 0xbb, 0xc9, 0xc1, 0xd5, 0x8d, 0x2c, 0x6e, 0x42, 0x3b, 0xa2, 0xf8, 0xd2, 0x27, 0x49, 0x9c,
+	0x9e, 0x65, 0x6a, 0x55, 0x05, 0x3d, 0x7a, 0x06, 0x6b, 0x79, 0x9d, 0x88, 0x60, 0x9f, 0x12, 0x72,
+	0xa6, 0xf6, 0xb6, 0x6b, 0x10, 0xe8, 0x0b, 0xb8, 0x5b, 0x3a, 0x6a, 0xec, 0x3f, 0xd7, 0x41, 0xf8,
+	0x99, 0x86, 0xaf, 0x7c, 0x96, 0x79, 0x3a, 0x23, 0x6c, 0x1a, 0x3a, 0xf4, 0x04, 0x3a, 0xba, 0xac,
+	0x79, 0xd8, 0x14, 0xe8, 0x29, 0xa3, 0x68, 0x07, 0xee, 0x14, 0x46, 0x94, 0x67, 0xb3, 0xc2, 0xb3,
+	0x69, 0xc3, 0xf6, 0xaf, 0x35, 0x95, 0xf5, 0x73, 0x37, 0x08, 0x70, 0x38, 0xc4, 0x37, 0xcc, 0x41,
+	0x07, 0x9a, 0x1e, 0x11, 0xff, 0xbe, 0xaa, 0x60, 0x29, 0xa1, 0x47, 0xb0, 0xec, 0xa5, 0x94, 0xd9,
+	0x92, 0x65, 0x98, 0x8b, 0x03, 0x3c, 0xba, 0x05, 0xa5, 0xb6, 0xf8, 0x86, 0x98, 0x77, 0x1d, 0x04,
+	0xed, 0xc2, 0xbd, 0xf2, 0x61, 0x15, 0x06, 0xb9, 0xef, 0x5f, 0x8b, 0xb1, 0x7f, 0xaf, 0xc1, 0x2a,
+	0x8f, 0x85, 0x83, 0xe3, 0x88, 0x84, 0x31, 0xfe, 0x77, 0x63, 0xb2, 0x09, 0x6d, 0xaa, 0x1c, 0xc9,
+	0xc0, 0x32, 0x10, 0x05, 0x3d, 0xaf, 0xee, 0xbc, 0x4e, 0x0b, 0x9f, 0xac, 0xb4, 0x6b, 0x10, 0x6f,
+	0xab, 0xee, 0xe6, 0x5b, 0xab, 0xdb, 0x3e, 0x81, 0x36, 0x0f, 0xdd, 0xa1, 0x1f, 0xba, 0x81, 0xff,
+	0xe6, 0x03, 0x45, 0xcc, 0xfe, 0x58, 0x16, 0x67, 0xe1, 0x38, 0x50, 0xe0, 0xaa, 0x01, 0xfe, 0x59,
+	0x6e, 0xc3, 0x7a, 0x5b, 0x5b, 0x86, 0xe3, 0x3f, 0xe2, 0x00, 0x87, 0x44, 0x6c, 0xf8, 0x3e, 0x09,
+	0xd5, 0x96, 0x61, 0xe8, 0xf8, 0x2e, 0x49, 0x5e, 0x87, 0x2a, 0x3d, 0xf3, 0x8e, 0x14, 0xcc, 0xad,
+	0xac, 0x91, 0xdf, 0xca, 0x7e, 0x69, 0x01, 0x3c, 0x17, 0x0d, 0xf9, 0x1e, 0xa1, 0xa2, 0x25, 0xbd,
+	0xc4, 0x34, 0xe6, 0x16, 0xd4, 0xb1, 0xa8, 0x44, 0x4e, 0x1e, 0x92, 0xd0, 0xc3, 0x6a, 0xb1, 0x52,
+	0xe0, 0x3d, 0xd8, 0xd0, 0x8d, 0x8f, 0xfc, 0x91, 0xea, 0x7a, 0x1a, 0x4e, 0x26, 0xab, 0xb1, 0x3e,
+	0xf5, 0x3d, 0xac, 0xec, 0x66, 0x32, 0xda, 0x86, 0x39, 0x96, 0xd6, 0x07, 0x88, 0xce, 0x70, 0x45,
+	0x3f, 0x2e, 0xd2, 0x70, 0xf4, 0x2a, 0x4e, 0x86, 0x43, 0x1f, 0x41, 0x83, 0xf7, 0xc1, 0xd6, 0x82,
+	0xc0, 0xb7, 0x75, 0x3c, 0xef, 0xdc, 0x7b, 0x15, 0x47, 0x8c, 0xa3, 0xc7, 0x30, 0x8f, 0xd3, 0xe6,
+	0xd1, 0x5a, 0x5c, 0xaf, 0xe6, 0xdb, 0xda, 0xac, 0xb3, 0xec, 0x55, 0x9c, 0x09, 0x12, 0x3d, 0x87,
+	0x56, 0xac, 0x77, 0x87, 0x56, 0xab, 0xd8, 0xb1, 0x1a, 0xed, 0x63, 0xaf, 0xe2, 0x98, 0x33, 0xd0,
+	0x33, 0x58, 0x8c, 0xb5, 0x6e, 0xcc, 0xba, 0x25, 0x18, 0x2c, 0x93, 0x61, 0x32, 0xde, 0xab, 0x38,
+	0x06, 0x9e, 0x47, 0x25, 0x52, 0x87, 0xa4, 0xb5, 0x54, 0x8c, 0x4a, 0x7a, 0x80, 0xf2, 0xa8, 0xa4,
+	0x38, 0xee, 0xb6, 0xa7, 0x1f, 0x7e, 0x56, 0xbb, 0xa4, 0xd1, 0xd6, 0x01, 0xdc, 0x6d, 0x63, 0x86,
+	0x58, 0xb9, 0x5e, 0xac, 0xd6, 0x72, 0xc9, 0xca, 0x75, 0x80, 0x58, 0xb9, 0xae, 0x40, 0x2f, 0x60,
+	0xc9, 0x33, 0x3b, 0x14, 0x0b, 0x09, 0x92, 0xbb, 0x45, 0x3f, 0x32, 0x48, 0xaf, 0xe2, 0xe4, 0x67,
+	0xa1, 0x3e, 0x20, 0x56, 0xe8, 0x6b, 0xac, 0xff, 0x08, 0xae, 0xfb, 0x46, 0x89, 0x14, 0x50, 0xbd,
+	0x8a, 0x53, 0x32, 0x97, 0x27, 0x25, 0xd2, 0xba, 0x0f, 0x6b, 0xa5, 0x98, 0x14, 0xbd, 0x3b, 0xe1,
+	0x49, 0xd1, 0xf1, 0xe8, 0x25, 0x2c, 0x47, 0xf9, 0x0e, 0xc3, 0xba, 0x2d, 0x48, 0xfe, 0x9b, 0x27,
+	0xc9, 0x07, 0xba, 0x38, 0x93, 0x07, 0x3b, 0xd2, 0x5b, 0x07, 0xab, 0x53, 0x0c, 0xb6, 0xd1, 0x5b,
+	0xf0, 0x60, 0x1b, 0x33, 0x32, 0x8f, 0xf4, 0x9d, 0xde, 0xba, 0x33, 0xc5, 0x23, 0x1d, 0x94, 0x79,
+	0xa4, 0x2b, 0x11, 0x86, 0xd5, 0x68, 0xda, 0x01, 0x62, 0x59, 0x82, 0xf6, 0xff, 0x79, 0xda, 0x52,
+	0x70, 0xaf, 0xe2, 0x4c, 0x67, 0x42, 0x5f, 0x41, 0x3b, 0xca, 0x6d, 0xb6, 0xd6, 0xaa, 0x60, 0xbf,
+	0x97, 0x67, 0xd7, 0x31, 0xbd, 0x8a, 0x53, 0x98, 0x97, 0x46, 0xc0, 0x28, 0x4a, 0x6b, 0xad, 0x3c,
+	0x02, 0xf9, 0xca, 0x2d, 0xce, 0x4c, 0x4b, 0x24, 0x3b, 0xb1, 0xee, 0x96, 0x97, 0x88, 0xb6, 0x2b,
+	0x19, 0x78, 0xf4, 0x03, 0x74, 0x06, 0x92, 0xea, 0x84, 0x38, 0xe2, 0xd2, 0xed, 0x87, 0xc3, 0xc3,
+	0x24, 0x1c, 0x58, 0xf7, 0x05, 0x93, 0xad, 0x33, 0xed, 0x97, 0x22, 0x7b, 0x15, 0x67, 0x0a, 0x07,
+	0x67, 0xf7, 0x02, 0xd7, 0x1f, 0x1d, 0x52, 0x32, 0x32, 0xd9, 0x1f, 0x14, 0xd9, 0xf7, 0x4a, 0x91,
+	0x9c, 0xbd, 0x9c, 0x03, 0x7d, 0x0e, 0x0b, 0x43, 0xea, 0x86, 0x4c, 0x6a, 0xad, 0x75, 0x41, 0x79,
+	0x47, 0xa7, 0x7c, 0x31, 0x19, 0xee, 0x55, 0x1c, 0x1d, 0x2d, 0x8a, 0x59, 0x7f, 0x0b, 0xb0, 0xb6,
+	0x4b, 0x8a, 0x59, 0x07, 0x88, 0x62, 0xd6, 0x15, 0xbb, 0x73, 0xd0, 0x94, 0x0f, 0x42, 0xf6, 0x25,
+	0x34, 0xe5, 0x49, 0x84, 0x36, 0xa1, 0xe1, 0x11, 0x8a, 0xd5, 0xf3, 0x8b, 0x71, 0xb5, 0x9b, 0x9c,
+	0x55, 0x8e, 0xc0, 0xf0, 0x83, 0x31, 0xc6, 0xe1, 0x00, 0xd3, 0xbe, 0x7c, 0x1a, 0x51, 0x07, 0xa3,
+	0xae, 0xe3, 0x47, 0x60, 0xec, 0x0f, 0x43, 0x97, 0x25, 0x14, 0xab, 0xde, 0x65, 0xa2, 0xb0, 0xff,
+	0xaa, 0xc2, 0xac, 0x83, 0x3d, 0xec, 0x47, 0xe2, 0x98, 0x8e, 0x99, 0xcb, 0x92, 0x38, 0x3d, 0x7e,
+	0xa5, 0xc4, 0x19, 0x4e, 0x83, 0x0b, 0xe3, 0xf2, 0x3c, 0x51, 0x88, 0x87, 0x1c, 0x8f, 0xf5, 0xdc,
+	0xf8, 0x3c, 0x7d, 0x55, 0x52, 0x22, 0xbf, 0xf1, 0x0f, 0xdd, 0x78, 0x8f, 0x84, 0x71, 0x32, 0xc2,
+	0x83, 0xf4, 0xc6, 0xaf, 0xa9, 0x78, 0xbf, 0x91, 0xbe, 0x5a, 0xa4, 0xfd, 0xc6, 0x8c, 0xec, 0x37,
+	0x72, 0x6a, 0xf4, 0x10, 0x1a, 0x01, 0x19, 0xc6, 0x56, 0x53, 0x5c, 0xaf, 0x96, 0xf4, 0xa8, 0x1c,
+	0x91, 0xa1, 0x23, 0x06, 0xf9, 0x35, 0x9f, 0xe2, 0x4b, 0x4c, 0xd9, 0x3e, 0x7f, 0xfe, 0x98, 0x15,
+	0xde, 0x68, 0x1a, 0xfb, 0xb7, 0x2a, 0xd4, 0x8f, 0xc8, 0xb0, 0xcc, 0x6c, 0xb5, 0xdc, 0x6c, 0x07,
+	0x9a, 0x8c, 0x44, 0xbe, 0xc7, 0x9f, 0x70, 0xea, 0xfc, 0xd5, 0x49, 0x4a, 0x65, 0x4f, 0x2c, 0x66,
+	0x98, 0x1a, 0xd7, 0x84, 0x69, 0xc6, 0x0c, 0x53, 0x76, 0xed, 0x6d, 0x8a, 0xa6, 0x43, 0x0a, 0xf6,
+	0x3e, 0x74, 0xca, 0x7f, 0x96, 0xa9, 0x97, 0xeb, 0xd4, 0xa7, 0x9a, 0xf6, 0xec, 0xb3, 0x0f, 0x9d,
+	0xf2, 0x9f, 0xe2, 0x46, 0x2c, 0xdf, 0xc2, 0x82, 0xf6, 0x1f, 0xf0, 0x0a, 0xe5, 0x91, 0x17, 0x13,
+	0x6f, 0x99, 0x15, 0x2a, 0x11, 0x27, 0xe3, 0x08, 0x3b, 0x02, 0x33, 0xed, 0xbe, 0xbc, 0xd9, 0x05,
+	0x98, 0x60, 0xd1, 0x12, 0x2c, 0x88, 0xf3, 0x46, 0xaa, 0xda, 0x15, 0xae, 0x38, 0x88, 0x88, 0x77,
+	0xae, 0x14, 0xd5, 0xdd, 0x9d, 0x57, 0x4f, 0x86, 0x3e, 0x3b, 0x4f, 0x4e, 0xbb, 0x1e, 0x19, 0x6d,
+	0x09, 0x8b, 0x11, 0x25, 0x3f, 0x61, 0x8f, 0x49, 0xe1, 0x13, 0xfe, 0x3f, 0xc8, 0xc7, 0xd3, 0x21,
+	0x0e, 0xb7, 0x26, 0x2e, 0x9d, 0x36, 0x85, 0xf2, 0xd3, 0x7f, 0x06, 0x00, 0xcc, 0xd1, 0xb2, 0x96,
+	0x87, 0x15, 0x00, 0x00,
 }
@@ -406,13 +406,15 @@ func (m *EpochData) GetGravityChainStartHeight() uint64 {
 
 // Blockchain Metadata
 type ChainMeta struct {
-	Height               uint64     `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	NumActions           int64      `protobuf:"varint,2,opt,name=numActions,proto3" json:"numActions,omitempty"`
-	Tps                  int64      `protobuf:"varint,3,opt,name=tps,proto3" json:"tps,omitempty"`
-	Epoch                *EpochData `protobuf:"bytes,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Height     uint64     `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	NumActions int64      `protobuf:"varint,2,opt,name=numActions,proto3" json:"numActions,omitempty"`
+	Tps        int64      `protobuf:"varint,3,opt,name=tps,proto3" json:"tps,omitempty"`
+	Epoch      *EpochData `protobuf:"bytes,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// the lowest height whose block body and receipts are not pruned
+	LowestHeight         uint64   `protobuf:"varint,5,opt,name=lowestHeight,proto3" json:"lowestHeight,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ChainMeta) Reset()         { *m = ChainMeta{} }
@@ -468,6 +470,13 @@ func (m *ChainMeta) GetEpoch() *EpochData {
 	return nil
 }
 
+func (m *ChainMeta) GetLowestHeight() uint64 {
+	if m != nil {
+		return m.LowestHeight
+	}
+	return 0
+}
+
 // Block Metadata
 type BlockMeta struct {
 	Hash                 string               `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
@@ -660,51 +669,52 @@ func init() {
 func init() { proto.RegisterFile("proto/types/blockchain.proto", fileDescriptor_0e828f5966a7c29d) }
 
 var fileDescriptor_0e828f5966a7c29d = []byte{
-	// 728 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x4d, 0x6f, 0xd3, 0x4c,
-	0x10, 0x96, 0x13, 0xa7, 0xa9, 0x27, 0xed, 0xdb, 0x6a, 0x5f, 0xa0, 0x56, 0x29, 0x10, 0x59, 0x08,
-	0x85, 0xaf, 0x58, 0x2a, 0x12, 0x2a, 0xea, 0x29, 0xfd, 0x40, 0xbd, 0x80, 0xd0, 0x96, 0x13, 0xb7,
-	0x8d, 0x3d, 0x75, 0x4c, 0x13, 0xaf, 0xb5, 0x5e, 0x57, 0xcd, 0x15, 0x71, 0xe1, 0xce, 0x8d, 0x5f,
-	0xc4, 0xbf, 0x42, 0x3b, 0xb6, 0x1b, 0xc7, 0xa1, 0x48, 0x1c, 0xb8, 0x79, 0x9e, 0x79, 0x32, 0x1f,
-	0xcf, 0xcc, 0x6c, 0x60, 0x2f, 0x55, 0x52, 0x4b, 0x5f, 0xcf, 0x53, 0xcc, 0xfc, 0xf1, 0x54, 0x06,
-	0x97, 0xc1, 0x44, 0xc4, 0xc9, 0x90, 0x60, 0x06, 0xb1, 0xd4, 0x78, 0x4d, 0xce, 0x5d, 0xb7, 0xce,
-	0x14, 0x81, 0x8e, 0x65, 0xc9, 0xda, 0x7d, 0x50, 0xf7, 0x60, 0x12, 0x4a, 0x95, 0xe1, 0x0c, 0x13,
-	0x5d, 0xba, 0x1f, 0x45, 0x52, 0x46, 0x53, 0xf4, 0xc9, 0x1a, 0xe7, 0x17, 0xbe, 0x8e, 0x67, 0x98,
-	0x69, 0x31, 0x4b, 0x0b, 0x82, 0xf7, 0xd5, 0x82, 0xde, 0x91, 0x49, 0x7d, 0x86, 0x22, 0x44, 0xc5,
-	0x7c, 0xb0, 0x03, 0xa9, 0xd0, 0xb5, 0xfa, 0xd6, 0xa0, 0xb7, 0x7f, 0x7f, 0xb8, 0x28, 0x62, 0x58,
-	0xa3, 0x1d, 0x4b, 0x85, 0x9c, 0x88, 0xec, 0x09, 0xfc, 0x97, 0x2a, 0x19, 0xe6, 0x01, 0xaa, 0x0f,
-	0xf9, 0xf8, 0x12, 0xe7, 0x6e, 0xab, 0x6f, 0x0d, 0x36, 0x78, 0x03, 0x65, 0x7b, 0xe0, 0x64, 0x71,
-	0x94, 0x08, 0x9d, 0x2b, 0x74, 0xdb, 0x44, 0x59, 0x00, 0xde, 0xb7, 0x16, 0x6c, 0x35, 0xe2, 0x33,
-	0x17, 0xba, 0x57, 0xa8, 0xb2, 0x58, 0x26, 0x54, 0xcd, 0x26, 0xaf, 0x4c, 0x76, 0x0f, 0xd6, 0x26,
-	0x18, 0x47, 0x13, 0x4d, 0xb9, 0x6c, 0x5e, 0x5a, 0xec, 0x00, 0x9c, 0x9b, 0xfe, 0x28, 0x47, 0x6f,
-	0x7f, 0x77, 0x58, 0x28, 0x30, 0xac, 0x14, 0x18, 0x7e, 0xac, 0x18, 0x7c, 0x41, 0x66, 0x8f, 0x61,
-	0x33, 0x55, 0x78, 0x55, 0x94, 0x20, 0xb2, 0x89, 0x6b, 0x53, 0x85, 0xcb, 0xa0, 0xc9, 0xab, 0xaf,
-	0xb9, 0x94, 0xda, 0xed, 0x90, 0xbb, 0xb4, 0xd8, 0x33, 0xd8, 0x0e, 0x71, 0xaa, 0xc5, 0xb9, 0x16,
-	0x1a, 0x4f, 0xe2, 0x08, 0x33, 0xed, 0xae, 0x11, 0x63, 0x05, 0x67, 0x7d, 0xe8, 0x29, 0x0c, 0x30,
-	0x4e, 0x35, 0x05, 0xea, 0x12, 0xad, 0x0e, 0x2d, 0x46, 0xf2, 0x56, 0x4a, 0x8d, 0x8a, 0x1d, 0xc2,
-	0x46, 0x6d, 0xb0, 0x99, 0x6b, 0xf5, 0xdb, 0x83, 0xde, 0xfe, 0x4e, 0x7d, 0x34, 0xa7, 0x0b, 0x3f,
-	0x5f, 0x22, 0x2f, 0x4b, 0xd2, 0xfa, 0x0b, 0x49, 0xbc, 0x37, 0xe0, 0x50, 0x15, 0x47, 0x32, 0x9c,
-	0xb3, 0x17, 0xd0, 0x2d, 0xd6, 0xae, 0x4a, 0xcf, 0xea, 0xe9, 0x47, 0xe4, 0xe2, 0x15, 0xc5, 0xfb,
-	0x6e, 0x41, 0x87, 0x7e, 0xcb, 0x7c, 0x33, 0x29, 0x33, 0xd1, 0x72, 0xa1, 0x76, 0x6e, 0x59, 0x28,
-	0x5e, 0xd2, 0xd8, 0x53, 0xb0, 0xc7, 0x32, 0x9c, 0x97, 0xa5, 0xde, 0x5d, 0xa1, 0x9b, 0x6a, 0x38,
-	0x51, 0x4c, 0xec, 0x0b, 0x52, 0xa8, 0x1c, 0xf5, 0x6a, 0xec, 0x42, 0x40, 0x5e, 0xd2, 0xbc, 0x43,
-	0x58, 0xe7, 0x85, 0xce, 0x19, 0xf3, 0x61, 0xbd, 0xd4, 0xbc, 0xea, 0xe8, 0xff, 0xfa, 0xcf, 0x4b,
-	0x1e, 0xbf, 0x21, 0x79, 0x12, 0x9c, 0xd3, 0x54, 0x06, 0x93, 0x13, 0xa1, 0x05, 0xdb, 0x86, 0x76,
-	0x92, 0xcf, 0xa8, 0x27, 0x9b, 0x9b, 0xcf, 0x3f, 0xac, 0xe4, 0x4e, 0xa4, 0xc4, 0x55, 0xac, 0xe7,
-	0xc7, 0xe6, 0xb6, 0xcf, 0xb5, 0x50, 0xfa, 0xac, 0x20, 0xb6, 0x89, 0x78, 0x9b, 0xdb, 0xfb, 0x62,
-	0x81, 0x43, 0xe0, 0x3b, 0xd4, 0xa2, 0x16, 0xdf, 0x5a, 0x8a, 0xff, 0x10, 0x20, 0xc9, 0x67, 0xa3,
-	0x72, 0x36, 0x26, 0x77, 0x9b, 0xd7, 0x10, 0x53, 0xa9, 0x4e, 0x33, 0xca, 0xd5, 0xe6, 0xe6, 0x93,
-	0x3d, 0x87, 0x0e, 0x9a, 0x46, 0x68, 0xc5, 0x1b, 0x12, 0xdf, 0x74, 0xc8, 0x0b, 0x8e, 0xf7, 0xb3,
-	0x55, 0x6e, 0x01, 0x15, 0xc1, 0xc0, 0x9e, 0x98, 0xe3, 0x30, 0x25, 0x38, 0x9c, 0xbe, 0xff, 0xc1,
-	0x2d, 0x2e, 0xb7, 0x64, 0xaf, 0xb4, 0x34, 0x80, 0xad, 0xea, 0x6d, 0x19, 0x85, 0xa1, 0xc2, 0x2c,
-	0xa3, 0x73, 0x74, 0x78, 0x13, 0x36, 0x6f, 0x93, 0x56, 0x22, 0xc9, 0x2e, 0x50, 0x8d, 0x66, 0x32,
-	0x4f, 0x8a, 0xab, 0x74, 0x78, 0x03, 0xad, 0xdd, 0x75, 0x97, 0xfc, 0xd5, 0x5d, 0x37, 0x6e, 0x75,
-	0x9d, 0x9c, 0x75, 0xe8, 0xb7, 0x97, 0xef, 0x10, 0x6d, 0x05, 0xf7, 0x7e, 0x58, 0xd0, 0x1b, 0x05,
-	0x81, 0xc9, 0x48, 0x6a, 0xba, 0xd0, 0x15, 0x65, 0xfd, 0x85, 0xa0, 0x95, 0x69, 0x3c, 0x63, 0x31,
-	0x15, 0x49, 0x80, 0x24, 0xaa, 0xc3, 0x2b, 0x93, 0xdd, 0x81, 0x4e, 0x22, 0x0d, 0x5e, 0x2c, 0x4f,
-	0x61, 0x30, 0x0f, 0x36, 0x52, 0x4c, 0xc2, 0x38, 0x89, 0xde, 0x93, 0xd3, 0x26, 0xe7, 0x12, 0xd6,
-	0x50, 0xb5, 0x43, 0x8c, 0x1a, 0x72, 0x74, 0xf0, 0xe9, 0x75, 0x14, 0xeb, 0x49, 0x3e, 0x1e, 0x06,
-	0x72, 0xe6, 0xd3, 0x4e, 0xa4, 0x4a, 0x7e, 0xc6, 0x40, 0x17, 0xc6, 0x4b, 0xf3, 0xda, 0x17, 0xff,
-	0x23, 0x11, 0x26, 0xfe, 0x62, 0x69, 0xc6, 0x6b, 0x04, 0xbe, 0xfa, 0x15, 0x00, 0x00, 0xff, 0xff,
-	0x80, 0x31, 0x64, 0xc8, 0xcf, 0x06, 0x00, 0x00,
+	// 740 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x3d, 0x6f, 0xdb, 0x48,
+	0x10, 0x05, 0x25, 0xca, 0x32, 0x47, 0xf6, 0xd9, 0xd8, 0xfb, 0x30, 0xe1, 0xf3, 0xdd, 0x09, 0xc4,
+	0xe1, 0xa0, 0xcb, 0x87, 0x08, 0x38, 0x40, 0xe0, 0xc0, 0x95, 0xfc, 0x11, 0xb8, 0x49, 0x10, 0xac,
+	0x53, 0xa5, 0x5b, 0x91, 0x63, 0x8a, 0xb1, 0xc4, 0x25, 0x96, 0x4b, 0xc7, 0xea, 0xd3, 0xa4, 0x4f,
+	0x97, 0x3e, 0xff, 0x25, 0xff, 0x2a, 0xd8, 0x59, 0xd2, 0xa2, 0xa4, 0x38, 0x40, 0x8a, 0x74, 0x9c,
+	0x37, 0x8f, 0x3b, 0x6f, 0xde, 0xcc, 0x92, 0x70, 0x90, 0x2b, 0xa9, 0x65, 0xa8, 0xe7, 0x39, 0x16,
+	0xe1, 0x78, 0x2a, 0xa3, 0xeb, 0x68, 0x22, 0xd2, 0x6c, 0x48, 0x30, 0x83, 0x54, 0x6a, 0xbc, 0xa5,
+	0xe4, 0xbe, 0xdf, 0x64, 0x8a, 0x48, 0xa7, 0xb2, 0x62, 0xed, 0xff, 0xd5, 0xcc, 0x60, 0x16, 0x4b,
+	0x55, 0xe0, 0x0c, 0x33, 0x5d, 0xa5, 0xff, 0x49, 0xa4, 0x4c, 0xa6, 0x18, 0x52, 0x34, 0x2e, 0xaf,
+	0x42, 0x9d, 0xce, 0xb0, 0xd0, 0x62, 0x96, 0x5b, 0x42, 0xf0, 0xde, 0x81, 0xde, 0x89, 0x29, 0x7d,
+	0x81, 0x22, 0x46, 0xc5, 0x42, 0x70, 0x23, 0xa9, 0xd0, 0x77, 0xfa, 0xce, 0xa0, 0x77, 0xf8, 0xe7,
+	0x70, 0x21, 0x62, 0xd8, 0xa0, 0x9d, 0x4a, 0x85, 0x9c, 0x88, 0xec, 0x3f, 0xf8, 0x25, 0x57, 0x32,
+	0x2e, 0x23, 0x54, 0xaf, 0xca, 0xf1, 0x35, 0xce, 0xfd, 0x56, 0xdf, 0x19, 0x6c, 0xf1, 0x15, 0x94,
+	0x1d, 0x80, 0x57, 0xa4, 0x49, 0x26, 0x74, 0xa9, 0xd0, 0x6f, 0x13, 0x65, 0x01, 0x04, 0x1f, 0x5a,
+	0xb0, 0xb3, 0x72, 0x3e, 0xf3, 0xa1, 0x7b, 0x83, 0xaa, 0x48, 0x65, 0x46, 0x6a, 0xb6, 0x79, 0x1d,
+	0xb2, 0x3f, 0x60, 0x63, 0x82, 0x69, 0x32, 0xd1, 0x54, 0xcb, 0xe5, 0x55, 0xc4, 0x8e, 0xc0, 0xbb,
+	0xeb, 0x8f, 0x6a, 0xf4, 0x0e, 0xf7, 0x87, 0xd6, 0x81, 0x61, 0xed, 0xc0, 0xf0, 0x75, 0xcd, 0xe0,
+	0x0b, 0x32, 0xfb, 0x17, 0xb6, 0x73, 0x85, 0x37, 0x56, 0x82, 0x28, 0x26, 0xbe, 0x4b, 0x0a, 0x97,
+	0x41, 0x53, 0x57, 0xdf, 0x72, 0x29, 0xb5, 0xdf, 0xa1, 0x74, 0x15, 0xb1, 0x07, 0xb0, 0x1b, 0xe3,
+	0x54, 0x8b, 0x4b, 0x2d, 0x34, 0x9e, 0xa5, 0x09, 0x16, 0xda, 0xdf, 0x20, 0xc6, 0x1a, 0xce, 0xfa,
+	0xd0, 0x53, 0x18, 0x61, 0x9a, 0x6b, 0x3a, 0xa8, 0x4b, 0xb4, 0x26, 0xb4, 0x18, 0xc9, 0x73, 0x29,
+	0x35, 0x2a, 0x76, 0x0c, 0x5b, 0x8d, 0xc1, 0x16, 0xbe, 0xd3, 0x6f, 0x0f, 0x7a, 0x87, 0x7b, 0xcd,
+	0xd1, 0x9c, 0x2f, 0xf2, 0x7c, 0x89, 0xbc, 0x6c, 0x49, 0xeb, 0x07, 0x2c, 0x09, 0x9e, 0x81, 0x47,
+	0x2a, 0x4e, 0x64, 0x3c, 0x67, 0x8f, 0xa0, 0x6b, 0xd7, 0xae, 0x2e, 0xcf, 0x9a, 0xe5, 0x47, 0x94,
+	0xe2, 0x35, 0x25, 0xf8, 0xe8, 0x40, 0x87, 0xde, 0x65, 0xa1, 0x99, 0x94, 0x99, 0x68, 0xb5, 0x50,
+	0x7b, 0xf7, 0x2c, 0x14, 0xaf, 0x68, 0xec, 0x7f, 0x70, 0xc7, 0x32, 0x9e, 0x57, 0x52, 0x7f, 0x5f,
+	0xa3, 0x1b, 0x35, 0x9c, 0x28, 0xe6, 0xec, 0x2b, 0x72, 0xc8, 0x6f, 0xdf, 0x73, 0xb6, 0x35, 0x90,
+	0x57, 0xb4, 0xe0, 0x18, 0x36, 0xb9, 0xf5, 0xb9, 0x60, 0x21, 0x6c, 0x56, 0x9e, 0xd7, 0x1d, 0xfd,
+	0xda, 0x7c, 0xbd, 0xe2, 0xf1, 0x3b, 0x52, 0x20, 0xc1, 0x3b, 0xcf, 0x65, 0x34, 0x39, 0x13, 0x5a,
+	0xb0, 0x5d, 0x68, 0x67, 0xe5, 0x8c, 0x7a, 0x72, 0xb9, 0x79, 0xfc, 0xce, 0x4a, 0xee, 0x25, 0x4a,
+	0xdc, 0xa4, 0x7a, 0x7e, 0x6a, 0xee, 0xf6, 0xa5, 0x16, 0x4a, 0x5f, 0x58, 0x62, 0x9b, 0x88, 0xf7,
+	0xa5, 0x83, 0xcf, 0x0e, 0x78, 0x04, 0xbe, 0x40, 0x2d, 0x1a, 0xe7, 0x3b, 0x4b, 0xe7, 0xff, 0x0d,
+	0x90, 0x95, 0xb3, 0x51, 0x35, 0x1b, 0x53, 0xbb, 0xcd, 0x1b, 0x88, 0x51, 0xaa, 0xf3, 0x82, 0x6a,
+	0xb5, 0xb9, 0x79, 0x64, 0x0f, 0xa1, 0x83, 0xa6, 0x11, 0xdf, 0x5d, 0xb7, 0xf8, 0xae, 0x43, 0x6e,
+	0x39, 0x2c, 0x80, 0xad, 0xa9, 0x7c, 0x87, 0x45, 0xad, 0xb9, 0x43, 0xc5, 0x97, 0xb0, 0xe0, 0x4b,
+	0xab, 0xda, 0x14, 0x12, 0xca, 0xc0, 0x9d, 0x98, 0x0b, 0x64, 0x64, 0x7a, 0x9c, 0x9e, 0x7f, 0xc2,
+	0x7d, 0x5d, 0x6e, 0xdb, 0x5d, 0x6b, 0x7b, 0x00, 0x3b, 0xf5, 0xf7, 0x67, 0x14, 0xc7, 0x0a, 0x8b,
+	0x82, 0xa4, 0x7b, 0x7c, 0x15, 0x36, 0xdf, 0x2f, 0xad, 0x44, 0x56, 0x5c, 0xa1, 0x1a, 0xcd, 0x64,
+	0x99, 0xd9, 0x9b, 0xeb, 0xf1, 0x15, 0xb4, 0x71, 0xf7, 0xbb, 0x94, 0xaf, 0xa2, 0xd5, 0xfb, 0xbc,
+	0x49, 0xc9, 0x26, 0xf4, 0xcd, 0xaf, 0x83, 0x47, 0xb4, 0x35, 0x3c, 0xf8, 0xe4, 0x40, 0x6f, 0x14,
+	0x45, 0xa6, 0x22, 0xb9, 0xe9, 0x43, 0x57, 0x54, 0xfa, 0xad, 0xa1, 0x75, 0x68, 0x32, 0x63, 0x31,
+	0x15, 0x59, 0x84, 0x64, 0xaa, 0xc7, 0xeb, 0x90, 0xfd, 0x06, 0x9d, 0x4c, 0x1a, 0xdc, 0x2e, 0x98,
+	0x0d, 0xcc, 0x24, 0x73, 0xcc, 0xe2, 0x34, 0x4b, 0x5e, 0x52, 0xd2, 0xb5, 0x93, 0x6c, 0x62, 0x2b,
+	0xae, 0xda, 0x59, 0x37, 0x90, 0x93, 0xa3, 0x37, 0x4f, 0x93, 0x54, 0x4f, 0xca, 0xf1, 0x30, 0x92,
+	0xb3, 0x90, 0xf6, 0x26, 0x57, 0xf2, 0x2d, 0x46, 0xda, 0x06, 0x8f, 0xcd, 0x1f, 0xc1, 0xfe, 0x6b,
+	0x12, 0xcc, 0xc2, 0xc5, 0x62, 0x8d, 0x37, 0x08, 0x7c, 0xf2, 0x75, 0x00, 0x73, 0x14, 0xb7, 0xec,
+	0xf3, 0x06, 0x00, 0x00,
 }
@@ -6,6 +6,7 @@ package iotextypes
 import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	math "math"
 )
 
@@ -48,6 +49,39 @@ func (ConsensusVote_Topic) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_2637092b19291c2e, []int{1, 0}
 }
 
+type ConsensusRound_Outcome int32
+
+const (
+	ConsensusRound_PENDING ConsensusRound_Outcome = 0
+	// the block is committed by the node in the round
+	ConsensusRound_COMMITTED ConsensusRound_Outcome = 1
+	// the block is committed by the other delegates, and synced by the node
+	ConsensusRound_SYNCED  ConsensusRound_Outcome = 2
+	ConsensusRound_TIMEOUT ConsensusRound_Outcome = 3
+)
+
+var ConsensusRound_Outcome_name = map[int32]string{
+	0: "PENDING",
+	1: "COMMITTED",
+	2: "SYNCED",
+	3: "TIMEOUT",
+}
+
+var ConsensusRound_Outcome_value = map[string]int32{
+	"PENDING":   0,
+	"COMMITTED": 1,
+	"SYNCED":    2,
+	"TIMEOUT":   3,
+}
+
+func (x ConsensusRound_Outcome) String() string {
+	return proto.EnumName(ConsensusRound_Outcome_name, int32(x))
+}
+
+func (ConsensusRound_Outcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_2637092b19291c2e, []int{5, 0}
+}
+
 type BlockProposal struct {
 	Block                *Block         `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
 	Endorsements         []*Endorsement `protobuf:"bytes,2,rep,name=endorsements,proto3" json:"endorsements,omitempty"`
@@ -148,6 +182,7 @@ type ConsensusMessage struct {
 	// Types that are valid to be assigned to Msg:
 	//	*ConsensusMessage_BlockProposal
 	//	*ConsensusMessage_Vote
+	//	*ConsensusMessage_Evidence
 	Msg                  isConsensusMessage_Msg `protobuf_oneof:"msg"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
@@ -205,10 +240,16 @@ type ConsensusMessage_Vote struct {
 	Vote *ConsensusVote `protobuf:"bytes,101,opt,name=vote,proto3,oneof"`
 }
 
+type ConsensusMessage_Evidence struct {
+	Evidence *ConsensusEvidence `protobuf:"bytes,102,opt,name=evidence,proto3,oneof"`
+}
+
 func (*ConsensusMessage_BlockProposal) isConsensusMessage_Msg() {}
 
 func (*ConsensusMessage_Vote) isConsensusMessage_Msg() {}
 
+func (*ConsensusMessage_Evidence) isConsensusMessage_Msg() {}
+
 func (m *ConsensusMessage) GetMsg() isConsensusMessage_Msg {
 	if m != nil {
 		return m.Msg
@@ -230,47 +271,440 @@ func (m *ConsensusMessage) GetVote() *ConsensusVote {
 	return nil
 }
 
+func (m *ConsensusMessage) GetEvidence() *ConsensusEvidence {
+	if x, ok := m.GetMsg().(*ConsensusMessage_Evidence); ok {
+		return x.Evidence
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*ConsensusMessage) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*ConsensusMessage_BlockProposal)(nil),
 		(*ConsensusMessage_Vote)(nil),
+		(*ConsensusMessage_Evidence)(nil),
+	}
+}
+
+// ConsensusEvidence proves that a delegate endorsed two conflicting consensus messages of the same height and round
+type ConsensusEvidence struct {
+	First                *ConsensusMessage `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second               *ConsensusMessage `protobuf:"bytes,2,opt,name=second,proto3" json:"second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ConsensusEvidence) Reset()         { *m = ConsensusEvidence{} }
+func (m *ConsensusEvidence) String() string { return proto.CompactTextString(m) }
+func (*ConsensusEvidence) ProtoMessage()    {}
+func (*ConsensusEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2637092b19291c2e, []int{3}
+}
+
+func (m *ConsensusEvidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsensusEvidence.Unmarshal(m, b)
+}
+func (m *ConsensusEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsensusEvidence.Marshal(b, m, deterministic)
+}
+func (m *ConsensusEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusEvidence.Merge(m, src)
+}
+func (m *ConsensusEvidence) XXX_Size() int {
+	return xxx_messageInfo_ConsensusEvidence.Size(m)
+}
+func (m *ConsensusEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsensusEvidence proto.InternalMessageInfo
+
+func (m *ConsensusEvidence) GetFirst() *ConsensusMessage {
+	if m != nil {
+		return m.First
+	}
+	return nil
+}
+
+func (m *ConsensusEvidence) GetSecond() *ConsensusMessage {
+	if m != nil {
+		return m.Second
+	}
+	return nil
+}
+
+// ConsensusWALRecord is a record in the write-ahead log of a delegate
+type ConsensusWALRecord struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// Types that are valid to be assigned to Record:
+	//	*ConsensusWALRecord_Message
+	//	*ConsensusWALRecord_Lock
+	Record               isConsensusWALRecord_Record `protobuf_oneof:"record"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *ConsensusWALRecord) Reset()         { *m = ConsensusWALRecord{} }
+func (m *ConsensusWALRecord) String() string { return proto.CompactTextString(m) }
+func (*ConsensusWALRecord) ProtoMessage()    {}
+func (*ConsensusWALRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2637092b19291c2e, []int{4}
+}
+
+func (m *ConsensusWALRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsensusWALRecord.Unmarshal(m, b)
+}
+func (m *ConsensusWALRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsensusWALRecord.Marshal(b, m, deterministic)
+}
+func (m *ConsensusWALRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusWALRecord.Merge(m, src)
+}
+func (m *ConsensusWALRecord) XXX_Size() int {
+	return xxx_messageInfo_ConsensusWALRecord.Size(m)
+}
+func (m *ConsensusWALRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusWALRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsensusWALRecord proto.InternalMessageInfo
+
+func (m *ConsensusWALRecord) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type isConsensusWALRecord_Record interface {
+	isConsensusWALRecord_Record()
+}
+
+type ConsensusWALRecord_Message struct {
+	Message *ConsensusMessage `protobuf:"bytes,2,opt,name=message,proto3,oneof"`
+}
+
+type ConsensusWALRecord_Lock struct {
+	Lock *BlockProposal `protobuf:"bytes,3,opt,name=lock,proto3,oneof"`
+}
+
+func (*ConsensusWALRecord_Message) isConsensusWALRecord_Record() {}
+
+func (*ConsensusWALRecord_Lock) isConsensusWALRecord_Record() {}
+
+func (m *ConsensusWALRecord) GetRecord() isConsensusWALRecord_Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+func (m *ConsensusWALRecord) GetMessage() *ConsensusMessage {
+	if x, ok := m.GetRecord().(*ConsensusWALRecord_Message); ok {
+		return x.Message
+	}
+	return nil
+}
+
+func (m *ConsensusWALRecord) GetLock() *BlockProposal {
+	if x, ok := m.GetRecord().(*ConsensusWALRecord_Lock); ok {
+		return x.Lock
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ConsensusWALRecord) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ConsensusWALRecord_Message)(nil),
+		(*ConsensusWALRecord_Lock)(nil),
+	}
+}
+
+// ConsensusRound is the timeline of a consensus round observed by the node
+type ConsensusRound struct {
+	Height    uint64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round     uint32               `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Proposer  string               `protobuf:"bytes,3,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	Delegates []string             `protobuf:"bytes,4,rep,name=delegates,proto3" json:"delegates,omitempty"`
+	StartTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	// proposalTime is when the proposal is received, which is unset if it is missed
+	ProposalTime         *timestamp.Timestamp           `protobuf:"bytes,6,opt,name=proposalTime,proto3" json:"proposalTime,omitempty"`
+	Endorsements         []*ConsensusEndorsementArrival `protobuf:"bytes,7,rep,name=endorsements,proto3" json:"endorsements,omitempty"`
+	Outcome              ConsensusRound_Outcome         `protobuf:"varint,8,opt,name=outcome,proto3,enum=iotextypes.ConsensusRound_Outcome" json:"outcome,omitempty"`
+	EndTime              *timestamp.Timestamp           `protobuf:"bytes,9,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *ConsensusRound) Reset()         { *m = ConsensusRound{} }
+func (m *ConsensusRound) String() string { return proto.CompactTextString(m) }
+func (*ConsensusRound) ProtoMessage()    {}
+func (*ConsensusRound) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2637092b19291c2e, []int{5}
+}
+
+func (m *ConsensusRound) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsensusRound.Unmarshal(m, b)
+}
+func (m *ConsensusRound) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsensusRound.Marshal(b, m, deterministic)
+}
+func (m *ConsensusRound) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusRound.Merge(m, src)
+}
+func (m *ConsensusRound) XXX_Size() int {
+	return xxx_messageInfo_ConsensusRound.Size(m)
+}
+func (m *ConsensusRound) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusRound.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsensusRound proto.InternalMessageInfo
+
+func (m *ConsensusRound) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ConsensusRound) GetRound() uint32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ConsensusRound) GetProposer() string {
+	if m != nil {
+		return m.Proposer
+	}
+	return ""
+}
+
+func (m *ConsensusRound) GetDelegates() []string {
+	if m != nil {
+		return m.Delegates
+	}
+	return nil
+}
+
+func (m *ConsensusRound) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *ConsensusRound) GetProposalTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ProposalTime
+	}
+	return nil
+}
+
+func (m *ConsensusRound) GetEndorsements() []*ConsensusEndorsementArrival {
+	if m != nil {
+		return m.Endorsements
+	}
+	return nil
+}
+
+func (m *ConsensusRound) GetOutcome() ConsensusRound_Outcome {
+	if m != nil {
+		return m.Outcome
+	}
+	return ConsensusRound_PENDING
+}
+
+func (m *ConsensusRound) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
 	}
+	return nil
+}
+
+// ConsensusEndorsementArrival is when the endorsement of a delegate in a phase is received
+type ConsensusEndorsementArrival struct {
+	Endorser             string               `protobuf:"bytes,1,opt,name=endorser,proto3" json:"endorser,omitempty"`
+	Topic                ConsensusVote_Topic  `protobuf:"varint,2,opt,name=topic,proto3,enum=iotextypes.ConsensusVote_Topic" json:"topic,omitempty"`
+	Time                 *timestamp.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ConsensusEndorsementArrival) Reset()         { *m = ConsensusEndorsementArrival{} }
+func (m *ConsensusEndorsementArrival) String() string { return proto.CompactTextString(m) }
+func (*ConsensusEndorsementArrival) ProtoMessage()    {}
+func (*ConsensusEndorsementArrival) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2637092b19291c2e, []int{6}
+}
+
+func (m *ConsensusEndorsementArrival) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsensusEndorsementArrival.Unmarshal(m, b)
+}
+func (m *ConsensusEndorsementArrival) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsensusEndorsementArrival.Marshal(b, m, deterministic)
+}
+func (m *ConsensusEndorsementArrival) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusEndorsementArrival.Merge(m, src)
+}
+func (m *ConsensusEndorsementArrival) XXX_Size() int {
+	return xxx_messageInfo_ConsensusEndorsementArrival.Size(m)
+}
+func (m *ConsensusEndorsementArrival) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusEndorsementArrival.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsensusEndorsementArrival proto.InternalMessageInfo
+
+func (m *ConsensusEndorsementArrival) GetEndorser() string {
+	if m != nil {
+		return m.Endorser
+	}
+	return ""
+}
+
+func (m *ConsensusEndorsementArrival) GetTopic() ConsensusVote_Topic {
+	if m != nil {
+		return m.Topic
+	}
+	return ConsensusVote_PROPOSAL
+}
+
+func (m *ConsensusEndorsementArrival) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// DelegateLiveness counts the proposals and endorsements missed by a delegate in the rounds observed by the node
+type DelegateLiveness struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	MissedProposals      uint64   `protobuf:"varint,2,opt,name=missedProposals,proto3" json:"missedProposals,omitempty"`
+	MissedEndorsements   uint64   `protobuf:"varint,3,opt,name=missedEndorsements,proto3" json:"missedEndorsements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DelegateLiveness) Reset()         { *m = DelegateLiveness{} }
+func (m *DelegateLiveness) String() string { return proto.CompactTextString(m) }
+func (*DelegateLiveness) ProtoMessage()    {}
+func (*DelegateLiveness) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2637092b19291c2e, []int{7}
+}
+
+func (m *DelegateLiveness) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DelegateLiveness.Unmarshal(m, b)
+}
+func (m *DelegateLiveness) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DelegateLiveness.Marshal(b, m, deterministic)
+}
+func (m *DelegateLiveness) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DelegateLiveness.Merge(m, src)
+}
+func (m *DelegateLiveness) XXX_Size() int {
+	return xxx_messageInfo_DelegateLiveness.Size(m)
+}
+func (m *DelegateLiveness) XXX_DiscardUnknown() {
+	xxx_messageInfo_DelegateLiveness.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DelegateLiveness proto.InternalMessageInfo
+
+func (m *DelegateLiveness) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *DelegateLiveness) GetMissedProposals() uint64 {
+	if m != nil {
+		return m.MissedProposals
+	}
+	return 0
+}
+
+func (m *DelegateLiveness) GetMissedEndorsements() uint64 {
+	if m != nil {
+		return m.MissedEndorsements
+	}
+	return 0
 }
 
 func init() {
 	proto.RegisterEnum("iotextypes.ConsensusVote_Topic", ConsensusVote_Topic_name, ConsensusVote_Topic_value)
+	proto.RegisterEnum("iotextypes.ConsensusRound_Outcome", ConsensusRound_Outcome_name, ConsensusRound_Outcome_value)
 	proto.RegisterType((*BlockProposal)(nil), "iotextypes.BlockProposal")
 	proto.RegisterType((*ConsensusVote)(nil), "iotextypes.ConsensusVote")
 	proto.RegisterType((*ConsensusMessage)(nil), "iotextypes.ConsensusMessage")
+	proto.RegisterType((*ConsensusEvidence)(nil), "iotextypes.ConsensusEvidence")
+	proto.RegisterType((*ConsensusWALRecord)(nil), "iotextypes.ConsensusWALRecord")
+	proto.RegisterType((*ConsensusRound)(nil), "iotextypes.ConsensusRound")
+	proto.RegisterType((*ConsensusEndorsementArrival)(nil), "iotextypes.ConsensusEndorsementArrival")
+	proto.RegisterType((*DelegateLiveness)(nil), "iotextypes.DelegateLiveness")
 }
 
 func init() { proto.RegisterFile("proto/types/consensus.proto", fileDescriptor_2637092b19291c2e) }
 
 var fileDescriptor_2637092b19291c2e = []byte{
-	// 373 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x51, 0x6b, 0xe2, 0x40,
-	0x10, 0xc7, 0x8d, 0x1a, 0xf1, 0x46, 0x3d, 0x72, 0xfb, 0x70, 0x97, 0xf3, 0x3c, 0x4e, 0xf2, 0x72,
-	0x42, 0x69, 0x02, 0x96, 0x96, 0x96, 0x3e, 0xa9, 0x14, 0x2c, 0x55, 0x22, 0x5b, 0xe9, 0x43, 0xdf,
-	0x92, 0x38, 0x24, 0x69, 0x35, 0x1b, 0xb2, 0x6b, 0x69, 0x3f, 0x46, 0xbf, 0x61, 0x3f, 0x4a, 0x71,
-	0x53, 0x9b, 0x0d, 0x42, 0x1f, 0x67, 0xfe, 0xbf, 0xff, 0x7f, 0x76, 0x86, 0x85, 0x3f, 0x69, 0xc6,
-	0x04, 0x73, 0xc4, 0x4b, 0x8a, 0xdc, 0x09, 0x58, 0xc2, 0x31, 0xe1, 0x5b, 0x6e, 0xcb, 0x2e, 0x81,
-	0x98, 0x09, 0x7c, 0x96, 0x5a, 0xb7, 0xa7, 0x82, 0xfe, 0x9a, 0x05, 0x8f, 0x41, 0xe4, 0xc5, 0x49,
-	0x4e, 0x76, 0xff, 0xaa, 0x2a, 0x26, 0x2b, 0x96, 0x71, 0xdc, 0x60, 0x22, 0x72, 0xd9, 0xda, 0x42,
-	0x67, 0xbc, 0xb3, 0x2c, 0x32, 0x96, 0x32, 0xee, 0xad, 0xc9, 0x7f, 0xd0, 0x65, 0x86, 0xa9, 0xf5,
-	0xb5, 0x41, 0x6b, 0xf8, 0xc3, 0x2e, 0x26, 0xd9, 0x92, 0xa4, 0xb9, 0x4e, 0x2e, 0xa1, 0xad, 0xc4,
-	0x71, 0xb3, 0xda, 0xaf, 0x0d, 0x5a, 0xc3, 0x5f, 0x2a, 0x7f, 0x55, 0xe8, 0xb4, 0x04, 0x5b, 0xaf,
-	0x1a, 0x74, 0x26, 0xfb, 0x9d, 0xee, 0x98, 0x40, 0xd2, 0x83, 0x6f, 0x32, 0x77, 0xea, 0xf1, 0x48,
-	0xce, 0x6e, 0xd3, 0xa2, 0x41, 0x4e, 0x41, 0x17, 0x2c, 0x8d, 0x03, 0xb3, 0xda, 0xd7, 0x06, 0xdf,
-	0x87, 0xff, 0xd4, 0x29, 0xa5, 0x1c, 0x7b, 0xb9, 0xc3, 0x68, 0x4e, 0x5b, 0x47, 0xa0, 0xcb, 0x9a,
-	0xb4, 0xa1, 0xb9, 0xa0, 0xee, 0xc2, 0xbd, 0x1d, 0xcd, 0x8c, 0x0a, 0x69, 0x42, 0x7d, 0xe6, 0x4e,
-	0x6e, 0x0c, 0x8d, 0x00, 0x34, 0x26, 0xee, 0x7c, 0x7e, 0xbd, 0x34, 0xaa, 0xd6, 0x9b, 0x06, 0xc6,
-	0x67, 0xd6, 0x1c, 0x39, 0xf7, 0x42, 0x24, 0x3f, 0xa1, 0x11, 0x61, 0x1c, 0x46, 0x42, 0xbe, 0xa9,
-	0x4e, 0x3f, 0x2a, 0x72, 0x01, 0x2d, 0x65, 0x21, 0xf9, 0xac, 0x2f, 0x96, 0x57, 0x59, 0x32, 0x82,
-	0x8e, 0xaf, 0x9e, 0xdc, 0x5c, 0x49, 0xf3, 0xef, 0x83, 0x4b, 0xef, 0x81, 0x69, 0x85, 0x96, 0x1d,
-	0xc4, 0x81, 0xfa, 0x13, 0x13, 0x68, 0xe2, 0xa1, 0xb3, 0x74, 0x8d, 0x69, 0x85, 0x4a, 0x70, 0xac,
-	0x43, 0x6d, 0xc3, 0xc3, 0xf1, 0xf9, 0xfd, 0x59, 0x18, 0x8b, 0x68, 0xeb, 0xdb, 0x01, 0xdb, 0x38,
-	0xd2, 0x95, 0x66, 0xec, 0x01, 0x03, 0x91, 0x17, 0xc7, 0x01, 0xcb, 0xd0, 0x91, 0x3f, 0x23, 0xc4,
-	0xc4, 0x29, 0x62, 0xfd, 0x86, 0x6c, 0x9e, 0xbc, 0x07, 0x00, 0x00, 0xff, 0xff, 0xdf, 0x9b, 0x76,
-	0x25, 0x96, 0x02, 0x00, 0x00,
+	// 773 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x95, 0x5f, 0x8f, 0xdb, 0x44,
+	0x10, 0xc0, 0xe3, 0x38, 0x7f, 0x27, 0x49, 0x71, 0x57, 0x08, 0x4c, 0x7a, 0x55, 0x23, 0xbf, 0x34,
+	0x12, 0xc2, 0x91, 0x42, 0x41, 0x87, 0x8a, 0x2a, 0xe5, 0x9f, 0xc8, 0xa9, 0xc9, 0x25, 0xda, 0x06,
+	0x10, 0xbc, 0x39, 0xf6, 0x9c, 0x63, 0x88, 0xbd, 0x96, 0x77, 0x13, 0xc1, 0x03, 0xaf, 0xbc, 0xf3,
+	0x01, 0x90, 0xe0, 0x33, 0xf2, 0x05, 0x90, 0xd7, 0x76, 0xe2, 0xf4, 0xd2, 0xe6, 0xd4, 0xc7, 0xd9,
+	0xf9, 0xcd, 0x5f, 0xcf, 0x8c, 0xe1, 0x49, 0x18, 0x31, 0xc1, 0x7a, 0xe2, 0xf7, 0x10, 0x79, 0xcf,
+	0x66, 0x01, 0xc7, 0x80, 0xef, 0xb8, 0x29, 0x5f, 0x09, 0x78, 0x4c, 0xe0, 0x6f, 0x52, 0xd7, 0xbe,
+	0xca, 0x83, 0xeb, 0x2d, 0xb3, 0x7f, 0xb5, 0x37, 0x96, 0x17, 0x24, 0x64, 0xfb, 0x69, 0x5e, 0x8b,
+	0x81, 0xc3, 0x22, 0x8e, 0x3e, 0x06, 0x22, 0x55, 0x3f, 0x73, 0x19, 0x73, 0xb7, 0xd8, 0x93, 0xd2,
+	0x7a, 0x77, 0xd7, 0x13, 0x9e, 0x8f, 0x5c, 0x58, 0x7e, 0x98, 0x00, 0xc6, 0x0e, 0x5a, 0xc3, 0xd8,
+	0xe7, 0x32, 0x62, 0x21, 0xe3, 0xd6, 0x96, 0x3c, 0x87, 0xb2, 0x0c, 0xa2, 0x2b, 0x1d, 0xa5, 0xdb,
+	0xe8, 0x3f, 0x36, 0x8f, 0xa9, 0x98, 0x92, 0xa4, 0x89, 0x9e, 0xbc, 0x84, 0x66, 0x2e, 0x1e, 0xd7,
+	0x8b, 0x1d, 0xb5, 0xdb, 0xe8, 0x7f, 0x9a, 0xe7, 0x27, 0x47, 0x3d, 0x3d, 0x81, 0x8d, 0xbf, 0x14,
+	0x68, 0x8d, 0xb2, 0xa2, 0x7f, 0x60, 0x02, 0xc9, 0x15, 0xd4, 0xa5, 0xdf, 0xa9, 0xc5, 0x37, 0x32,
+	0x76, 0x93, 0x1e, 0x1f, 0xc8, 0x57, 0x50, 0x16, 0x2c, 0xf4, 0x6c, 0xbd, 0xd8, 0x51, 0xba, 0x8f,
+	0xfa, 0xcf, 0xf2, 0x51, 0x4e, 0xfc, 0x98, 0xab, 0x18, 0xa3, 0x09, 0x6d, 0x7c, 0x0e, 0x65, 0x29,
+	0x93, 0x26, 0xd4, 0x96, 0x74, 0xb1, 0x5c, 0xbc, 0x19, 0xcc, 0xb4, 0x02, 0xa9, 0x41, 0x69, 0xb6,
+	0x18, 0xbd, 0xd6, 0x14, 0x02, 0x50, 0x19, 0x2d, 0xe6, 0xf3, 0x9b, 0x95, 0x56, 0x34, 0xfe, 0x2e,
+	0x82, 0x76, 0xf0, 0x35, 0x47, 0xce, 0x2d, 0x17, 0xc9, 0x27, 0x50, 0xd9, 0xa0, 0xe7, 0x6e, 0x84,
+	0xcc, 0xa9, 0x44, 0x53, 0x89, 0x7c, 0x03, 0x8d, 0x5c, 0x41, 0x32, 0xad, 0xf7, 0x14, 0x9f, 0x67,
+	0xc9, 0x00, 0x5a, 0xeb, 0x7c, 0xcb, 0x75, 0x47, 0x1a, 0x7f, 0x76, 0xaf, 0xd3, 0x19, 0x30, 0x2d,
+	0xd0, 0x53, 0x0b, 0xd2, 0x83, 0xd2, 0x9e, 0x09, 0xd4, 0xf1, 0xbe, 0xe5, 0x49, 0x37, 0xa6, 0x05,
+	0x2a, 0x41, 0xf2, 0x12, 0x6a, 0xb8, 0xf7, 0x1c, 0x0c, 0x6c, 0xd4, 0xef, 0xa4, 0xd1, 0xd3, 0xb3,
+	0x46, 0x93, 0x14, 0x9a, 0x16, 0xe8, 0xc1, 0x60, 0x58, 0x06, 0xd5, 0xe7, 0xae, 0xf1, 0x07, 0x3c,
+	0xbe, 0xc7, 0x91, 0x3e, 0x94, 0xef, 0xbc, 0x88, 0x8b, 0x74, 0x5c, 0xae, 0xce, 0x7a, 0x4d, 0x9b,
+	0x49, 0x13, 0x94, 0xbc, 0x80, 0x0a, 0x47, 0x9b, 0x05, 0x8e, 0x5e, 0x7c, 0x80, 0x51, 0xca, 0x1a,
+	0xff, 0x2a, 0x40, 0x0e, 0xca, 0x1f, 0x07, 0x33, 0x8a, 0x36, 0x8b, 0x9c, 0x77, 0x7e, 0xa0, 0x6b,
+	0xa8, 0xfa, 0x89, 0x87, 0x87, 0x44, 0x99, 0x16, 0x68, 0x86, 0xc7, 0xcd, 0x95, 0x0b, 0xa0, 0x5e,
+	0xfe, 0x2c, 0x12, 0x1c, 0xd6, 0xa0, 0x12, 0xc9, 0x64, 0x8c, 0xff, 0x54, 0x78, 0x74, 0x70, 0x4d,
+	0xd9, 0x2e, 0x78, 0x77, 0x7e, 0x1f, 0x43, 0x39, 0x8a, 0x01, 0x99, 0x5d, 0x8b, 0x26, 0x02, 0x69,
+	0x43, 0x2d, 0x94, 0xee, 0x31, 0x92, 0xf1, 0xeb, 0xf4, 0x20, 0xc7, 0x1b, 0xe2, 0xe0, 0x16, 0x5d,
+	0x4b, 0x20, 0xd7, 0x4b, 0x1d, 0xb5, 0x5b, 0xa7, 0xc7, 0x07, 0x72, 0x0d, 0x75, 0x2e, 0xac, 0x48,
+	0xac, 0x3c, 0x1f, 0xf5, 0xb2, 0x4c, 0xbd, 0x6d, 0x26, 0xdb, 0x6f, 0x66, 0xdb, 0x6f, 0xae, 0xb2,
+	0xed, 0xa7, 0x47, 0x98, 0xbc, 0x82, 0x66, 0x98, 0x96, 0x24, 0x8d, 0x2b, 0x17, 0x8d, 0x4f, 0x78,
+	0xf2, 0xfa, 0xad, 0x43, 0x50, 0x95, 0x87, 0xe0, 0xf9, 0xf9, 0xf9, 0x3a, 0x82, 0x83, 0x28, 0xf2,
+	0xf6, 0xd6, 0xf6, 0xf4, 0x30, 0x90, 0x6f, 0xa1, 0xca, 0x76, 0xc2, 0x66, 0x3e, 0xea, 0x35, 0xb9,
+	0xea, 0xc6, 0x59, 0x3f, 0xb2, 0xb7, 0xe6, 0x22, 0x21, 0x69, 0x66, 0x42, 0x5e, 0x40, 0x15, 0x03,
+	0x47, 0x56, 0x51, 0xbf, 0x58, 0x45, 0x86, 0x1a, 0xaf, 0xa0, 0x9a, 0x7a, 0x22, 0x0d, 0xa8, 0x2e,
+	0x27, 0xb7, 0xe3, 0x9b, 0xdb, 0xef, 0xb4, 0x02, 0x69, 0x41, 0x3d, 0x39, 0x0e, 0xab, 0xc9, 0x38,
+	0xb9, 0x15, 0x6f, 0x7e, 0xba, 0x1d, 0x4d, 0xc6, 0x5a, 0x31, 0xe6, 0x56, 0x37, 0xf3, 0xc9, 0xe2,
+	0xfb, 0x95, 0xa6, 0x1a, 0xff, 0x28, 0xf0, 0xe4, 0x3d, 0x15, 0xc6, 0x1f, 0x35, 0xad, 0x31, 0x92,
+	0x43, 0x50, 0xa7, 0x07, 0xf9, 0x03, 0x0f, 0x1b, 0x31, 0xa1, 0x14, 0x5f, 0x72, 0x5d, 0xbd, 0x58,
+	0xa5, 0xe4, 0x8c, 0x3f, 0x15, 0xd0, 0xc6, 0xe9, 0xac, 0xcc, 0xbc, 0x3d, 0x06, 0xc8, 0x39, 0xd1,
+	0xa1, 0x6a, 0x39, 0x4e, 0x84, 0x9c, 0xa7, 0x69, 0x65, 0x22, 0xe9, 0xc2, 0x47, 0xbe, 0xc7, 0x39,
+	0x3a, 0xd9, 0xac, 0x73, 0x99, 0x5f, 0x89, 0xbe, 0xfd, 0x4c, 0x4c, 0x20, 0xc9, 0xd3, 0x24, 0x3f,
+	0x02, 0xaa, 0x84, 0xcf, 0x68, 0x86, 0xd7, 0x3f, 0x7f, 0xed, 0x7a, 0x62, 0xb3, 0x5b, 0x9b, 0x36,
+	0xf3, 0x7b, 0xb2, 0xd8, 0x30, 0x62, 0xbf, 0xa0, 0x2d, 0x12, 0xe1, 0x0b, 0x9b, 0x45, 0xe9, 0xef,
+	0xca, 0xc5, 0xa0, 0x77, 0xec, 0xc6, 0xba, 0x22, 0x1f, 0xbf, 0xfc, 0x7f, 0x00, 0x78, 0xfb, 0x86,
+	0x5d, 0x39, 0x07, 0x00, 0x00,
 }
@@ -0,0 +1,617 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: proto/signer/signer.proto
+
+package signerpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetPublicKeyRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPublicKeyRequest) Reset()         { *m = GetPublicKeyRequest{} }
+func (m *GetPublicKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPublicKeyRequest) ProtoMessage()    {}
+func (*GetPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb39d7ffbf21e4ab, []int{0}
+}
+
+func (m *GetPublicKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPublicKeyRequest.Unmarshal(m, b)
+}
+func (m *GetPublicKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPublicKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPublicKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPublicKeyRequest.Merge(m, src)
+}
+func (m *GetPublicKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPublicKeyRequest.Size(m)
+}
+func (m *GetPublicKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPublicKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPublicKeyRequest proto.InternalMessageInfo
+
+type GetPublicKeyResponse struct {
+	PublicKey            []byte   `protobuf:"bytes,1,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPublicKeyResponse) Reset()         { *m = GetPublicKeyResponse{} }
+func (m *GetPublicKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPublicKeyResponse) ProtoMessage()    {}
+func (*GetPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb39d7ffbf21e4ab, []int{1}
+}
+
+func (m *GetPublicKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPublicKeyResponse.Unmarshal(m, b)
+}
+func (m *GetPublicKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPublicKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPublicKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPublicKeyResponse.Merge(m, src)
+}
+func (m *GetPublicKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPublicKeyResponse.Size(m)
+}
+func (m *GetPublicKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPublicKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPublicKeyResponse proto.InternalMessageInfo
+
+func (m *GetPublicKeyResponse) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+type SignBlockHeaderRequest struct {
+	// core is the serialized iotextypes.BlockHeaderCore
+	Core                 []byte   `protobuf:"bytes,1,opt,name=core,proto3" json:"core,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignBlockHeaderRequest) Reset()         { *m = SignBlockHeaderRequest{} }
+func (m *SignBlockHeaderRequest) String() string { return proto.CompactTextString(m) }
+func (*SignBlockHeaderRequest) ProtoMessage()    {}
+func (*SignBlockHeaderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb39d7ffbf21e4ab, []int{2}
+}
+
+func (m *SignBlockHeaderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignBlockHeaderRequest.Unmarshal(m, b)
+}
+func (m *SignBlockHeaderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignBlockHeaderRequest.Marshal(b, m, deterministic)
+}
+func (m *SignBlockHeaderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignBlockHeaderRequest.Merge(m, src)
+}
+func (m *SignBlockHeaderRequest) XXX_Size() int {
+	return xxx_messageInfo_SignBlockHeaderRequest.Size(m)
+}
+func (m *SignBlockHeaderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignBlockHeaderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignBlockHeaderRequest proto.InternalMessageInfo
+
+func (m *SignBlockHeaderRequest) GetCore() []byte {
+	if m != nil {
+		return m.Core
+	}
+	return nil
+}
+
+type SignActionRequest struct {
+	// core is the serialized iotextypes.ActionCore
+	Core                 []byte   `protobuf:"bytes,1,opt,name=core,proto3" json:"core,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignActionRequest) Reset()         { *m = SignActionRequest{} }
+func (m *SignActionRequest) String() string { return proto.CompactTextString(m) }
+func (*SignActionRequest) ProtoMessage()    {}
+func (*SignActionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb39d7ffbf21e4ab, []int{3}
+}
+
+func (m *SignActionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignActionRequest.Unmarshal(m, b)
+}
+func (m *SignActionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignActionRequest.Marshal(b, m, deterministic)
+}
+func (m *SignActionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignActionRequest.Merge(m, src)
+}
+func (m *SignActionRequest) XXX_Size() int {
+	return xxx_messageInfo_SignActionRequest.Size(m)
+}
+func (m *SignActionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignActionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignActionRequest proto.InternalMessageInfo
+
+func (m *SignActionRequest) GetCore() []byte {
+	if m != nil {
+		return m.Core
+	}
+	return nil
+}
+
+type SignEvidenceRequest struct {
+	// evidence is the serialized iotextypes.ConsensusEvidence
+	Evidence             []byte               `protobuf:"bytes,1,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SignEvidenceRequest) Reset()         { *m = SignEvidenceRequest{} }
+func (m *SignEvidenceRequest) String() string { return proto.CompactTextString(m) }
+func (*SignEvidenceRequest) ProtoMessage()    {}
+func (*SignEvidenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb39d7ffbf21e4ab, []int{4}
+}
+
+func (m *SignEvidenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignEvidenceRequest.Unmarshal(m, b)
+}
+func (m *SignEvidenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignEvidenceRequest.Marshal(b, m, deterministic)
+}
+func (m *SignEvidenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignEvidenceRequest.Merge(m, src)
+}
+func (m *SignEvidenceRequest) XXX_Size() int {
+	return xxx_messageInfo_SignEvidenceRequest.Size(m)
+}
+func (m *SignEvidenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignEvidenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignEvidenceRequest proto.InternalMessageInfo
+
+func (m *SignEvidenceRequest) GetEvidence() []byte {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+func (m *SignEvidenceRequest) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+type SignConsensusRequest struct {
+	// height is the height of a vote, while the height of a proposal is the height of its block
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// Types that are valid to be assigned to Msg:
+	//	*SignConsensusRequest_Proposal
+	//	*SignConsensusRequest_Vote
+	Msg isSignConsensusRequest_Msg `protobuf_oneof:"msg"`
+	// timestamp is the time of the endorsement, which tells the round of the message
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SignConsensusRequest) Reset()         { *m = SignConsensusRequest{} }
+func (m *SignConsensusRequest) String() string { return proto.CompactTextString(m) }
+func (*SignConsensusRequest) ProtoMessage()    {}
+func (*SignConsensusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb39d7ffbf21e4ab, []int{5}
+}
+
+func (m *SignConsensusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignConsensusRequest.Unmarshal(m, b)
+}
+func (m *SignConsensusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignConsensusRequest.Marshal(b, m, deterministic)
+}
+func (m *SignConsensusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignConsensusRequest.Merge(m, src)
+}
+func (m *SignConsensusRequest) XXX_Size() int {
+	return xxx_messageInfo_SignConsensusRequest.Size(m)
+}
+func (m *SignConsensusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignConsensusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignConsensusRequest proto.InternalMessageInfo
+
+func (m *SignConsensusRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type isSignConsensusRequest_Msg interface {
+	isSignConsensusRequest_Msg()
+}
+
+type SignConsensusRequest_Proposal struct {
+	Proposal []byte `protobuf:"bytes,2,opt,name=proposal,proto3,oneof"`
+}
+
+type SignConsensusRequest_Vote struct {
+	Vote []byte `protobuf:"bytes,3,opt,name=vote,proto3,oneof"`
+}
+
+func (*SignConsensusRequest_Proposal) isSignConsensusRequest_Msg() {}
+
+func (*SignConsensusRequest_Vote) isSignConsensusRequest_Msg() {}
+
+func (m *SignConsensusRequest) GetMsg() isSignConsensusRequest_Msg {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (m *SignConsensusRequest) GetProposal() []byte {
+	if x, ok := m.GetMsg().(*SignConsensusRequest_Proposal); ok {
+		return x.Proposal
+	}
+	return nil
+}
+
+func (m *SignConsensusRequest) GetVote() []byte {
+	if x, ok := m.GetMsg().(*SignConsensusRequest_Vote); ok {
+		return x.Vote
+	}
+	return nil
+}
+
+func (m *SignConsensusRequest) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SignConsensusRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SignConsensusRequest_Proposal)(nil),
+		(*SignConsensusRequest_Vote)(nil),
+	}
+}
+
+type SignResponse struct {
+	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignResponse) Reset()         { *m = SignResponse{} }
+func (m *SignResponse) String() string { return proto.CompactTextString(m) }
+func (*SignResponse) ProtoMessage()    {}
+func (*SignResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb39d7ffbf21e4ab, []int{6}
+}
+
+func (m *SignResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignResponse.Unmarshal(m, b)
+}
+func (m *SignResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignResponse.Marshal(b, m, deterministic)
+}
+func (m *SignResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignResponse.Merge(m, src)
+}
+func (m *SignResponse) XXX_Size() int {
+	return xxx_messageInfo_SignResponse.Size(m)
+}
+func (m *SignResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignResponse proto.InternalMessageInfo
+
+func (m *SignResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetPublicKeyRequest)(nil), "signerpb.GetPublicKeyRequest")
+	proto.RegisterType((*GetPublicKeyResponse)(nil), "signerpb.GetPublicKeyResponse")
+	proto.RegisterType((*SignBlockHeaderRequest)(nil), "signerpb.SignBlockHeaderRequest")
+	proto.RegisterType((*SignActionRequest)(nil), "signerpb.SignActionRequest")
+	proto.RegisterType((*SignEvidenceRequest)(nil), "signerpb.SignEvidenceRequest")
+	proto.RegisterType((*SignConsensusRequest)(nil), "signerpb.SignConsensusRequest")
+	proto.RegisterType((*SignResponse)(nil), "signerpb.SignResponse")
+}
+
+func init() { proto.RegisterFile("proto/signer/signer.proto", fileDescriptor_fb39d7ffbf21e4ab) }
+
+var fileDescriptor_fb39d7ffbf21e4ab = []byte{
+	// 437 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x5d, 0x6f, 0xd3, 0x30,
+	0x14, 0x5d, 0x68, 0x98, 0xba, 0x4b, 0x26, 0x84, 0x57, 0xaa, 0x12, 0xc6, 0xa8, 0xf2, 0xc2, 0x1e,
+	0x46, 0x22, 0x8d, 0x09, 0xf1, 0x4a, 0x27, 0xb4, 0x21, 0x84, 0x40, 0x29, 0x4f, 0xbc, 0x25, 0xde,
+	0xc5, 0x35, 0x4b, 0x62, 0x13, 0x3b, 0x15, 0xfc, 0x03, 0xfe, 0x07, 0x7f, 0x14, 0xc5, 0xf9, 0x9e,
+	0xda, 0x4a, 0x7b, 0x4a, 0xee, 0xf1, 0xf1, 0xb9, 0x5f, 0xc7, 0xf0, 0x4c, 0xe6, 0x42, 0x8b, 0x40,
+	0x71, 0x96, 0x61, 0x5e, 0x7f, 0x7c, 0x83, 0x91, 0x71, 0x15, 0xc9, 0xd8, 0x7d, 0xc9, 0x84, 0x60,
+	0x09, 0x06, 0x06, 0x8f, 0x8b, 0x1f, 0x81, 0xe6, 0x29, 0x2a, 0x1d, 0xa5, 0xb2, 0xa2, 0x7a, 0x4f,
+	0xe1, 0xe8, 0x0a, 0xf5, 0xd7, 0x22, 0x4e, 0x38, 0xfd, 0x84, 0x7f, 0x42, 0xfc, 0x55, 0xa0, 0xd2,
+	0xde, 0x05, 0x4c, 0x86, 0xb0, 0x92, 0x22, 0x53, 0x48, 0x8e, 0xe1, 0x40, 0x36, 0xe0, 0xcc, 0x9a,
+	0x5b, 0xa7, 0x4e, 0xd8, 0x01, 0xde, 0x19, 0x4c, 0x97, 0x9c, 0x65, 0x8b, 0x44, 0xd0, 0xdb, 0x6b,
+	0x8c, 0x6e, 0x30, 0xaf, 0xf5, 0x08, 0x01, 0x9b, 0x8a, 0x1c, 0xeb, 0x2b, 0xe6, 0xdf, 0x7b, 0x05,
+	0x4f, 0x4a, 0xf6, 0x7b, 0xaa, 0xb9, 0xc8, 0x76, 0x11, 0x6f, 0xe1, 0xa8, 0x24, 0x7e, 0x58, 0xf3,
+	0x1b, 0xcc, 0x28, 0x36, 0x54, 0x17, 0xc6, 0x58, 0x43, 0x35, 0xbd, 0x8d, 0xc9, 0x3b, 0x38, 0x68,
+	0x3b, 0x9d, 0x3d, 0x98, 0x5b, 0xa7, 0x8f, 0xce, 0x5d, 0xbf, 0x9a, 0x85, 0xdf, 0xcc, 0xc2, 0xff,
+	0xd6, 0x30, 0xc2, 0x8e, 0xec, 0xfd, 0xb3, 0x60, 0x52, 0x66, 0xbb, 0x2c, 0xfb, 0xcd, 0x54, 0xa1,
+	0x9a, 0x74, 0x53, 0xd8, 0x5f, 0x21, 0x67, 0x2b, 0x6d, 0x92, 0xd9, 0x61, 0x1d, 0x91, 0x63, 0x18,
+	0xcb, 0x5c, 0x48, 0xa1, 0xa2, 0xc4, 0x64, 0x72, 0xae, 0xf7, 0xc2, 0x16, 0x21, 0x13, 0xb0, 0xd7,
+	0x42, 0xe3, 0x6c, 0x54, 0x9f, 0x98, 0x68, 0x58, 0x9e, 0x7d, 0x8f, 0xf2, 0x16, 0x0f, 0x61, 0x94,
+	0x2a, 0xe6, 0x9d, 0x81, 0x53, 0x16, 0xd9, 0xdf, 0x4b, 0xb9, 0xf3, 0x48, 0x17, 0xed, 0xec, 0x3a,
+	0xe0, 0xfc, 0xef, 0x08, 0x0e, 0x97, 0xc6, 0x12, 0x4b, 0xcc, 0xd7, 0x9c, 0x22, 0xf9, 0x02, 0x4e,
+	0x7f, 0xbf, 0xe4, 0x85, 0xdf, 0x58, 0xc6, 0xdf, 0x60, 0x07, 0xf7, 0x64, 0xdb, 0x71, 0x95, 0xde,
+	0xdb, 0x23, 0x9f, 0xe1, 0xf1, 0x9d, 0xd5, 0x93, 0x79, 0x77, 0x69, 0xb3, 0x2b, 0xdc, 0xe9, 0x90,
+	0xd1, 0x93, 0xbb, 0x04, 0xe8, 0xbc, 0x41, 0x9e, 0x0f, 0x79, 0x03, 0xc7, 0xec, 0x10, 0xb9, 0x02,
+	0xa7, 0xef, 0x9b, 0x7e, 0x93, 0x1b, 0xfc, 0xb4, 0x43, 0xe8, 0x23, 0x1c, 0x0e, 0x2c, 0x41, 0x4e,
+	0x86, 0xd4, 0xbb, 0x5e, 0xd9, 0x2e, 0xb5, 0x78, 0xfb, 0xfd, 0x82, 0x71, 0xbd, 0x2a, 0x62, 0x9f,
+	0x8a, 0x34, 0xe0, 0x42, 0xe3, 0x6f, 0x99, 0x8b, 0x9f, 0x48, 0x75, 0x15, 0xbc, 0x2e, 0x1d, 0x5f,
+	0x3d, 0x57, 0x86, 0x59, 0xd0, 0xc8, 0xc4, 0xfb, 0x06, 0x7a, 0xf3, 0x7f, 0x00, 0xc4, 0x26, 0x1b,
+	0x56, 0xf6, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SignerServiceClient is the client API for SignerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SignerServiceClient interface {
+	// get the public key of the signer
+	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
+	// sign a block header, which is refused below the height signed in
+	SignBlockHeader(ctx context.Context, in *SignBlockHeaderRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	// sign an action, which is refused unless it is of the actions a block producer creates itself
+	SignAction(ctx context.Context, in *SignActionRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	// sign the endorsement of an equivocation evidence
+	SignEvidence(ctx context.Context, in *SignEvidenceRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	// sign the endorsement of a consensus message, which is refused if it conflicts with a message signed before
+	SignConsensus(ctx context.Context, in *SignConsensusRequest, opts ...grpc.CallOption) (*SignResponse, error)
+}
+
+type signerServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSignerServiceClient(cc *grpc.ClientConn) SignerServiceClient {
+	return &signerServiceClient{cc}
+}
+
+func (c *signerServiceClient) GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error) {
+	out := new(GetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, "/signerpb.SignerService/GetPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerServiceClient) SignBlockHeader(ctx context.Context, in *SignBlockHeaderRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := c.cc.Invoke(ctx, "/signerpb.SignerService/SignBlockHeader", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerServiceClient) SignAction(ctx context.Context, in *SignActionRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := c.cc.Invoke(ctx, "/signerpb.SignerService/SignAction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerServiceClient) SignEvidence(ctx context.Context, in *SignEvidenceRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := c.cc.Invoke(ctx, "/signerpb.SignerService/SignEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerServiceClient) SignConsensus(ctx context.Context, in *SignConsensusRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := c.cc.Invoke(ctx, "/signerpb.SignerService/SignConsensus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SignerServiceServer is the server API for SignerService service.
+type SignerServiceServer interface {
+	// get the public key of the signer
+	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
+	// sign a block header, which is refused below the height signed in
+	SignBlockHeader(context.Context, *SignBlockHeaderRequest) (*SignResponse, error)
+	// sign an action, which is refused unless it is of the actions a block producer creates itself
+	SignAction(context.Context, *SignActionRequest) (*SignResponse, error)
+	// sign the endorsement of an equivocation evidence
+	SignEvidence(context.Context, *SignEvidenceRequest) (*SignResponse, error)
+	// sign the endorsement of a consensus message, which is refused if it conflicts with a message signed before
+	SignConsensus(context.Context, *SignConsensusRequest) (*SignResponse, error)
+}
+
+func RegisterSignerServiceServer(s *grpc.Server, srv SignerServiceServer) {
+	s.RegisterService(&_SignerService_serviceDesc, srv)
+}
+
+func _SignerService_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServiceServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signerpb.SignerService/GetPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServiceServer).GetPublicKey(ctx, req.(*GetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SignerService_SignBlockHeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignBlockHeaderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServiceServer).SignBlockHeader(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signerpb.SignerService/SignBlockHeader",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServiceServer).SignBlockHeader(ctx, req.(*SignBlockHeaderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SignerService_SignAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServiceServer).SignAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signerpb.SignerService/SignAction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServiceServer).SignAction(ctx, req.(*SignActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SignerService_SignEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignEvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServiceServer).SignEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signerpb.SignerService/SignEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServiceServer).SignEvidence(ctx, req.(*SignEvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SignerService_SignConsensus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignConsensusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServiceServer).SignConsensus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signerpb.SignerService/SignConsensus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServiceServer).SignConsensus(ctx, req.(*SignConsensusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SignerService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "signerpb.SignerService",
+	HandlerType: (*SignerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _SignerService_GetPublicKey_Handler,
+		},
+		{
+			MethodName: "SignBlockHeader",
+			Handler:    _SignerService_SignBlockHeader_Handler,
+		},
+		{
+			MethodName: "SignAction",
+			Handler:    _SignerService_SignAction_Handler,
+		},
+		{
+			MethodName: "SignEvidence",
+			Handler:    _SignerService_SignEvidence_Handler,
+		},
+		{
+			MethodName: "SignConsensus",
+			Handler:    _SignerService_SignConsensus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/signer/signer.proto",
+}
@@ -23,6 +23,8 @@ func GetTypeFromRPCMsg(msg proto.Message) (iotexrpc.MessageType, error) {
 		return iotexrpc.MessageType_BLOCK, nil
 	case *iotexrpc.BlockSync:
 		return iotexrpc.MessageType_BLOCK_REQUEST, nil
+	case *iotexrpc.BlockHeaders:
+		return iotexrpc.MessageType_BLOCK_HEADERS, nil
 	case *iotextypes.Action:
 		return iotexrpc.MessageType_ACTION, nil
 	case *iotextypes.ConsensusMessage:
@@ -44,6 +46,8 @@ func TypifyRPCMsg(t iotexrpc.MessageType, msg []byte) (proto.Message, error) {
 		m = &iotextypes.ConsensusMessage{}
 	case iotexrpc.MessageType_BLOCK_REQUEST:
 		m = &iotexrpc.BlockSync{}
+	case iotexrpc.MessageType_BLOCK_HEADERS:
+		m = &iotexrpc.BlockHeaders{}
 	case iotexrpc.MessageType_ACTION:
 		m = &iotextypes.Action{}
 	case iotexrpc.MessageType_TEST:
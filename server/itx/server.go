@@ -87,11 +87,19 @@ func newServer(cfg config.Config, testing bool) (*Server, error) {
 	// Add action validators
 	cs.ActionPool().
 		AddActionEnvelopeValidators(
-			protocol.NewGenericValidator(cs.Blockchain(), cfg.Genesis.ActionGasLimit),
+			protocol.NewGenericValidator(
+				cs.Blockchain(),
+				cfg.Genesis.ActionGasLimit,
+				protocol.WithSignatureCache(cs.SignatureCache()),
+			),
 		)
 	cs.Blockchain().Validator().
 		AddActionEnvelopeValidators(
-			protocol.NewGenericValidator(cs.Blockchain(), cfg.Genesis.ActionGasLimit),
+			protocol.NewGenericValidator(
+				cs.Blockchain(),
+				cfg.Genesis.ActionGasLimit,
+				protocol.WithSignatureCache(cs.SignatureCache()),
+			),
 		)
 	// Install protocols
 	if err := registerDefaultProtocols(cs, cfg.Genesis); err != nil {
@@ -181,11 +189,19 @@ func (s *Server) newSubChainService(cfg config.Config, opts ...chainservice.Opti
 	}
 	cs.ActionPool().
 		AddActionEnvelopeValidators(
-			protocol.NewGenericValidator(cs.Blockchain(), cfg.Genesis.ActionGasLimit),
+			protocol.NewGenericValidator(
+				cs.Blockchain(),
+				cfg.Genesis.ActionGasLimit,
+				protocol.WithSignatureCache(cs.SignatureCache()),
+			),
 		)
 	cs.Blockchain().Validator().
 		AddActionEnvelopeValidators(
-			protocol.NewGenericValidator(cs.Blockchain(), cfg.Genesis.ActionGasLimit),
+			protocol.NewGenericValidator(
+				cs.Blockchain(),
+				cfg.Genesis.ActionGasLimit,
+				protocol.WithSignatureCache(cs.SignatureCache()),
+			),
 		)
 	if err := registerDefaultProtocols(cs, cfg.Genesis); err != nil {
 		return err
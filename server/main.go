@@ -62,6 +62,7 @@ func main() {
 	cfg.Genesis = genesisCfg
 	cfgToLog := cfg
 	cfgToLog.Chain.ProducerPrivKey = ""
+	cfgToLog.Signer.KeystorePassword = ""
 	log.S().Infof("Config in use: %+v", cfgToLog)
 
 	// liveness start
@@ -0,0 +1,240 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// signState is the latest height and round signed in, and the hashes signed of each kind in the round
+type signState struct {
+	Height uint64            `json:"height"`
+	Round  uint64            `json:"round"`
+	Hashes map[string]string `json:"hashes"`
+}
+
+// Rounds are the consensus rounds, which start every block interval since the genesis. The round of a block header or
+// a consensus message is known from its time, which is signed with it, rather than told by the client.
+type Rounds struct {
+	Genesis  time.Time
+	Interval time.Duration
+}
+
+// Round returns the number of the round since the genesis at the time
+func (r Rounds) Round(ts time.Time) (uint64, error) {
+	if r.Interval <= 0 {
+		return 0, errors.New("block interval of the rounds is not set")
+	}
+	if ts.Before(r.Genesis) {
+		return 0, errors.Wrapf(ErrNotAllowed, "time %s is before the genesis", ts)
+	}
+	return uint64(ts.Sub(r.Genesis) / r.Interval), nil
+}
+
+// protectedSigner refuses to sign a consensus message or a block header in a round lower than the latest one, at a
+// height other than the one of the round, or to sign a different message of the same kind in the round, e.g., a
+// different block header, proposal, or vote of a topic. The rounds are derived from the time of the messages, and the
+// hashes are derived from their contents. It refuses to sign an action other than the ones a block producer creates
+// itself as well, i.e., granting rewards, putting poll results or sub-chain blocks, and the memorial transfer of no
+// amount to itself. Plain hashes are never signed. The state is persisted before a signature is returned, so that
+// the protection survives a restart of the signer.
+type protectedSigner struct {
+	Signer
+	mutex  sync.Mutex
+	path   string
+	rounds Rounds
+	state  signState
+}
+
+// NewProtected creates a signer with double-sign protection on top of a signer. The state of the protection is
+// persisted at the path, or kept in memory only if the path is empty.
+func NewProtected(s Signer, path string, rounds Rounds) (Signer, error) {
+	if rounds.Interval <= 0 {
+		return nil, errors.New("block interval of the rounds is not set")
+	}
+	ps := &protectedSigner{
+		Signer: s,
+		path:   path,
+		rounds: rounds,
+		state:  signState{Hashes: map[string]string{}},
+	}
+	if path == "" {
+		return ps, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ps, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read sign state %s", path)
+	}
+	if err := json.Unmarshal(data, &ps.state); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode sign state %s", path)
+	}
+	if ps.state.Hashes == nil {
+		ps.state.Hashes = map[string]string{}
+	}
+	return ps, nil
+}
+
+// SignConsensus signs the endorsement of a consensus message, unless it conflicts with a message signed before
+func (s *protectedSigner) SignConsensus(msg ConsensusMessage, ts time.Time) ([]byte, error) {
+	doc, err := decodeConsensusMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	if doc.proposal != nil &&
+		!bytes.Equal(doc.proposal.Block.Header.ProducerPubkey, s.PublicKey().Bytes()) {
+		return nil, errors.Wrap(ErrNotAllowed, "proposed block is not produced by the signer")
+	}
+	if err := s.guard(doc.height, ts, doc.kind, doc.hash); err != nil {
+		return nil, err
+	}
+	return s.Signer.SignConsensus(msg, ts)
+}
+
+// Sign refuses to sign a plain hash, which could be of anything
+func (s *protectedSigner) Sign([]byte) ([]byte, error) {
+	return nil, errors.Wrap(ErrNotAllowed, "plain hashes are not signed")
+}
+
+// SignBlockHeader signs a block header, unless it conflicts with a block header signed before, i.e., a different
+// block header of the same height in the round of its time, or a block header in a lower round
+func (s *protectedSigner) SignBlockHeader(core []byte) ([]byte, error) {
+	header := &iotextypes.BlockHeaderCore{}
+	if err := proto.Unmarshal(core, header); err != nil {
+		return nil, errors.Wrap(err, "failed to decode block header")
+	}
+	ts, err := ptypes.Timestamp(header.Timestamp)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotAllowed, "block header misses the time")
+	}
+	h := hash.Hash256b(core)
+	if err := s.guard(header.Height, ts, "block", h[:]); err != nil {
+		return nil, err
+	}
+	return s.Signer.SignBlockHeader(core)
+}
+
+// guard checks that signing the hash of the kind at the height and time conflicts with nothing signed before, and
+// records it in the state
+func (s *protectedSigner) guard(height uint64, ts time.Time, kind string, h []byte) error {
+	round, err := s.rounds.Round(ts)
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	switch {
+	case round < s.state.Round:
+		return errors.Wrapf(ErrDoubleSign, "round %d is lower than the signed round %d", round, s.state.Round)
+	case round == s.state.Round && height != s.state.Height:
+		return errors.Wrapf(
+			ErrDoubleSign,
+			"height %d is not the signed height %d of round %d",
+			height,
+			s.state.Height,
+			round,
+		)
+	case round == s.state.Round:
+		signed, ok := s.state.Hashes[kind]
+		if !ok {
+			break
+		}
+		signedHash, err := hex.DecodeString(signed)
+		if err != nil {
+			return errors.Wrap(err, "failed to decode signed hash")
+		}
+		if !bytes.Equal(signedHash, h) {
+			return errors.Wrapf(ErrDoubleSign, "a different %s has been signed in height %d round %d", kind, height, round)
+		}
+		// the same message is signed again
+		return nil
+	case height < s.state.Height:
+		return errors.Wrapf(ErrDoubleSign, "height %d is lower than the signed height %d", height, s.state.Height)
+	default:
+		s.state = signState{Height: height, Round: round, Hashes: map[string]string{}}
+	}
+	s.state.Hashes[kind] = hex.EncodeToString(h)
+	return s.persist()
+}
+
+// SignAction signs an action, if it is of the actions a block producer creates itself
+func (s *protectedSigner) SignAction(core []byte) ([]byte, error) {
+	act := &iotextypes.ActionCore{}
+	if err := proto.Unmarshal(core, act); err != nil {
+		return nil, errors.Wrap(err, "failed to decode action")
+	}
+	switch {
+	case act.GetGrantReward() != nil, act.GetPutPollResult() != nil, act.GetPutBlock() != nil:
+	case act.GetTransfer() != nil:
+		self, err := address.FromBytes(s.PublicKey().Hash())
+		if err != nil {
+			return nil, err
+		}
+		if tsf := act.GetTransfer(); tsf.Recipient != self.String() || tsf.Amount != "0" {
+			return nil, errors.Wrap(ErrNotAllowed, "transfer of any amount or to others is not signed")
+		}
+	default:
+		return nil, errors.Wrapf(ErrNotAllowed, "action %T is not signed", act.GetAction())
+	}
+	return s.Signer.SignAction(core)
+}
+
+// SignEvidence signs the endorsement of an evidence
+func (s *protectedSigner) SignEvidence(evidence []byte, ts time.Time) ([]byte, error) {
+	e := &iotextypes.ConsensusEvidence{}
+	if err := proto.Unmarshal(evidence, e); err != nil {
+		return nil, errors.Wrap(err, "failed to decode evidence")
+	}
+	if e.First == nil || e.Second == nil {
+		return nil, errors.Wrap(ErrNotAllowed, "evidence misses a conflicting message")
+	}
+	return s.Signer.SignEvidence(evidence, ts)
+}
+
+// persist writes the state to a temporary file, and then replaces the state file with it
+func (s *protectedSigner) persist() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(s.state)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode sign state")
+	}
+	tmpPath := s.path + ".new"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create sign state %s", tmpPath)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to write sign state %s", tmpPath)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to sync sign state %s", tmpPath)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close sign state %s", tmpPath)
+	}
+	return errors.Wrapf(os.Rename(tmpPath, s.path), "failed to replace sign state %s", s.path)
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/iotexproject/iotex-address/address"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+var testRounds = Rounds{Genesis: time.Unix(1546329600, 0), Interval: 10 * time.Second}
+
+// roundTime returns the time in the round since the genesis
+func roundTime(round uint64, offset time.Duration) time.Time {
+	return testRounds.Genesis.Add(time.Duration(round)*testRounds.Interval + offset)
+}
+
+func testVote(t *testing.T, height uint64, topic iotextypes.ConsensusVote_Topic, blk string) ConsensusMessage {
+	h := hash.Hash256b([]byte(blk))
+	vote, err := proto.Marshal(&iotextypes.ConsensusVote{BlockHash: h[:], Topic: topic})
+	require.NoError(t, err)
+	return ConsensusMessage{Height: height, Vote: vote}
+}
+
+func testHeader(t *testing.T, height uint64, ts time.Time, txRoot string) *iotextypes.BlockHeaderCore {
+	timestamp, err := ptypes.TimestampProto(ts)
+	require.NoError(t, err)
+	h := hash.Hash256b([]byte(txRoot))
+	return &iotextypes.BlockHeaderCore{Height: height, Timestamp: timestamp, TxRoot: h[:]}
+}
+
+func testProposal(t *testing.T, producer []byte, core *iotextypes.BlockHeaderCore) ConsensusMessage {
+	proposal, err := proto.Marshal(&iotextypes.BlockProposal{
+		Block: &iotextypes.Block{Header: &iotextypes.BlockHeader{Core: core, ProducerPubkey: producer}},
+	})
+	require.NoError(t, err)
+	return ConsensusMessage{Proposal: proposal}
+}
+
+func TestProtectedSigner(t *testing.T) {
+	require := require.New(t)
+	dir, err := ioutil.TempDir("", "signer")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := dir + "/signer.state"
+
+	sk := identityset.PrivateKey(0)
+	s, err := NewProtected(NewInProcess(sk), path, testRounds)
+	require.NoError(err)
+	_, err = NewProtected(NewInProcess(sk), path, Rounds{})
+	require.Error(err)
+
+	voteA := testVote(t, 5, iotextypes.ConsensusVote_LOCK, "a")
+	voteB := testVote(t, 5, iotextypes.ConsensusVote_LOCK, "b")
+	ts := roundTime(7, 6*time.Second)
+	sig, err := s.SignConsensus(voteA, ts)
+	require.NoError(err)
+	vh := blake2b.Sum256(voteA.Vote)
+	require.True(s.PublicKey().Verify(endorsementHash(vh[:], ts), sig))
+	// the same message could be signed again
+	_, err = s.SignConsensus(voteA, ts.Add(time.Second))
+	require.NoError(err)
+	// but not a different one of the same kind in the round
+	_, err = s.SignConsensus(voteB, ts)
+	require.Equal(ErrDoubleSign, errors.Cause(err))
+	// nor one claiming another height in the round
+	_, err = s.SignConsensus(testVote(t, 6, iotextypes.ConsensusVote_LOCK, "b"), ts)
+	require.Equal(ErrDoubleSign, errors.Cause(err))
+	// messages of other kinds are fine
+	_, err = s.SignConsensus(testVote(t, 5, iotextypes.ConsensusVote_COMMIT, "b"), ts)
+	require.NoError(err)
+	// a lower round is refused
+	_, err = s.SignConsensus(testVote(t, 5, iotextypes.ConsensusVote_PROPOSAL, "a"), roundTime(6, 0))
+	require.Equal(ErrDoubleSign, errors.Cause(err))
+	// so is a lower height in a higher round
+	_, err = s.SignConsensus(testVote(t, 4, iotextypes.ConsensusVote_PROPOSAL, "a"), roundTime(8, 0))
+	require.Equal(ErrDoubleSign, errors.Cause(err))
+	// a higher round resets the signed messages
+	_, err = s.SignConsensus(voteB, roundTime(8, 6*time.Second))
+	require.NoError(err)
+	// plain hashes are refused
+	h := hash.Hash256b([]byte("a"))
+	_, err = s.Sign(h[:])
+	require.Equal(ErrNotAllowed, errors.Cause(err))
+	// so are the messages which are neither proposals nor votes
+	_, err = s.SignConsensus(ConsensusMessage{Height: 5}, ts)
+	require.Equal(ErrNotAllowed, errors.Cause(err))
+	_, err = s.SignConsensus(ConsensusMessage{Height: 5, Vote: []byte{0xff}}, ts)
+	require.Error(err)
+	_, err = s.SignConsensus(ConsensusMessage{Height: 5, Vote: voteA.Vote, Proposal: voteA.Vote}, ts)
+	require.Equal(ErrNotAllowed, errors.Cause(err))
+	_, err = s.SignConsensus(testVote(t, 9, iotextypes.ConsensusVote_LOCK, "a"), testRounds.Genesis.Add(-time.Second))
+	require.Equal(ErrNotAllowed, errors.Cause(err))
+
+	// the protection survives a restart
+	s, err = NewProtected(NewInProcess(sk), path, testRounds)
+	require.NoError(err)
+	_, err = s.SignConsensus(voteA, roundTime(8, 6*time.Second))
+	require.Equal(ErrDoubleSign, errors.Cause(err))
+	_, err = s.SignConsensus(voteA, ts)
+	require.Equal(ErrDoubleSign, errors.Cause(err))
+	_, err = s.SignConsensus(testVote(t, 6, iotextypes.ConsensusVote_PROPOSAL, "c"), roundTime(9, 0))
+	require.NoError(err)
+
+	// a corrupted state is reported
+	require.NoError(ioutil.WriteFile(path, []byte("{"), 0600))
+	_, err = NewProtected(NewInProcess(sk), path, testRounds)
+	require.Error(err)
+	require.NoError(os.Remove(path))
+
+	// an in-memory state protects as well
+	s, err = NewProtected(NewInProcess(sk), "", testRounds)
+	require.NoError(err)
+	_, err = s.SignConsensus(voteA, ts)
+	require.NoError(err)
+	_, err = s.SignConsensus(voteB, ts)
+	require.Equal(ErrDoubleSign, errors.Cause(err))
+}
+
+func TestProtectedSignerTypedRequests(t *testing.T) {
+	require := require.New(t)
+	sk := identityset.PrivateKey(0)
+	s, err := NewProtected(NewInProcess(sk), "", testRounds)
+	require.NoError(err)
+
+	signHeader := func(core *iotextypes.BlockHeaderCore) ([]byte, error) {
+		ser, err := proto.Marshal(core)
+		require.NoError(err)
+		return s.SignBlockHeader(ser)
+	}
+	headerA := testHeader(t, 5, roundTime(7, 0), "a")
+	_, err = signHeader(headerA)
+	require.NoError(err)
+	_, err = signHeader(headerA)
+	require.NoError(err)
+	// a different block header of the height in the round is refused
+	_, err = signHeader(testHeader(t, 5, roundTime(7, 0), "b"))
+	require.Equal(ErrDoubleSign, errors.Cause(err))
+	// the block proposal of it is fine, unless the block is produced by another
+	_, err = s.SignConsensus(testProposal(t, sk.PublicKey().Bytes(), headerA), roundTime(7, 0))
+	require.NoError(err)
+	_, err = s.SignConsensus(
+		testProposal(t, identityset.PrivateKey(1).PublicKey().Bytes(), headerA),
+		roundTime(7, 0),
+	)
+	require.Equal(ErrNotAllowed, errors.Cause(err))
+	// so is a proposal claiming another height
+	proposal := testProposal(t, sk.PublicKey().Bytes(), headerA)
+	proposal.Height = 6
+	_, err = s.SignConsensus(proposal, roundTime(7, 0))
+	require.Equal(ErrNotAllowed, errors.Cause(err))
+	// a different block of the height in a later round is fine
+	headerB := testHeader(t, 5, roundTime(8, 0), "b")
+	_, err = signHeader(headerB)
+	require.NoError(err)
+	_, err = s.SignConsensus(testProposal(t, sk.PublicKey().Bytes(), headerB), roundTime(8, 0))
+	require.NoError(err)
+	_, err = s.SignConsensus(testProposal(t, sk.PublicKey().Bytes(), headerA), roundTime(8, 0))
+	require.Equal(ErrDoubleSign, errors.Cause(err))
+	// a lower block header is refused
+	_, err = signHeader(testHeader(t, 4, roundTime(9, 0), "c"))
+	require.Equal(ErrDoubleSign, errors.Cause(err))
+	_, err = signHeader(headerA)
+	require.Equal(ErrDoubleSign, errors.Cause(err))
+	_, err = s.SignBlockHeader([]byte{0xff})
+	require.Error(err)
+	_, err = signHeader(&iotextypes.BlockHeaderCore{Height: 6})
+	require.Equal(ErrNotAllowed, errors.Cause(err))
+
+	self, err := address.FromBytes(sk.PublicKey().Hash())
+	require.NoError(err)
+	for _, c := range []struct {
+		act     *iotextypes.ActionCore
+		allowed bool
+	}{
+		{&iotextypes.ActionCore{Action: &iotextypes.ActionCore_GrantReward{GrantReward: &iotextypes.GrantReward{}}}, true},
+		{&iotextypes.ActionCore{Action: &iotextypes.ActionCore_PutPollResult{PutPollResult: &iotextypes.PutPollResult{}}}, true},
+		{
+			&iotextypes.ActionCore{Action: &iotextypes.ActionCore_Transfer{
+				Transfer: &iotextypes.Transfer{Amount: "0", Recipient: self.String()},
+			}},
+			true,
+		},
+		{
+			&iotextypes.ActionCore{Action: &iotextypes.ActionCore_Transfer{
+				Transfer: &iotextypes.Transfer{Amount: "1", Recipient: self.String()},
+			}},
+			false,
+		},
+		{
+			&iotextypes.ActionCore{Action: &iotextypes.ActionCore_Transfer{
+				Transfer: &iotextypes.Transfer{Amount: "0", Recipient: identityset.Address(1).String()},
+			}},
+			false,
+		},
+		{&iotextypes.ActionCore{Action: &iotextypes.ActionCore_Execution{Execution: &iotextypes.Execution{}}}, false},
+		{&iotextypes.ActionCore{}, false},
+	} {
+		core, err := proto.Marshal(c.act)
+		require.NoError(err)
+		sig, err := s.SignAction(core)
+		if !c.allowed {
+			require.Equal(ErrNotAllowed, errors.Cause(err))
+			continue
+		}
+		require.NoError(err)
+		h := hash.Hash256b(core)
+		require.True(sk.PublicKey().Verify(h[:], sig))
+	}
+
+	evidence, err := proto.Marshal(&iotextypes.ConsensusEvidence{
+		First:  &iotextypes.ConsensusMessage{Height: 1},
+		Second: &iotextypes.ConsensusMessage{Height: 1},
+	})
+	require.NoError(err)
+	_, err = s.SignEvidence(evidence, time.Now())
+	require.NoError(err)
+	evidence, err = proto.Marshal(&iotextypes.ConsensusEvidence{First: &iotextypes.ConsensusMessage{Height: 1}})
+	require.NoError(err)
+	_, err = s.SignEvidence(evidence, time.Now())
+	require.Equal(ErrNotAllowed, errors.Cause(err))
+}
@@ -0,0 +1,324 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/protogen/signerpb"
+)
+
+// RemoteSigner is the client of a remote signer
+type RemoteSigner struct {
+	conn    *grpc.ClientConn
+	client  signerpb.SignerServiceClient
+	pubKey  keypair.PublicKey
+	timeout time.Duration
+}
+
+// NewRemote connects to the remote signer at the address over TLS, and fetches its public key. The TLS config should
+// carry the client certificate, since the signer authenticates its clients.
+func NewRemote(addr string, timeout time.Duration, tlsCfg *tls.Config) (*RemoteSigner, error) {
+	if tlsCfg == nil {
+		return nil, errors.New("TLS config of the remote signer is required")
+	}
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to remote signer %s", addr)
+	}
+	s := &RemoteSigner{
+		conn:    conn,
+		client:  signerpb.NewSignerServiceClient(conn),
+		timeout: timeout,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	res, err := s.client.GetPublicKey(ctx, &signerpb.GetPublicKeyRequest{})
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "failed to get public key from remote signer %s", addr)
+	}
+	if s.pubKey, err = keypair.BytesToPublicKey(res.PublicKey); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewClientTLSConfig creates the TLS config of a client of the remote signer, with the client certificate and key,
+// and the CA certificate to verify the signer
+func NewClientTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load client certificate")
+	}
+	pool, err := loadCertPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// PublicKey returns the public key of the remote signer
+func (s *RemoteSigner) PublicKey() keypair.PublicKey {
+	return s.pubKey
+}
+
+// Sign refuses to sign a plain hash, since the remote signer only signs what it could check
+func (s *RemoteSigner) Sign([]byte) ([]byte, error) {
+	return nil, errors.Wrap(ErrNotAllowed, "remote signer doesn't sign plain hashes")
+}
+
+// SignBlockHeader signs a block header with the remote signer, which returns ErrDoubleSign if the height is lower
+// than the one signed in
+func (s *RemoteSigner) SignBlockHeader(core []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	res, err := s.client.SignBlockHeader(ctx, &signerpb.SignBlockHeaderRequest{Core: core})
+	if err != nil {
+		return nil, errors.Wrap(fromStatus(err), "failed to sign block header with remote signer")
+	}
+	return res.Signature, nil
+}
+
+// SignAction signs an action with the remote signer, which returns ErrNotAllowed if it doesn't sign such an action
+func (s *RemoteSigner) SignAction(core []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	res, err := s.client.SignAction(ctx, &signerpb.SignActionRequest{Core: core})
+	if err != nil {
+		return nil, errors.Wrap(fromStatus(err), "failed to sign action with remote signer")
+	}
+	return res.Signature, nil
+}
+
+// SignEvidence signs the endorsement of an evidence with the remote signer
+func (s *RemoteSigner) SignEvidence(evidence []byte, ts time.Time) ([]byte, error) {
+	timestamp, err := ptypes.TimestampProto(ts)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	res, err := s.client.SignEvidence(ctx, &signerpb.SignEvidenceRequest{Evidence: evidence, Timestamp: timestamp})
+	if err != nil {
+		return nil, errors.Wrap(fromStatus(err), "failed to sign evidence with remote signer")
+	}
+	return res.Signature, nil
+}
+
+// SignConsensus signs the endorsement of a consensus message with the remote signer, which returns ErrDoubleSign if
+// the message conflicts with a message signed before
+func (s *RemoteSigner) SignConsensus(msg ConsensusMessage, ts time.Time) ([]byte, error) {
+	timestamp, err := ptypes.TimestampProto(ts)
+	if err != nil {
+		return nil, err
+	}
+	req := &signerpb.SignConsensusRequest{Height: msg.Height, Timestamp: timestamp}
+	switch {
+	case len(msg.Proposal) != 0:
+		req.Msg = &signerpb.SignConsensusRequest_Proposal{Proposal: msg.Proposal}
+	case len(msg.Vote) != 0:
+		req.Msg = &signerpb.SignConsensusRequest_Vote{Vote: msg.Vote}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	res, err := s.client.SignConsensus(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(fromStatus(err), "failed to sign consensus message with remote signer")
+	}
+	return res.Signature, nil
+}
+
+// Close closes the connection to the remote signer
+func (s *RemoteSigner) Close() error {
+	return s.conn.Close()
+}
+
+// Server serves a signer to the node over mutual TLS, with double-sign protection
+type Server struct {
+	addr       string
+	signer     Signer
+	grpcServer *grpc.Server
+}
+
+// NewServer creates a server of the signer at the address, e.g., 127.0.0.1:14015. The state of the double-sign
+// protection is persisted at the state path, in the rounds of the chain. The TLS config should require and verify the
+// client certificates, as the one created by NewServerTLSConfig.
+func NewServer(addr string, s Signer, statePath string, rounds Rounds, tlsCfg *tls.Config) (*Server, error) {
+	if tlsCfg == nil || tlsCfg.ClientAuth != tls.RequireAndVerifyClientCert {
+		return nil, errors.New("signer server requires TLS with verified client certificates")
+	}
+	protected, err := NewProtected(s, statePath, rounds)
+	if err != nil {
+		return nil, err
+	}
+	svr := &Server{
+		addr:       addr,
+		signer:     protected,
+		grpcServer: grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsCfg))),
+	}
+	signerpb.RegisterSignerServiceServer(svr.grpcServer, svr)
+	return svr, nil
+}
+
+// NewServerTLSConfig creates the TLS config of the signer server, with the server certificate and key, and the CA
+// certificate to verify the clients
+func NewServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load server certificate")
+	}
+	pool, err := loadCertPool(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// Start starts the server
+func (svr *Server) Start(_ context.Context) error {
+	lis, err := net.Listen("tcp", svr.addr)
+	if err != nil {
+		return errors.Wrap(err, "signer server failed to listen")
+	}
+	log.L().Info("Signer server is listening.",
+		zap.String("addr", lis.Addr().String()),
+		zap.String("publicKey", svr.signer.PublicKey().HexString()))
+
+	go func() {
+		if err := svr.grpcServer.Serve(lis); err != nil {
+			log.L().Fatal("Signer server failed to serve.", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Stop stops the server
+func (svr *Server) Stop(_ context.Context) error {
+	svr.grpcServer.Stop()
+	return nil
+}
+
+// GetPublicKey returns the public key of the signer
+func (svr *Server) GetPublicKey(
+	_ context.Context,
+	_ *signerpb.GetPublicKeyRequest,
+) (*signerpb.GetPublicKeyResponse, error) {
+	return &signerpb.GetPublicKeyResponse{PublicKey: svr.signer.PublicKey().Bytes()}, nil
+}
+
+// SignBlockHeader signs a block header, unless it is lower than the latest height signed in
+func (svr *Server) SignBlockHeader(
+	_ context.Context,
+	in *signerpb.SignBlockHeaderRequest,
+) (*signerpb.SignResponse, error) {
+	sig, err := svr.signer.SignBlockHeader(in.Core)
+	if err != nil {
+		return nil, toStatus(err, zap.String("request", "block header"))
+	}
+	return &signerpb.SignResponse{Signature: sig}, nil
+}
+
+// SignAction signs an action, if it is of the actions a block producer creates itself
+func (svr *Server) SignAction(_ context.Context, in *signerpb.SignActionRequest) (*signerpb.SignResponse, error) {
+	sig, err := svr.signer.SignAction(in.Core)
+	if err != nil {
+		return nil, toStatus(err, zap.String("request", "action"))
+	}
+	return &signerpb.SignResponse{Signature: sig}, nil
+}
+
+// SignEvidence signs the endorsement of an evidence
+func (svr *Server) SignEvidence(_ context.Context, in *signerpb.SignEvidenceRequest) (*signerpb.SignResponse, error) {
+	ts, err := ptypes.Timestamp(in.Timestamp)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	sig, err := svr.signer.SignEvidence(in.Evidence, ts)
+	if err != nil {
+		return nil, toStatus(err, zap.String("request", "evidence"))
+	}
+	return &signerpb.SignResponse{Signature: sig}, nil
+}
+
+// SignConsensus signs the endorsement of a consensus message, unless it conflicts with a message signed before
+func (svr *Server) SignConsensus(_ context.Context, in *signerpb.SignConsensusRequest) (*signerpb.SignResponse, error) {
+	ts, err := ptypes.Timestamp(in.Timestamp)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	msg := ConsensusMessage{Height: in.Height, Proposal: in.GetProposal(), Vote: in.GetVote()}
+	sig, err := svr.signer.SignConsensus(msg, ts)
+	if err != nil {
+		return nil, toStatus(err, zap.String("request", "consensus"), zap.Uint64("height", in.Height), zap.Time("time", ts))
+	}
+	return &signerpb.SignResponse{Signature: sig}, nil
+}
+
+// toStatus converts an error of the signer to a gRPC status, logging the refusals
+func toStatus(err error, fields ...zap.Field) error {
+	var code codes.Code
+	switch errors.Cause(err) {
+	case ErrDoubleSign:
+		code = codes.FailedPrecondition
+	case ErrNotAllowed:
+		code = codes.PermissionDenied
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+	log.L().Warn("Refused to sign.", append(fields, zap.Error(err))...)
+	return status.Error(code, err.Error())
+}
+
+// fromStatus converts a gRPC status of the signer server back to the error of the signer
+func fromStatus(err error) error {
+	switch status.Code(err) {
+	case codes.FailedPrecondition:
+		return errors.Wrap(ErrDoubleSign, status.Convert(err).Message())
+	case codes.PermissionDenied:
+		return errors.Wrap(ErrNotAllowed, status.Convert(err).Message())
+	default:
+		return err
+	}
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read CA certificate %s", caFile)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.Errorf("no certificate in %s", caFile)
+	}
+	return pool, nil
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/signer"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// document is an endorsed document, i.e., a serialized evidence or block proposal
+type document []byte
+
+func (d document) Hash() ([]byte, error) {
+	h := hash.Hash256b(d)
+	return h[:], nil
+}
+
+func TestRemoteSigner(t *testing.T) {
+	require := require.New(t)
+	dir, err := ioutil.TempDir("", "signer")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	ca := newTestCA(t, dir, "ca")
+	serverCert, serverKey := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, "client", x509.ExtKeyUsageClientAuth)
+	other := newTestCA(t, dir, "other")
+	otherCert, otherKey := other.issue(t, "other-client", x509.ExtKeyUsageClientAuth)
+
+	ctx := context.Background()
+	sk := identityset.PrivateKey(2)
+	rounds := signer.Rounds{Genesis: time.Unix(1546329600, 0), Interval: 10 * time.Second}
+	_, err = signer.NewServer("127.0.0.1:0", signer.NewInProcess(sk), "", rounds, nil)
+	require.Error(err)
+	serverTLS, err := signer.NewServerTLSConfig(serverCert, serverKey, ca.certFile)
+	require.NoError(err)
+	addr := "127.0.0.1:" + strconv.Itoa(testutil.RandomPort())
+	svr, err := signer.NewServer(addr, signer.NewInProcess(sk), filepath.Join(dir, "signer.state"), rounds, serverTLS)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+
+	// the clients without a certificate issued by the client CA are refused
+	_, err = signer.NewRemote(addr, time.Second, &tls.Config{RootCAs: ca.pool(t)})
+	require.Error(err)
+	otherTLS, err := signer.NewClientTLSConfig(otherCert, otherKey, ca.certFile)
+	require.NoError(err)
+	_, err = signer.NewRemote(addr, time.Second, otherTLS)
+	require.Error(err)
+	// so is a server not issued by the CA
+	untrustedTLS, err := signer.NewClientTLSConfig(clientCert, clientKey, other.certFile)
+	require.NoError(err)
+	_, err = signer.NewRemote(addr, time.Second, untrustedTLS)
+	require.Error(err)
+
+	clientTLS, err := signer.NewClientTLSConfig(clientCert, clientKey, ca.certFile)
+	require.NoError(err)
+	s, err := signer.NewRemote(addr, 2*time.Second, clientTLS)
+	require.NoError(err)
+	defer func() {
+		require.NoError(s.Close())
+	}()
+	require.Equal(sk.PublicKey().Bytes(), s.PublicKey().Bytes())
+
+	// plain hashes are not signed
+	hashA := hash.Hash256b([]byte("a"))
+	_, err = s.Sign(hashA[:])
+	require.Equal(signer.ErrNotAllowed, errors.Cause(err))
+
+	// block headers
+	round := rounds.Genesis.Add(100 * rounds.Interval)
+	buildBlock := func(height uint64, ts time.Time) (block.Block, error) {
+		ra := block.NewRunnableActionsBuilder().
+			SetHeight(height).
+			SetTimeStamp(ts).
+			Build(s.PublicKey())
+		return block.NewBuilder(ra).SignAndBuild(s)
+	}
+	blk, err := buildBlock(3, round)
+	require.NoError(err)
+	require.True(blk.VerifySignature())
+	// a different block of the height in the round is refused
+	_, err = buildBlock(3, round.Add(time.Second))
+	require.Equal(signer.ErrDoubleSign, errors.Cause(err))
+
+	// consensus messages
+	proposal, err := proto.Marshal(&iotextypes.BlockProposal{Block: blk.ConvertToBlockPb()})
+	require.NoError(err)
+	en, err := endorsement.EndorseWith(
+		s.PublicKey(),
+		func([]byte) ([]byte, error) {
+			return s.SignConsensus(signer.ConsensusMessage{Proposal: proposal}, round)
+		},
+		document(proposal),
+		round,
+	)
+	require.NoError(err)
+	require.True(endorsement.VerifyEndorsement(document(proposal), en))
+	vote := func(blk string) signer.ConsensusMessage {
+		h := hash.Hash256b([]byte(blk))
+		v, err := proto.Marshal(&iotextypes.ConsensusVote{BlockHash: h[:], Topic: iotextypes.ConsensusVote_LOCK})
+		require.NoError(err)
+		return signer.ConsensusMessage{Height: 3, Vote: v}
+	}
+	_, err = s.SignConsensus(vote("a"), round.Add(6*time.Second))
+	require.NoError(err)
+	_, err = s.SignConsensus(vote("b"), round.Add(6*time.Second))
+	require.Equal(signer.ErrDoubleSign, errors.Cause(err))
+	_, err = s.SignConsensus(signer.ConsensusMessage{Height: 3}, round)
+	require.Equal(signer.ErrNotAllowed, errors.Cause(err))
+	_, err = buildBlock(2, round.Add(rounds.Interval))
+	require.Equal(signer.ErrDoubleSign, errors.Cause(err))
+
+	// actions
+	grant := (&action.GrantRewardBuilder{}).SetRewardType(action.BlockReward).SetHeight(3).Build()
+	selp, err := action.Sign((&action.EnvelopeBuilder{}).SetAction(&grant).SetGasPrice(big.NewInt(0)).Build(), s)
+	require.NoError(err)
+	require.NoError(action.Verify(selp))
+	tsf, err := action.NewTransfer(1, big.NewInt(1), identityset.Address(3).String(), nil, 0, big.NewInt(0))
+	require.NoError(err)
+	_, err = action.Sign((&action.EnvelopeBuilder{}).SetNonce(1).SetAction(tsf).Build(), s)
+	require.Error(err)
+
+	// evidences
+	ev, err := proto.Marshal(&iotextypes.ConsensusEvidence{
+		First:  &iotextypes.ConsensusMessage{Height: 1},
+		Second: &iotextypes.ConsensusMessage{Height: 1},
+	})
+	require.NoError(err)
+	ts := time.Now()
+	en, err = endorsement.EndorseWith(
+		s.PublicKey(),
+		func([]byte) ([]byte, error) {
+			return s.SignEvidence(ev, ts)
+		},
+		document(ev),
+		ts,
+	)
+	require.NoError(err)
+	require.True(endorsement.VerifyEndorsement(document(ev), en))
+	_, err = s.SignEvidence([]byte{}, ts)
+	require.Equal(signer.ErrNotAllowed, errors.Cause(err))
+}
+
+// testCA issues the certificates of the test
+type testCA struct {
+	dir      string
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certFile string
+}
+
+func newTestCA(t *testing.T, dir string, name string) *testCA {
+	require := require.New(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(err)
+	ca := &testCA{dir: dir, cert: cert, key: key, certFile: filepath.Join(dir, name+".pem")}
+	writePEM(t, ca.certFile, "CERTIFICATE", der)
+	return ca
+}
+
+// issue issues a certificate for 127.0.0.1, and returns the paths of the certificate and the key
+func (ca *testCA) issue(t *testing.T, name string, usage x509.ExtKeyUsage) (string, string) {
+	require := require.New(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(err)
+	certFile := filepath.Join(ca.dir, name+".pem")
+	keyFile := filepath.Join(ca.dir, name+"-key.pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+func (ca *testCA) pool(t *testing.T) *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	require.NoError(t, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+}
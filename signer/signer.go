@@ -0,0 +1,187 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/golang/protobuf/proto"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+var (
+	// ErrDoubleSign indicates that signing a consensus message or a block header is refused, since it conflicts
+	// with a message signed before
+	ErrDoubleSign = errors.New("double sign")
+	// ErrNotAllowed indicates that signing is refused, since the signer doesn't sign such a message
+	ErrNotAllowed = errors.New("not allowed to sign")
+)
+
+type (
+	// HashSigner signs hashes on behalf of a public key, which is satisfied by a keypair.PrivateKey
+	HashSigner interface {
+		PublicKey() keypair.PublicKey
+		Sign([]byte) ([]byte, error)
+	}
+
+	// BlockHeaderSigner signs a block header from the serialized iotextypes.BlockHeaderCore, rather than its hash, so
+	// that the signer knows what it signs
+	BlockHeaderSigner interface {
+		SignBlockHeader(core []byte) ([]byte, error)
+	}
+
+	// ActionSigner signs an action from the serialized iotextypes.ActionCore of its envelope, rather than its hash,
+	// so that the signer knows what it signs
+	ActionSigner interface {
+		SignAction(core []byte) ([]byte, error)
+	}
+
+	// Signer signs for a block producer, without exposing its private key. The block headers, actions, evidences and
+	// consensus messages are signed from their contents, so that a signer may refuse to sign conflicting or
+	// unexpected messages. A signer may refuse to sign plain hashes.
+	Signer interface {
+		HashSigner
+		BlockHeaderSigner
+		ActionSigner
+		// SignEvidence signs the endorsement at the time of the serialized iotextypes.ConsensusEvidence
+		SignEvidence(evidence []byte, ts time.Time) ([]byte, error)
+		// SignConsensus signs the endorsement at the time of a consensus message
+		SignConsensus(msg ConsensusMessage, ts time.Time) ([]byte, error)
+	}
+
+	// ConsensusMessage is a consensus message to endorse, which is either a block proposal or a vote at the height
+	ConsensusMessage struct {
+		// Height is the height of a vote, while the height of a proposal is the height of its block
+		Height uint64
+		// Proposal is the serialized iotextypes.BlockProposal
+		Proposal []byte
+		// Vote is the serialized iotextypes.ConsensusVote
+		Vote []byte
+	}
+
+	// consensusDoc is what a consensus message endorses. A delegate endorses at most one document of each kind in a
+	// round.
+	consensusDoc struct {
+		height uint64
+		kind   string
+		hash   []byte
+		// proposal is the decoded proposal, if the message is a proposal
+		proposal *iotextypes.BlockProposal
+	}
+
+	inProcessSigner struct {
+		sk keypair.PrivateKey
+	}
+)
+
+// NewInProcess creates a signer with the private key in memory
+func NewInProcess(sk keypair.PrivateKey) Signer {
+	return &inProcessSigner{sk: sk}
+}
+
+// NewKeystore creates a signer with the private key decrypted from a keystore file
+func NewKeystore(path string, password string) (Signer, error) {
+	sk, err := keypair.KeystoreToPrivateKey(accounts.Account{URL: accounts.URL{Path: path}}, password)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decrypt keystore %s", path)
+	}
+	return NewInProcess(sk), nil
+}
+
+// PublicKey returns the public key of the signer
+func (s *inProcessSigner) PublicKey() keypair.PublicKey {
+	return s.sk.PublicKey()
+}
+
+// Sign signs a hash
+func (s *inProcessSigner) Sign(hash []byte) ([]byte, error) {
+	return s.sk.Sign(hash)
+}
+
+// SignBlockHeader signs a block header
+func (s *inProcessSigner) SignBlockHeader(core []byte) ([]byte, error) {
+	h := hash.Hash256b(core)
+	return s.sk.Sign(h[:])
+}
+
+// SignAction signs an action
+func (s *inProcessSigner) SignAction(core []byte) ([]byte, error) {
+	h := hash.Hash256b(core)
+	return s.sk.Sign(h[:])
+}
+
+// SignEvidence signs the endorsement of an evidence
+func (s *inProcessSigner) SignEvidence(evidence []byte, ts time.Time) ([]byte, error) {
+	h := hash.Hash256b(evidence)
+	return s.sk.Sign(endorsementHash(h[:], ts))
+}
+
+// SignConsensus signs the endorsement of a consensus message. The conflicting messages are not checked, which is the
+// job of the consensus write-ahead log of the node.
+func (s *inProcessSigner) SignConsensus(msg ConsensusMessage, ts time.Time) ([]byte, error) {
+	doc, err := decodeConsensusMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	return s.sk.Sign(endorsementHash(doc.hash, ts))
+}
+
+// decodeConsensusMessage decodes the document a consensus message endorses, and hashes it as the consensus does
+func decodeConsensusMessage(msg ConsensusMessage) (*consensusDoc, error) {
+	switch {
+	case len(msg.Proposal) != 0 && len(msg.Vote) == 0:
+		proposal := &iotextypes.BlockProposal{}
+		if err := proto.Unmarshal(msg.Proposal, proposal); err != nil {
+			return nil, errors.Wrap(err, "failed to decode block proposal")
+		}
+		header := proposal.GetBlock().GetHeader()
+		if header.GetCore() == nil {
+			return nil, errors.Wrap(ErrNotAllowed, "block proposal misses the block header")
+		}
+		if msg.Height != 0 && msg.Height != header.Core.Height {
+			return nil, errors.Wrapf(
+				ErrNotAllowed,
+				"height %d mismatches the proposed block height %d",
+				msg.Height,
+				header.Core.Height,
+			)
+		}
+		h := hash.Hash256b(msg.Proposal)
+		return &consensusDoc{height: header.Core.Height, kind: "proposal", hash: h[:], proposal: proposal}, nil
+	case len(msg.Vote) != 0 && len(msg.Proposal) == 0:
+		vote := &iotextypes.ConsensusVote{}
+		if err := proto.Unmarshal(msg.Vote, vote); err != nil {
+			return nil, errors.Wrap(err, "failed to decode consensus vote")
+		}
+		if _, ok := iotextypes.ConsensusVote_Topic_name[int32(vote.Topic)]; !ok {
+			return nil, errors.Wrapf(ErrNotAllowed, "invalid vote topic %d", vote.Topic)
+		}
+		if len(vote.BlockHash) != len(hash.ZeroHash256) || msg.Height == 0 {
+			return nil, errors.Wrap(ErrNotAllowed, "vote misses the block hash or the height")
+		}
+		// a vote is hashed with blake2b as ConsensusVote.Hash does
+		h := blake2b.Sum256(msg.Vote)
+		return &consensusDoc{height: msg.Height, kind: "vote-" + vote.Topic.String(), hash: h[:]}, nil
+	default:
+		return nil, errors.Wrap(ErrNotAllowed, "consensus message is neither a proposal nor a vote")
+	}
+}
+
+// endorsementHash returns the hash of the endorsement of a document at the time, as the endorsement package hashes a
+// document with the time
+func endorsementHash(docHash []byte, ts time.Time) []byte {
+	b := append(append([]byte{}, docHash...), byteutil.Uint64ToBytes(uint64(ts.Unix()))...)
+	h := hash.Hash256b(append(b, byteutil.Uint32ToBytes(uint32(ts.Nanosecond()))...))
+	return h[:]
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestInProcessSigner(t *testing.T) {
+	require := require.New(t)
+	sk := identityset.PrivateKey(0)
+	s := NewInProcess(sk)
+	require.Equal(sk.PublicKey().Bytes(), s.PublicKey().Bytes())
+
+	h := hash.Hash256b([]byte("block"))
+	sig, err := s.Sign(h[:])
+	require.NoError(err)
+	require.True(s.PublicKey().Verify(h[:], sig))
+	// the in-process signer does not check conflicts
+	ts := time.Now()
+	voteA := testVote(t, 1, iotextypes.ConsensusVote_PROPOSAL, "a")
+	sig, err = s.SignConsensus(voteA, ts)
+	require.NoError(err)
+	vh := blake2b.Sum256(voteA.Vote)
+	require.True(s.PublicKey().Verify(endorsementHash(vh[:], ts), sig))
+	_, err = s.SignConsensus(testVote(t, 1, iotextypes.ConsensusVote_PROPOSAL, "b"), ts)
+	require.NoError(err)
+	// but it signs nothing other than proposals and votes
+	_, err = s.SignConsensus(ConsensusMessage{Height: 1}, ts)
+	require.Equal(ErrNotAllowed, errors.Cause(err))
+	// typed requests are signed over the hash of their bytes
+	core := []byte("core")
+	h = hash.Hash256b(core)
+	sig, err = s.SignBlockHeader(core)
+	require.NoError(err)
+	require.True(s.PublicKey().Verify(h[:], sig))
+	sig, err = s.SignAction(core)
+	require.NoError(err)
+	require.True(s.PublicKey().Verify(h[:], sig))
+	sig, err = s.SignEvidence(core, ts)
+	require.NoError(err)
+	require.True(s.PublicKey().Verify(endorsementHash(h[:], ts), sig))
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	require := require.New(t)
+	dir, err := ioutil.TempDir("", "keystore")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	sk := identityset.PrivateKey(1)
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(sk.EcdsaPrivateKey(), "password")
+	require.NoError(err)
+
+	s, err := NewKeystore(account.URL.Path, "password")
+	require.NoError(err)
+	require.Equal(sk.PublicKey().Bytes(), s.PublicKey().Bytes())
+	h := hash.Hash256b([]byte("block"))
+	sig, err := s.Sign(h[:])
+	require.NoError(err)
+	require.True(sk.PublicKey().Verify(h[:], sig))
+
+	_, err = NewKeystore(account.URL.Path, "wrong password")
+	require.Error(err)
+	_, err = NewKeystore(dir+"/not-exist", "password")
+	require.Error(err)
+}
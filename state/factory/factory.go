@@ -37,12 +37,20 @@ const (
 	// CandidateKVNameSpace is the bucket name for candidate data storage
 	CandidateKVNameSpace = "Candidate"
 
+	// ContractKVNameSpace is the bucket name for contract storage tries, the same as evm.ContractKVNameSpace
+	ContractKVNameSpace = "Contract"
+
 	// CurrentHeightKey indicates the key of current factory height in underlying DB
 	CurrentHeightKey = "currentHeight"
 	// AccountTrieRootKey indicates the key of accountTrie root hash in underlying DB
 	AccountTrieRootKey = "accountTrieRoot"
 )
 
+var (
+	// ErrNotArchiveMode indicates the historical state is not retained since archive mode is disabled
+	ErrNotArchiveMode = errors.New("historical state is only available in archive mode")
+)
+
 type (
 	// Factory defines an interface for managing states
 	Factory interface {
@@ -51,15 +59,18 @@ type (
 		Balance(string) (*big.Int, error)
 		Nonce(string) (uint64, error) // Note that Nonce starts with 1.
 		AccountState(string) (*state.Account, error)
+		AccountStateAtHeight(string, uint64) (*state.Account, error)
 		RootHash() hash.Hash256
 		RootHashByHeight(uint64) (hash.Hash256, error)
 		Height() (uint64, error)
 		NewWorkingSet() (WorkingSet, error)
+		NewWorkingSetAtHeight(uint64) (WorkingSet, error)
 		Commit(WorkingSet) error
 		// Candidate pool
 		CandidatesByHeight(uint64) ([]*state.Candidate, error)
 
 		State(hash.Hash160, interface{}) error
+		StateProof(hash.Hash160, hash.Hash256) ([][]byte, error)
 		AddActionHandlers(...protocol.ActionHandler)
 	}
 
@@ -69,6 +80,9 @@ type (
 		mutex              sync.RWMutex
 		currentChainHeight uint64
 		accountTrie        trie.Trie                // global state trie
+		archive            bool                     // retain the state trie of every height
+		pruner             *trie.Pruner             // prune the stale trie nodes out of the retention window
+		retention          uint64                   // number of recent heights whose state tries are retained
 		dao                db.KVStore               // the underlying DB for account/contract storage
 		actionHandlers     []protocol.ActionHandler // the handlers to handle actions
 		timerFactory       *prometheustimer.TimerFactory
@@ -122,6 +136,26 @@ func NewFactory(cfg config.Config, opts ...Option) (Factory, error) {
 			return nil, err
 		}
 	}
+	switch {
+	case cfg.Chain.EnableArchiveMode:
+		// stale trie nodes are kept, so the state trie of any past height could be loaded by its root
+		sf.archive = true
+		sf.dao = db.NewKVStoreForArchive(sf.dao, AccountKVNameSpace, ContractKVNameSpace)
+	case cfg.Chain.TrieRetentionHeight > 0:
+		// stale trie nodes are kept until they fall out of the retention window, and then deleted by the pruner
+		var err error
+		if sf.pruner, err = trie.NewPruner(
+			sf.dao,
+			cfg.Chain.TrieRetentionHeight,
+			&sf.mutex,
+			AccountKVNameSpace,
+			ContractKVNameSpace,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to create trie pruner")
+		}
+		sf.retention = cfg.Chain.TrieRetentionHeight
+		sf.dao = db.NewKVStoreForArchive(sf.dao, AccountKVNameSpace, ContractKVNameSpace)
+	}
 	dbForTrie, err := db.NewKVStoreForTrie(AccountKVNameSpace, sf.dao)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create db for trie")
@@ -153,10 +187,32 @@ func (sf *factory) Start(ctx context.Context) error {
 	if err := sf.dao.Start(ctx); err != nil {
 		return err
 	}
-	return sf.lifecycle.OnStart(ctx)
+	if err := sf.lifecycle.OnStart(ctx); err != nil {
+		return err
+	}
+	if sf.pruner == nil {
+		return nil
+	}
+	if err := sf.pruner.Start(ctx); err != nil {
+		return err
+	}
+	switch data, err := sf.dao.Get(AccountKVNameSpace, []byte(CurrentHeightKey)); errors.Cause(err) {
+	case nil:
+		sf.pruner.SetTipHeight(byteutil.BytesToUint64(data))
+	case db.ErrNotExist:
+	default:
+		return errors.Wrap(err, "failed to get factory's height from underlying DB")
+	}
+	return nil
 }
 
 func (sf *factory) Stop(ctx context.Context) error {
+	if sf.pruner != nil {
+		// the pruner acquires the mutex while pruning, so it has to be stopped before locking
+		if err := sf.pruner.Stop(ctx); err != nil {
+			return err
+		}
+	}
 	sf.mutex.Lock()
 	defer sf.mutex.Unlock()
 	if err := sf.dao.Stop(ctx); err != nil {
@@ -206,6 +262,22 @@ func (sf *factory) AccountState(addr string) (*state.Account, error) {
 	return sf.accountState(addr)
 }
 
+// AccountStateAtHeight returns the confirmed account state at the given height
+func (sf *factory) AccountStateAtHeight(addr string, height uint64) (*state.Account, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+
+	rootHash, err := sf.rootHashAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	tr, err := sf.trieWithRoot(rootHash)
+	if err != nil {
+		return nil, err
+	}
+	return accountState(tr, addr)
+}
+
 // RootHash returns the hash of the root node of the state trie
 func (sf *factory) RootHash() hash.Hash256 {
 	sf.mutex.RLock()
@@ -244,6 +316,18 @@ func (sf *factory) NewWorkingSet() (WorkingSet, error) {
 	return NewWorkingSet(sf.currentChainHeight, sf.dao, sf.rootHash(), sf.actionHandlers)
 }
 
+// NewWorkingSetAtHeight returns a working set on top of the state at the given height, which is for reading
+// historical states and should never be committed
+func (sf *factory) NewWorkingSetAtHeight(height uint64) (WorkingSet, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	rootHash, err := sf.rootHashAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return NewWorkingSet(height, sf.dao, rootHash, sf.actionHandlers)
+}
+
 // Commit persists all changes in RunActions() into the DB
 func (sf *factory) Commit(ws WorkingSet) error {
 	if ws == nil {
@@ -261,9 +345,17 @@ func (sf *factory) Commit(ws WorkingSet) error {
 			ws.Version(),
 		)
 	}
+	if sf.pruner != nil {
+		if err := sf.pruner.Track(ws.GetCachedBatch(), ws.Height()); err != nil {
+			return errors.Wrap(err, "failed to track stale trie nodes")
+		}
+	}
 	if err := ws.Commit(); err != nil {
 		return errors.Wrap(err, "failed to commit working set")
 	}
+	if sf.pruner != nil {
+		sf.pruner.SetTipHeight(ws.Height())
+	}
 	// Update chain height and root
 	sf.currentChainHeight = ws.Height()
 	h := ws.RootHash()
@@ -311,6 +403,18 @@ func (sf *factory) State(addr hash.Hash160, state interface{}) error {
 	return sf.state(addr, state)
 }
 
+// StateProof returns the merkle proof of the state of addr in the state trie with the given root hash
+func (sf *factory) StateProof(addr hash.Hash160, rootHash hash.Hash256) ([][]byte, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+
+	tr, err := sf.trieWithRoot(rootHash)
+	if err != nil {
+		return nil, err
+	}
+	return tr.Prove(addr[:])
+}
+
 //======================================
 // private trie constructor functions
 //======================================
@@ -319,8 +423,59 @@ func (sf *factory) rootHash() hash.Hash256 {
 	return hash.BytesToHash256(sf.accountTrie.RootHash())
 }
 
+// rootHashAtHeight returns the root hash of the state trie at the given height, only the heights in the retention
+// window are available if archive mode is disabled
+func (sf *factory) rootHashAtHeight(height uint64) (hash.Hash256, error) {
+	var current uint64
+	switch data, err := sf.dao.Get(AccountKVNameSpace, []byte(CurrentHeightKey)); errors.Cause(err) {
+	case nil:
+		current = byteutil.BytesToUint64(data)
+	case db.ErrNotExist:
+	default:
+		return hash.ZeroHash256, errors.Wrap(err, "failed to get factory's height from underlying DB")
+	}
+	if height > current {
+		return hash.ZeroHash256, errors.Errorf("height %d is higher than current height %d", height, current)
+	}
+	if height == current {
+		return sf.rootHash(), nil
+	}
+	if !sf.archive && height+sf.retention <= current {
+		return hash.ZeroHash256, errors.Wrapf(ErrNotArchiveMode, "failed to get state at height %d", height)
+	}
+	data, err := sf.dao.Get(AccountKVNameSpace, []byte(fmt.Sprintf("%s-%d", AccountTrieRootKey, height)))
+	if err != nil {
+		return hash.ZeroHash256, errors.Wrapf(err, "failed to get state root at height %d", height)
+	}
+	return hash.BytesToHash256(data), nil
+}
+
+// trieWithRoot loads the state trie with the given root hash
+func (sf *factory) trieWithRoot(rootHash hash.Hash256) (trie.Trie, error) {
+	dbForTrie, err := db.NewKVStoreForTrie(AccountKVNameSpace, sf.dao)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create db for trie")
+	}
+	tr, err := trie.NewTrie(trie.KVStoreOption(dbForTrie), trie.RootHashOption(rootHash[:]))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate trie")
+	}
+	if err := tr.Start(context.Background()); err != nil {
+		return nil, errors.Wrapf(err, "failed to load trie of root hash %x", rootHash)
+	}
+	return tr, nil
+}
+
 func (sf *factory) state(addr hash.Hash160, s interface{}) error {
-	data, err := sf.accountTrie.Get(addr[:])
+	return stateFromTrie(sf.accountTrie, addr, s)
+}
+
+func (sf *factory) accountState(encodedAddr string) (*state.Account, error) {
+	return accountState(sf.accountTrie, encodedAddr)
+}
+
+func stateFromTrie(tr trie.Trie, addr hash.Hash160, s interface{}) error {
+	data, err := tr.Get(addr[:])
 	if err != nil {
 		if errors.Cause(err) == trie.ErrNotExist {
 			return errors.Wrapf(state.ErrStateNotExist, "state of %x doesn't exist", addr)
@@ -333,7 +488,7 @@ func (sf *factory) state(addr hash.Hash160, s interface{}) error {
 	return nil
 }
 
-func (sf *factory) accountState(encodedAddr string) (*state.Account, error) {
+func accountState(tr trie.Trie, encodedAddr string) (*state.Account, error) {
 	// TODO: state db shouldn't serve this function
 	addr, err := address.FromString(encodedAddr)
 	if err != nil {
@@ -341,7 +496,7 @@ func (sf *factory) accountState(encodedAddr string) (*state.Account, error) {
 	}
 	pkHash := hash.BytesToHash160(addr.Bytes())
 	var account state.Account
-	if err := sf.state(pkHash, &account); err != nil {
+	if err := stateFromTrie(tr, pkHash, &account); err != nil {
 		if errors.Cause(err) == state.ErrStateNotExist {
 			account = state.EmptyAccount()
 			return &account, nil
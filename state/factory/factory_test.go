@@ -542,6 +542,111 @@ func TestFactory_RootHashByHeight(t *testing.T) {
 	require.NotEqual(t, hash.ZeroHash256, rootHash)
 }
 
+func TestFactory_HistoricalState(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	a := testaddress.Addrinfo["alfa"].String()
+	pkHash := hash.BytesToHash160(testaddress.Addrinfo["alfa"].Bytes())
+
+	for _, c := range []struct {
+		archive   bool
+		retention uint64
+		lowest    uint64
+	}{
+		{false, 0, 3},
+		{true, 0, 1},
+		{false, 2, 2},
+	} {
+		cfg := config.Default
+		cfg.Chain.EnableArchiveMode = c.archive
+		cfg.Chain.TrieRetentionHeight = c.retention
+		sf, err := NewFactory(cfg, InMemTrieOption())
+		require.NoError(err)
+		require.NoError(sf.Start(ctx))
+
+		for h := uint64(1); h <= 3; h++ {
+			ws, err := sf.NewWorkingSet()
+			require.NoError(err)
+			acct, err := accountutil.LoadOrCreateAccount(ws, a, big.NewInt(0))
+			require.NoError(err)
+			acct.Balance = new(big.Int).SetUint64(100 * h)
+			require.NoError(accountutil.StoreAccount(ws, a, acct))
+			_, err = ws.RunActions(ctx, h, nil)
+			require.NoError(err)
+			require.NoError(sf.Commit(ws))
+		}
+
+		for h := uint64(1); h <= 3; h++ {
+			acct, err := sf.AccountStateAtHeight(a, h)
+			ws, wsErr := sf.NewWorkingSetAtHeight(h)
+			if h < c.lowest {
+				require.Equal(ErrNotArchiveMode, errors.Cause(err))
+				require.Equal(ErrNotArchiveMode, errors.Cause(wsErr))
+				continue
+			}
+			require.NoError(err)
+			require.Equal(new(big.Int).SetUint64(100*h), acct.Balance)
+			require.NoError(wsErr)
+			acct, err = accountutil.LoadAccount(ws, pkHash)
+			require.NoError(err)
+			require.Equal(new(big.Int).SetUint64(100*h), acct.Balance)
+		}
+		_, err = sf.AccountStateAtHeight(a, 4)
+		require.Error(err)
+		require.NoError(sf.Stop(ctx))
+	}
+}
+
+func TestCommitStateSnapshot(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	a := testaddress.Addrinfo["alfa"].String()
+	code := []byte("contract code")
+	codeHash := hash.Hash256b(code)
+
+	kv := db.NewMemKVStore()
+	sf, err := NewFactory(config.Default, PrecreatedTrieDBOption(kv))
+	require.NoError(err)
+	require.NoError(sf.Start(ctx))
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	acct, err := accountutil.LoadOrCreateAccount(ws, a, big.NewInt(0))
+	require.NoError(err)
+	acct.CodeHash = codeHash[:]
+	require.NoError(accountutil.StoreAccount(ws, a, acct))
+	_, err = ws.RunActions(ctx, 1, nil)
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+	rootHash := sf.RootHash()
+	require.NoError(sf.Stop(ctx))
+
+	require.NoError(kv.Put(CodeKVNameSpace, codeHash[:], code))
+	// the state is copied into the trie DB once verified
+	trieDB := db.NewMemKVStore()
+	require.NoError(CommitStateSnapshot(kv, trieDB, 1, rootHash))
+	height, err := stateHeight(trieDB)
+	require.NoError(err)
+	require.Equal(uint64(1), height)
+	value, err := trieDB.Get(CodeKVNameSpace, codeHash[:])
+	require.NoError(err)
+	require.Equal(code, value)
+	_, err = ExportState(trieDB, 1, func(string, []byte, []byte) error { return nil })
+	require.NoError(err)
+
+	// nothing is written into the trie DB if the code doesn't match the code hash of the contract
+	trieDB = db.NewMemKVStore()
+	require.NoError(kv.Put(CodeKVNameSpace, codeHash[:], []byte("other code")))
+	require.Error(CommitStateSnapshot(kv, trieDB, 1, rootHash))
+	_, err = trieDB.Get(AccountKVNameSpace, rootHash[:])
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+	height, err = stateHeight(trieDB)
+	require.NoError(err)
+	require.Equal(uint64(0), height)
+	// or if the code is missing
+	require.NoError(kv.Delete(CodeKVNameSpace, codeHash[:]))
+	require.Error(CommitStateSnapshot(kv, trieDB, 1, rootHash))
+}
+
 func TestRunActions(t *testing.T) {
 	sf, err := NewFactory(config.Default, InMemTrieOption())
 	require.NoError(t, err)
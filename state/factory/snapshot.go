@@ -0,0 +1,174 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// CodeKVNameSpace is the bucket name for contract code, the same as evm.CodeKVNameSpace
+	CodeKVNameSpace = "Code"
+
+	// snapshotCopyBatchSize is the number of state records copied into the trie DB at a time
+	snapshotCopyBatchSize = 10000
+)
+
+// ExportState visits the records of the state at the given height, which are the nodes of the account trie and the
+// contract storage tries, and the contract code. It returns the root hash of the state
+func ExportState(
+	kv db.KVStore,
+	height uint64,
+	f func(namespace string, key, value []byte) error,
+) (hash.Hash256, error) {
+	current, err := stateHeight(kv)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	if height > current {
+		return hash.ZeroHash256, errors.Errorf("height %d is higher than current height %d", height, current)
+	}
+	rootKey := fmt.Sprintf("%s-%d", AccountTrieRootKey, height)
+	if height == current {
+		rootKey = AccountTrieRootKey
+	}
+	data, err := kv.Get(AccountKVNameSpace, []byte(rootKey))
+	if err != nil {
+		return hash.ZeroHash256, errors.Wrapf(err, "failed to get state root at height %d", height)
+	}
+	rootHash := hash.BytesToHash256(data)
+	if err := walkState(kv, rootHash, f); err != nil {
+		return hash.ZeroHash256, err
+	}
+	return rootHash, nil
+}
+
+// CommitStateSnapshot verifies the state of the root hash in the staging store, written from a snapshot, is complete
+// and intact, and only then copies the records of the state into the trie DB and sets it as the state at the given
+// height
+func CommitStateSnapshot(staging db.KVStore, kv db.KVStore, height uint64, rootHash hash.Hash256) error {
+	if err := walkState(staging, rootHash, func(string, []byte, []byte) error { return nil }); err != nil {
+		return errors.Wrap(err, "failed to verify state snapshot")
+	}
+	batch := db.NewBatch()
+	if err := walkState(staging, rootHash, func(namespace string, key, value []byte) error {
+		batch.Put(namespace, key, value, "failed to put state record %x", key)
+		if batch.Size() < snapshotCopyBatchSize {
+			return nil
+		}
+		if err := kv.Commit(batch); err != nil {
+			return err
+		}
+		batch.Clear()
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "failed to copy state snapshot")
+	}
+	batch.Put(AccountKVNameSpace, []byte(CurrentHeightKey), byteutil.Uint64ToBytes(height),
+		"failed to put current height")
+	batch.Put(AccountKVNameSpace, []byte(AccountTrieRootKey), rootHash[:], "failed to put state root")
+	batch.Put(AccountKVNameSpace, []byte(fmt.Sprintf("%s-%d", AccountTrieRootKey, height)), rootHash[:],
+		"failed to put state root of height %d", height)
+	return kv.Commit(batch)
+}
+
+// stateHeight returns the height of the state in the kv store, 0 if nothing has been committed
+func stateHeight(kv db.KVStore) (uint64, error) {
+	switch data, err := kv.Get(AccountKVNameSpace, []byte(CurrentHeightKey)); errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64(data), nil
+	case db.ErrNotExist:
+		return 0, nil
+	default:
+		return 0, errors.Wrap(err, "failed to get factory's height from underlying DB")
+	}
+}
+
+// walkState visits all the records of the state of the root hash, and verifies every trie node and contract code
+// against its hash
+func walkState(kv db.KVStore, rootHash hash.Hash256, f func(namespace string, key, value []byte) error) error {
+	accountTrie, err := loadTrie(kv, AccountKVNameSpace, rootHash, trie.DefaultHashFunc)
+	if err != nil {
+		return err
+	}
+	if err := trie.WalkNodes(accountTrie, func(key, value []byte) error {
+		return f(AccountKVNameSpace, key, value)
+	}); err != nil {
+		return errors.Wrap(err, "failed to walk account trie")
+	}
+	iter, err := trie.NewLeafIterator(accountTrie)
+	if err != nil {
+		return err
+	}
+	for {
+		key, value, err := iter.Next()
+		if err == trie.ErrEndOfIterator {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var account state.Account
+		if err := account.Deserialize(value); err != nil || !account.IsContract() {
+			// not a contract, or not an account at all
+			continue
+		}
+		code, err := kv.Get(CodeKVNameSpace, account.CodeHash)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get code of contract %x", key)
+		}
+		if hash.Hash256b(code) != hash.BytesToHash256(account.CodeHash) {
+			return errors.Errorf("code of contract %x doesn't match its hash %x", key, account.CodeHash)
+		}
+		if err := f(CodeKVNameSpace, account.CodeHash, code); err != nil {
+			return err
+		}
+		if account.Root == hash.ZeroHash256 {
+			continue
+		}
+		addr := hash.BytesToHash160(key)
+		storageTrie, err := loadTrie(kv, ContractKVNameSpace, account.Root, func(data []byte) []byte {
+			return trie.DefaultHashFunc(append(addr[:], data...))
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to load storage trie of contract %x", key)
+		}
+		if err := trie.WalkNodes(storageTrie, func(key, value []byte) error {
+			return f(ContractKVNameSpace, key, value)
+		}); err != nil {
+			return errors.Wrapf(err, "failed to walk storage trie of contract %x", addr)
+		}
+	}
+}
+
+func loadTrie(kv db.KVStore, namespace string, rootHash hash.Hash256, hashFunc trie.HashFunc) (trie.Trie, error) {
+	dbForTrie, err := db.NewKVStoreForTrie(namespace, kv)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create db for trie")
+	}
+	tr, err := trie.NewTrie(
+		trie.KVStoreOption(dbForTrie),
+		trie.RootHashOption(rootHash[:]),
+		trie.HashFuncOption(hashFunc),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate trie")
+	}
+	if err := tr.Start(context.Background()); err != nil {
+		return nil, errors.Wrapf(err, "failed to load trie of root hash %x", rootHash)
+	}
+	return tr, nil
+}
@@ -137,6 +137,17 @@ func (sdb *stateDB) AccountState(addr string) (*state.Account, error) {
 	return sdb.accountState(addr)
 }
 
+// AccountStateAtHeight returns the confirmed account state at the given height, only the current height is
+// supported since stateDB doesn't retain historical states
+func (sdb *stateDB) AccountStateAtHeight(addr string, height uint64) (*state.Account, error) {
+	sdb.mutex.RLock()
+	defer sdb.mutex.RUnlock()
+	if err := sdb.checkCurrentHeight(height); err != nil {
+		return nil, err
+	}
+	return sdb.accountState(addr)
+}
+
 // RootHash returns the hash of the root node of the state trie
 func (sdb *stateDB) RootHash() hash.Hash256 { return hash.ZeroHash256 }
 
@@ -162,6 +173,16 @@ func (sdb *stateDB) NewWorkingSet() (WorkingSet, error) {
 	return newStateTX(sdb.currentChainHeight, sdb.dao, sdb.actionHandlers), nil
 }
 
+// NewWorkingSetAtHeight returns a working set at the given height, only the current height is supported
+func (sdb *stateDB) NewWorkingSetAtHeight(height uint64) (WorkingSet, error) {
+	sdb.mutex.RLock()
+	defer sdb.mutex.RUnlock()
+	if err := sdb.checkCurrentHeight(height); err != nil {
+		return nil, err
+	}
+	return newStateTX(sdb.currentChainHeight, sdb.dao, sdb.actionHandlers), nil
+}
+
 // Commit persists all changes in RunActions() into the DB
 func (sdb *stateDB) Commit(ws WorkingSet) error {
 	if ws == nil {
@@ -225,10 +246,30 @@ func (sdb *stateDB) State(addr hash.Hash160, state interface{}) error {
 	return sdb.state(addr, state)
 }
 
+// StateProof is not supported since stateDB doesn't maintain a state trie
+func (sdb *stateDB) StateProof(addr hash.Hash160, rootHash hash.Hash256) ([][]byte, error) {
+	return nil, errors.New("state proof is not supported by stateDB")
+}
+
 //======================================
 // private trie constructor functions
 //======================================
 
+func (sdb *stateDB) checkCurrentHeight(height uint64) error {
+	var current uint64
+	switch data, err := sdb.dao.Get(AccountKVNameSpace, []byte(CurrentHeightKey)); errors.Cause(err) {
+	case nil:
+		current = byteutil.BytesToUint64(data)
+	case db.ErrNotExist:
+	default:
+		return errors.Wrap(err, "failed to get factory's height from underlying DB")
+	}
+	if height != current {
+		return errors.Wrapf(ErrNotArchiveMode, "failed to get state at height %d", height)
+	}
+	return nil
+}
+
 func (sdb *stateDB) state(addr hash.Hash160, s interface{}) error {
 	data, err := sdb.dao.Get(AccountKVNameSpace, addr[:])
 	if err != nil {
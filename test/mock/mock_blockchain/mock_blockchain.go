@@ -10,6 +10,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	vm "github.com/ethereum/go-ethereum/core/vm"
 	gomock "github.com/golang/mock/gomock"
 	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/action"
@@ -263,6 +264,32 @@ func (mr *MockBlockchainMockRecorder) GetReceiptByActionHash(h interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptByActionHash", reflect.TypeOf((*MockBlockchain)(nil).GetReceiptByActionHash), h)
 }
 
+// GetReceiptsByHeight mocks base method
+func (m *MockBlockchain) GetReceiptsByHeight(height uint64) ([]*action.Receipt, error) {
+	ret := m.ctrl.Call(m, "GetReceiptsByHeight", height)
+	ret0, _ := ret[0].([]*action.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceiptsByHeight indicates an expected call of GetReceiptsByHeight
+func (mr *MockBlockchainMockRecorder) GetReceiptsByHeight(height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptsByHeight", reflect.TypeOf((*MockBlockchain)(nil).GetReceiptsByHeight), height)
+}
+
+// GetLogBlockHeights mocks base method
+func (m *MockBlockchain) GetLogBlockHeights(addrs []string, topics [][]hash.Hash256, start, end uint64) ([]uint64, error) {
+	ret := m.ctrl.Call(m, "GetLogBlockHeights", addrs, topics, start, end)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogBlockHeights indicates an expected call of GetLogBlockHeights
+func (mr *MockBlockchainMockRecorder) GetLogBlockHeights(addrs, topics, start, end interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogBlockHeights", reflect.TypeOf((*MockBlockchain)(nil).GetLogBlockHeights), addrs, topics, start, end)
+}
+
 // GetActionsFromAddress mocks base method
 func (m *MockBlockchain) GetActionsFromAddress(address string) ([]hash.Hash256, error) {
 	ret := m.ctrl.Call(m, "GetActionsFromAddress", address)
@@ -388,6 +415,18 @@ func (mr *MockBlockchainMockRecorder) TipHeight() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TipHeight", reflect.TypeOf((*MockBlockchain)(nil).TipHeight))
 }
 
+// LowestAvailableHeight mocks base method
+func (m *MockBlockchain) LowestAvailableHeight() uint64 {
+	ret := m.ctrl.Call(m, "LowestAvailableHeight")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// LowestAvailableHeight indicates an expected call of LowestAvailableHeight
+func (mr *MockBlockchainMockRecorder) LowestAvailableHeight() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LowestAvailableHeight", reflect.TypeOf((*MockBlockchain)(nil).LowestAvailableHeight))
+}
+
 // StateByAddr mocks base method
 func (m *MockBlockchain) StateByAddr(address string) (*state.Account, error) {
 	ret := m.ctrl.Call(m, "StateByAddr", address)
@@ -451,15 +490,20 @@ func (mr *MockBlockchainMockRecorder) CommitBlock(blk interface{}) *gomock.Call
 }
 
 // ValidateBlock mocks base method
-func (m *MockBlockchain) ValidateBlock(blk *block.Block) error {
-	ret := m.ctrl.Call(m, "ValidateBlock", blk)
+func (m *MockBlockchain) ValidateBlock(blk *block.Block, opts ...blockchain.ValidateOption) error {
+	varargs := []interface{}{blk}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ValidateBlock", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ValidateBlock indicates an expected call of ValidateBlock
-func (mr *MockBlockchainMockRecorder) ValidateBlock(blk interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlock", reflect.TypeOf((*MockBlockchain)(nil).ValidateBlock), blk)
+func (mr *MockBlockchainMockRecorder) ValidateBlock(blk interface{}, opts ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{blk}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlock", reflect.TypeOf((*MockBlockchain)(nil).ValidateBlock), varargs...)
 }
 
 // Validator mocks base method
@@ -498,6 +542,34 @@ func (mr *MockBlockchainMockRecorder) ExecuteContractRead(caller, ex interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteContractRead", reflect.TypeOf((*MockBlockchain)(nil).ExecuteContractRead), caller, ex)
 }
 
+// ExecuteContractReadAtHeight mocks base method
+func (m *MockBlockchain) ExecuteContractReadAtHeight(caller address.Address, ex *action.Execution, height uint64) ([]byte, *action.Receipt, error) {
+	ret := m.ctrl.Call(m, "ExecuteContractReadAtHeight", caller, ex, height)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*action.Receipt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExecuteContractReadAtHeight indicates an expected call of ExecuteContractReadAtHeight
+func (mr *MockBlockchainMockRecorder) ExecuteContractReadAtHeight(caller, ex, height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteContractReadAtHeight", reflect.TypeOf((*MockBlockchain)(nil).ExecuteContractReadAtHeight), caller, ex, height)
+}
+
+// TraceExecution mocks base method
+func (m *MockBlockchain) TraceExecution(actHash hash.Hash256, tracer vm.Tracer) ([]byte, *action.Receipt, error) {
+	ret := m.ctrl.Call(m, "TraceExecution", actHash, tracer)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*action.Receipt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TraceExecution indicates an expected call of TraceExecution
+func (mr *MockBlockchainMockRecorder) TraceExecution(actHash, tracer interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceExecution", reflect.TypeOf((*MockBlockchain)(nil).TraceExecution), actHash, tracer)
+}
+
 // AddSubscriber mocks base method
 func (m *MockBlockchain) AddSubscriber(arg0 blockchain.BlockCreationSubscriber) error {
 	ret := m.ctrl.Call(m, "AddSubscriber", arg0)
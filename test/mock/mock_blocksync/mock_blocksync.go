@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
+	blocksync "github.com/iotexproject/iotex-core/blocksync"
 	iotexrpc "github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	go_libp2p_peerstore "github.com/libp2p/go-libp2p-peerstore"
 	reflect "reflect"
@@ -72,6 +73,18 @@ func (mr *MockBlockSyncMockRecorder) TargetHeight() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TargetHeight", reflect.TypeOf((*MockBlockSync)(nil).TargetHeight))
 }
 
+// SyncStatus mocks base method
+func (m *MockBlockSync) SyncStatus() blocksync.SyncStatus {
+	ret := m.ctrl.Call(m, "SyncStatus")
+	ret0, _ := ret[0].(blocksync.SyncStatus)
+	return ret0
+}
+
+// SyncStatus indicates an expected call of SyncStatus
+func (mr *MockBlockSyncMockRecorder) SyncStatus() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockBlockSync)(nil).SyncStatus))
+}
+
 // ProcessSyncRequest mocks base method
 func (m *MockBlockSync) ProcessSyncRequest(ctx context.Context, peer go_libp2p_peerstore.PeerInfo, sync *iotexrpc.BlockSync) error {
 	ret := m.ctrl.Call(m, "ProcessSyncRequest", ctx, peer, sync)
@@ -97,13 +110,25 @@ func (mr *MockBlockSyncMockRecorder) ProcessBlock(ctx, blk interface{}) *gomock.
 }
 
 // ProcessBlockSync mocks base method
-func (m *MockBlockSync) ProcessBlockSync(ctx context.Context, blk *block.Block) error {
-	ret := m.ctrl.Call(m, "ProcessBlockSync", ctx, blk)
+func (m *MockBlockSync) ProcessBlockSync(ctx context.Context, peer go_libp2p_peerstore.PeerInfo, blk *block.Block) error {
+	ret := m.ctrl.Call(m, "ProcessBlockSync", ctx, peer, blk)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ProcessBlockSync indicates an expected call of ProcessBlockSync
-func (mr *MockBlockSyncMockRecorder) ProcessBlockSync(ctx, blk interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockSync", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockSync), ctx, blk)
+func (mr *MockBlockSyncMockRecorder) ProcessBlockSync(ctx, peer, blk interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockSync", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockSync), ctx, peer, blk)
+}
+
+// ProcessBlockHeaders mocks base method
+func (m *MockBlockSync) ProcessBlockHeaders(ctx context.Context, peer go_libp2p_peerstore.PeerInfo, headers *iotexrpc.BlockHeaders) error {
+	ret := m.ctrl.Call(m, "ProcessBlockHeaders", ctx, peer, headers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessBlockHeaders indicates an expected call of ProcessBlockHeaders
+func (mr *MockBlockSyncMockRecorder) ProcessBlockHeaders(ctx, peer, headers interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockHeaders", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockHeaders), ctx, peer, headers)
 }
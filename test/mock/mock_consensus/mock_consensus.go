@@ -94,6 +94,18 @@ func (mr *MockConsensusMockRecorder) ValidateBlockFooter(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlockFooter", reflect.TypeOf((*MockConsensus)(nil).ValidateBlockFooter), arg0)
 }
 
+// ValidateHeaderFooter mocks base method
+func (m *MockConsensus) ValidateHeaderFooter(header *block.Header, footer, prevFooter *block.Footer) error {
+	ret := m.ctrl.Call(m, "ValidateHeaderFooter", header, footer, prevFooter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateHeaderFooter indicates an expected call of ValidateHeaderFooter
+func (mr *MockConsensusMockRecorder) ValidateHeaderFooter(header, footer, prevFooter interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateHeaderFooter", reflect.TypeOf((*MockConsensus)(nil).ValidateHeaderFooter), header, footer, prevFooter)
+}
+
 // Metrics mocks base method
 func (m *MockConsensus) Metrics() (scheme.ConsensusMetrics, error) {
 	ret := m.ctrl.Call(m, "Metrics")
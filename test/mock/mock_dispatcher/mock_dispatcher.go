@@ -63,15 +63,27 @@ func (mr *MockSubscriberMockRecorder) HandleBlock(arg0, arg1 interface{}) *gomoc
 }
 
 // HandleBlockSync mocks base method
-func (m *MockSubscriber) HandleBlockSync(arg0 context.Context, arg1 *iotextypes.Block) error {
-	ret := m.ctrl.Call(m, "HandleBlockSync", arg0, arg1)
+func (m *MockSubscriber) HandleBlockSync(arg0 context.Context, arg1 go_libp2p_peerstore.PeerInfo, arg2 *iotextypes.Block) error {
+	ret := m.ctrl.Call(m, "HandleBlockSync", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HandleBlockSync indicates an expected call of HandleBlockSync
-func (mr *MockSubscriberMockRecorder) HandleBlockSync(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockSync", reflect.TypeOf((*MockSubscriber)(nil).HandleBlockSync), arg0, arg1)
+func (mr *MockSubscriberMockRecorder) HandleBlockSync(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockSync", reflect.TypeOf((*MockSubscriber)(nil).HandleBlockSync), arg0, arg1, arg2)
+}
+
+// HandleBlockHeaders mocks base method
+func (m *MockSubscriber) HandleBlockHeaders(arg0 context.Context, arg1 go_libp2p_peerstore.PeerInfo, arg2 *iotexrpc.BlockHeaders) error {
+	ret := m.ctrl.Call(m, "HandleBlockHeaders", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleBlockHeaders indicates an expected call of HandleBlockHeaders
+func (mr *MockSubscriberMockRecorder) HandleBlockHeaders(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockHeaders", reflect.TypeOf((*MockSubscriber)(nil).HandleBlockHeaders), arg0, arg1, arg2)
 }
 
 // HandleSyncRequest mocks base method
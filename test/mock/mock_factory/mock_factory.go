@@ -101,6 +101,19 @@ func (mr *MockFactoryMockRecorder) AccountState(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountState", reflect.TypeOf((*MockFactory)(nil).AccountState), arg0)
 }
 
+// AccountStateAtHeight mocks base method
+func (m *MockFactory) AccountStateAtHeight(arg0 string, arg1 uint64) (*state.Account, error) {
+	ret := m.ctrl.Call(m, "AccountStateAtHeight", arg0, arg1)
+	ret0, _ := ret[0].(*state.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountStateAtHeight indicates an expected call of AccountStateAtHeight
+func (mr *MockFactoryMockRecorder) AccountStateAtHeight(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountStateAtHeight", reflect.TypeOf((*MockFactory)(nil).AccountStateAtHeight), arg0, arg1)
+}
+
 // RootHash mocks base method
 func (m *MockFactory) RootHash() hash.Hash256 {
 	ret := m.ctrl.Call(m, "RootHash")
@@ -152,6 +165,19 @@ func (mr *MockFactoryMockRecorder) NewWorkingSet() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWorkingSet", reflect.TypeOf((*MockFactory)(nil).NewWorkingSet))
 }
 
+// NewWorkingSetAtHeight mocks base method
+func (m *MockFactory) NewWorkingSetAtHeight(arg0 uint64) (factory.WorkingSet, error) {
+	ret := m.ctrl.Call(m, "NewWorkingSetAtHeight", arg0)
+	ret0, _ := ret[0].(factory.WorkingSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewWorkingSetAtHeight indicates an expected call of NewWorkingSetAtHeight
+func (mr *MockFactoryMockRecorder) NewWorkingSetAtHeight(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWorkingSetAtHeight", reflect.TypeOf((*MockFactory)(nil).NewWorkingSetAtHeight), arg0)
+}
+
 // Commit mocks base method
 func (m *MockFactory) Commit(arg0 factory.WorkingSet) error {
 	ret := m.ctrl.Call(m, "Commit", arg0)
@@ -189,6 +215,19 @@ func (mr *MockFactoryMockRecorder) State(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockFactory)(nil).State), arg0, arg1)
 }
 
+// StateProof mocks base method
+func (m *MockFactory) StateProof(arg0 hash.Hash160, arg1 hash.Hash256) ([][]byte, error) {
+	ret := m.ctrl.Call(m, "StateProof", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateProof indicates an expected call of StateProof
+func (mr *MockFactoryMockRecorder) StateProof(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateProof", reflect.TypeOf((*MockFactory)(nil).StateProof), arg0, arg1)
+}
+
 // AddActionHandlers mocks base method
 func (m *MockFactory) AddActionHandlers(arg0 ...protocol.ActionHandler) {
 	varargs := []interface{}{}
@@ -119,6 +119,19 @@ func (mr *MockTrieMockRecorder) SetRootHash(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRootHash", reflect.TypeOf((*MockTrie)(nil).SetRootHash), arg0)
 }
 
+// Prove mocks base method
+func (m *MockTrie) Prove(arg0 []byte) ([][]byte, error) {
+	ret := m.ctrl.Call(m, "Prove", arg0)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prove indicates an expected call of Prove
+func (mr *MockTrieMockRecorder) Prove(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prove", reflect.TypeOf((*MockTrie)(nil).Prove), arg0)
+}
+
 // DB mocks base method
 func (m *MockTrie) DB() trie.KVStore {
 	ret := m.ctrl.Call(m, "DB")
@@ -0,0 +1,287 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// This is a tool that inspects the chain and state databases of a stopped node. It lists the namespaces and their
+// key counts, decodes blocks, receipts and account states, verifies the indexes of the chain database, and rebuilds
+// the indexes from the block headers and bodies.
+// To use, run "make dbtool"
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	glog "log"
+	"os"
+	"strconv"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+// errUsage indicates the command or its arguments are invalid
+var errUsage = errors.New("invalid command")
+
+// trieDBNamespaces are the namespaces of the trie DB
+var trieDBNamespaces = []string{
+	factory.AccountKVNameSpace,
+	factory.CandidateKVNameSpace,
+	factory.ContractKVNameSpace,
+	factory.CodeKVNameSpace,
+	evm.PreimageKVNameSpace,
+	trie.PrunerNameSpace,
+}
+
+func init() {
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr,
+			"usage: dbtool -config-path=[string] <command> [args]\n"+
+				"commands:\n"+
+				"  namespaces                   list the namespaces of the chain and trie DBs and their key counts\n"+
+				"  block <height|hash>          decode a block\n"+
+				"  receipts <height>            decode the receipts of a block\n"+
+				"  receipt <action hash>        decode the receipt of an action\n"+
+				"  account <address>            decode the state of an account\n"+
+				"  verify                       verify the indexes of the chain DB\n"+
+				"  rebuild                      rebuild the indexes of the chain DB from the blocks\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	flag.Parse()
+}
+
+func main() {
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+	}
+	genesisCfg, err := genesis.New()
+	if err != nil {
+		glog.Fatalln("Failed to new genesis config.", zap.Error(err))
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		glog.Fatalln("Failed to new config.", zap.Error(err))
+	}
+
+	cfg.Genesis = genesisCfg
+
+	ctx := context.Background()
+	if err := run(ctx, cfg, args); err != nil {
+		if errors.Cause(err) == errUsage {
+			flag.Usage()
+		}
+		log.L().Fatal("Failed to run dbtool.", zap.String("command", args[0]), zap.Error(err))
+	}
+}
+
+// run runs the command, and returns an error after the databases it opens are closed
+func run(ctx context.Context, cfg config.Config, args []string) error {
+	if args[0] == "account" {
+		if len(args) != 2 {
+			return errUsage
+		}
+		return printAccount(ctx, cfg, args[1])
+	}
+
+	chainDBCfg := cfg.DB
+	chainDBCfg.DbPath = cfg.Chain.ChainDBPath
+	chainDB := db.NewOnDiskDB(chainDBCfg)
+	if args[0] == "namespaces" {
+		trieDBCfg := cfg.DB
+		trieDBCfg.DbPath = cfg.Chain.TrieDBPath
+		return printNamespaces(ctx, chainDB, db.NewOnDiskDB(trieDBCfg))
+	}
+
+	inspector := blockchain.NewChainDBInspector(cfg, chainDB)
+	if err := inspector.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to open chain DB")
+	}
+	defer func() {
+		if err := inspector.Stop(ctx); err != nil {
+			log.L().Error("Failed to close chain DB.", zap.Error(err))
+		}
+	}()
+	return inspect(ctx, inspector, args)
+}
+
+// inspect runs a command on the chain DB
+func inspect(ctx context.Context, inspector *blockchain.ChainDBInspector, args []string) error {
+	switch {
+	case args[0] == "block" && len(args) == 2:
+		var blk *block.Block
+		if height, err := strconv.ParseUint(args[1], 10, 64); err == nil {
+			blk, err = inspector.BlockByHeight(height)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get block %d", height)
+			}
+		} else {
+			h, err := parseHash(args[1])
+			if err != nil {
+				return err
+			}
+			if blk, err = inspector.BlockByHash(h); err != nil {
+				return errors.Wrapf(err, "failed to get block %x", h)
+			}
+		}
+		return printProto(blk.ConvertToBlockPb())
+	case args[0] == "receipts" && len(args) == 2:
+		height, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "invalid height %s", args[1])
+		}
+		receipts, err := inspector.ReceiptsByHeight(height)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get receipts of block %d", height)
+		}
+		for _, r := range receipts {
+			if err := printProto(r.ConvertToReceiptPb()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case args[0] == "receipt" && len(args) == 2:
+		h, err := parseHash(args[1])
+		if err != nil {
+			return err
+		}
+		r, err := inspector.ReceiptByActionHash(h)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get receipt of action %x", h)
+		}
+		return printProto(r.ConvertToReceiptPb())
+	case args[0] == "verify" && len(args) == 1:
+		problems, err := inspector.Verify()
+		if err != nil {
+			return errors.Wrap(err, "failed to verify chain DB")
+		}
+		for _, p := range problems {
+			if err := printJSON(p); err != nil {
+				return err
+			}
+		}
+		if len(problems) > 0 {
+			return errors.Errorf("chain DB is inconsistent with %d problems, run rebuild to repair the indexes",
+				len(problems))
+		}
+		log.L().Info("Chain DB is consistent.")
+		return nil
+	case args[0] == "rebuild" && len(args) == 1:
+		blocks, err := inspector.RebuildHeightIndexes()
+		if err != nil {
+			return errors.Wrap(err, "failed to rebuild height indexes")
+		}
+		log.L().Info("Rebuilt height indexes.", zap.Uint64("blocks", blocks))
+		if ib := inspector.IndexBuilder(); ib != nil && blocks > 0 {
+			// an interrupted rebuild resumes where it stopped
+			if err := ib.Reindex(ctx, inspector.LowestAvailableHeight(), 0); err != nil {
+				return errors.Wrap(err, "failed to rebuild chain DB indexes")
+			}
+			log.L().Info("Rebuilt chain DB indexes.")
+		}
+		return nil
+	default:
+		return errUsage
+	}
+}
+
+func printNamespaces(ctx context.Context, chainDB db.KVStore, trieDB db.KVStore) error {
+	for _, store := range []struct {
+		kv         db.KVStore
+		namespaces []string
+	}{
+		{chainDB, blockchain.ChainDBNamespaces},
+		{trieDB, trieDBNamespaces},
+	} {
+		if err := store.kv.Start(ctx); err != nil {
+			return errors.Wrap(err, "failed to open DB")
+		}
+		stats, err := blockchain.CountKeys(store.kv, store.namespaces)
+		if err == nil {
+			for _, stat := range stats {
+				if err = printJSON(stat); err != nil {
+					break
+				}
+			}
+		}
+		if err := store.kv.Stop(ctx); err != nil {
+			log.L().Error("Failed to close DB.", zap.Error(err))
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to count keys")
+		}
+	}
+	return nil
+}
+
+func printAccount(ctx context.Context, cfg config.Config, addr string) error {
+	var sf factory.Factory
+	var err error
+	if cfg.Chain.EnableTrielessStateDB {
+		sf, err = factory.NewStateDB(cfg, factory.DefaultStateDBOption())
+	} else {
+		sf, err = factory.NewFactory(cfg, factory.DefaultTrieOption())
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to create state factory")
+	}
+	if err := sf.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to open trie DB")
+	}
+	defer func() {
+		if err := sf.Stop(ctx); err != nil {
+			log.L().Error("Failed to close trie DB.", zap.Error(err))
+		}
+	}()
+	account, err := sf.AccountState(addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get account state of %s", addr)
+	}
+	return printProto(account.ToProto())
+}
+
+func parseHash(s string) (hash.Hash256, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(hash.ZeroHash256) {
+		return hash.ZeroHash256, errors.Errorf("invalid hash %s", s)
+	}
+	return hash.BytesToHash256(b), nil
+}
+
+func printProto(pb proto.Message) error {
+	m := jsonpb.Marshaler{Indent: "  "}
+	s, err := m.MarshalToString(pb)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal")
+	}
+	fmt.Println(s)
+	return nil
+}
+
+func printJSON(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal")
+	}
+	fmt.Println(string(b))
+	return nil
+}
@@ -85,6 +85,10 @@ func main() {
 		dbFilePaths = append(dbFilePaths, chainDBPath)
 		trieDBPath := fmt.Sprintf("./trie%d.db", i+1)
 		dbFilePaths = append(dbFilePaths, trieDBPath)
+		walPath := fmt.Sprintf("./consensus%d.wal", i+1)
+		dbFilePaths = append(dbFilePaths, walPath)
+		evidenceDBPath := fmt.Sprintf("./evidence%d.db", i+1)
+		dbFilePaths = append(dbFilePaths, evidenceDBPath)
 		networkPort := 4689 + i
 		apiPort := 14014 + i
 		config := newConfig(chainDBPath, trieDBPath, chainAddrs[i].PriKey,
@@ -93,6 +97,8 @@ func main() {
 			config.Network.BootstrapNodes = []string{}
 			config.Network.MasterKey = "bootnode"
 		}
+		config.Consensus.RollDPoS.WALPath = walPath
+		config.Consensus.RollDPoS.EvidenceDBPath = evidenceDBPath
 		configs[i] = config
 	}
 	defer func() {
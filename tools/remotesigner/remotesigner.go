@@ -0,0 +1,93 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// This is a remote signer, which holds the private key of a block producer decrypted from a keystore file, and signs
+// for the node configured with the REMOTE signer. It serves over mutual TLS, i.e., only the clients with certificates
+// issued by the client CA are served, and listens on localhost by default. It refuses to sign a consensus message or
+// a block header conflicting with one signed before, or an action other than the ones a block producer creates
+// itself, and persists the latest signed height and round in the state file. The rounds are derived from the genesis
+// timestamp and the block interval of the chain.
+// To use, run "make remotesigner"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
+)
+
+var (
+	_keystorePath string
+	_passwordEnv  string
+	_statePath    string
+	_addr         string
+	_certFile     string
+	_keyFile      string
+	_clientCAFile string
+	_genesisTime  int64
+	_interval     time.Duration
+)
+
+func init() {
+	flag.StringVar(&_keystorePath, "keystore", "", "path of the keystore file of the producer private key")
+	flag.StringVar(&_passwordEnv, "password-env", "IOTEX_KEYSTORE_PASSWORD",
+		"environment variable of the keystore password")
+	flag.StringVar(&_statePath, "state", "./signer.state", "path of the double-sign protection state")
+	flag.StringVar(&_addr, "addr", "127.0.0.1:14015", "address of the signer server")
+	flag.StringVar(&_certFile, "cert", "", "path of the TLS certificate of the signer server")
+	flag.StringVar(&_keyFile, "key", "", "path of the TLS key of the signer server")
+	flag.StringVar(&_clientCAFile, "client-ca", "", "path of the CA certificate to verify the clients")
+	flag.Int64Var(&_genesisTime, "genesis-timestamp", genesis.Default.Timestamp, "genesis timestamp of the chain")
+	flag.DurationVar(&_interval, "block-interval", genesis.Default.BlockInterval, "block interval of the chain")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr,
+			"usage: remotesigner -keystore=[string] -state=[string] -addr=[string] -cert=[string] -key=[string] "+
+				"-client-ca=[string] -genesis-timestamp=[int] -block-interval=[duration]\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	flag.Parse()
+}
+
+func main() {
+	if _keystorePath == "" || _certFile == "" || _keyFile == "" || _clientCAFile == "" {
+		flag.Usage()
+	}
+	s, err := signer.NewKeystore(_keystorePath, os.Getenv(_passwordEnv))
+	if err != nil {
+		log.L().Fatal("Failed to load the producer key.", zap.Error(err))
+	}
+	tlsCfg, err := signer.NewServerTLSConfig(_certFile, _keyFile, _clientCAFile)
+	if err != nil {
+		log.L().Fatal("Failed to load the TLS certificates.", zap.Error(err))
+	}
+	rounds := signer.Rounds{Genesis: time.Unix(_genesisTime, 0), Interval: _interval}
+	svr, err := signer.NewServer(_addr, s, _statePath, rounds, tlsCfg)
+	if err != nil {
+		log.L().Fatal("Failed to create the signer server.", zap.Error(err))
+	}
+	ctx := context.Background()
+	if err := svr.Start(ctx); err != nil {
+		log.L().Fatal("Failed to start the signer server.", zap.Error(err))
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+	if err := svr.Stop(ctx); err != nil {
+		log.L().Error("Failed to stop the signer server.", zap.Error(err))
+	}
+}
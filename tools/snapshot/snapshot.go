@@ -0,0 +1,136 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// This is a tool that exports a snapshot of the chain and state databases at a height, or bootstraps a node by
+// importing a snapshot into empty databases.
+// To use, run "make snapshot"
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	glog "log"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+var (
+	// exportPath is the path of the snapshot file to export
+	exportPath string
+	// importPath is the path of the snapshot file to import
+	importPath string
+	// height is the height of the snapshot to export
+	height uint64
+	// numHeaders is the number of block headers to export
+	numHeaders uint64
+	// trustedHash is the block hash at the snapshot height obtained from a trusted source
+	trustedHash string
+	// trustedRoot is the state root at the snapshot height obtained from a trusted source
+	trustedRoot string
+	// insecure imports a snapshot without the trusted block hash and state root
+	insecure bool
+)
+
+func init() {
+	flag.StringVar(&exportPath, "export", "", "Path of the snapshot file to export")
+	flag.StringVar(&importPath, "import", "", "Path of the snapshot file to import")
+	flag.Uint64Var(&height, "height", 0, "Height of the snapshot to export, 0 for the tip height")
+	flag.Uint64Var(&numHeaders, "headers", 720, "Number of block headers to export")
+	flag.StringVar(&trustedHash, "trusted-hash", "", "Trusted block hash at the snapshot height to verify on import")
+	flag.StringVar(&trustedRoot, "trusted-root", "",
+		"Trusted state root at the snapshot height to verify on import, e.g. from GetAccountProof of a trusted node")
+	flag.BoolVar(&insecure, "insecure", false,
+		"Import the snapshot without the trusted block hash and state root, trusting whoever provides it")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr,
+			"usage: snapshot -config-path=[string]\n -export=[string] -height=[int] -headers=[int]\n"+
+				" -import=[string] -trusted-hash=[string] -trusted-root=[string] -insecure\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	flag.Parse()
+}
+
+func main() {
+	if (exportPath == "") == (importPath == "") {
+		flag.Usage()
+	}
+	genesisCfg, err := genesis.New()
+	if err != nil {
+		glog.Fatalln("Failed to new genesis config.", zap.Error(err))
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		glog.Fatalln("Failed to new config.", zap.Error(err))
+	}
+
+	cfg.Genesis = genesisCfg
+
+	chainDBCfg := cfg.DB
+	chainDBCfg.DbPath = cfg.Chain.ChainDBPath
+	trieDBCfg := cfg.DB
+	trieDBCfg.DbPath = cfg.Chain.TrieDBPath
+	chainDB := db.NewOnDiskDB(chainDBCfg)
+	trieDB := db.NewOnDiskDB(trieDBCfg)
+
+	if exportPath != "" {
+		f, err := os.Create(exportPath)
+		if err != nil {
+			log.L().Fatal("Failed to create snapshot file.", zap.Error(err))
+		}
+		defer f.Close()
+		manifest, err := blockchain.ExportSnapshot(cfg, chainDB, trieDB, height, numHeaders, f)
+		if err != nil {
+			log.L().Fatal("Failed to export snapshot.", zap.Error(err))
+		}
+		log.L().Info("Exported snapshot.",
+			zap.Uint64("height", manifest.Height),
+			zap.String("blockHash", manifest.BlockHash),
+			zap.String("stateRoot", manifest.StateRoot))
+		return
+	}
+
+	trust := blockchain.SnapshotTrust{
+		BlockHash: parseHash("trusted hash", trustedHash),
+		StateRoot: parseHash("trusted root", trustedRoot),
+		Insecure:  insecure,
+	}
+	f, err := os.Open(importPath)
+	if err != nil {
+		log.L().Fatal("Failed to open snapshot file.", zap.Error(err))
+	}
+	defer f.Close()
+	manifest, err := blockchain.ImportSnapshot(cfg, chainDB, trieDB, f, trust)
+	if err != nil {
+		log.L().Fatal("Failed to import snapshot.", zap.Error(err))
+	}
+	log.L().Info("Imported snapshot, the node will start from the snapshot height.",
+		zap.Uint64("height", manifest.Height),
+		zap.String("blockHash", manifest.BlockHash),
+		zap.String("stateRoot", manifest.StateRoot))
+}
+
+// parseHash parses a hex encoded hash, and returns zero hash for an empty string
+func parseHash(name string, s string) hash.Hash256 {
+	if s == "" {
+		return hash.ZeroHash256
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(hash.ZeroHash256) {
+		log.L().Fatal("Invalid "+name+".", zap.String("hash", s))
+	}
+	return hash.BytesToHash256(b)
+}